@@ -1039,6 +1039,47 @@ const docTemplate = `{
                 }
             }
         },
+        "/jobs/{id}/cancel": {
+            "post": {
+                "description": "Cancel by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job"
+                ],
+                "summary": "Cancel Job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Job"
+                        }
+                    },
+                    "400": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": ""
+                    }
+                }
+            }
+        },
         "/oauth": {
             "get": {
                 "description": "Get Oauths",
@@ -3698,6 +3739,9 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "quota": {
+                    "$ref": "#/definitions/model.JSONMap"
+                },
                 "scheduler_clusters": {
                     "type": "array",
                     "items": {
@@ -3793,6 +3837,9 @@ const docTemplate = `{
                 "bio": {
                     "type": "string"
                 },
+                "progress": {
+                    "$ref": "#/definitions/model.JSONMap"
+                },
                 "result": {
                     "$ref": "#/definitions/model.JSONMap"
                 },
@@ -4102,6 +4149,20 @@ const docTemplate = `{
                 }
             }
         },
+        "types.ApplicationQuota": {
+            "type": "object",
+            "properties": {
+                "max_back_to_source_bandwidth": {
+                    "type": "integer"
+                },
+                "max_concurrent_tasks": {
+                    "type": "integer"
+                },
+                "max_seed_peer_triggers": {
+                    "type": "integer"
+                }
+            }
+        },
         "types.CreateApplicationRequest": {
             "type": "object",
             "required": [
@@ -4118,6 +4179,9 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "quota": {
+                    "$ref": "#/definitions/types.ApplicationQuota"
+                },
                 "state": {
                     "type": "string",
                     "enum": [
@@ -4529,10 +4593,33 @@ const docTemplate = `{
         "types.SchedulerClusterConfig": {
             "type": "object",
             "properties": {
+                "filter_expression": {
+                    "type": "string"
+                },
                 "filter_parent_limit": {
                     "type": "integer",
                     "maximum": 100,
                     "minimum": 1
+                },
+                "max_back_to_source_bandwidth_per_origin": {
+                    "description": "MaxBackToSourceBandwidthPerOrigin is the maximum back-to-source bandwidth in bytes per second per origin host,\nit is the limit of the whole cluster, each active scheduler enforces its share divided by the number of active schedulers.",
+                    "type": "integer"
+                },
+                "max_back_to_source_peers_per_origin": {
+                    "description": "MaxBackToSourcePeersPerOrigin is the maximum number of concurrent back-to-source peers per origin host,\nit is the limit of the whole cluster, each active scheduler enforces its share divided by the number of active schedulers.",
+                    "type": "integer"
+                },
+                "score_expression": {
+                    "type": "string"
+                },
+                "url_policies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.URLPolicy"
+                    }
+                },
+                "url_policy_default_action": {
+                    "type": "string"
                 }
             }
         },
@@ -4609,6 +4696,25 @@ const docTemplate = `{
                 }
             }
         },
+        "types.URLPolicy": {
+            "type": "object",
+            "required": [
+                "action",
+                "pattern",
+                "type"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "pattern": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
         "types.UpdateApplicationRequest": {
             "type": "object",
             "required": [
@@ -4624,6 +4730,9 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "quota": {
+                    "$ref": "#/definitions/types.ApplicationQuota"
+                },
                 "state": {
                     "type": "string",
                     "enum": [
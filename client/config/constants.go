@@ -45,11 +45,21 @@ const (
 	PatternSource   = "source"
 )
 
+/* download priority */
+const (
+	PriorityNormal     = "normal"
+	PriorityUrgent     = "urgent"
+	PriorityBackground = "background"
+)
+
 const (
 	DefaultPerPeerDownloadLimit = 20 * unit.MB
 	DefaultTotalDownloadLimit   = 100 * unit.MB
 	DefaultUploadLimit          = 100 * unit.MB
 	DefaultMinRate              = 20 * unit.MB
+
+	DefaultMemoryTierCapacity    = 512 * unit.MB
+	DefaultMemoryTierMaxTaskSize = 64 * unit.MB
 )
 
 /* others */
@@ -60,11 +70,21 @@ const (
 	ServerPortLowerLimit = 15000
 	ServerPortUpperLimit = 65000
 
-	DefaultTaskExpireTime  = 6 * time.Hour
-	DefaultGCInterval      = 1 * time.Minute
-	DefaultDaemonAliveTime = 5 * time.Minute
-	DefaultScheduleTimeout = 5 * time.Minute
-	DefaultDownloadTimeout = 5 * time.Minute
+	DefaultTaskExpireTime   = 6 * time.Hour
+	DefaultGCInterval       = 1 * time.Minute
+	DefaultHostLoadInterval = 10 * time.Second
+	DefaultProbeInterval    = 20 * time.Minute
+	DefaultProbeTimeout     = 3 * time.Second
+	DefaultDaemonAliveTime  = 5 * time.Minute
+	DefaultScheduleTimeout  = 5 * time.Minute
+	DefaultDownloadTimeout  = 5 * time.Minute
+
+	DefaultStreamResumeRetryLimit    = 5
+	DefaultStreamResumeRetryInterval = 1 * time.Second
+
+	DefaultDiscoveryListenPort = 65008
+	DefaultDiscoveryTimeout    = 500 * time.Millisecond
+	DefaultDiscoveryMaxPeers   = 4
 
 	DefaultSchedulerSchema = "http"
 	DefaultSchedulerIP     = "127.0.0.1"
@@ -76,6 +96,17 @@ const (
 const (
 	SimpleLocalTaskStoreStrategy  = StoreStrategy("io.d7y.storage.v2.simple")
 	AdvanceLocalTaskStoreStrategy = StoreStrategy("io.d7y.storage.v2.advance")
+	TieredLocalTaskStoreStrategy  = StoreStrategy("io.d7y.storage.v2.tiered")
+
+	// CompressionZstd compresses task data with zstd at rest, it is also the content coding of compressed pieces
+	CompressionZstd = "zstd"
+
+	// EvictionPolicyLRU evicts the least recently used tasks first
+	EvictionPolicyLRU = "lru"
+	// EvictionPolicyLFU evicts the least frequently used tasks first
+	EvictionPolicyLFU = "lfu"
+	// EvictionPolicySizeWeightedLRU evicts the tasks with the largest product of idle time and size first
+	EvictionPolicySizeWeightedLRU = "size-weighted-lru"
 )
 
 /* dfcache subcommand names */
@@ -45,6 +45,7 @@ const (
 	AttributeGetPieceRetry     = attribute.Key("d7y.peer.piece.retry")
 	AttributeWritePieceSuccess = attribute.Key("d7y.peer.piece.write.success")
 	AttributeSeedTaskSuccess   = attribute.Key("d7y.seed.task.success")
+	AttributeDiscoveredPeers   = attribute.Key("d7y.peer.discovered.count")
 
 	SpanFileTask          = "file-task"
 	SpanStreamTask        = "stream-task"
@@ -64,4 +65,5 @@ const (
 	SpanWriteBackPiece    = "write-back-piece"
 	SpanWaitPieceLimit    = "wait-limit"
 	SpanPeerGC            = "peer-gc"
+	SpanDiscoverPeers     = "discover-peers"
 )
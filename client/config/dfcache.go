@@ -70,6 +70,12 @@ type CacheOption struct {
 
 	// LocalOnly indicates check local cache only
 	LocalOnly bool `yaml:"localOnly,omitempty" mapstructure:"localOnly,omitempty"`
+
+	// Pin keeps the imported task from being reclaimed by gc until it is unpinned
+	Pin bool `yaml:"pin,omitempty" mapstructure:"pin,omitempty"`
+
+	// Unpin only unpins the task and leaves it to gc instead of deleting it
+	Unpin bool `yaml:"unpin,omitempty" mapstructure:"unpin,omitempty"`
 }
 
 func NewDfcacheConfig() *CacheOption {
@@ -69,6 +69,9 @@ type ClientOption struct {
 	// Tag identify download task, it is available merely when md5 param not exist.
 	Tag string `yaml:"tag,omitempty" mapstructure:"tag,omitempty"`
 
+	// Application to which the download task belongs, it is used by quota of scheduler.
+	Application string `yaml:"application,omitempty" mapstructure:"application,omitempty"`
+
 	// CallSystem system name that executes dfget.
 	CallSystem string `yaml:"callSystem,omitempty" mapstructure:"callSystem,omitempty"`
 
@@ -76,6 +79,10 @@ type ClientOption struct {
 	// default:`p2p`.
 	Pattern string `yaml:"pattern,omitempty" mapstructure:"pattern,omitempty"`
 
+	// Priority download priority, must be 'urgent' or 'normal' or 'background',
+	// default:`normal`.
+	Priority string `yaml:"priority,omitempty" mapstructure:"priority,omitempty"`
+
 	// CA certificate to verify when supernode interact with the source.
 	Cacerts []string `yaml:"cacert,omitempty" mapstructure:"cacert,omitempty"`
 
@@ -167,6 +174,10 @@ func (cfg *ClientOption) Validate() error {
 		return errors.Wrapf(dferrors.ErrInvalidArgument, "rate limit must be greater than %s", DefaultMinRate.String())
 	}
 
+	if err := checkPriority(cfg.Priority); err != nil {
+		return errors.Wrapf(dferrors.ErrInvalidArgument, "priority: %v", err)
+	}
+
 	return nil
 }
 
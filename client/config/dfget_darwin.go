@@ -37,8 +37,10 @@ var dfgetConfig = ClientOption{
 	DigestMethod:      "",
 	DigestValue:       "",
 	Tag:               "",
+	Application:       "",
 	CallSystem:        "",
 	Pattern:           "",
+	Priority:          "",
 	Cacerts:           nil,
 	Filter:            "",
 	Header:            nil,
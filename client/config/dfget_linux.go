@@ -36,8 +36,10 @@ var dfgetConfig = ClientOption{
 	DigestMethod:      "",
 	DigestValue:       "",
 	Tag:               "",
+	Application:       "",
 	CallSystem:        "",
 	Pattern:           "",
+	Priority:          "",
 	Cacerts:           nil,
 	Filter:            "",
 	Header:            nil,
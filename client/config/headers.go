@@ -22,6 +22,10 @@ const (
 	HeaderDragonflyTask   = "X-Dragonfly-Task"
 	HeaderDragonflyRange  = "X-Dragonfly-Range"
 	HeaderDragonflyBiz    = "X-Dragonfly-Biz"
+	// HeaderDragonflyApplication is used for application to which the download task belongs
+	HeaderDragonflyApplication = "X-Dragonfly-Application"
+	// HeaderDragonflyPriority is used for download priority, like: urgent, normal, background
+	HeaderDragonflyPriority = "X-Dragonfly-Priority"
 	// HeaderDragonflyRegistry is used for dynamic registry mirrors
 	HeaderDragonflyRegistry = "X-Dragonfly-Registry"
 )
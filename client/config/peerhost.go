@@ -30,6 +30,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/docker/go-connections/tlsconfig"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 
@@ -38,6 +39,7 @@ import (
 	logger "d7y.io/dragonfly/v2/internal/dflog"
 	"d7y.io/dragonfly/v2/pkg/dfnet"
 	netip "d7y.io/dragonfly/v2/pkg/net/ip"
+	rpcbase "d7y.io/dragonfly/v2/pkg/rpc/base"
 	"d7y.io/dragonfly/v2/pkg/rpc/scheduler"
 	"d7y.io/dragonfly/v2/pkg/unit"
 )
@@ -116,6 +118,101 @@ func (p *DaemonOption) Convert() error {
 }
 
 func (p *DaemonOption) Validate() error {
+	if p.Scheduler.StreamResume.Enable && p.Scheduler.StreamResume.RetryLimit <= 0 {
+		return errors.New("stream resume retryLimit must be greater than 0")
+	}
+
+	if p.Scheduler.Discovery.Enable {
+		if len(p.Scheduler.Discovery.Peers) == 0 && p.Scheduler.Discovery.MulticastGroup == "" {
+			return errors.New("discovery peers and multicastGroup are not specified")
+		}
+
+		if p.Scheduler.Discovery.Secret == "" {
+			return errors.New("discovery secret is not specified")
+		}
+
+		if p.Scheduler.Discovery.Timeout <= 0 {
+			return errors.New("discovery timeout must be greater than 0")
+		}
+
+		if p.Scheduler.Discovery.MaxPeers <= 0 {
+			return errors.New("discovery maxPeers must be greater than 0")
+		}
+	}
+
+	if p.Scheduler.Probe.Enable {
+		if p.Scheduler.Probe.Interval <= 0 {
+			return errors.New("probe interval must be greater than 0")
+		}
+
+		if p.Scheduler.Probe.Timeout <= 0 {
+			return errors.New("probe timeout must be greater than 0")
+		}
+	}
+
+	if p.Storage.StoreStrategy == TieredLocalTaskStoreStrategy && p.Storage.MemoryTier.Capacity <= 0 {
+		return errors.New("storage memoryTier capacity must be greater than 0")
+	}
+
+	if p.Storage.Compression != "" {
+		if p.Storage.Compression != CompressionZstd {
+			return fmt.Errorf("storage compression %s is not supported", p.Storage.Compression)
+		}
+
+		// the subtasks of prefetch write the data file of parent task directly
+		if p.Download.Prefetch {
+			return errors.New("storage compression is not supported with prefetch")
+		}
+	}
+
+	switch p.Storage.EvictionPolicy {
+	case "", EvictionPolicyLRU, EvictionPolicyLFU, EvictionPolicySizeWeightedLRU:
+	default:
+		return fmt.Errorf("storage evictionPolicy %s is not supported", p.Storage.EvictionPolicy)
+	}
+
+	quotas := map[string]bool{}
+	for _, quota := range p.Storage.Quotas {
+		if quota.Name == "" {
+			return errors.New("storage quota name is not specified")
+		}
+
+		if quotas[quota.Name] {
+			return fmt.Errorf("storage quota %s is duplicated", quota.Name)
+		}
+		quotas[quota.Name] = true
+
+		if quota.Tag == "" && quota.Application == "" && quota.Callsystem == "" {
+			return fmt.Errorf("storage quota %s requires tag, application or callsystem", quota.Name)
+		}
+
+		if quota.Limit <= 0 {
+			return fmt.Errorf("storage quota %s limit must be greater than 0", quota.Name)
+		}
+	}
+
+	if p.Storage.Encryption.Enable {
+		// the data file of advance strategy is the output file
+		if p.Storage.StoreStrategy == AdvanceLocalTaskStoreStrategy {
+			return errors.New("storage encryption is not supported with advance strategy")
+		}
+
+		if p.Download.Prefetch {
+			return errors.New("storage encryption is not supported with prefetch")
+		}
+
+		if p.Storage.Encryption.KeyFile == "" {
+			if !p.Scheduler.Manager.Enable {
+				return errors.New("storage encryption keyFile is not specified and manager is not enabled")
+			}
+
+			// the master key is only served to the client authenticated by certificate
+			if managerTLS := p.Scheduler.Manager.TLS; managerTLS == nil || managerTLS.Cert == "" || managerTLS.Key == "" {
+				return errors.New("storage encryption keyFile is not specified and manager tls cert is not specified")
+			}
+		}
+	}
+
 	if p.Scheduler.Manager.Enable {
 		if len(p.Scheduler.Manager.NetAddrs) == 0 {
 			return errors.New("manager addr is not specified")
@@ -124,6 +221,10 @@ func (p *DaemonOption) Validate() error {
 		if p.Scheduler.Manager.RefreshInterval == 0 {
 			return errors.New("manager refreshInterval is not specified")
 		}
+
+		if p.Scheduler.Manager.TLS != nil && p.Scheduler.Manager.TLS.CACert == "" {
+			return errors.New("manager tls caCert is not specified")
+		}
 		return nil
 	}
 	if len(p.Scheduler.NetAddrs) == 0 {
@@ -143,6 +244,14 @@ func (p *DaemonOption) Validate() error {
 	default:
 		return errors.New("available pattern: p2p, seed-peer, source")
 	}
+
+	if p.Proxy != nil {
+		for _, rule := range p.Proxy.Proxies {
+			if err := checkPriority(rule.Priority); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
@@ -161,6 +270,29 @@ func ConvertPattern(p string, defaultPattern scheduler.Pattern) scheduler.Patter
 	return defaultPattern
 }
 
+// ConvertPriority converts priority name to download priority, the unknown priority is normal.
+func ConvertPriority(p string) rpcbase.Priority {
+	switch p {
+	case PriorityUrgent:
+		return rpcbase.Priority_URGENT_PRIORITY
+	case PriorityBackground:
+		return rpcbase.Priority_BACKGROUND_PRIORITY
+	case PriorityNormal, "":
+		return rpcbase.Priority_NORMAL_PRIORITY
+	}
+	logger.Warnf("unknown priority %s, use normal priority", p)
+	return rpcbase.Priority_NORMAL_PRIORITY
+}
+
+// checkPriority checks whether the priority name is available.
+func checkPriority(p string) error {
+	switch p {
+	case PriorityNormal, PriorityUrgent, PriorityBackground, "":
+		return nil
+	}
+	return errors.Errorf("available priority: %s, %s, %s", PriorityUrgent, PriorityNormal, PriorityBackground)
+}
+
 type SchedulerOption struct {
 	// Manager is to get the scheduler configuration remotely.
 	Manager ManagerOption `mapstructure:"manager" yaml:"manager"`
@@ -170,6 +302,50 @@ type SchedulerOption struct {
 	ScheduleTimeout clientutil.Duration `mapstructure:"scheduleTimeout" yaml:"scheduleTimeout"`
 	// DisableAutoBackSource indicates not back source normally, only scheduler says back source.
 	DisableAutoBackSource bool `mapstructure:"disableAutoBackSource" yaml:"disableAutoBackSource"`
+	// StreamResume is to resume the broken stream of reporting piece results,
+	// e.g. the stream is broken by rolling restart of scheduler.
+	StreamResume StreamResumeOption `mapstructure:"streamResume" yaml:"streamResume"`
+	// Discovery is to find the peers holding the task by gossip when all schedulers are unreachable,
+	// instead of downloading from source directly.
+	Discovery DiscoveryOption `mapstructure:"discovery" yaml:"discovery"`
+	// Probe is to measure the latency to the hosts chosen by scheduler and report it to scheduler.
+	Probe ProbeOption `mapstructure:"probe" yaml:"probe"`
+}
+
+type StreamResumeOption struct {
+	// Enable resuming stream.
+	Enable bool `mapstructure:"enable" yaml:"enable"`
+	// RetryLimit is the max retry times of resuming stream.
+	RetryLimit int `mapstructure:"retryLimit" yaml:"retryLimit"`
+	// RetryInterval is the interval of retrying to resume stream.
+	RetryInterval time.Duration `mapstructure:"retryInterval" yaml:"retryInterval"`
+}
+
+type DiscoveryOption struct {
+	// Enable discovering peers when schedulers are unreachable.
+	Enable bool `mapstructure:"enable" yaml:"enable"`
+	// ListenPort is the udp port to answer the queries of other peers.
+	ListenPort int `mapstructure:"listenPort" yaml:"listenPort"`
+	// Peers is the udp addresses of other peers to query, e.g. 192.168.0.2:65008.
+	Peers []string `mapstructure:"peers" yaml:"peers"`
+	// MulticastGroup is the udp multicast address to query and listen, e.g. 239.255.0.1:65008.
+	MulticastGroup string `mapstructure:"multicastGroup" yaml:"multicastGroup"`
+	// Secret is the shared key of peers to sign the queries and answers by HMAC-SHA256,
+	// the messages with invalid signature are dropped.
+	Secret string `mapstructure:"secret" yaml:"secret"`
+	// Timeout is the timeout of waiting the answers of other peers.
+	Timeout time.Duration `mapstructure:"timeout" yaml:"timeout"`
+	// MaxPeers is the max count of discovered peers to download from.
+	MaxPeers int `mapstructure:"maxPeers" yaml:"maxPeers"`
+}
+
+type ProbeOption struct {
+	// Enable probing the hosts chosen by scheduler.
+	Enable bool `mapstructure:"enable" yaml:"enable"`
+	// Interval is the interval of probing.
+	Interval time.Duration `mapstructure:"interval" yaml:"interval"`
+	// Timeout is the timeout of probing a host.
+	Timeout time.Duration `mapstructure:"timeout" yaml:"timeout"`
 }
 
 type ManagerOption struct {
@@ -181,6 +357,26 @@ type ManagerOption struct {
 	RefreshInterval time.Duration `mapstructure:"refreshInterval" yaml:"refreshInterval"`
 	// SeedPeer configuration.
 	SeedPeer SeedPeerOption `mapstructure:"seedPeer" yaml:"seedPeer"`
+	// TLS configuration of manager client, nil means insecure connection.
+	TLS *ManagerTLSOption `mapstructure:"tls" yaml:"tls"`
+}
+
+type ManagerTLSOption struct {
+	// CACert is the CA file which verifies the certificate of manager.
+	CACert string `mapstructure:"caCert" yaml:"caCert"`
+	// Cert is the client certificate file, which authenticates the client to get the encryption key.
+	Cert string `mapstructure:"cert" yaml:"cert"`
+	// Key is the client key file.
+	Key string `mapstructure:"key" yaml:"key"`
+}
+
+// Client generates the tls config of manager client.
+func (t *ManagerTLSOption) Client() (*tls.Config, error) {
+	return tlsconfig.Client(tlsconfig.Options{
+		CAFile:   t.CACert,
+		CertFile: t.Cert,
+		KeyFile:  t.Key,
+	})
 }
 
 type SeedPeerOption struct {
@@ -497,10 +693,50 @@ type StorageOption struct {
 	// Multiplex indicates reusing underlying storage for same task id
 	Multiplex     bool          `mapstructure:"multiplex" yaml:"multiplex"`
 	StoreStrategy StoreStrategy `mapstructure:"strategy" yaml:"strategy"`
+	// MemoryTier is the memory tier option of tiered strategy
+	MemoryTier MemoryTierOption `mapstructure:"memoryTier" yaml:"memoryTier"`
+	// Compression indicates the compression algorithm of task data at rest, only zstd is supported,
+	// it works with simple and tiered strategy, empty means no compression
+	Compression string `mapstructure:"compression" yaml:"compression"`
+	// Encryption indicates encrypting task data at rest, it works with simple and tiered strategy
+	Encryption EncryptionOption `mapstructure:"encryption" yaml:"encryption"`
+	// EvictionPolicy indicates the order of evicting tasks when DiskGCThreshold or DiskGCThresholdPercent is exceeded,
+	// the policies are lru, lfu and size-weighted-lru, empty means lru
+	EvictionPolicy string `mapstructure:"evictionPolicy" yaml:"evictionPolicy"`
+	// Quotas limit the disk usage of task groups, the tasks of the group exceeding its quota are evicted first
+	Quotas []StorageQuotaOption `mapstructure:"quotas" yaml:"quotas"`
 }
 
 type StoreStrategy string
 
+type MemoryTierOption struct {
+	// Capacity indicates the max memory used by task data, the least recently used tasks spill to disk when exceeded
+	Capacity unit.Bytes `mapstructure:"capacity" yaml:"capacity"`
+	// MaxTaskSize indicates the max content length of task kept in memory, larger tasks are written to disk directly
+	MaxTaskSize unit.Bytes `mapstructure:"maxTaskSize" yaml:"maxTaskSize"`
+}
+
+type StorageQuotaOption struct {
+	// Name is the name of group, it is the label of group metrics
+	Name string `mapstructure:"name" yaml:"name"`
+	// Tag matches the tag in url meta of task, empty matches any tag
+	Tag string `mapstructure:"tag" yaml:"tag"`
+	// Application matches the application in url meta of task, empty matches any application
+	Application string `mapstructure:"application" yaml:"application"`
+	// Callsystem matches the caller system of task, e.g. the callsystem of dfget, empty matches any callsystem
+	Callsystem string `mapstructure:"callsystem" yaml:"callsystem"`
+	// Limit indicates the max disk usage of the tasks in group
+	Limit unit.Bytes `mapstructure:"limit" yaml:"limit"`
+}
+
+type EncryptionOption struct {
+	// Enable indicates encrypting every piece with AES-256-GCM by the data key of task
+	Enable bool `mapstructure:"enable" yaml:"enable"`
+	// KeyFile is the file of base64 encoded 32 bytes master key, which seals the data keys of tasks,
+	// the master key is fetched from manager with the tls client certificate and kept in memory only when it is empty
+	KeyFile string `mapstructure:"keyFile" yaml:"keyFile"`
+}
+
 type HealthOption struct {
 	ListenOption `yaml:",inline" mapstructure:",squash"`
 	Path         string `mapstructure:"path" yaml:"pash"`
@@ -724,6 +960,9 @@ type ProxyRule struct {
 
 	// Redirect is the host to redirect to, if not empty
 	Redirect string `yaml:"redirect" mapstructure:"redirect"`
+
+	// Priority is the download priority of matched requests, like: urgent, normal, background
+	Priority string `yaml:"priority" mapstructure:"priority"`
 }
 
 func NewProxyRule(regx string, useHTTPS bool, direct bool, redirect string) (*ProxyRule, error) {
@@ -56,6 +56,22 @@ var peerHostConfig = DaemonOption{
 			},
 		},
 		ScheduleTimeout: clientutil.Duration{Duration: DefaultScheduleTimeout},
+		StreamResume: StreamResumeOption{
+			Enable:        true,
+			RetryLimit:    DefaultStreamResumeRetryLimit,
+			RetryInterval: DefaultStreamResumeRetryInterval,
+		},
+		Discovery: DiscoveryOption{
+			Enable:     false,
+			ListenPort: DefaultDiscoveryListenPort,
+			Timeout:    DefaultDiscoveryTimeout,
+			MaxPeers:   DefaultDiscoveryMaxPeers,
+		},
+		Probe: ProbeOption{
+			Enable:   true,
+			Interval: DefaultProbeInterval,
+			Timeout:  DefaultProbeTimeout,
+		},
 	},
 	Host: HostOption{
 		Hostname:       fqdn.FQDNHostname,
@@ -151,6 +167,10 @@ var peerHostConfig = DaemonOption{
 		StoreStrategy:          AdvanceLocalTaskStoreStrategy,
 		Multiplex:              false,
 		DiskGCThresholdPercent: 95,
+		MemoryTier: MemoryTierOption{
+			Capacity:    DefaultMemoryTierCapacity,
+			MaxTaskSize: DefaultMemoryTierMaxTaskSize,
+		},
 	},
 	Reload: ReloadOption{
 		Interval: clientutil.Duration{
@@ -248,6 +248,11 @@ func TestPeerHostOption_Load(t *testing.T) {
 						Interval: 10 * time.Second,
 					},
 				},
+				TLS: &ManagerTLSOption{
+					CACert: "ca.crt",
+					Cert:   "client.crt",
+					Key:    "client.key",
+				},
 			},
 			NetAddrs: []dfnet.NetAddr{
 				{
@@ -258,6 +263,25 @@ func TestPeerHostOption_Load(t *testing.T) {
 			ScheduleTimeout: clientutil.Duration{
 				Duration: 0,
 			},
+			StreamResume: StreamResumeOption{
+				Enable:        true,
+				RetryLimit:    3,
+				RetryInterval: 2 * time.Second,
+			},
+			Discovery: DiscoveryOption{
+				Enable:         true,
+				ListenPort:     65008,
+				Peers:          []string{"127.0.0.1:65018"},
+				MulticastGroup: "239.255.0.1:65008",
+				Secret:         "foo",
+				Timeout:        1 * time.Second,
+				MaxPeers:       3,
+			},
+			Probe: ProbeOption{
+				Enable:   true,
+				Interval: 10 * time.Minute,
+				Timeout:  2 * time.Second,
+			},
 		},
 		Host: HostOption{
 			Hostname:       "d7y.io",
@@ -350,6 +374,33 @@ func TestPeerHostOption_Load(t *testing.T) {
 				Duration: 180000000000,
 			},
 			StoreStrategy: StoreStrategy("io.d7y.storage.v2.simple"),
+			MemoryTier: MemoryTierOption{
+				Capacity:    512 * unit.MB,
+				MaxTaskSize: 64 * unit.MB,
+			},
+			Compression: "zstd",
+			Encryption: EncryptionOption{
+				Enable:  true,
+				KeyFile: "/etc/dragonfly/encryption.key",
+			},
+			EvictionPolicy: "lfu",
+			Quotas: []StorageQuotaOption{
+				{
+					Name:  "checkpoints",
+					Tag:   "ml-checkpoints",
+					Limit: 20 * unit.GB,
+				},
+				{
+					Name:        "kubelet",
+					Application: "kubelet",
+					Limit:       100 * unit.GB,
+				},
+				{
+					Name:       "sync-jobs",
+					Callsystem: "sync-job",
+					Limit:      10 * unit.GB,
+				},
+			},
 		},
 		Proxy: &ProxyOption{
 			ListenOption: ListenOption{
@@ -384,6 +435,7 @@ func TestPeerHostOption_Load(t *testing.T) {
 					UseHTTPS: false,
 					Direct:   false,
 					Redirect: "d7y.io",
+					Priority: PriorityUrgent,
 				},
 			},
 			HijackHTTPS: &HijackConfig{
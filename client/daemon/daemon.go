@@ -39,10 +39,13 @@ import (
 
 	"d7y.io/dragonfly/v2/client/clientutil"
 	"d7y.io/dragonfly/v2/client/config"
+	"d7y.io/dragonfly/v2/client/daemon/discovery"
 	"d7y.io/dragonfly/v2/client/daemon/gc"
+	"d7y.io/dragonfly/v2/client/daemon/hostload"
 	"d7y.io/dragonfly/v2/client/daemon/metrics"
 	"d7y.io/dragonfly/v2/client/daemon/objectstorage"
 	"d7y.io/dragonfly/v2/client/daemon/peer"
+	"d7y.io/dragonfly/v2/client/daemon/probe"
 	"d7y.io/dragonfly/v2/client/daemon/proxy"
 	"d7y.io/dragonfly/v2/client/daemon/rpcserver"
 	"d7y.io/dragonfly/v2/client/daemon/storage"
@@ -84,6 +87,9 @@ type clientDaemon struct {
 	ProxyManager   proxy.Manager
 	StorageManager storage.Manager
 	GCManager      gc.Manager
+	HostLoad       hostload.Collector
+	Prober         probe.Prober
+	Discovery      discovery.Discovery
 
 	PeerTaskManager peer.TaskManager
 	PieceManager    peer.PieceManager
@@ -122,7 +128,15 @@ func New(opt *config.DaemonOption, d dfpath.Dfpath) (Daemon, error) {
 	if opt.Scheduler.Manager.Enable == true {
 		// New manager client
 		var err error
-		managerClient, err = managerclient.NewWithAddrs(opt.Scheduler.Manager.NetAddrs)
+		var managerOpts []grpc.DialOption
+		if opt.Scheduler.Manager.TLS != nil {
+			tlsConfig, err := opt.Scheduler.Manager.TLS.Client()
+			if err != nil {
+				return nil, err
+			}
+			managerOpts = append(managerOpts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+		}
+		managerClient, err = managerclient.NewWithAddrs(opt.Scheduler.Manager.NetAddrs, managerOpts...)
 		if err != nil {
 			return nil, err
 		}
@@ -170,8 +184,29 @@ func New(opt *config.DaemonOption, d dfpath.Dfpath) (Daemon, error) {
 			logger.Infof("step 4:leave task %s/%s state ok", request.TaskID, request.PeerID)
 		}
 	}
+	var keyProvider storage.KeyProvider
+	if opt.Storage.Encryption.Enable {
+		if opt.Storage.Encryption.KeyFile != "" {
+			keyProvider = storage.NewFileKeyProvider(opt.Storage.Encryption.KeyFile)
+		} else {
+			// the master key is fetched from manager by the client authenticated with tls, and never written to disk
+			keyProvider = storage.NewCachedKeyProvider(func() ([]byte, error) {
+				encryptionKey, err := managerClient.GetEncryptionKey(&manager.GetEncryptionKeyRequest{
+					SourceType: manager.SourceType_PEER_SOURCE,
+					HostName:   host.HostName,
+					Ip:         host.Ip,
+				})
+				if err != nil {
+					return nil, err
+				}
+				return encryptionKey.Key, nil
+			})
+		}
+	}
 	storageManager, err := storage.NewStorageManager(opt.Storage.StoreStrategy, &opt.Storage,
-		gcCallback, storage.WithGCInterval(opt.GCInterval.Duration))
+		gcCallback, storage.WithGCInterval(opt.GCInterval.Duration),
+		storage.WithDeduplication(opt.Download.CalculateDigest),
+		storage.WithEncryption(keyProvider))
 	if err != nil {
 		return nil, err
 	}
@@ -184,8 +219,28 @@ func New(opt *config.DaemonOption, d dfpath.Dfpath) (Daemon, error) {
 	if err != nil {
 		return nil, err
 	}
-	peerTaskManager, err := peer.NewPeerTaskManager(host, pieceManager, storageManager, sched, opt.Scheduler,
-		opt.Download.PerPeerRateLimit.Limit, opt.Storage.Multiplex, opt.Download.Prefetch, opt.Download.CalculateDigest,
+	hostLoad := hostload.New(opt.Storage.DataPath, config.DefaultHostLoadInterval)
+
+	var prober probe.Prober
+	if opt.Scheduler.Probe.Enable {
+		prober = probe.New(host, sched, opt.Scheduler.Probe.Interval, opt.Scheduler.Probe.Timeout)
+	}
+
+	var (
+		peerTaskManager peer.TaskManager
+		peerDiscovery   discovery.Discovery
+	)
+	if opt.Scheduler.Discovery.Enable {
+		peerDiscovery, err = discovery.New(host, opt.Scheduler.Discovery, func(taskID string) (string, bool) {
+			return findTask(storageManager, peerTaskManager, taskID)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	peerTaskManager, err = peer.NewPeerTaskManager(host, hostLoad, pieceManager, storageManager, sched, peerDiscovery, opt.Scheduler,
+		opt.Download.PerPeerRateLimit.Limit, opt.Download.TotalRateLimit.Limit, opt.Storage.Multiplex, opt.Download.Prefetch, opt.Download.CalculateDigest,
 		opt.Download.GetPiecesMaxRetry, opt.Download.WatchdogTimeout)
 	if err != nil {
 		return nil, err
@@ -243,6 +298,9 @@ func New(opt *config.DaemonOption, d dfpath.Dfpath) (Daemon, error) {
 		ObjectStorage:   objectStorage,
 		StorageManager:  storageManager,
 		GCManager:       gc.NewManager(opt.GCInterval.Duration),
+		HostLoad:        hostLoad,
+		Prober:          prober,
+		Discovery:       peerDiscovery,
 		dynconfig:       dynconfig,
 		dfpath:          d,
 		schedulers:      schedulers,
@@ -251,6 +309,25 @@ func New(opt *config.DaemonOption, d dfpath.Dfpath) (Daemon, error) {
 	}, nil
 }
 
+// findTask returns the peer holding the task for answering the queries of discovery,
+// the running task is answered only after its content length is known,
+// which avoids the peers waiting for each other when they are discovering at the same time
+func findTask(storageManager storage.Manager, peerTaskManager peer.TaskManager, taskID string) (string, bool) {
+	if task := storageManager.FindCompletedTask(taskID); task != nil {
+		return task.PeerID, true
+	}
+
+	if peerTaskManager == nil {
+		return "", false
+	}
+
+	task, ok := peerTaskManager.IsPeerTaskRunning(taskID)
+	if !ok || task.GetContentLength() < 0 {
+		return "", false
+	}
+	return task.GetPeerID(), true
+}
+
 func loadGPRCTLSCredentials(opt config.SecurityOption) (credentials.TransportCredentials, error) {
 	// Load certificate of the CA who signed client's certificate
 	pemClientCA, err := os.ReadFile(opt.CACert)
@@ -355,6 +432,10 @@ func (*clientDaemon) prepareTCPListener(opt config.ListenOption, withTLS bool) (
 
 func (cd *clientDaemon) Serve() error {
 	cd.GCManager.Start()
+	cd.HostLoad.Start()
+	if cd.Prober != nil {
+		cd.Prober.Start()
+	}
 	// prepare download service listen
 	if cd.Option.Download.DownloadGRPC.UnixListen == nil {
 		return errors.New("download grpc unix listen option is empty")
@@ -499,6 +580,17 @@ func (cd *clientDaemon) Serve() error {
 		return nil
 	})
 
+	// serve discovery service
+	if cd.Discovery != nil {
+		g.Go(func() error {
+			if err := cd.Discovery.Serve(); err != nil {
+				logger.Errorf("failed to serve for discovery service: %v", err)
+				return err
+			}
+			return nil
+		})
+	}
+
 	// enable seed peer mode
 	if cd.managerClient != nil && cd.Option.Scheduler.Manager.SeedPeer.Enable {
 		logger.Info("announce to manager")
@@ -610,6 +702,13 @@ func (cd *clientDaemon) Stop() {
 	cd.once.Do(func() {
 		close(cd.done)
 		cd.GCManager.Stop()
+		cd.HostLoad.Stop()
+		if cd.Prober != nil {
+			cd.Prober.Stop()
+		}
+		if cd.Discovery != nil {
+			cd.Discovery.Stop()
+		}
 		cd.RPCManager.Stop()
 		if err := cd.UploadManager.Stop(); err != nil {
 			logger.Errorf("upload manager stop failed %s", err)
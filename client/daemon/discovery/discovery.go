@@ -0,0 +1,392 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"d7y.io/dragonfly/v2/client/config"
+	logger "d7y.io/dragonfly/v2/internal/dflog"
+	"d7y.io/dragonfly/v2/pkg/rpc/scheduler"
+)
+
+const (
+	// messageTypeQuery asks other peers whether they hold the task
+	messageTypeQuery = "query"
+	// messageTypeAnswer tells the querier the peer holding the task
+	messageTypeAnswer = "answer"
+
+	// maxMessageSize is the max size of udp message
+	maxMessageSize = 1024
+
+	// nonceSize is the size of random nonce of query, the answer echoes it to avoid replaying
+	nonceSize = 16
+)
+
+//go:generate mockgen -source discovery.go -destination ../test/mock/discovery/discovery.go
+
+// Discovery finds the peers holding the task by gossip within the configured peers or multicast group,
+// it is used to download without scheduler when all schedulers are unreachable
+type Discovery interface {
+	// Serve answers the queries of other peers until Stop is called
+	Serve() error
+	// Stop stops answering the queries
+	Stop()
+	// FindPeers queries other peers and returns the peers holding the task
+	FindPeers(ctx context.Context, taskID string) []*scheduler.PeerPacket_DestPeer
+}
+
+// TaskFinder returns the local peer id holding the task
+type TaskFinder func(taskID string) (peerID string, ok bool)
+
+// message is signed by HMAC-SHA256 with the shared secret of peers,
+// the signature is computed with the json of message without signature
+type message struct {
+	Type      string `json:"type"`
+	TaskID    string `json:"taskID"`
+	HostID    string `json:"hostID"`
+	Nonce     string `json:"nonce"`
+	PeerID    string `json:"peerID,omitempty"`
+	IP        string `json:"ip,omitempty"`
+	RPCPort   int32  `json:"rpcPort,omitempty"`
+	Signature string `json:"signature,omitempty"`
+}
+
+type discovery struct {
+	host    *scheduler.PeerHost
+	finder  TaskFinder
+	option  config.DiscoveryOption
+	secret  []byte
+	targets []*net.UDPAddr
+	group   *net.UDPAddr
+
+	mu    sync.Mutex
+	conns []*net.UDPConn
+	done  chan struct{}
+	once  sync.Once
+}
+
+var _ Discovery = (*discovery)(nil)
+
+// New returns a Discovery which answers the queries with the tasks found by finder
+func New(host *scheduler.PeerHost, option config.DiscoveryOption, finder TaskFinder) (Discovery, error) {
+	// any host reaching the peers or multicast group can answer without secret
+	if option.Secret == "" {
+		return nil, errors.New("discovery secret is not specified")
+	}
+
+	d := &discovery{
+		host:   host,
+		finder: finder,
+		option: option,
+		secret: []byte(option.Secret),
+		done:   make(chan struct{}),
+	}
+
+	for _, peer := range option.Peers {
+		addr, err := net.ResolveUDPAddr("udp", peer)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolve discovery peer %s", peer)
+		}
+		d.targets = append(d.targets, addr)
+	}
+
+	if option.MulticastGroup != "" {
+		group, err := net.ResolveUDPAddr("udp", option.MulticastGroup)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolve discovery multicast group %s", option.MulticastGroup)
+		}
+		if !group.IP.IsMulticast() {
+			return nil, errors.Errorf("discovery multicast group %s is not a multicast address", option.MulticastGroup)
+		}
+		d.group = group
+		d.targets = append(d.targets, group)
+	}
+
+	return d, nil
+}
+
+func (d *discovery) Serve() error {
+	// bind the advertised ip of host, the answers are sent from it and the querier
+	// trusts the source address of answers only. The socket of multicast group binds
+	// the same port with SO_REUSEADDR, so the unicast socket needs it as well.
+	lc := net.ListenConfig{Control: reuseAddr}
+	pc, err := lc.ListenPacket(context.Background(), "udp", net.JoinHostPort(d.host.Ip, strconv.Itoa(d.option.ListenPort)))
+	if err != nil {
+		return err
+	}
+	conn := pc.(*net.UDPConn)
+	conns := []*net.UDPConn{conn}
+
+	if d.group != nil {
+		groupConn, err := net.ListenMulticastUDP("udp", nil, d.group)
+		if err != nil {
+			conn.Close()
+			return err
+		}
+		conns = append(conns, groupConn)
+	}
+
+	d.mu.Lock()
+	select {
+	case <-d.done:
+		// stopped before serving
+		d.mu.Unlock()
+		for _, c := range conns {
+			c.Close()
+		}
+		return nil
+	default:
+	}
+	d.conns = conns
+	d.mu.Unlock()
+
+	logger.Infof("discovery serves at %s", conn.LocalAddr())
+
+	var wg sync.WaitGroup
+	for _, c := range conns {
+		wg.Add(1)
+		go func(c *net.UDPConn) {
+			defer wg.Done()
+			// answer by the unicast conn, the querier takes its source address as the peer ip
+			d.serve(c, conn)
+		}(c)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (d *discovery) Stop() {
+	d.once.Do(func() {
+		close(d.done)
+		d.mu.Lock()
+		for _, c := range d.conns {
+			c.Close()
+		}
+		d.mu.Unlock()
+	})
+}
+
+// reuseAddr sets SO_REUSEADDR of socket before binding
+func reuseAddr(network, address string, c syscall.RawConn) error {
+	var serr error
+	if err := c.Control(func(fd uintptr) {
+		serr = syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, syscall.SO_REUSEADDR, 1)
+	}); err != nil {
+		return err
+	}
+	return serr
+}
+
+// serve reads the queries from conn and writes the answers by answerConn
+func (d *discovery) serve(conn *net.UDPConn, answerConn *net.UDPConn) {
+	buf := make([]byte, maxMessageSize)
+	for {
+		n, addr, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-d.done:
+				return
+			default:
+			}
+			logger.Warnf("discovery read query error: %s", err)
+			continue
+		}
+
+		answer, ok := d.answer(buf[:n])
+		if !ok {
+			continue
+		}
+
+		if _, err := answerConn.WriteToUDP(answer, addr); err != nil {
+			logger.Warnf("discovery write answer to %s error: %s", addr, err)
+		}
+	}
+}
+
+// answer returns the answer of query when the task is held by local peer
+func (d *discovery) answer(data []byte) ([]byte, bool) {
+	var query message
+	if err := json.Unmarshal(data, &query); err != nil {
+		logger.Debugf("discovery receives invalid message: %s", err)
+		return nil, false
+	}
+
+	// ignore the queries sent by self, e.g. the multicast loopback
+	if query.Type != messageTypeQuery || query.HostID == d.host.Id {
+		return nil, false
+	}
+
+	if !d.verify(query) {
+		logger.Warnf("discovery receives query of task %s with invalid signature from host %s", query.TaskID, query.HostID)
+		return nil, false
+	}
+
+	peerID, ok := d.finder(query.TaskID)
+	if !ok {
+		return nil, false
+	}
+
+	answer, err := d.sign(&message{
+		Type:    messageTypeAnswer,
+		TaskID:  query.TaskID,
+		HostID:  d.host.Id,
+		Nonce:   query.Nonce,
+		PeerID:  peerID,
+		IP:      d.host.Ip,
+		RPCPort: d.host.RpcPort,
+	})
+	if err != nil {
+		logger.Errorf("discovery marshal answer error: %s", err)
+		return nil, false
+	}
+
+	logger.Debugf("discovery answers task %s with peer %s to host %s", query.TaskID, peerID, query.HostID)
+	return answer, true
+}
+
+func (d *discovery) FindPeers(ctx context.Context, taskID string) []*scheduler.PeerPacket_DestPeer {
+	conn, err := net.ListenUDP("udp", nil)
+	if err != nil {
+		logger.Errorf("discovery listen error: %s", err)
+		return nil
+	}
+	defer conn.Close()
+
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		logger.Errorf("discovery generate nonce error: %s", err)
+		return nil
+	}
+
+	query, err := d.sign(&message{
+		Type:   messageTypeQuery,
+		TaskID: taskID,
+		HostID: d.host.Id,
+		Nonce:  hex.EncodeToString(nonce),
+	})
+	if err != nil {
+		logger.Errorf("discovery marshal query error: %s", err)
+		return nil
+	}
+
+	for _, target := range d.targets {
+		if _, err := conn.WriteToUDP(query, target); err != nil {
+			logger.Warnf("discovery write query to %s error: %s", target, err)
+		}
+	}
+
+	deadline := time.Now().Add(d.option.Timeout)
+	if dl, ok := ctx.Deadline(); ok && dl.Before(deadline) {
+		deadline = dl
+	}
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		logger.Errorf("discovery set read deadline error: %s", err)
+		return nil
+	}
+
+	var (
+		peers []*scheduler.PeerPacket_DestPeer
+		hosts = map[string]bool{}
+		buf   = make([]byte, maxMessageSize)
+	)
+	for len(peers) < d.option.MaxPeers {
+		n, addr, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			// timeout, return the peers found already
+			break
+		}
+
+		var answer message
+		if err := json.Unmarshal(buf[:n], &answer); err != nil {
+			logger.Debugf("discovery receives invalid message: %s", err)
+			continue
+		}
+
+		if answer.Type != messageTypeAnswer || answer.TaskID != taskID ||
+			answer.HostID == d.host.Id || hosts[answer.HostID] {
+			continue
+		}
+
+		// the answer must be signed for this query, and sent from the advertised ip of answerer
+		if !d.verify(answer) || answer.Nonce != hex.EncodeToString(nonce) {
+			logger.Warnf("discovery receives answer of task %s with invalid signature from %s", taskID, addr)
+			continue
+		}
+
+		if ip := net.ParseIP(answer.IP); ip == nil || !ip.Equal(addr.IP) {
+			logger.Warnf("discovery receives answer of task %s with ip %s from %s", taskID, answer.IP, addr)
+			continue
+		}
+
+		hosts[answer.HostID] = true
+		peers = append(peers, &scheduler.PeerPacket_DestPeer{
+			Ip:      addr.IP.String(),
+			RpcPort: answer.RPCPort,
+			PeerId:  answer.PeerID,
+		})
+	}
+
+	logger.Infof("discovery finds %d peers holding task %s", len(peers), taskID)
+	return peers
+}
+
+// sign returns the json of message signed with the shared secret
+func (d *discovery) sign(m *message) ([]byte, error) {
+	m.Signature = ""
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	m.Signature = hex.EncodeToString(d.mac(data))
+	return json.Marshal(m)
+}
+
+// verify returns whether the message is signed with the shared secret
+func (d *discovery) verify(m message) bool {
+	signature, err := hex.DecodeString(m.Signature)
+	if err != nil || len(signature) == 0 {
+		return false
+	}
+
+	m.Signature = ""
+	data, err := json.Marshal(&m)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(signature, d.mac(data))
+}
+
+func (d *discovery) mac(data []byte) []byte {
+	h := hmac.New(sha256.New, d.secret)
+	h.Write(data)
+	return h.Sum(nil)
+}
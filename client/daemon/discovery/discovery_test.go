@@ -0,0 +1,439 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"d7y.io/dragonfly/v2/client/config"
+	"d7y.io/dragonfly/v2/pkg/rpc/scheduler"
+)
+
+func freeUDPPort(t *testing.T) int {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).Port
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name   string
+		option config.DiscoveryOption
+		expect func(t *testing.T, d Discovery, err error)
+	}{
+		{
+			name: "new discovery with peers and multicast group",
+			option: config.DiscoveryOption{
+				Peers:          []string{"127.0.0.1:65008", "127.0.0.2:65008"},
+				MulticastGroup: "239.255.0.1:65008",
+				Secret:         "secret",
+			},
+			expect: func(t *testing.T, d Discovery, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				assert.Equal(3, len(d.(*discovery).targets))
+				assert.Equal("239.255.0.1:65008", d.(*discovery).group.String())
+			},
+		},
+		{
+			name: "new discovery with invalid peer",
+			option: config.DiscoveryOption{
+				Peers:  []string{"127.0.0.1"},
+				Secret: "secret",
+			},
+			expect: func(t *testing.T, d Discovery, err error) {
+				assert := assert.New(t)
+				assert.Error(err)
+			},
+		},
+		{
+			name: "new discovery with unicast group",
+			option: config.DiscoveryOption{
+				MulticastGroup: "127.0.0.1:65008",
+				Secret:         "secret",
+			},
+			expect: func(t *testing.T, d Discovery, err error) {
+				assert := assert.New(t)
+				assert.EqualError(err, "discovery multicast group 127.0.0.1:65008 is not a multicast address")
+			},
+		},
+		{
+			name: "new discovery without secret",
+			option: config.DiscoveryOption{
+				Peers: []string{"127.0.0.1:65008"},
+			},
+			expect: func(t *testing.T, d Discovery, err error) {
+				assert := assert.New(t)
+				assert.EqualError(err, "discovery secret is not specified")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := New(&scheduler.PeerHost{Id: "foo"}, tc.option, func(string) (string, bool) { return "", false })
+			tc.expect(t, d, err)
+		})
+	}
+}
+
+func TestDiscovery_FindPeers(t *testing.T) {
+	tests := []struct {
+		name     string
+		tasks    []map[string]string
+		maxPeers int
+		taskID   string
+		expect   func(t *testing.T, peers []*scheduler.PeerPacket_DestPeer)
+	}{
+		{
+			name:     "find peers holding the task",
+			tasks:    []map[string]string{{"foo": "peer-0"}, {"bar": "peer-1"}, {"foo": "peer-2"}},
+			maxPeers: 3,
+			taskID:   "foo",
+			expect: func(t *testing.T, peers []*scheduler.PeerPacket_DestPeer) {
+				assert := assert.New(t)
+				assert.Equal(2, len(peers))
+				ids := []string{peers[0].PeerId, peers[1].PeerId}
+				assert.ElementsMatch([]string{"peer-0", "peer-2"}, ids)
+				for _, peer := range peers {
+					assert.Equal("127.0.0.1", peer.Ip)
+				}
+			},
+		},
+		{
+			name:     "find peers limited by max peers",
+			tasks:    []map[string]string{{"foo": "peer-0"}, {"foo": "peer-1"}, {"foo": "peer-2"}},
+			maxPeers: 1,
+			taskID:   "foo",
+			expect: func(t *testing.T, peers []*scheduler.PeerPacket_DestPeer) {
+				assert := assert.New(t)
+				assert.Equal(1, len(peers))
+			},
+		},
+		{
+			name:     "task is not found",
+			tasks:    []map[string]string{{"foo": "peer-0"}, {"bar": "peer-1"}},
+			maxPeers: 3,
+			taskID:   "baz",
+			expect: func(t *testing.T, peers []*scheduler.PeerPacket_DestPeer) {
+				assert := assert.New(t)
+				assert.Empty(peers)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var addrs []string
+			for i, tasks := range tc.tasks {
+				tasks := tasks
+				port := freeUDPPort(t)
+				addrs = append(addrs, fmt.Sprintf("127.0.0.1:%d", port))
+				d, err := New(&scheduler.PeerHost{
+					Id:      fmt.Sprintf("host-%d", i),
+					Ip:      "127.0.0.1",
+					RpcPort: int32(65000 + i),
+				}, config.DiscoveryOption{ListenPort: port, Secret: "secret"}, func(taskID string) (string, bool) {
+					peerID, ok := tasks[taskID]
+					return peerID, ok
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				go d.Serve()
+				defer d.Stop()
+			}
+			// wait for serving
+			time.Sleep(100 * time.Millisecond)
+
+			querier, err := New(&scheduler.PeerHost{Id: "querier"}, config.DiscoveryOption{
+				Peers:    addrs,
+				Secret:   "secret",
+				Timeout:  500 * time.Millisecond,
+				MaxPeers: tc.maxPeers,
+			}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tc.expect(t, querier.FindPeers(context.Background(), tc.taskID))
+		})
+	}
+}
+
+func TestDiscovery_FindPeersWithForgedAnswer(t *testing.T) {
+	tests := []struct {
+		name   string
+		forge  func(answerer *discovery, query message) []byte
+		expect func(t *testing.T, peers []*scheduler.PeerPacket_DestPeer)
+	}{
+		{
+			name: "accept answer signed for the query",
+			forge: func(answerer *discovery, query message) []byte {
+				answer, _ := answerer.answer(mustSign(answerer, &query))
+				return answer
+			},
+			expect: func(t *testing.T, peers []*scheduler.PeerPacket_DestPeer) {
+				assert := assert.New(t)
+				assert.Equal(1, len(peers))
+				assert.Equal("127.0.0.1", peers[0].Ip)
+				assert.Equal(int32(65000), peers[0].RpcPort)
+				assert.Equal("peer", peers[0].PeerId)
+			},
+		},
+		{
+			name: "drop answer without signature",
+			forge: func(answerer *discovery, query message) []byte {
+				return []byte(fmt.Sprintf(`{"type":"answer","taskID":"foo","hostID":"host","nonce":"%s","peerID":"peer","ip":"127.0.0.1","rpcPort":65000}`, query.Nonce))
+			},
+			expect: func(t *testing.T, peers []*scheduler.PeerPacket_DestPeer) {
+				assert := assert.New(t)
+				assert.Empty(peers)
+			},
+		},
+		{
+			name: "drop answer signed with other secret",
+			forge: func(answerer *discovery, query message) []byte {
+				answerer.secret = []byte("other")
+				return mustSign(answerer, &message{Type: messageTypeAnswer, TaskID: "foo", HostID: "host", Nonce: query.Nonce, PeerID: "peer", IP: "127.0.0.1", RPCPort: 65000})
+			},
+			expect: func(t *testing.T, peers []*scheduler.PeerPacket_DestPeer) {
+				assert := assert.New(t)
+				assert.Empty(peers)
+			},
+		},
+		{
+			name: "drop answer replayed for other query",
+			forge: func(answerer *discovery, query message) []byte {
+				return mustSign(answerer, &message{Type: messageTypeAnswer, TaskID: "foo", HostID: "host", Nonce: "other", PeerID: "peer", IP: "127.0.0.1", RPCPort: 65000})
+			},
+			expect: func(t *testing.T, peers []*scheduler.PeerPacket_DestPeer) {
+				assert := assert.New(t)
+				assert.Empty(peers)
+			},
+		},
+		{
+			name: "drop answer with ip of other host",
+			forge: func(answerer *discovery, query message) []byte {
+				return mustSign(answerer, &message{Type: messageTypeAnswer, TaskID: "foo", HostID: "host", Nonce: query.Nonce, PeerID: "peer", IP: "10.0.0.1", RPCPort: 65000})
+			},
+			expect: func(t *testing.T, peers []*scheduler.PeerPacket_DestPeer) {
+				assert := assert.New(t)
+				assert.Empty(peers)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			answerer := &discovery{
+				host: &scheduler.PeerHost{
+					Id:      "host",
+					Ip:      "127.0.0.1",
+					RpcPort: 65000,
+				},
+				finder: func(taskID string) (string, bool) {
+					return "peer", taskID == "foo"
+				},
+				secret: []byte("secret"),
+			}
+			go func() {
+				buf := make([]byte, maxMessageSize)
+				n, addr, err := conn.ReadFromUDP(buf)
+				if err != nil {
+					return
+				}
+				var query message
+				if err := json.Unmarshal(buf[:n], &query); err != nil {
+					return
+				}
+				conn.WriteToUDP(tc.forge(answerer, query), addr) // nolint: errcheck
+			}()
+
+			querier, err := New(&scheduler.PeerHost{Id: "querier"}, config.DiscoveryOption{
+				Peers:    []string{conn.LocalAddr().String()},
+				Secret:   "secret",
+				Timeout:  500 * time.Millisecond,
+				MaxPeers: 1,
+			}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tc.expect(t, querier.FindPeers(context.Background(), "foo"))
+		})
+	}
+}
+
+func mustSign(d *discovery, m *message) []byte {
+	data, err := d.sign(m)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func TestDiscovery_ServeWithMulticastGroup(t *testing.T) {
+	assert := assert.New(t)
+	port := freeUDPPort(t)
+	d, err := New(&scheduler.PeerHost{
+		Id:      "host",
+		Ip:      "127.0.0.1",
+		RpcPort: 65000,
+	}, config.DiscoveryOption{
+		ListenPort:     port,
+		MulticastGroup: fmt.Sprintf("239.255.0.1:%d", port),
+		Secret:         "secret",
+	}, func(taskID string) (string, bool) {
+		return "peer", taskID == "foo"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- d.Serve()
+	}()
+
+	// the unicast and multicast sockets share the same port
+	select {
+	case err := <-serveErr:
+		t.Fatalf("serve failed: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	querier, err := New(&scheduler.PeerHost{Id: "querier"}, config.DiscoveryOption{
+		Peers:    []string{fmt.Sprintf("127.0.0.1:%d", port)},
+		Secret:   "secret",
+		Timeout:  500 * time.Millisecond,
+		MaxPeers: 1,
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peers := querier.FindPeers(context.Background(), "foo")
+	assert.Equal(1, len(peers))
+	assert.Equal("peer", peers[0].PeerId)
+
+	d.Stop()
+	assert.NoError(<-serveErr)
+}
+
+func TestDiscovery_answer(t *testing.T) {
+	d := &discovery{
+		host: &scheduler.PeerHost{
+			Id:      "host",
+			Ip:      "127.0.0.1",
+			RpcPort: 65000,
+		},
+		finder: func(taskID string) (string, bool) {
+			return "peer", taskID == "foo"
+		},
+		secret: []byte("secret"),
+	}
+
+	tests := []struct {
+		name   string
+		data   []byte
+		expect func(t *testing.T, answer []byte, ok bool)
+	}{
+		{
+			name: "answer query",
+			data: mustSign(d, &message{Type: messageTypeQuery, TaskID: "foo", HostID: "bar", Nonce: "nonce"}),
+			expect: func(t *testing.T, answer []byte, ok bool) {
+				assert := assert.New(t)
+				assert.True(ok)
+				var m message
+				assert.NoError(json.Unmarshal(answer, &m))
+				assert.True(d.verify(m))
+				m.Signature = ""
+				assert.Equal(message{Type: messageTypeAnswer, TaskID: "foo", HostID: "host", Nonce: "nonce", PeerID: "peer", IP: "127.0.0.1", RPCPort: 65000}, m)
+			},
+		},
+		{
+			name: "ignore query without signature",
+			data: []byte(`{"type":"query","taskID":"foo","hostID":"bar","nonce":"nonce"}`),
+			expect: func(t *testing.T, answer []byte, ok bool) {
+				assert := assert.New(t)
+				assert.False(ok)
+			},
+		},
+		{
+			name: "ignore query with invalid signature",
+			data: []byte(`{"type":"query","taskID":"foo","hostID":"bar","nonce":"nonce","signature":"00"}`),
+			expect: func(t *testing.T, answer []byte, ok bool) {
+				assert := assert.New(t)
+				assert.False(ok)
+			},
+		},
+		{
+			name: "ignore query sent by self",
+			data: mustSign(d, &message{Type: messageTypeQuery, TaskID: "foo", HostID: "host", Nonce: "nonce"}),
+			expect: func(t *testing.T, answer []byte, ok bool) {
+				assert := assert.New(t)
+				assert.False(ok)
+			},
+		},
+		{
+			name: "ignore answer",
+			data: mustSign(d, &message{Type: messageTypeAnswer, TaskID: "foo", HostID: "bar", Nonce: "nonce"}),
+			expect: func(t *testing.T, answer []byte, ok bool) {
+				assert := assert.New(t)
+				assert.False(ok)
+			},
+		},
+		{
+			name: "ignore query of unknown task",
+			data: mustSign(d, &message{Type: messageTypeQuery, TaskID: "baz", HostID: "bar", Nonce: "nonce"}),
+			expect: func(t *testing.T, answer []byte, ok bool) {
+				assert := assert.New(t)
+				assert.False(ok)
+			},
+		},
+		{
+			name: "ignore invalid message",
+			data: []byte(`{`),
+			expect: func(t *testing.T, answer []byte, ok bool) {
+				assert := assert.New(t)
+				assert.False(ok)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			answer, ok := d.answer(tc.data)
+			tc.expect(t, answer, ok)
+		})
+	}
+}
@@ -0,0 +1,253 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hostload
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/disk"
+
+	logger "d7y.io/dragonfly/v2/internal/dflog"
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
+)
+
+// Collector samples the load of current host periodically
+type Collector interface {
+	// Start starts to sample host load in background
+	Start()
+	// Stop stops sampling host load
+	Stop()
+	// Load returns the latest sampled host load, returns nil when not sampled yet
+	Load() *base.HostLoad
+}
+
+type collector struct {
+	dataPath string
+	interval time.Duration
+	done     chan bool
+
+	// load stores the latest *base.HostLoad
+	load atomic.Value
+	// lastCPU is the cpu times of previous sample, used for calculating cpu ratio
+	lastCPU *cpuTimes
+}
+
+var _ Collector = (*collector)(nil)
+
+// New returns a Collector which samples cpu, memory and the disk usage of dataPath every interval
+func New(dataPath string, interval time.Duration) Collector {
+	return &collector{
+		dataPath: dataPath,
+		interval: interval,
+		done:     make(chan bool),
+	}
+}
+
+func (c *collector) Start() {
+	c.sample()
+	go func() {
+		tick := time.NewTicker(c.interval)
+		defer tick.Stop()
+		for {
+			select {
+			case <-tick.C:
+				c.sample()
+			case <-c.done:
+				logger.Infof("host load collector exited")
+				return
+			}
+		}
+	}()
+}
+
+func (c *collector) Stop() {
+	close(c.done)
+}
+
+func (c *collector) Load() *base.HostLoad {
+	load, ok := c.load.Load().(*base.HostLoad)
+	if !ok {
+		return nil
+	}
+
+	// return a copy, the result will be sent in grpc messages concurrently
+	return &base.HostLoad{
+		CpuRatio:  load.CpuRatio,
+		MemRatio:  load.MemRatio,
+		DiskRatio: load.DiskRatio,
+	}
+}
+
+func (c *collector) sample() {
+	load := &base.HostLoad{}
+
+	cpu, err := readCPUTimes()
+	if err != nil {
+		logger.Debugf("read cpu times error: %s", err)
+	} else {
+		if c.lastCPU != nil {
+			load.CpuRatio = cpu.ratio(c.lastCPU)
+		}
+		c.lastCPU = cpu
+	}
+
+	if load.MemRatio, err = readMemRatio(); err != nil {
+		logger.Debugf("read memory ratio error: %s", err)
+	}
+
+	if c.dataPath != "" {
+		usage, err := disk.Usage(c.dataPath)
+		if err != nil {
+			logger.Debugf("read disk usage of %s error: %s", c.dataPath, err)
+		} else {
+			load.DiskRatio = clamp(float32(usage.UsedPercent / 100))
+		}
+	}
+
+	c.load.Store(load)
+}
+
+// cpuTimes is the accumulated cpu time in /proc/stat
+type cpuTimes struct {
+	idle  uint64
+	total uint64
+}
+
+// ratio returns the busy ratio of cpu between prev and c
+func (c *cpuTimes) ratio(prev *cpuTimes) float32 {
+	if c.total <= prev.total || c.idle < prev.idle {
+		return 0
+	}
+
+	total := c.total - prev.total
+	idle := c.idle - prev.idle
+	if idle > total {
+		return 0
+	}
+
+	return clamp(float32(total-idle) / float32(total))
+}
+
+// parseCPUTimes parses the aggregate cpu line of /proc/stat
+func parseCPUTimes(r io.Reader) (*cpuTimes, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 || fields[0] != "cpu" {
+			continue
+		}
+
+		times := &cpuTimes{}
+		for i, field := range fields[1:] {
+			v, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+
+			// guest and guest_nice are already accounted in user and nice
+			if i >= 8 {
+				break
+			}
+
+			times.total += v
+			// idle and iowait
+			if i == 3 || i == 4 {
+				times.idle += v
+			}
+		}
+
+		return times, nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("cpu line not found")
+}
+
+// parseMemRatio parses /proc/meminfo and returns the used ratio of memory
+func parseMemRatio(r io.Reader) (float32, error) {
+	var (
+		total, available, free, buffers, cached uint64
+		hasAvailable                            bool
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		v, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0, err
+		}
+
+		switch fields[0] {
+		case "MemTotal:":
+			total = v
+		case "MemAvailable:":
+			available = v
+			hasAvailable = true
+		case "MemFree:":
+			free = v
+		case "Buffers:":
+			buffers = v
+		case "Cached:":
+			cached = v
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	if total == 0 {
+		return 0, fmt.Errorf("MemTotal not found")
+	}
+
+	// old kernels do not export MemAvailable
+	if !hasAvailable {
+		available = free + buffers + cached
+	}
+
+	if available > total {
+		return 0, nil
+	}
+
+	return clamp(float32(total-available) / float32(total)), nil
+}
+
+func clamp(ratio float32) float32 {
+	if ratio < 0 {
+		return 0
+	}
+
+	if ratio > 1 {
+		return 1
+	}
+
+	return ratio
+}
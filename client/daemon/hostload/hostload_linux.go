@@ -0,0 +1,46 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hostload
+
+import (
+	"os"
+)
+
+const (
+	procStat    = "/proc/stat"
+	procMeminfo = "/proc/meminfo"
+)
+
+func readCPUTimes() (*cpuTimes, error) {
+	f, err := os.Open(procStat)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseCPUTimes(f)
+}
+
+func readMemRatio() (float32, error) {
+	f, err := os.Open(procMeminfo)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return parseMemRatio(f)
+}
@@ -0,0 +1,156 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hostload
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCPUTimes(t *testing.T) {
+	tests := []struct {
+		name   string
+		stat   string
+		expect func(t *testing.T, times *cpuTimes, err error)
+	}{
+		{
+			name: "parse aggregate cpu line",
+			stat: "cpu  100 20 30 800 50 0 0 0 10 0\ncpu0 50 10 15 400 25 0 0 0 5 0\nintr 1\n",
+			expect: func(t *testing.T, times *cpuTimes, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				assert.Equal(uint64(850), times.idle)
+				assert.Equal(uint64(1000), times.total)
+			},
+		},
+		{
+			name: "cpu line not found",
+			stat: "intr 1\n",
+			expect: func(t *testing.T, times *cpuTimes, err error) {
+				assert := assert.New(t)
+				assert.EqualError(err, "cpu line not found")
+			},
+		},
+		{
+			name: "invalid cpu line",
+			stat: "cpu  100 foo 30 800 50\n",
+			expect: func(t *testing.T, times *cpuTimes, err error) {
+				assert := assert.New(t)
+				assert.Error(err)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			times, err := parseCPUTimes(strings.NewReader(tc.stat))
+			tc.expect(t, times, err)
+		})
+	}
+}
+
+func TestCPUTimes_Ratio(t *testing.T) {
+	tests := []struct {
+		name   string
+		prev   *cpuTimes
+		cur    *cpuTimes
+		expect float32
+	}{
+		{
+			name:   "half busy",
+			prev:   &cpuTimes{idle: 100, total: 200},
+			cur:    &cpuTimes{idle: 150, total: 300},
+			expect: 0.5,
+		},
+		{
+			name:   "total not changed",
+			prev:   &cpuTimes{idle: 100, total: 200},
+			cur:    &cpuTimes{idle: 100, total: 200},
+			expect: 0,
+		},
+		{
+			name:   "counter reset",
+			prev:   &cpuTimes{idle: 100, total: 200},
+			cur:    &cpuTimes{idle: 10, total: 20},
+			expect: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, tc.cur.ratio(tc.prev))
+		})
+	}
+}
+
+func TestParseMemRatio(t *testing.T) {
+	tests := []struct {
+		name    string
+		meminfo string
+		expect  func(t *testing.T, ratio float32, err error)
+	}{
+		{
+			name:    "parse with MemAvailable",
+			meminfo: "MemTotal:       1000 kB\nMemFree:         100 kB\nMemAvailable:    250 kB\nBuffers:          10 kB\nCached:           20 kB\n",
+			expect: func(t *testing.T, ratio float32, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				assert.Equal(float32(0.75), ratio)
+			},
+		},
+		{
+			name:    "parse without MemAvailable",
+			meminfo: "MemTotal:       1000 kB\nMemFree:         100 kB\nBuffers:          50 kB\nCached:          350 kB\n",
+			expect: func(t *testing.T, ratio float32, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				assert.Equal(float32(0.5), ratio)
+			},
+		},
+		{
+			name:    "MemTotal not found",
+			meminfo: "MemFree:         100 kB\n",
+			expect: func(t *testing.T, ratio float32, err error) {
+				assert := assert.New(t)
+				assert.EqualError(err, "MemTotal not found")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ratio, err := parseMemRatio(strings.NewReader(tc.meminfo))
+			tc.expect(t, ratio, err)
+		})
+	}
+}
+
+func TestCollector_Load(t *testing.T) {
+	assert := assert.New(t)
+	c := New(t.TempDir(), time.Hour)
+	assert.Nil(c.Load())
+
+	c.Start()
+	defer c.Stop()
+
+	load := c.Load()
+	assert.NotNil(load)
+	assert.NoError(load.Validate())
+}
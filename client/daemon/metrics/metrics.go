@@ -159,6 +159,34 @@ var (
 		Name:      "prefetch_task_total",
 		Help:      "Counter of the total prefetched tasks.",
 	})
+
+	StorageEvictionCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: constants.MetricsNamespace,
+		Subsystem: constants.DfdaemonMetricsName,
+		Name:      "storage_eviction_total",
+		Help:      "Counter of the total evicted tasks in storage.",
+	}, []string{"reason"})
+
+	StorageEvictionBytesCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: constants.MetricsNamespace,
+		Subsystem: constants.DfdaemonMetricsName,
+		Name:      "storage_eviction_bytes_total",
+		Help:      "Counter of the total bytes of evicted tasks in storage.",
+	}, []string{"reason"})
+
+	StorageQuotaUsageGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: constants.MetricsNamespace,
+		Subsystem: constants.DfdaemonMetricsName,
+		Name:      "storage_quota_usage_bytes",
+		Help:      "Gauger of the disk usage of the task group limited by storage quota.",
+	}, []string{"group"})
+
+	StorageQuotaLimitGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: constants.MetricsNamespace,
+		Subsystem: constants.DfdaemonMetricsName,
+		Name:      "storage_quota_limit_bytes",
+		Help:      "Gauger of the disk limit of the task group limited by storage quota.",
+	}, []string{"group"})
 )
 
 func New(addr string) *http.Server {
@@ -70,3 +70,10 @@ func (b *Bitmap) Sets(xs ...int32) {
 		b.Set(x)
 	}
 }
+
+// Bytes returns a copy of bits, the most significant bit of byte i/8 is the first bit.
+func (b *Bitmap) Bytes() []byte {
+	bits := make([]byte, len(b.bits))
+	copy(bits, b.bits)
+	return bits
+}
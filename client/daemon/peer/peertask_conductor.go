@@ -30,6 +30,8 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/atomic"
 	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"d7y.io/dragonfly/v2/client/clientutil"
 	"d7y.io/dragonfly/v2/client/config"
@@ -159,6 +161,9 @@ type peerTaskConductor struct {
 
 	startTime time.Time
 
+	// callsystem is the caller system of task, it matches the storage quota of task
+	callsystem string
+
 	// subtask only
 	parent *peerTaskConductor
 	rg     *clientutil.Range
@@ -170,6 +175,7 @@ func (ptm *peerTaskManager) newPeerTaskConductor(
 	limit rate.Limit,
 	parent *peerTaskConductor,
 	rg *clientutil.Range,
+	callsystem string,
 	seed bool) *peerTaskConductor {
 	// use a new context with span info
 	ctx = trace.ContextWithSpan(context.Background(), trace.SpanFromContext(ctx))
@@ -181,6 +187,9 @@ func (ptm *peerTaskManager) newPeerTaskConductor(
 
 	taskID := idgen.TaskID(request.Url, request.UrlMeta)
 	request.TaskId = taskID
+	if request.HostLoad == nil {
+		request.HostLoad = ptm.loadHost()
+	}
 
 	var (
 		log     *logger.SugaredLoggerOnWith
@@ -234,6 +243,7 @@ func (ptm *peerTaskManager) newPeerTaskConductor(
 		usedTraffic:         atomic.NewUint64(0),
 		SugaredLoggerOnWith: log,
 		seed:                seed,
+		callsystem:          callsystem,
 
 		parent: parent,
 		rg:     rg,
@@ -285,11 +295,40 @@ func (pt *peerTaskConductor) register() error {
 			pt.cancel(base.Code_SchedError, err.Error())
 			return err
 		}
-		needBackSource = true
-		// can not detect source or scheduler error, create a new dummy scheduler client
-		pt.schedulerClient = &dummySchedulerClient{}
-		result = &scheduler.RegisterResult{TaskId: pt.taskID}
-		pt.Warnf("register peer task failed: %s, peer id: %s, try to back source", err, pt.request.PeerId)
+		// back source is not allowed when the quota of application is exceeded
+		if de, ok := err.(*dferrors.DfError); ok && isQuotaExceeded(de.Code) {
+			pt.peerPacketStream = &dummyPeerPacketStream{}
+			pt.Errorf("register peer task failed: %s, peer id: %s, quota exceeded", err, pt.request.PeerId)
+			pt.span.RecordError(err)
+			pt.cancel(de.Code, de.Message)
+			return err
+		}
+		// back source is not allowed when the url is denied by url policy of scheduler
+		if de, ok := err.(*dferrors.DfError); ok && de.Code == base.Code_SchedURLDenied {
+			pt.peerPacketStream = &dummyPeerPacketStream{}
+			pt.Errorf("register peer task failed: %s, peer id: %s, url denied", err, pt.request.PeerId)
+			pt.span.RecordError(err)
+			pt.cancel(de.Code, de.Message)
+			return err
+		}
+		// schedulers are unreachable, try to download from the peers holding the task,
+		// to avoid all peers back source at the same time
+		var peers []*scheduler.PeerPacket_DestPeer
+		if isSchedulerUnreachable(err) {
+			peers = pt.discoverPeers()
+		}
+		if len(peers) > 0 {
+			pt.schedulerClient = newGossipSchedulerClient(pt.taskID, pt.peerID, peers)
+			result = &scheduler.RegisterResult{TaskId: pt.taskID, SizeScope: base.SizeScope_NORMAL}
+			pt.Warnf("register peer task failed: %s, peer id: %s, try to download from %d discovered peers", err, pt.request.PeerId, len(peers))
+		} else {
+			// scheduler says download from source directly, or can not detect source or scheduler error,
+			// create a new dummy scheduler client
+			needBackSource = true
+			pt.schedulerClient = &dummySchedulerClient{}
+			result = &scheduler.RegisterResult{TaskId: pt.taskID}
+			pt.Warnf("register peer task failed: %s, peer id: %s, try to back source", err, pt.request.PeerId)
+		}
 	} else {
 		pt.Infof("register task success, SizeScope: %s", base.SizeScope_name[int32(result.SizeScope)])
 	}
@@ -353,6 +392,19 @@ func (pt *peerTaskConductor) register() error {
 	return nil
 }
 
+// discoverPeers finds the peers holding the task by discovery, returns nil when discovery is disabled
+func (pt *peerTaskConductor) discoverPeers() []*scheduler.PeerPacket_DestPeer {
+	if pt.peerTaskManager.discovery == nil {
+		return nil
+	}
+
+	ctx, span := tracer.Start(pt.ctx, config.SpanDiscoverPeers)
+	defer span.End()
+	peers := pt.peerTaskManager.discovery.FindPeers(ctx, pt.taskID)
+	span.SetAttributes(config.AttributeDiscoveredPeers.Int(len(peers)))
+	return peers
+}
+
 func (pt *peerTaskConductor) start() error {
 	// when is seed task, setup back source
 	if pt.seed {
@@ -558,6 +610,9 @@ func (pt *peerTaskConductor) storeTinyPeerTask() {
 			ContentLength:   contentLength,
 			TotalPieces:     1,
 			// TODO check digest
+			Tag:         pt.request.UrlMeta.Tag,
+			Application: pt.request.UrlMeta.Application,
+			Callsystem:  pt.callsystem,
 		})
 	pt.storage = storageDriver
 	if err != nil {
@@ -615,6 +670,7 @@ func (pt *peerTaskConductor) receivePeerPacket(pieceRequestCh chan *DownloadPiec
 		peerPacket          *scheduler.PeerPacket
 		err                 error
 		firstPacketReceived bool
+		resumeTimes         int
 	)
 	// only record first schedule result
 	// other schedule result will record as an event in peer task span
@@ -652,12 +708,17 @@ loop:
 			break loop
 		}
 		if err != nil {
+			// stream is broken by transient errors, resume it and keep downloading
+			if pt.resumePeerPacketStream(err, &resumeTimes) {
+				continue
+			}
 			pt.confirmReceivePeerPacketError(err)
 			if !firstPacketReceived {
 				firstPeerSpan.RecordError(err)
 			}
 			break loop
 		}
+		resumeTimes = 0
 
 		pt.Debugf("receive peerPacket %v", peerPacket)
 		if peerPacket.Code != base.Code_Success {
@@ -775,6 +836,80 @@ func (pt *peerTaskConductor) confirmReceivePeerPacketError(err error) {
 	return
 }
 
+// resumePeerPacketStream reopens the stream of reporting piece results with the ready pieces,
+// when the stream is broken by transient errors, e.g. rolling restart of scheduler.
+// Scheduler re-attaches the peer to the new stream and keeps its parents,
+// resumeTimes is the times of resuming since the last peer packet received.
+func (pt *peerTaskConductor) resumePeerPacketStream(cause error, resumeTimes *int) bool {
+	option := pt.schedulerOption.StreamResume
+	if !option.Enable {
+		return false
+	}
+
+	for ; *resumeTimes < option.RetryLimit && pt.isResumableError(cause); *resumeTimes++ {
+		select {
+		case <-pt.successCh:
+			return false
+		case <-pt.failCh:
+			return false
+		case <-time.After(option.RetryInterval):
+		}
+
+		pt.Warnf("resume peer packet stream %d times, cause: %s", *resumeTimes+1, cause)
+		// peer is not found in scheduler, e.g. scheduler is restarted without resources snapshot,
+		// register the peer again with the same peer id before resuming
+		if de, ok := cause.(*dferrors.DfError); ok && de.Code == base.Code_SchedPeerNotFound {
+			regCtx, cancel := context.WithTimeout(pt.ctx, pt.schedulerOption.ScheduleTimeout.Duration)
+			_, err := pt.schedulerClient.RegisterPeerTask(regCtx, pt.request)
+			cancel()
+			if err != nil {
+				pt.Errorf("register peer task for resuming failed: %s", err)
+				cause = err
+				continue
+			}
+		}
+
+		pt.readyPiecesLock.RLock()
+		finishedPieces := pt.readyPieces.Bytes()
+		pt.readyPiecesLock.RUnlock()
+
+		peerPacketStream, err := pt.schedulerClient.ResumePieceResult(pt.ctx, pt.request, finishedPieces)
+		if err != nil {
+			pt.Errorf("resume peer packet stream failed: %s", err)
+			cause = err
+			continue
+		}
+
+		pt.sendPieceResultLock.Lock()
+		pt.peerPacketStream = peerPacketStream
+		pt.sendPieceResultLock.Unlock()
+
+		*resumeTimes++
+		pt.span.AddEvent("resume peer packet stream")
+		pt.Infof("resume peer packet stream success")
+		return true
+	}
+
+	return false
+}
+
+// isResumableError returns whether the stream broken by the error can be resumed
+func (pt *peerTaskConductor) isResumableError(err error) bool {
+	if pt.ctx.Err() != nil {
+		return false
+	}
+
+	if de, ok := err.(*dferrors.DfError); ok {
+		return de.Code == base.Code_SchedPeerNotFound
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Aborted, codes.Internal, codes.Unknown:
+		return true
+	}
+	return false
+}
+
 func (pt *peerTaskConductor) isExitPeerPacketCode(pp *scheduler.PeerPacket) bool {
 	switch pp.Code {
 	case base.Code_ResourceLacked, base.Code_BadRequest,
@@ -1227,7 +1362,7 @@ func (pt *peerTaskConductor) waitLimit(ctx context.Context, request *DownloadPie
 		PieceInfo:     request.piece,
 		Success:       false,
 		Code:          base.Code_ClientRequestLimitFail,
-		HostLoad:      nil,
+		HostLoad:      pt.ptm.loadHost(),
 		FinishedCount: 0, // update by peer task
 	})
 	if sendError != nil {
@@ -1331,7 +1466,7 @@ func (pt *peerTaskConductor) reportSuccessResult(request *DownloadPieceRequest,
 			EndTime:       uint64(result.FinishTime),
 			Success:       true,
 			Code:          base.Code_Success,
-			HostLoad:      nil, // TODO(jim): update host load
+			HostLoad:      pt.ptm.loadHost(),
 			FinishedCount: pt.readyPieces.Settled(),
 			// TODO range_start, range_size, piece_md5, piece_offset, piece_style
 		})
@@ -1357,7 +1492,7 @@ func (pt *peerTaskConductor) reportFailResult(request *DownloadPieceRequest, res
 		EndTime:       uint64(result.FinishTime),
 		Success:       false,
 		Code:          code,
-		HostLoad:      nil,
+		HostLoad:      pt.ptm.loadHost(),
 		FinishedCount: pt.readyPieces.Settled(),
 	})
 	if err != nil {
@@ -1369,6 +1504,11 @@ func (pt *peerTaskConductor) reportFailResult(request *DownloadPieceRequest, res
 func (pt *peerTaskConductor) initStorage(desiredLocation string) (err error) {
 	// prepare storage
 	if pt.parent == nil {
+		var digest string
+		// the digest is of the whole file, ranged task holds part of it
+		if pt.request.UrlMeta.Range == "" {
+			digest = pt.request.UrlMeta.Digest
+		}
 		pt.storage, err = pt.storageManager.RegisterTask(pt.ctx,
 			&storage.RegisterTaskRequest{
 				PeerTaskMetadata: storage.PeerTaskMetadata{
@@ -1379,6 +1519,10 @@ func (pt *peerTaskConductor) initStorage(desiredLocation string) (err error) {
 				ContentLength:   pt.GetContentLength(),
 				TotalPieces:     pt.GetTotalPieces(),
 				PieceMd5Sign:    pt.GetPieceMd5Sign(),
+				Digest:          digest,
+				Tag:             pt.request.UrlMeta.Tag,
+				Application:     pt.request.UrlMeta.Application,
+				Callsystem:      pt.callsystem,
 			})
 	} else {
 		pt.storage, err = pt.storageManager.RegisterSubTask(pt.ctx,
@@ -1483,7 +1627,7 @@ func (pt *peerTaskConductor) done() {
 		schedulerclient.NewEndOfPiece(pt.taskID, pt.peerID, pt.readyPieces.Settled()))
 	pt.Debugf("peer task finished, end piece result sent result: %v", err)
 
-	err = pt.peerPacketStream.CloseSend()
+	err = pt.closePeerPacketStream()
 	pt.Debugf("close stream result: %v", err)
 
 	err = pt.schedulerClient.ReportPeerResult(
@@ -1538,7 +1682,7 @@ func (pt *peerTaskConductor) fail() {
 		schedulerclient.NewEndOfPiece(pt.taskID, pt.peerID, pt.readyPieces.Settled()))
 	pt.Debugf("end piece result sent: %v, peer task finished", err)
 
-	err = pt.peerPacketStream.CloseSend()
+	err = pt.closePeerPacketStream()
 	pt.Debugf("close stream result: %v", err)
 
 	ctx := trace.ContextWithSpan(context.Background(), trace.SpanFromContext(pt.ctx))
@@ -1635,3 +1779,33 @@ func (pt *peerTaskConductor) sendPieceResult(pr *scheduler.PieceResult) error {
 	pt.sendPieceResultLock.Unlock()
 	return err
 }
+
+func (pt *peerTaskConductor) closePeerPacketStream() error {
+	pt.sendPieceResultLock.Lock()
+	err := pt.peerPacketStream.CloseSend()
+	pt.sendPieceResultLock.Unlock()
+	return err
+}
+
+// isQuotaExceeded returns whether the code is returned by scheduler when the quota of application is exceeded
+func isQuotaExceeded(code base.Code) bool {
+	switch code {
+	case base.Code_SchedTaskQuotaExceeded, base.Code_SchedBackSourceQuotaExceeded, base.Code_SchedSeedPeerQuotaExceeded:
+		return true
+	}
+	return false
+}
+
+// isSchedulerUnreachable returns whether the register error is caused by all the schedulers are unreachable,
+// rather than an error returned by scheduler
+func isSchedulerUnreachable(err error) bool {
+	if errors.Is(err, dferrors.ErrNoCandidateNode) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	switch status.Code(errors.Cause(err)) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
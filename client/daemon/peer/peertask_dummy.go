@@ -39,6 +39,10 @@ func (d *dummySchedulerClient) ReportPieceResult(ctx context.Context, request *s
 	return &dummyPeerPacketStream{}, nil
 }
 
+func (d *dummySchedulerClient) ResumePieceResult(ctx context.Context, request *scheduler.PeerTaskRequest, finishedPieces []byte, option ...grpc.CallOption) (scheduler.Scheduler_ReportPieceResultClient, error) {
+	return &dummyPeerPacketStream{}, nil
+}
+
 func (d *dummySchedulerClient) ReportPeerResult(ctx context.Context, result *scheduler.PeerResult, option ...grpc.CallOption) error {
 	return nil
 }
@@ -55,6 +59,10 @@ func (d *dummySchedulerClient) AnnounceTask(ctx context.Context, request *schedu
 	panic("should not call this function")
 }
 
+func (d *dummySchedulerClient) SyncProbes(ctx context.Context, request *scheduler.SyncProbesRequest, option ...grpc.CallOption) (*scheduler.SyncProbesResponse, error) {
+	return &scheduler.SyncProbesResponse{}, nil
+}
+
 func (d *dummySchedulerClient) Close() error {
 	return nil
 }
@@ -0,0 +1,129 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package peer
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc"
+
+	"d7y.io/dragonfly/v2/internal/dferrors"
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
+	"d7y.io/dragonfly/v2/pkg/rpc/scheduler"
+)
+
+// gossipParallelCount is the count of piece download workers when downloading from discovered peers,
+// same with the default parallel count of scheduler
+const gossipParallelCount = 4
+
+// when scheduler is not available, use gossipSchedulerClient to download from the peers found by discovery
+type gossipSchedulerClient struct {
+	dummySchedulerClient
+	stream *gossipPeerPacketStream
+}
+
+func newGossipSchedulerClient(taskID, peerID string, peers []*scheduler.PeerPacket_DestPeer) *gossipSchedulerClient {
+	return &gossipSchedulerClient{
+		stream: newGossipPeerPacketStream(taskID, peerID, peers),
+	}
+}
+
+func (g *gossipSchedulerClient) ReportPieceResult(ctx context.Context, request *scheduler.PeerTaskRequest, option ...grpc.CallOption) (scheduler.Scheduler_ReportPieceResultClient, error) {
+	return g.stream, nil
+}
+
+func (g *gossipSchedulerClient) ResumePieceResult(ctx context.Context, request *scheduler.PeerTaskRequest, finishedPieces []byte, option ...grpc.CallOption) (scheduler.Scheduler_ReportPieceResultClient, error) {
+	return g.stream, nil
+}
+
+// gossipPeerPacketStream sends the discovered peers as the only peer packet,
+// and says back source after all the discovered peers failed
+type gossipPeerPacketStream struct {
+	grpc.ClientStream
+
+	packet *scheduler.PeerPacket
+	sent   bool
+
+	mu     sync.Mutex
+	peers  map[string]bool
+	failed chan struct{}
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newGossipPeerPacketStream(taskID, peerID string, peers []*scheduler.PeerPacket_DestPeer) *gossipPeerPacketStream {
+	s := &gossipPeerPacketStream{
+		packet: &scheduler.PeerPacket{
+			TaskId:        taskID,
+			SrcPid:        peerID,
+			ParallelCount: gossipParallelCount,
+			MainPeer:      peers[0],
+			StealPeers:    peers[1:],
+			Code:          base.Code_Success,
+		},
+		peers:  map[string]bool{},
+		failed: make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	for _, peer := range peers {
+		s.peers[peer.PeerId] = true
+	}
+	return s
+}
+
+func (s *gossipPeerPacketStream) Recv() (*scheduler.PeerPacket, error) {
+	if !s.sent {
+		s.sent = true
+		return s.packet, nil
+	}
+
+	select {
+	case <-s.failed:
+		return nil, dferrors.New(base.Code_SchedNeedBackSource, "all discovered peers failed")
+	case <-s.done:
+		return nil, io.EOF
+	}
+}
+
+// Send marks the dest peer of failed piece result as failed
+func (s *gossipPeerPacketStream) Send(pr *scheduler.PieceResult) error {
+	if pr.Success || pr.DstPid == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.peers[pr.DstPid] {
+		return nil
+	}
+
+	delete(s.peers, pr.DstPid)
+	if len(s.peers) == 0 {
+		close(s.failed)
+	}
+	return nil
+}
+
+func (s *gossipPeerPacketStream) CloseSend() error {
+	s.once.Do(func() {
+		close(s.done)
+	})
+	return nil
+}
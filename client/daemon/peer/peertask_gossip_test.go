@@ -0,0 +1,243 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package peer
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	testifyassert "github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"d7y.io/dragonfly/v2/client/clientutil"
+	"d7y.io/dragonfly/v2/client/config"
+	mock_discovery "d7y.io/dragonfly/v2/client/daemon/test/mock/discovery"
+	"d7y.io/dragonfly/v2/internal/dferrors"
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
+	"d7y.io/dragonfly/v2/pkg/rpc/scheduler"
+	mock_scheduler_client "d7y.io/dragonfly/v2/pkg/rpc/scheduler/client/mocks"
+)
+
+var (
+	mockDiscoveredPeers = []*scheduler.PeerPacket_DestPeer{
+		{
+			Ip:      "127.0.0.1",
+			RpcPort: 65000,
+			PeerId:  "foo",
+		},
+		{
+			Ip:      "127.0.0.2",
+			RpcPort: 65000,
+			PeerId:  "bar",
+		},
+	}
+)
+
+func TestGossipPeerPacketStream(t *testing.T) {
+	tests := []struct {
+		name   string
+		run    func(s *gossipPeerPacketStream)
+		expect func(t *testing.T, s *gossipPeerPacketStream)
+	}{
+		{
+			name: "receive discovered peers",
+			run:  func(s *gossipPeerPacketStream) {},
+			expect: func(t *testing.T, s *gossipPeerPacketStream) {
+				assert := testifyassert.New(t)
+				pp, err := s.Recv()
+				assert.NoError(err)
+				assert.Equal(base.Code_Success, pp.Code)
+				assert.Equal(int32(gossipParallelCount), pp.ParallelCount)
+				assert.Equal(mockDiscoveredPeers[0], pp.MainPeer)
+				assert.Equal(mockDiscoveredPeers[1:], pp.StealPeers)
+			},
+		},
+		{
+			name: "all discovered peers failed",
+			run: func(s *gossipPeerPacketStream) {
+				s.Send(&scheduler.PieceResult{DstPid: "foo", Code: base.Code_ClientPieceRequestFail})
+				s.Send(&scheduler.PieceResult{DstPid: "bar", Code: base.Code_ClientConnectionError})
+			},
+			expect: func(t *testing.T, s *gossipPeerPacketStream) {
+				assert := testifyassert.New(t)
+				_, err := s.Recv()
+				assert.NoError(err)
+				_, err = s.Recv()
+				de, ok := err.(*dferrors.DfError)
+				assert.True(ok)
+				assert.Equal(base.Code_SchedNeedBackSource, de.Code)
+			},
+		},
+		{
+			name: "part of discovered peers failed",
+			run: func(s *gossipPeerPacketStream) {
+				s.Send(&scheduler.PieceResult{DstPid: "foo", Code: base.Code_ClientPieceRequestFail})
+				s.Send(&scheduler.PieceResult{DstPid: "foo", Code: base.Code_ClientPieceRequestFail})
+				s.Send(&scheduler.PieceResult{DstPid: "bar", Success: true, Code: base.Code_Success})
+				s.Send(&scheduler.PieceResult{DstPid: "baz", Code: base.Code_ClientPieceRequestFail})
+				s.Send(&scheduler.PieceResult{Code: base.Code_ClientError})
+				s.CloseSend()
+			},
+			expect: func(t *testing.T, s *gossipPeerPacketStream) {
+				assert := testifyassert.New(t)
+				_, err := s.Recv()
+				assert.NoError(err)
+				_, err = s.Recv()
+				assert.Equal(io.EOF, err)
+				assert.Equal(map[string]bool{"bar": true}, s.peers)
+			},
+		},
+		{
+			name: "close send repeatedly",
+			run: func(s *gossipPeerPacketStream) {
+				s.CloseSend()
+				s.CloseSend()
+			},
+			expect: func(t *testing.T, s *gossipPeerPacketStream) {
+				assert := testifyassert.New(t)
+				_, err := s.Recv()
+				assert.NoError(err)
+				_, err = s.Recv()
+				assert.Equal(io.EOF, err)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newGossipPeerPacketStream("task", "peer", mockDiscoveredPeers)
+			tc.run(s)
+			tc.expect(t, s)
+		})
+	}
+}
+
+func TestPeerTaskConductor_registerWithDiscovery(t *testing.T) {
+	tests := []struct {
+		name        string
+		registerErr error
+		mock        func(md *mock_discovery.MockDiscoveryMockRecorder)
+		expect      func(t *testing.T, pt *peerTaskConductor, err error)
+	}{
+		{
+			name:        "download from discovered peers",
+			registerErr: status.Error(codes.Unavailable, "scheduler is unavailable"),
+			mock: func(md *mock_discovery.MockDiscoveryMockRecorder) {
+				md.FindPeers(gomock.Any(), gomock.Any()).Return(mockDiscoveredPeers).Times(1)
+			},
+			expect: func(t *testing.T, pt *peerTaskConductor, err error) {
+				assert := testifyassert.New(t)
+				assert.NoError(err)
+				assert.False(pt.needBackSource.Load())
+				assert.Equal(base.SizeScope_NORMAL, pt.sizeScope)
+				assert.IsType(&gossipSchedulerClient{}, pt.schedulerClient)
+				assert.IsType(&gossipPeerPacketStream{}, pt.peerPacketStream)
+			},
+		},
+		{
+			name:        "download from discovered peers when dial to all schedulers failed",
+			registerErr: errors.Wrapf(dferrors.ErrNoCandidateNode, "prob candidate client conn for hash key %s", "task"),
+			mock: func(md *mock_discovery.MockDiscoveryMockRecorder) {
+				md.FindPeers(gomock.Any(), gomock.Any()).Return(mockDiscoveredPeers).Times(1)
+			},
+			expect: func(t *testing.T, pt *peerTaskConductor, err error) {
+				assert := testifyassert.New(t)
+				assert.NoError(err)
+				assert.False(pt.needBackSource.Load())
+				assert.IsType(&gossipSchedulerClient{}, pt.schedulerClient)
+			},
+		},
+		{
+			name:        "back source without discovered peers",
+			registerErr: status.Error(codes.Unavailable, "scheduler is unavailable"),
+			mock: func(md *mock_discovery.MockDiscoveryMockRecorder) {
+				md.FindPeers(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			},
+			expect: func(t *testing.T, pt *peerTaskConductor, err error) {
+				assert := testifyassert.New(t)
+				assert.NoError(err)
+				assert.True(pt.needBackSource.Load())
+				assert.IsType(&dummySchedulerClient{}, pt.schedulerClient)
+			},
+		},
+		{
+			name:        "back source directly when scheduler says url direct",
+			registerErr: dferrors.New(base.Code_SchedURLDirect, "download from source directly"),
+			mock: func(md *mock_discovery.MockDiscoveryMockRecorder) {
+				md.FindPeers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expect: func(t *testing.T, pt *peerTaskConductor, err error) {
+				assert := testifyassert.New(t)
+				assert.NoError(err)
+				assert.True(pt.needBackSource.Load())
+				assert.IsType(&dummySchedulerClient{}, pt.schedulerClient)
+			},
+		},
+		{
+			name:        "back source without discovery when scheduler returns error",
+			registerErr: status.Error(codes.InvalidArgument, "invalid request"),
+			mock: func(md *mock_discovery.MockDiscoveryMockRecorder) {
+				md.FindPeers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expect: func(t *testing.T, pt *peerTaskConductor, err error) {
+				assert := testifyassert.New(t)
+				assert.NoError(err)
+				assert.True(pt.needBackSource.Load())
+				assert.IsType(&dummySchedulerClient{}, pt.schedulerClient)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+
+			schedulerClient := mock_scheduler_client.NewMockClient(ctl)
+			schedulerClient.EXPECT().RegisterPeerTask(gomock.Any(), gomock.Any()).
+				Return(nil, tc.registerErr).Times(1)
+			discovery := mock_discovery.NewMockDiscovery(ctl)
+			tc.mock(discovery.EXPECT())
+
+			ptm := &peerTaskManager{
+				host: &scheduler.PeerHost{
+					Ip: "127.0.0.1",
+				},
+				conductorLock:    &sync.Mutex{},
+				runningPeerTasks: sync.Map{},
+				schedulerClient:  schedulerClient,
+				discovery:        discovery,
+				schedulerOption: config.SchedulerOption{
+					ScheduleTimeout: clientutil.Duration{Duration: time.Second},
+				},
+			}
+			pt := ptm.newPeerTaskConductor(context.Background(), &scheduler.PeerTaskRequest{
+				Url:     "http://example.com/foo",
+				UrlMeta: &base.UrlMeta{},
+				PeerId:  "peer",
+			}, rate.Inf, nil, nil, "", false)
+			tc.expect(t, pt, pt.register())
+		})
+	}
+}
@@ -31,6 +31,8 @@ import (
 
 	"d7y.io/dragonfly/v2/client/clientutil"
 	"d7y.io/dragonfly/v2/client/config"
+	"d7y.io/dragonfly/v2/client/daemon/discovery"
+	"d7y.io/dragonfly/v2/client/daemon/hostload"
 	"d7y.io/dragonfly/v2/client/daemon/metrics"
 	"d7y.io/dragonfly/v2/client/daemon/storage"
 	logger "d7y.io/dragonfly/v2/internal/dflog"
@@ -117,15 +119,20 @@ func init() {
 
 type peerTaskManager struct {
 	host            *scheduler.PeerHost
+	hostLoad        hostload.Collector
 	schedulerClient schedulerclient.Client
 	schedulerOption config.SchedulerOption
 	pieceManager    PieceManager
 	storageManager  storage.Manager
+	// discovery finds the peers holding the task when schedulers are unreachable, nil when disabled
+	discovery discovery.Discovery
 
 	conductorLock    sync.Locker
 	runningPeerTasks sync.Map
 
 	perPeerRateLimit rate.Limit
+	// priorityLimiter shares the total rate limit between running tasks by priority
+	priorityLimiter *priorityLimiter
 
 	// enableMultiplex indicates to reuse the data of completed peer tasks
 	enableMultiplex bool
@@ -141,11 +148,14 @@ type peerTaskManager struct {
 
 func NewPeerTaskManager(
 	host *scheduler.PeerHost,
+	hostLoad hostload.Collector,
 	pieceManager PieceManager,
 	storageManager storage.Manager,
 	schedulerClient schedulerclient.Client,
+	peerDiscovery discovery.Discovery,
 	schedulerOption config.SchedulerOption,
 	perPeerRateLimit rate.Limit,
+	totalRateLimit rate.Limit,
 	multiplex bool,
 	prefetch bool,
 	calculateDigest bool,
@@ -154,13 +164,16 @@ func NewPeerTaskManager(
 
 	ptm := &peerTaskManager{
 		host:              host,
+		hostLoad:          hostLoad,
 		runningPeerTasks:  sync.Map{},
 		conductorLock:     &sync.Mutex{},
 		pieceManager:      pieceManager,
 		storageManager:    storageManager,
 		schedulerClient:   schedulerClient,
+		discovery:         peerDiscovery,
 		schedulerOption:   schedulerOption,
 		perPeerRateLimit:  perPeerRateLimit,
+		priorityLimiter:   newPriorityLimiter(totalRateLimit),
 		enableMultiplex:   multiplex,
 		enablePrefetch:    prefetch,
 		watchdogTimeout:   watchdog,
@@ -172,6 +185,14 @@ func NewPeerTaskManager(
 
 var _ TaskManager = (*peerTaskManager)(nil)
 
+// loadHost returns the latest host load, returns nil when host load collector is not set
+func (ptm *peerTaskManager) loadHost() *base.HostLoad {
+	if ptm == nil || ptm.hostLoad == nil {
+		return nil
+	}
+	return ptm.hostLoad.Load()
+}
+
 func (ptm *peerTaskManager) findPeerTaskConductor(taskID string) (*peerTaskConductor, bool) {
 	pt, ok := ptm.runningPeerTasks.Load(taskID)
 	if !ok {
@@ -187,8 +208,9 @@ func (ptm *peerTaskManager) getPeerTaskConductor(ctx context.Context,
 	parent *peerTaskConductor,
 	rg *clientutil.Range,
 	desiredLocation string,
+	callsystem string,
 	seed bool) (*peerTaskConductor, error) {
-	ptc, created, err := ptm.getOrCreatePeerTaskConductor(ctx, taskID, request, limit, parent, rg, desiredLocation, seed)
+	ptc, created, err := ptm.getOrCreatePeerTaskConductor(ctx, taskID, request, limit, parent, rg, desiredLocation, callsystem, seed)
 	if err != nil {
 		return nil, err
 	}
@@ -210,12 +232,13 @@ func (ptm *peerTaskManager) getOrCreatePeerTaskConductor(
 	parent *peerTaskConductor,
 	rg *clientutil.Range,
 	desiredLocation string,
+	callsystem string,
 	seed bool) (*peerTaskConductor, bool, error) {
 	if ptc, ok := ptm.findPeerTaskConductor(taskID); ok {
 		logger.Debugf("peer task found: %s/%s", ptc.taskID, ptc.peerID)
 		return ptc, false, nil
 	}
-	ptc := ptm.newPeerTaskConductor(ctx, request, limit, parent, rg, seed)
+	ptc := ptm.newPeerTaskConductor(ctx, request, limit, parent, rg, callsystem, seed)
 
 	ptm.conductorLock.Lock()
 	// double check
@@ -231,6 +254,7 @@ func (ptm *peerTaskManager) getOrCreatePeerTaskConductor(
 	}
 	ptm.runningPeerTasks.Store(taskID, ptc)
 	ptm.conductorLock.Unlock()
+	ptm.priorityLimiter.add(taskID, request.Priority, ptc.limiter)
 	metrics.PeerTaskCount.Add(1)
 	logger.Debugf("peer task created: %s/%s", ptc.taskID, ptc.peerID)
 	return ptc, true, ptc.initStorage(desiredLocation)
@@ -248,11 +272,13 @@ func (ptm *peerTaskManager) prefetchParentTask(request *scheduler.PeerTaskReques
 		HostLoad:    request.HostLoad,
 		IsMigrating: request.IsMigrating,
 		Pattern:     request.Pattern,
+		Priority:    request.Priority,
 		UrlMeta: &base.UrlMeta{
-			Digest: request.UrlMeta.Digest,
-			Tag:    request.UrlMeta.Tag,
-			Filter: request.UrlMeta.Filter,
-			Header: map[string]string{},
+			Digest:      request.UrlMeta.Digest,
+			Tag:         request.UrlMeta.Tag,
+			Filter:      request.UrlMeta.Filter,
+			Header:      map[string]string{},
+			Application: request.UrlMeta.Application,
 		},
 	}
 	for k, v := range request.UrlMeta.Header {
@@ -270,7 +296,7 @@ func (ptm *peerTaskManager) prefetchParentTask(request *scheduler.PeerTaskReques
 	}
 
 	logger.Infof("prefetch peer task %s/%s", taskID, req.PeerId)
-	prefetch, err := ptm.getPeerTaskConductor(context.Background(), taskID, req, limit, nil, nil, desiredLocation, false)
+	prefetch, err := ptm.getPeerTaskConductor(context.Background(), taskID, req, limit, nil, nil, desiredLocation, "", false)
 	if err != nil {
 		logger.Errorf("prefetch peer task %s/%s error: %s", prefetch.taskID, prefetch.peerID, err)
 		return nil
@@ -317,9 +343,10 @@ func (ptm *peerTaskManager) StartStreamTask(ctx context.Context, req *StreamTask
 		UrlMeta:     req.URLMeta,
 		PeerId:      req.PeerID,
 		PeerHost:    ptm.host,
-		HostLoad:    nil,
+		HostLoad:    ptm.loadHost(),
 		IsMigrating: false,
 		Pattern:     req.Pattern,
+		Priority:    req.Priority,
 	}
 
 	if ptm.enableMultiplex {
@@ -393,6 +420,7 @@ func (ptm *peerTaskManager) Stop(ctx context.Context) error {
 func (ptm *peerTaskManager) PeerTaskDone(taskID string) {
 	logger.Debugf("delete done task %s in running tasks", taskID)
 	ptm.runningPeerTasks.Delete(taskID)
+	ptm.priorityLimiter.remove(taskID)
 }
 
 func (ptm *peerTaskManager) IsPeerTaskRunning(taskID string) (Task, bool) {
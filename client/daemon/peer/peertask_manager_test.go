@@ -766,7 +766,7 @@ func (ts *testSpec) runConductorTest(assert *testifyassert.Assertions, require *
 	}
 
 	ptc, created, err := ptm.getOrCreatePeerTaskConductor(
-		context.Background(), taskID, peerTaskRequest, rate.Limit(pieceSize*4), nil, nil, "", false)
+		context.Background(), taskID, peerTaskRequest, rate.Limit(pieceSize*4), nil, nil, "", "", false)
 	assert.Nil(err, "load first peerTaskConductor")
 	assert.True(created, "should create a new peerTaskConductor")
 
@@ -811,7 +811,7 @@ func (ts *testSpec) runConductorTest(assert *testifyassert.Assertions, require *
 			PeerHost: &scheduler.PeerHost{},
 		}
 		p, created, err := ptm.getOrCreatePeerTaskConductor(
-			context.Background(), taskID, request, rate.Limit(pieceSize*3), nil, nil, "", false)
+			context.Background(), taskID, request, rate.Limit(pieceSize*3), nil, nil, "", "", false)
 		assert.Nil(err, fmt.Sprintf("load peerTaskConductor %d", i))
 		assert.Equal(ptc.peerID, p.GetPeerID(), fmt.Sprintf("ptc %d should be same with ptc", i))
 		assert.False(created, "should not create a new peerTaskConductor")
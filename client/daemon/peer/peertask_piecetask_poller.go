@@ -129,7 +129,7 @@ retry:
 		PieceInfo:     &base.PieceInfo{},
 		Success:       false,
 		Code:          code,
-		HostLoad:      nil,
+		HostLoad:      ptc.ptm.loadHost(),
 		FinishedCount: -1,
 	})
 	// error code should be sent to scheduler and the scheduler can schedule a new peer
@@ -213,7 +213,7 @@ func (poller *pieceTaskPoller) getPieceTasksByPeer(
 			PieceInfo:     &base.PieceInfo{},
 			Success:       false,
 			Code:          base.Code_ClientWaitPieceReady,
-			HostLoad:      nil,
+			HostLoad:      ptc.ptm.loadHost(),
 			FinishedCount: ptc.readyPieces.Settled(),
 		})
 		if sendError != nil {
@@ -261,7 +261,7 @@ func compositePieceResult(peerTaskConductor *peerTaskConductor, destPeer *schedu
 		PieceInfo:     &base.PieceInfo{},
 		Success:       false,
 		Code:          code,
-		HostLoad:      nil,
+		HostLoad:      peerTaskConductor.ptm.loadHost(),
 		FinishedCount: peerTaskConductor.readyPieces.Settled(),
 	}
 }
@@ -45,6 +45,19 @@ var _ *logger.SugaredLoggerOnWith // pin this package for no log code generation
 //    for ranged request, 1, find completed normal task, 2, find partial completed parent task
 //    for non-ranged request, just find completed task
 
+// findCompletedTask finds the completed task by task id, when not found, tries to find a completed task
+// with the same digest, the identical content of different urls is reused without downloading again
+func (ptm *peerTaskManager) findCompletedTask(taskID string, rg *clientutil.Range, meta *base.UrlMeta) *storage.ReusePeerTask {
+	if reuse := ptm.storageManager.FindCompletedTask(taskID); reuse != nil {
+		return reuse
+	}
+	// the digest is of the whole file, skip ranged request
+	if !ptm.calculateDigest || rg != nil || meta == nil || meta.Digest == "" {
+		return nil
+	}
+	return ptm.storageManager.FindCompletedTaskByDigest(taskID, meta.Digest)
+}
+
 func (ptm *peerTaskManager) tryReuseFilePeerTask(ctx context.Context,
 	request *FileTaskRequest) (chan *FileTaskProgress, bool) {
 	taskID := idgen.TaskID(request.Url, request.UrlMeta)
@@ -59,7 +72,7 @@ func (ptm *peerTaskManager) tryReuseFilePeerTask(ctx context.Context,
 	if ptm.enabledPrefetch(request.Range) {
 		reuse = ptm.storageManager.FindCompletedSubTask(taskID)
 	} else {
-		reuse = ptm.storageManager.FindCompletedTask(taskID)
+		reuse = ptm.findCompletedTask(taskID, request.Range, request.UrlMeta)
 	}
 
 	if reuse == nil {
@@ -200,7 +213,7 @@ func (ptm *peerTaskManager) tryReuseStreamPeerTask(ctx context.Context,
 	if ptm.enabledPrefetch(request.Range) {
 		reuse = ptm.storageManager.FindCompletedSubTask(taskID)
 	} else {
-		reuse = ptm.storageManager.FindCompletedTask(taskID)
+		reuse = ptm.findCompletedTask(taskID, request.Range, request.URLMeta)
 	}
 
 	if reuse == nil {
@@ -306,7 +319,7 @@ func (ptm *peerTaskManager) tryReuseSeedPeerTask(ctx context.Context,
 	if ptm.enabledPrefetch(request.Range) {
 		reuse = ptm.storageManager.FindCompletedSubTask(taskID)
 	} else {
-		reuse = ptm.storageManager.FindCompletedTask(taskID)
+		reuse = ptm.findCompletedTask(taskID, request.Range, request.UrlMeta)
 	}
 
 	if reuse == nil {
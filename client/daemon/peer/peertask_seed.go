@@ -63,7 +63,7 @@ func (ptm *peerTaskManager) newSeedTask(
 	limit rate.Limit) (*SeedTaskResponse, error) {
 
 	taskID := idgen.TaskID(request.Url, request.UrlMeta)
-	ptc, err := ptm.getPeerTaskConductor(ctx, taskID, &request.PeerTaskRequest, limit, nil, request.Range, "", true)
+	ptc, err := ptm.getPeerTaskConductor(ctx, taskID, &request.PeerTaskRequest, limit, nil, request.Range, "", request.Callsystem, true)
 	if err != nil {
 		return nil, err
 	}
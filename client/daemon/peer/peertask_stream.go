@@ -46,6 +46,8 @@ type StreamTaskRequest struct {
 	PeerID string
 	// Pattern to register to scheduler
 	Pattern scheduler.Pattern
+	// Priority to register to scheduler
+	Priority base.Priority
 }
 
 // StreamTask represents a peer task with stream io for reading directly without once more disk io
@@ -81,7 +83,7 @@ func (ptm *peerTaskManager) newStreamTask(
 	}
 
 	taskID := idgen.TaskID(request.Url, request.UrlMeta)
-	ptc, err := ptm.getPeerTaskConductor(ctx, taskID, request, limit, parent, rg, "", false)
+	ptc, err := ptm.getPeerTaskConductor(ctx, taskID, request, limit, parent, rg, "", "", false)
 	if err != nil {
 		return nil, err
 	}
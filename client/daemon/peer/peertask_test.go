@@ -16,7 +16,18 @@
 
 package peer
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"testing"
+
+	testifyassert "github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"d7y.io/dragonfly/v2/internal/dferrors"
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
+)
 
 func TestBitmap_Sets(t *testing.T) {
 	b := NewBitmap()
@@ -26,3 +37,65 @@ func TestBitmap_Sets(t *testing.T) {
 	b.Sets(2, 3, 3, 4)
 	//t.Logf("%s, %d", b.String(), b.Settled())
 }
+
+func TestBitmap_Bytes(t *testing.T) {
+	assert := testifyassert.New(t)
+	b := NewBitmap()
+	b.Sets(0, 9, 63)
+	bits := b.Bytes()
+	assert.Equal([]byte{0x80, 0x40, 0, 0, 0, 0, 0, 0x01}, bits)
+
+	bits[0] = 0
+	assert.True(b.IsSet(0))
+}
+
+func TestPeerTaskConductor_isResumableError(t *testing.T) {
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		err      error
+		expected bool
+	}{
+		{
+			name:     "scheduler is unavailable",
+			ctx:      context.Background(),
+			err:      status.Error(codes.Unavailable, "transport is closing"),
+			expected: true,
+		},
+		{
+			name:     "peer is not found in scheduler",
+			ctx:      context.Background(),
+			err:      dferrors.New(base.Code_SchedPeerNotFound, "peer not found"),
+			expected: true,
+		},
+		{
+			name:     "scheduler returns task status error",
+			ctx:      context.Background(),
+			err:      dferrors.New(base.Code_SchedTaskStatusError, "foo"),
+			expected: false,
+		},
+		{
+			name:     "request is invalid",
+			ctx:      context.Background(),
+			err:      status.Error(codes.InvalidArgument, "foo"),
+			expected: false,
+		},
+		{
+			name:     "peer task is canceled",
+			ctx:      canceledCtx,
+			err:      errors.New("foo"),
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := testifyassert.New(t)
+			pt := &peerTaskConductor{ctx: tc.ctx}
+			assert.Equal(tc.expected, pt.isResumableError(tc.err))
+		})
+	}
+}
@@ -25,6 +25,10 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/go-http-utils/headers"
+	"github.com/klauspost/compress/zstd"
+
+	"d7y.io/dragonfly/v2/client/config"
 	"d7y.io/dragonfly/v2/client/daemon/storage"
 	logger "d7y.io/dragonfly/v2/internal/dflog"
 	"d7y.io/dragonfly/v2/pkg/digest"
@@ -176,9 +180,19 @@ func (p *pieceDownloader) DownloadPiece(ctx context.Context, req *DownloadPieceR
 		}
 	}
 	reader, closer := resp.Body.(io.Reader), resp.Body.(io.Closer)
+	// the piece is sent compressed by the peer which stores it compressed
+	if resp.Header.Get(headers.ContentEncoding) == config.CompressionZstd {
+		decoder, err := zstd.NewReader(resp.Body, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			_ = closer.Close()
+			req.log.Errorf("init zstd decoder error: %s", err.Error())
+			return nil, nil, err
+		}
+		reader, closer = decoder, &zstdReadCloser{decoder: decoder, body: resp.Body}
+	}
 	if req.CalcDigest {
 		req.log.Debugf("calculate digest for piece %d, digest: %s", req.piece.PieceNum, req.piece.PieceMd5)
-		reader, err = digest.NewReader(io.LimitReader(resp.Body, int64(req.piece.RangeSize)), digest.WithDigest(req.piece.PieceMd5), digest.WithLogger(req.log))
+		reader, err = digest.NewReader(io.LimitReader(reader, int64(req.piece.RangeSize)), digest.WithDigest(req.piece.PieceMd5), digest.WithLogger(req.log))
 		if err != nil {
 			_ = closer.Close()
 			req.log.Errorf("init digest reader error: %s", err.Error())
@@ -203,5 +217,17 @@ func buildDownloadPieceHTTPRequest(ctx context.Context, d *DownloadPieceRequest)
 	// TODO use string.Builder
 	req.Header.Add("Range", fmt.Sprintf("bytes=%d-%d",
 		d.piece.RangeStart, d.piece.RangeStart+uint64(d.piece.RangeSize)-1))
+	// accept the compressed piece to save the bandwidth and the decompression of uploader
+	req.Header.Add(headers.AcceptEncoding, config.CompressionZstd)
 	return req
 }
+
+type zstdReadCloser struct {
+	decoder *zstd.Decoder
+	body    io.Closer
+}
+
+func (z *zstdReadCloser) Close() error {
+	z.decoder.Close()
+	return z.body.Close()
+}
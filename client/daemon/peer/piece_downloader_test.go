@@ -31,6 +31,7 @@ import (
 	"time"
 
 	"github.com/go-http-utils/headers"
+	"github.com/klauspost/compress/zstd"
 	testifyassert "github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -119,6 +120,25 @@ func TestPieceDownloader_DownloadPiece(t *testing.T) {
 			rangeSize:       513,
 			targetPieceData: testData[512:1025],
 		},
+		{
+			handleFunc: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal("/download/tas/task-4", r.URL.Path)
+				assert.Equal("zstd", r.Header.Get(headers.AcceptEncoding))
+				rg := clientutil.MustParseRange(r.Header.Get("Range"), math.MaxInt64)
+				encoder, _ := zstd.NewWriter(nil)
+				data := encoder.EncodeAll(testData[rg.Start:rg.Start+rg.Length], nil)
+				w.Header().Set(headers.ContentLength, fmt.Sprintf("%d", len(data)))
+				w.Header().Set(headers.ContentEncoding, "zstd")
+				if _, err := w.Write(data); err != nil {
+					t.Error(err)
+				}
+			},
+			taskID:          "task-4",
+			pieceRange:      "bytes=512-1024",
+			rangeStart:      512,
+			rangeSize:       513,
+			targetPieceData: testData[512:1025],
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,133 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package peer
+
+import (
+	"sort"
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
+)
+
+// priorityWeights is the weight of total rate limit shared by running tasks
+var priorityWeights = map[base.Priority]float64{
+	base.Priority_URGENT_PRIORITY:     4,
+	base.Priority_NORMAL_PRIORITY:     2,
+	base.Priority_BACKGROUND_PRIORITY: 1,
+}
+
+type limitedTask struct {
+	limiter *rate.Limiter
+	// limit and burst are the original settings of task
+	limit  rate.Limit
+	burst  int
+	weight float64
+}
+
+// priorityLimiter shares the total rate limit between running tasks by the weight of priority
+type priorityLimiter struct {
+	mu    sync.Mutex
+	total rate.Limit
+	tasks map[string]*limitedTask
+}
+
+func newPriorityLimiter(total rate.Limit) *priorityLimiter {
+	return &priorityLimiter{
+		total: total,
+		tasks: map[string]*limitedTask{},
+	}
+}
+
+func (pl *priorityLimiter) enabled() bool {
+	return pl != nil && pl.total != rate.Inf && pl.total > 0
+}
+
+// add registers the limiter of task and rebalances all running tasks
+func (pl *priorityLimiter) add(taskID string, priority base.Priority, limiter *rate.Limiter) {
+	if !pl.enabled() || limiter == nil {
+		return
+	}
+
+	weight, ok := priorityWeights[priority]
+	if !ok {
+		weight = priorityWeights[base.Priority_NORMAL_PRIORITY]
+	}
+
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	if _, ok := pl.tasks[taskID]; ok {
+		return
+	}
+
+	pl.tasks[taskID] = &limitedTask{
+		limiter: limiter,
+		limit:   limiter.Limit(),
+		burst:   limiter.Burst(),
+		weight:  weight,
+	}
+	pl.rebalance()
+}
+
+// remove unregisters the limiter of task and rebalances the rest running tasks
+func (pl *priorityLimiter) remove(taskID string) {
+	if !pl.enabled() {
+		return
+	}
+
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	task, ok := pl.tasks[taskID]
+	if !ok {
+		return
+	}
+
+	task.limiter.SetLimit(task.limit)
+	task.limiter.SetBurst(task.burst)
+	delete(pl.tasks, taskID)
+	pl.rebalance()
+}
+
+// rebalance splits the total rate limit by weight, the part exceeding
+// the original limit of task is shared by the other tasks
+func (pl *priorityLimiter) rebalance() {
+	var tasks []*limitedTask
+	var weights float64
+	for _, task := range pl.tasks {
+		tasks = append(tasks, task)
+		weights += task.weight
+	}
+
+	// tasks with lower original limit per weight are filled first
+	sort.Slice(tasks, func(i, j int) bool {
+		return float64(tasks[i].limit)/tasks[i].weight < float64(tasks[j].limit)/tasks[j].weight
+	})
+
+	remaining := float64(pl.total)
+	for _, task := range tasks {
+		share := remaining * task.weight / weights
+		if task.limit != rate.Inf && float64(task.limit) < share {
+			share = float64(task.limit)
+		}
+
+		task.limiter.SetLimit(rate.Limit(share))
+		task.limiter.SetBurst(int(pl.total))
+		remaining -= share
+		weights -= task.weight
+	}
+}
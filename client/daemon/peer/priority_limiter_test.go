@@ -0,0 +1,79 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package peer
+
+import (
+	"testing"
+
+	testifyassert "github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
+)
+
+func TestPriorityLimiter(t *testing.T) {
+	assert := testifyassert.New(t)
+
+	pl := newPriorityLimiter(700)
+	urgent := rate.NewLimiter(rate.Inf, 0)
+	normal := rate.NewLimiter(rate.Inf, 0)
+	background := rate.NewLimiter(rate.Inf, 0)
+
+	pl.add("urgent", base.Priority_URGENT_PRIORITY, urgent)
+	assert.Equal(rate.Limit(700), urgent.Limit())
+	assert.Equal(700, urgent.Burst())
+
+	pl.add("normal", base.Priority_NORMAL_PRIORITY, normal)
+	pl.add("background", base.Priority_BACKGROUND_PRIORITY, background)
+	assert.InDelta(400, float64(urgent.Limit()), 0.001)
+	assert.InDelta(200, float64(normal.Limit()), 0.001)
+	assert.InDelta(100, float64(background.Limit()), 0.001)
+
+	pl.remove("urgent")
+	assert.Equal(rate.Inf, urgent.Limit())
+	assert.Equal(0, urgent.Burst())
+	assert.InDelta(466.666, float64(normal.Limit()), 0.001)
+	assert.InDelta(233.333, float64(background.Limit()), 0.001)
+}
+
+func TestPriorityLimiter_OriginalLimit(t *testing.T) {
+	assert := testifyassert.New(t)
+
+	pl := newPriorityLimiter(700)
+	urgent := rate.NewLimiter(100, 100)
+	background := rate.NewLimiter(rate.Inf, 0)
+
+	pl.add("urgent", base.Priority_URGENT_PRIORITY, urgent)
+	pl.add("background", base.Priority_BACKGROUND_PRIORITY, background)
+	assert.Equal(rate.Limit(100), urgent.Limit())
+	assert.Equal(rate.Limit(600), background.Limit())
+}
+
+func TestPriorityLimiter_Disabled(t *testing.T) {
+	assert := testifyassert.New(t)
+
+	var nilLimiter *priorityLimiter
+	limiter := rate.NewLimiter(100, 100)
+	nilLimiter.add("foo", base.Priority_URGENT_PRIORITY, limiter)
+	nilLimiter.remove("foo")
+	assert.Equal(rate.Limit(100), limiter.Limit())
+
+	pl := newPriorityLimiter(rate.Inf)
+	pl.add("foo", base.Priority_URGENT_PRIORITY, limiter)
+	assert.Equal(rate.Limit(100), limiter.Limit())
+	assert.Empty(pl.tasks)
+}
@@ -0,0 +1,160 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	logger "d7y.io/dragonfly/v2/internal/dflog"
+	"d7y.io/dragonfly/v2/pkg/rpc/scheduler"
+	schedulerclient "d7y.io/dragonfly/v2/pkg/rpc/scheduler/client"
+)
+
+// syncTimeout is the timeout of syncing probes with schedulers
+const syncTimeout = 30 * time.Second
+
+// Prober probes the round-trip time to the hosts chosen by scheduler periodically
+type Prober interface {
+	// Start starts to probe hosts in background
+	Start()
+	// Stop stops probing hosts
+	Stop()
+}
+
+type prober struct {
+	host            *scheduler.PeerHost
+	schedulerClient schedulerclient.Client
+	// interval is used when scheduler does not return the interval of probing
+	interval time.Duration
+	// timeout is the timeout of probing a host
+	timeout time.Duration
+	done    chan bool
+}
+
+var _ Prober = (*prober)(nil)
+
+// New returns a Prober which reports the probes of host to schedulers every interval
+func New(host *scheduler.PeerHost, schedulerClient schedulerclient.Client, interval, timeout time.Duration) Prober {
+	return &prober{
+		host:            host,
+		schedulerClient: schedulerClient,
+		interval:        interval,
+		timeout:         timeout,
+		done:            make(chan bool),
+	}
+}
+
+func (p *prober) Start() {
+	go func() {
+		// fetch the targets of the first round
+		targets, _ := p.sync(nil)
+		for {
+			var interval time.Duration
+			targets, interval = p.sync(p.probe(targets))
+
+			timer := time.NewTimer(interval)
+			select {
+			case <-timer.C:
+			case <-p.done:
+				timer.Stop()
+				logger.Infof("prober exited")
+				return
+			}
+		}
+	}()
+}
+
+func (p *prober) Stop() {
+	close(p.done)
+}
+
+// sync reports probes to schedulers, and returns the targets and the interval of next round
+func (p *prober) sync(probes []*scheduler.Probe) ([]*scheduler.ProbeTarget, time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), syncTimeout)
+	defer cancel()
+
+	resp, err := p.schedulerClient.SyncProbes(ctx, &scheduler.SyncProbesRequest{
+		Host:   p.host,
+		Probes: probes,
+	})
+	if err != nil {
+		logger.Warnf("sync probes error: %s", err)
+		return nil, p.interval
+	}
+
+	interval := p.interval
+	if resp.Interval > 0 {
+		interval = time.Duration(resp.Interval)
+	}
+
+	return resp.Targets, interval
+}
+
+// probe probes the targets concurrently, the unreachable targets are skipped
+func (p *prober) probe(targets []*scheduler.ProbeTarget) []*scheduler.Probe {
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		probes []*scheduler.Probe
+	)
+
+	for _, target := range targets {
+		wg.Add(1)
+		go func(target *scheduler.ProbeTarget) {
+			defer wg.Done()
+
+			rtt, err := p.ping(target)
+			if err != nil {
+				logger.Debugf("probe host %s error: %s", target.HostId, err)
+				return
+			}
+
+			mu.Lock()
+			probes = append(probes, &scheduler.Probe{
+				HostId: target.HostId,
+				Rtt:    int64(rtt),
+			})
+			mu.Unlock()
+		}(target)
+	}
+
+	wg.Wait()
+	return probes
+}
+
+// ping measures the round-trip time by the tcp handshake with the grpc port of target
+func (p *prober) ping(target *scheduler.ProbeTarget) (time.Duration, error) {
+	addr := net.JoinHostPort(target.Ip, strconv.Itoa(int(target.RpcPort)))
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", addr, p.timeout)
+	if err != nil {
+		return 0, err
+	}
+	rtt := time.Since(start)
+	conn.Close()
+
+	// rtt must be positive in the probes reported to scheduler
+	if rtt <= 0 {
+		rtt = time.Nanosecond
+	}
+
+	return rtt, nil
+}
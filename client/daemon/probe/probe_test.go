@@ -0,0 +1,148 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"d7y.io/dragonfly/v2/pkg/rpc/scheduler"
+	"d7y.io/dragonfly/v2/pkg/rpc/scheduler/client/mocks"
+)
+
+var mockHost = &scheduler.PeerHost{
+	Id:      "foo",
+	Ip:      "127.0.0.1",
+	RpcPort: 65001,
+}
+
+func TestProber_sync(t *testing.T) {
+	tests := []struct {
+		name   string
+		mock   func(m *mocks.MockClientMockRecorder)
+		expect func(t *testing.T, targets []*scheduler.ProbeTarget, interval time.Duration)
+	}{
+		{
+			name: "sync probes",
+			mock: func(m *mocks.MockClientMockRecorder) {
+				m.SyncProbes(gomock.Any(), gomock.Any()).Return(&scheduler.SyncProbesResponse{
+					Targets:  []*scheduler.ProbeTarget{{HostId: "bar", Ip: "127.0.0.1", RpcPort: 65002}},
+					Interval: int64(time.Minute),
+				}, nil).Times(1)
+			},
+			expect: func(t *testing.T, targets []*scheduler.ProbeTarget, interval time.Duration) {
+				assert := assert.New(t)
+				assert.Len(targets, 1)
+				assert.Equal("bar", targets[0].HostId)
+				assert.Equal(time.Minute, interval)
+			},
+		},
+		{
+			name: "scheduler does not return interval",
+			mock: func(m *mocks.MockClientMockRecorder) {
+				m.SyncProbes(gomock.Any(), gomock.Any()).Return(&scheduler.SyncProbesResponse{}, nil).Times(1)
+			},
+			expect: func(t *testing.T, targets []*scheduler.ProbeTarget, interval time.Duration) {
+				assert := assert.New(t)
+				assert.Empty(targets)
+				assert.Equal(time.Hour, interval)
+			},
+		},
+		{
+			name: "sync probes failed",
+			mock: func(m *mocks.MockClientMockRecorder) {
+				m.SyncProbes(gomock.Any(), gomock.Any()).Return(nil, errors.New("foo")).Times(1)
+			},
+			expect: func(t *testing.T, targets []*scheduler.ProbeTarget, interval time.Duration) {
+				assert := assert.New(t)
+				assert.Empty(targets)
+				assert.Equal(time.Hour, interval)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			schedulerClient := mocks.NewMockClient(ctl)
+			tc.mock(schedulerClient.EXPECT())
+
+			p := New(mockHost, schedulerClient, time.Hour, time.Second).(*prober)
+			targets, interval := p.sync(nil)
+			tc.expect(t, targets, interval)
+		})
+	}
+}
+
+func TestProber_probe(t *testing.T) {
+	assert := assert.New(t)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	// closed listener makes an unreachable target
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed.Close()
+
+	p := New(mockHost, nil, time.Hour, time.Second).(*prober)
+	probes := p.probe([]*scheduler.ProbeTarget{
+		{HostId: "bar", Ip: "127.0.0.1", RpcPort: int32(ln.Addr().(*net.TCPAddr).Port)},
+		{HostId: "baz", Ip: "127.0.0.1", RpcPort: int32(closed.Addr().(*net.TCPAddr).Port)},
+	})
+
+	assert.Len(probes, 1)
+	assert.Equal("bar", probes[0].HostId)
+	assert.Greater(probes[0].Rtt, int64(0))
+}
+
+func TestProber_StartAndStop(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	synced := make(chan *scheduler.SyncProbesRequest, 2)
+	schedulerClient := mocks.NewMockClient(ctl)
+	schedulerClient.EXPECT().SyncProbes(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *scheduler.SyncProbesRequest, _ ...grpc.CallOption) (*scheduler.SyncProbesResponse, error) {
+			synced <- req
+			return &scheduler.SyncProbesResponse{}, nil
+		}).Times(2)
+
+	p := New(mockHost, schedulerClient, time.Hour, time.Second)
+	p.Start()
+	for i := 0; i < 2; i++ {
+		select {
+		case req := <-synced:
+			assert.Equal(t, mockHost.Id, req.Host.Id)
+		case <-time.After(5 * time.Second):
+			t.Fatal("sync probes timeout")
+		}
+	}
+	p.Stop()
+}
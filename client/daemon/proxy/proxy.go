@@ -577,7 +577,7 @@ func (proxy *Proxy) checkWhiteList(r *http.Request) bool {
 
 // shouldUseDragonfly returns whether we should use dragonfly to proxy a request. It
 // also change the scheme of the given request if the matched rule has
-// UseHTTPS = true
+// UseHTTPS = true, and set the priority header if the matched rule has priority
 func (proxy *Proxy) shouldUseDragonfly(req *http.Request) bool {
 	if req.Method != http.MethodGet {
 		return false
@@ -601,6 +601,9 @@ func (proxy *Proxy) shouldUseDragonfly(req *http.Request) bool {
 				req.URL.Host = rule.Redirect
 				req.Host = rule.Redirect
 			}
+			if rule.Priority != "" && req.Header.Get(config.HeaderDragonflyPriority) == "" {
+				req.Header.Set(config.HeaderDragonflyPriority, rule.Priority)
+			}
 			return !rule.Direct
 		}
 	}
@@ -326,6 +326,7 @@ func (s *server) doDownload(ctx context.Context, req *dfdaemongrpc.DownRequest,
 			PeerId:   peerID,
 			PeerHost: s.peerHost,
 			Pattern:  config.ConvertPattern(req.Pattern, s.defaultPattern),
+			Priority: req.Priority,
 		},
 		Output:             req.Output,
 		Limit:              req.Limit,
@@ -466,6 +467,18 @@ func (s *server) ImportTask(ctx context.Context, req *dfdaemongrpc.ImportTaskReq
 			log.Infof("Announce task (peerID %s) to scheduler in %.6f seconds", ptm.PeerID, time.Since(start).Seconds())
 		}
 	}
+	pinFunc := func() error {
+		if !req.Pin {
+			return nil
+		}
+		if err := s.storageManager.PinTask(&storage.PinTaskRequest{PeerTaskMetadata: ptm}); err != nil {
+			msg := fmt.Sprintf("pin task failed: %v", err)
+			log.Error(msg)
+			return errors.New(msg)
+		}
+		log.Info("task is pinned")
+		return nil
+	}
 
 	// 0. Task exists in local storage
 	if task := s.storageManager.FindCompletedTask(taskID); task != nil {
@@ -474,6 +487,9 @@ func (s *server) ImportTask(ctx context.Context, req *dfdaemongrpc.ImportTaskReq
 
 		// Announce to scheduler as well, but in background
 		ptm.PeerID = task.PeerID
+		if err := pinFunc(); err != nil {
+			return err
+		}
 		go announceFunc()
 		return nil
 	}
@@ -485,6 +501,8 @@ func (s *server) ImportTask(ctx context.Context, req *dfdaemongrpc.ImportTaskReq
 			PeerID: peerID,
 			TaskID: taskID,
 		},
+		Tag:         req.UrlMeta.Tag,
+		Application: req.UrlMeta.Application,
 	})
 	if err != nil {
 		msg := fmt.Sprintf("register task to storage manager failed: %v", err)
@@ -500,6 +518,9 @@ func (s *server) ImportTask(ctx context.Context, req *dfdaemongrpc.ImportTaskReq
 		return errors.New(msg)
 	}
 	log.Info("import file succeeded")
+	if err := pinFunc(); err != nil {
+		return err
+	}
 
 	// 3. Announce to scheduler asynchronously
 	go announceFunc()
@@ -631,6 +652,20 @@ func (s *server) DeleteTask(ctx context.Context, req *dfdaemongrpc.DeleteTaskReq
 		return nil
 	}
 
+	// Unpin task only, it will be reclaimed by gc
+	if req.Unpin {
+		if err := s.storageManager.UnpinTask(&storage.PeerTaskMetadata{
+			PeerID: task.PeerID,
+			TaskID: taskID,
+		}); err != nil {
+			msg := fmt.Sprintf("failed to UnpinTask: %s", err)
+			log.Errorf(msg)
+			return errors.New(msg)
+		}
+		log.Info("task is unpinned")
+		return nil
+	}
+
 	// Unregister task
 	unregReq := storage.CommonTaskRequest{
 		PeerID: task.PeerID,
@@ -582,3 +582,74 @@ func setupPeerServerAndClient(t *testing.T, srv *server, assert *testifyassert.A
 	assert.Nil(err, "grpc dial should be ok")
 	return port, client
 }
+
+func Test_ImportTaskPin(t *testing.T) {
+	assert := testifyassert.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := &dfdaemongrpc.ImportTaskRequest{
+		Cid:     "cid",
+		UrlMeta: &base.UrlMeta{},
+		Path:    "/path/to/file",
+		Pin:     true,
+	}
+	taskID := idgen.TaskID(req.Cid, req.UrlMeta)
+
+	mockStorageManger := mock_storage.NewMockManager(ctrl)
+	mockStorageManger.EXPECT().FindCompletedTask(taskID).Return(&storage.ReusePeerTask{
+		PeerTaskMetadata: storage.PeerTaskMetadata{
+			PeerID: "peer",
+			TaskID: taskID,
+		},
+	})
+	mockStorageManger.EXPECT().PinTask(gomock.Eq(&storage.PinTaskRequest{
+		PeerTaskMetadata: storage.PeerTaskMetadata{
+			PeerID: "peer",
+			TaskID: taskID,
+		},
+	})).Return(nil).Times(1)
+	mockPeerTaskManager := mock_peer.NewMockTaskManager(ctrl)
+	mockPeerTaskManager.EXPECT().AnnouncePeerTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	s := &server{
+		KeepAlive:       clientutil.NewKeepAlive("test"),
+		peerHost:        &scheduler.PeerHost{Ip: "127.0.0.1"},
+		storageManager:  mockStorageManger,
+		peerTaskManager: mockPeerTaskManager,
+	}
+	assert.Nil(s.ImportTask(context.Background(), req))
+}
+
+func Test_DeleteTaskUnpin(t *testing.T) {
+	assert := testifyassert.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := &dfdaemongrpc.DeleteTaskRequest{
+		Cid:     "cid",
+		UrlMeta: &base.UrlMeta{},
+		Unpin:   true,
+	}
+	taskID := idgen.TaskID(req.Cid, req.UrlMeta)
+
+	// the task is only unpinned, not unregistered
+	mockStorageManger := mock_storage.NewMockManager(ctrl)
+	mockStorageManger.EXPECT().FindCompletedTask(taskID).Return(&storage.ReusePeerTask{
+		PeerTaskMetadata: storage.PeerTaskMetadata{
+			PeerID: "peer",
+			TaskID: taskID,
+		},
+	})
+	mockStorageManger.EXPECT().UnpinTask(gomock.Eq(&storage.PeerTaskMetadata{
+		PeerID: "peer",
+		TaskID: taskID,
+	})).Return(nil).Times(1)
+
+	s := &server{
+		KeepAlive:      clientutil.NewKeepAlive("test"),
+		peerHost:       &scheduler.PeerHost{Ip: "127.0.0.1"},
+		storageManager: mockStorageManger,
+	}
+	assert.Nil(s.DeleteTask(context.Background(), req))
+}
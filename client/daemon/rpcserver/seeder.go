@@ -29,6 +29,7 @@ import (
 	"d7y.io/dragonfly/v2/client/config"
 	"d7y.io/dragonfly/v2/client/daemon/metrics"
 	"d7y.io/dragonfly/v2/client/daemon/peer"
+	"d7y.io/dragonfly/v2/client/daemon/storage"
 	logger "d7y.io/dragonfly/v2/internal/dflog"
 	"d7y.io/dragonfly/v2/pkg/idgen"
 	"d7y.io/dragonfly/v2/pkg/net/http"
@@ -111,6 +112,19 @@ func (s *seeder) ObtainSeeds(seedRequest *cdnsystem.SeedRequest, seedsServer cdn
 		return err
 	}
 
+	// Pin the persistent cache task, failure of pinning does not affect seeding.
+	if seedRequest.PersistentTtl > 0 {
+		if err := s.server.storageManager.PinTask(&storage.PinTaskRequest{
+			PeerTaskMetadata: storage.PeerTaskMetadata{
+				PeerID: resp.PeerID,
+				TaskID: resp.TaskID,
+			},
+			TTL: time.Duration(seedRequest.PersistentTtl),
+		}); err != nil {
+			log.Warnf("pin task error: %s", err.Error())
+		}
+	}
+
 	log.Infof("start seed task")
 
 	err = seedsServer.Send(
@@ -135,6 +149,7 @@ func (s *seeder) ObtainSeeds(seedRequest *cdnsystem.SeedRequest, seedsServer cdn
 		seedsServer:         seedsServer,
 		seedTaskRequest:     &req,
 		startNanoSecond:     time.Now().UnixNano(),
+		finishedPieces:      seedRequest.FinishedPieces,
 	}
 	defer resp.Span.End()
 
@@ -153,6 +168,8 @@ type seedSynchronizer struct {
 	seedTaskRequest *peer.SeedTaskRequest
 	startNanoSecond int64
 	attributeSent   bool
+	// finishedPieces is bitmap of the pieces obtained from the previous seed peers, they are not sent again
+	finishedPieces []byte
 }
 
 func (s *seedSynchronizer) sendPieceSeeds(reuse bool) (err error) {
@@ -244,6 +261,10 @@ func (s *seedSynchronizer) sendRemindingPieceSeeds(desired int32, reuse bool) er
 				ps.Done, ps.EndTime = true, uint64(time.Now().UnixNano())
 				s.Infof("seed tasks start time: %d, end time: %d, cost: %dms", ps.BeginTime, ps.EndTime, (ps.EndTime-ps.BeginTime)/1000000)
 			}
+			if s.skipFinishedPiece(&ps) {
+				desired++
+				continue
+			}
 
 			err = s.seedsServer.Send(&ps)
 			if err != nil {
@@ -293,6 +314,9 @@ func (s *seedSynchronizer) sendOrderedPieceSeeds(desired, orderedNum int32, fini
 			ps.Done, ps.EndTime = true, uint64(time.Now().UnixNano())
 			s.Infof("seed tasks start time: %d, end time: %d, cost: %dms", ps.BeginTime, ps.EndTime, (ps.EndTime-ps.BeginTime)/1000000)
 		}
+		if s.skipFinishedPiece(&ps) {
+			continue
+		}
 		err = s.seedsServer.Send(&ps)
 		if err != nil {
 			s.Errorf("send ordered piece seeds error: %s", err.Error())
@@ -304,6 +328,21 @@ func (s *seedSynchronizer) sendOrderedPieceSeeds(desired, orderedNum int32, fini
 	return cur, nil
 }
 
+// skipFinishedPiece returns true when the piece has been obtained from the previous seed peers,
+// the last piece seed is still sent without piece info to tell the scheduler it is done.
+func (s *seedSynchronizer) skipFinishedPiece(ps *cdnsystem.PieceSeed) bool {
+	num := ps.PieceInfo.PieceNum
+	if num < 0 || int(num/8) >= len(s.finishedPieces) || s.finishedPieces[num/8]&(1<<uint(7-num%8)) == 0 {
+		return false
+	}
+	if ps.Done {
+		ps.PieceInfo = nil
+		return false
+	}
+	s.Debugf("piece %d has been obtained from previous seed peers, skip it", num)
+	return true
+}
+
 func (s *seedSynchronizer) compositePieceSeed(pp *base.PiecePacket, piece *base.PieceInfo, reuse bool) cdnsystem.PieceSeed {
 	seedPeerDownloadType := metrics.SeedPeerDownloadTypeBackToSource
 	if reuse {
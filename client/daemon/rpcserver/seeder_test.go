@@ -23,6 +23,7 @@ import (
 	"net"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/phayes/freeport"
@@ -63,8 +64,10 @@ func Test_ObtainSeeds(t *testing.T) {
 		existTaskID     string       // test for non-exists task
 		existPieces     []pieceRange // already exist pieces in storage
 		followingPieces []pieceRange // following pieces in running task subscribe channel
+		finishedPieces  []int32      // pieces obtained from previous seed peers, they are not sent again
 		limit           uint32
 		totalPieces     uint32
+		persistentTTL   time.Duration
 		success         bool
 		verify          func(t *testing.T, assert *testifyassert.Assertions)
 	}{
@@ -81,6 +84,20 @@ func Test_ObtainSeeds(t *testing.T) {
 			verify: func(t *testing.T, assert *testifyassert.Assertions) {
 			},
 		},
+		{
+			name: "already exists in storage and pinned",
+			existPieces: []pieceRange{
+				{
+					start: 0,
+					end:   10,
+				},
+			},
+			totalPieces:   11,
+			persistentTTL: time.Hour,
+			success:       true,
+			verify: func(t *testing.T, assert *testifyassert.Assertions) {
+			},
+		},
 		{
 			name: "already exists in storage with extra get piece request",
 			existPieces: []pieceRange{
@@ -158,6 +175,40 @@ func Test_ObtainSeeds(t *testing.T) {
 			verify: func(t *testing.T, assert *testifyassert.Assertions) {
 			},
 		},
+		{
+			name: "partial exists in storage and obtained from previous seed peers",
+			existPieces: []pieceRange{
+				{
+					start: 0,
+					end:   10,
+				},
+			},
+			followingPieces: []pieceRange{
+				{
+					start: 11,
+					end:   20,
+				},
+			},
+			finishedPieces: []int32{0, 1, 2, 3, 4, 12, 13},
+			totalPieces:    21,
+			success:        true,
+			verify: func(t *testing.T, assert *testifyassert.Assertions) {
+			},
+		},
+		{
+			name: "last piece obtained from previous seed peers",
+			followingPieces: []pieceRange{
+				{
+					start: 0,
+					end:   20,
+				},
+			},
+			finishedPieces: []int32{19, 20},
+			totalPieces:    21,
+			success:        true,
+			verify: func(t *testing.T, assert *testifyassert.Assertions) {
+			},
+		},
 		{
 			name: "not exists in storage",
 			followingPieces: []pieceRange{
@@ -254,6 +305,14 @@ func Test_ObtainSeeds(t *testing.T) {
 							},
 						}, nil
 					})
+				if tc.persistentTTL > 0 {
+					mockStorageManger.EXPECT().PinTask(gomock.Any()).DoAndReturn(
+						func(req *storage.PinTaskRequest) error {
+							assert.Equal("fake-task-id", req.TaskID)
+							assert.Equal(tc.persistentTTL, req.TTL)
+							return nil
+						}).Times(1)
+				}
 				mockTaskManager := mock_peer.NewMockTaskManager(ctrl)
 				mockTaskManager.EXPECT().StartSeedTask(gomock.Any(), gomock.Any()).DoAndReturn(
 					func(ctx context.Context, req *peer.SeedTaskRequest) (*peer.SeedTaskResponse, bool, error) {
@@ -288,8 +347,9 @@ func Test_ObtainSeeds(t *testing.T) {
 									}
 									if !delay {
 										ch <- &peer.PieceInfo{
-											Num:      int32(j),
-											Finished: finished,
+											Num:        int32(j),
+											OrderedNum: int32(j),
+											Finished:   finished,
 										}
 									}
 								}
@@ -322,18 +382,32 @@ func Test_ObtainSeeds(t *testing.T) {
 
 				_, client := setupSeederServerAndClient(t, s, sd, assert, s.ServePeer)
 
+				var (
+					finished       = make(map[int32]bool)
+					finishedPieces []byte
+				)
+				for _, num := range tc.finishedPieces {
+					for int(num/8) >= len(finishedPieces) {
+						finishedPieces = append(finishedPieces, 0)
+					}
+					finishedPieces[num/8] |= 1 << uint(7-num%8)
+					finished[num] = true
+				}
+
 				pps, err := client.ObtainSeeds(
 					context.Background(),
 					&cdnsystem.SeedRequest{
-						TaskId:  "fake-task-id",
-						Url:     "http://localhost/path/to/file",
-						UrlMeta: nil,
+						TaskId:         "fake-task-id",
+						Url:            "http://localhost/path/to/file",
+						UrlMeta:        nil,
+						PersistentTtl:  uint64(tc.persistentTTL),
+						FinishedPieces: finishedPieces,
 					})
 				assert.Nil(err, "client obtain seeds grpc call should be ok")
 
 				var (
-					total  = make(map[int32]bool)
-					maxNum int32
+					total = make(map[int32]bool)
+					done  bool
 				)
 
 				for {
@@ -341,19 +415,26 @@ func Test_ObtainSeeds(t *testing.T) {
 					if err == io.EOF {
 						break
 					}
+					if tc.success {
+						assert.Nil(err, "receive seed info should be ok")
+					}
+					if p.Done {
+						done = true
+					}
+					// the last piece obtained from previous seed peers is not sent
+					if p.PieceInfo == nil {
+						assert.True(p.Done)
+						continue
+					}
 					if p.PieceInfo.PieceNum == common.BeginOfPiece {
 						continue
 					}
+					assert.False(finished[p.PieceInfo.PieceNum], "piece obtained from previous seed peers should not be sent")
 					total[p.PieceInfo.PieceNum] = true
-					if p.PieceInfo.PieceNum >= maxNum {
-						maxNum = p.PieceInfo.PieceNum
-					}
-					if tc.success {
-						assert.Nil(err, "receive seed info should be ok")
-					}
 				}
 				if tc.success {
-					assert.Equal(int(maxNum+1), len(total))
+					assert.True(done)
+					assert.Equal(int(tc.totalPieces), len(total)+len(finished))
 				}
 				s.peerServer.GracefulStop()
 			}
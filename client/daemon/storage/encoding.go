@@ -0,0 +1,321 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package storage
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/klauspost/compress/zstd"
+
+	"d7y.io/dragonfly/v2/client/clientutil"
+)
+
+// the encoder and decoder are safe for concurrent EncodeAll and DecodeAll
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// encodedSlice is the part of a piece in encoded data file
+type encodedSlice struct {
+	num int32
+	// stored is the range of encoded piece in data file
+	stored clientutil.Range
+	// from and to are the offsets of the part in decoded piece
+	from, to int64
+}
+
+// encodedReader decodes the pieces one by one when reading
+type encodedReader struct {
+	task   *localTaskStore
+	file   *os.File
+	slices []encodedSlice
+	buf    []byte
+}
+
+func (r *encodedReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if len(r.slices) == 0 {
+			return 0, io.EOF
+		}
+		slice := r.slices[0]
+		r.slices = r.slices[1:]
+		data, err := r.task.readEncoded(r.file, slice.num, slice.stored)
+		if err != nil {
+			return 0, err
+		}
+		if int64(len(data)) < slice.to {
+			return 0, io.ErrUnexpectedEOF
+		}
+		r.buf = data[slice.from:slice.to]
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *encodedReader) Close() error {
+	return r.file.Close()
+}
+
+// encoded indicates the pieces are compressed or encrypted, and appended to data file one by one
+func (t *localTaskStore) encoded() bool {
+	return t.Compression != "" || t.Encryption != ""
+}
+
+// encode compresses and then encrypts the piece data
+func (t *localTaskStore) encode(num int32, data []byte) ([]byte, error) {
+	if t.Compression != "" {
+		data = zstdEncoder.EncodeAll(data, nil)
+	}
+	if t.Encryption != "" {
+		return seal(t.aead, pieceAdditionalData(num), data)
+	}
+	return data, nil
+}
+
+// decode decrypts and then decompresses the piece data
+func (t *localTaskStore) decode(num int32, data []byte) ([]byte, error) {
+	data, err := t.decrypt(num, data)
+	if err != nil {
+		return nil, err
+	}
+	if t.Compression != "" {
+		return zstdDecoder.DecodeAll(data, nil)
+	}
+	return data, nil
+}
+
+func (t *localTaskStore) decrypt(num int32, data []byte) ([]byte, error) {
+	if t.Encryption != "" {
+		return open(t.aead, pieceAdditionalData(num), data)
+	}
+	return data, nil
+}
+
+// readEncoded reads and decodes the piece num stored in rg of file
+func (t *localTaskStore) readEncoded(file *os.File, num int32, rg clientutil.Range) ([]byte, error) {
+	buf := make([]byte, rg.Length)
+	if _, err := file.ReadAt(buf, rg.Start); err != nil {
+		return nil, err
+	}
+	return t.decode(num, buf)
+}
+
+// writeEncoded encodes the piece data and appends it to data file
+func (t *localTaskStore) writeEncoded(num int32, reader io.Reader, length int64) (int64, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, length))
+	n, err := io.Copy(buf, io.LimitReader(reader, length))
+	if err != nil || n == 0 {
+		return n, err
+	}
+	encoded, err := t.encode(num, buf.Bytes())
+	if err != nil {
+		return 0, err
+	}
+
+	// reserve the space at the end of data file, the pieces are written concurrently
+	t.Lock()
+	stored := clientutil.Range{
+		Start:  t.encodedSize,
+		Length: int64(len(encoded)),
+	}
+	t.encodedSize += stored.Length
+	t.Unlock()
+
+	file, err := os.OpenFile(t.DataFilePath, os.O_RDWR, defaultFileMode)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	if _, err := file.WriteAt(encoded, stored.Start); err != nil {
+		return 0, err
+	}
+
+	t.Lock()
+	t.EncodedPieces[num] = stored
+	t.Unlock()
+	return n, nil
+}
+
+// appendEncodedLocked encodes the piece data and appends it to data file, it must be called with lock held
+func (t *localTaskStore) appendEncodedLocked(file *os.File, num int32, data []byte) error {
+	encoded, err := t.encode(num, data)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteAt(encoded, t.encodedSize); err != nil {
+		return err
+	}
+	t.EncodedPieces[num] = clientutil.Range{
+		Start:  t.encodedSize,
+		Length: int64(len(encoded)),
+	}
+	t.encodedSize += int64(len(encoded))
+	return nil
+}
+
+// readEncodedPiece returns the decoded data of piece num
+func (t *localTaskStore) readEncodedPiece(num int32) (io.Reader, error) {
+	t.RLock()
+	stored, ok := t.EncodedPieces[num]
+	t.RUnlock()
+	if !ok {
+		return nil, ErrPieceNotFound
+	}
+
+	file, err := os.Open(t.DataFilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	data, err := t.readEncoded(file, num, stored)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
+
+// readEncodedRange returns the reader of decoded data in range rg
+func (t *localTaskStore) readEncodedRange(rg clientutil.Range) (io.ReadCloser, error) {
+	t.RLock()
+	pieces := make([]PieceMetadata, 0, len(t.Pieces))
+	for _, piece := range t.Pieces {
+		pieces = append(pieces, piece)
+	}
+	sort.Slice(pieces, func(i, j int) bool {
+		return pieces[i].Range.Start < pieces[j].Range.Start
+	})
+
+	var (
+		slices []encodedSlice
+		pos    = rg.Start
+		end    = rg.Start + rg.Length
+	)
+	for _, piece := range pieces {
+		if pos >= end {
+			break
+		}
+		pieceEnd := piece.Range.Start + piece.Range.Length
+		if pieceEnd <= pos {
+			continue
+		}
+		stored, ok := t.EncodedPieces[piece.Num]
+		if !ok || piece.Range.Start > pos {
+			break
+		}
+		to := pieceEnd
+		if to > end {
+			to = end
+		}
+		slices = append(slices, encodedSlice{
+			num:    piece.Num,
+			stored: stored,
+			from:   pos - piece.Range.Start,
+			to:     to - piece.Range.Start,
+		})
+		pos = to
+	}
+	t.RUnlock()
+
+	if pos < end {
+		t.Errorf("pieces of range %d-%d not found", pos, end)
+		return nil, ErrPieceNotFound
+	}
+
+	file, err := os.Open(t.DataFilePath)
+	if err != nil {
+		return nil, err
+	}
+	return &encodedReader{
+		task:   t,
+		file:   file,
+		slices: slices,
+	}, nil
+}
+
+// readCompressedRaw returns the compressed data of piece without decompression, it is used for uploading
+func (t *localTaskStore) readCompressedRaw(req *ReadPieceRequest) (io.Reader, io.Closer, int64, error) {
+	if t.invalid.Load() {
+		t.Errorf("invalid digest, refuse to get pieces")
+		return nil, nil, 0, ErrInvalidDigest
+	}
+	if t.Compression == "" {
+		return nil, nil, 0, ErrPieceNotCompressed
+	}
+	t.touch()
+
+	t.RLock()
+	num := req.Num
+	if num == -1 {
+		for _, piece := range t.Pieces {
+			if piece.Range == req.Range {
+				num = piece.Num
+				break
+			}
+		}
+	}
+	stored, ok := t.EncodedPieces[num]
+	t.RUnlock()
+	if !ok {
+		return nil, nil, 0, ErrPieceNotCompressed
+	}
+
+	file, err := os.Open(t.DataFilePath)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if t.Encryption == "" {
+		return io.NewSectionReader(file, stored.Start, stored.Length), file, stored.Length, nil
+	}
+
+	// the compressed data is sent to peers in plaintext, as the decompressed data does
+	defer file.Close()
+	buf := make([]byte, stored.Length)
+	if _, err := file.ReadAt(buf, stored.Start); err != nil {
+		return nil, nil, 0, err
+	}
+	data, err := t.decrypt(num, buf)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	rc := io.NopCloser(bytes.NewReader(data))
+	return rc, rc, int64(len(data)), nil
+}
+
+// storeEncoded writes the decoded data to destination
+func (t *localTaskStore) storeEncoded(destination string) error {
+	rc, err := t.readEncodedRange(clientutil.Range{Start: 0, Length: t.ContentLength})
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	dstFile, err := os.OpenFile(destination, os.O_CREATE|os.O_RDWR|os.O_TRUNC, defaultFileMode)
+	if err != nil {
+		t.Errorf("open tasks destination file error: %s", err)
+		return err
+	}
+	defer dstFile.Close()
+	n, err := io.Copy(dstFile, rc)
+	t.Debugf("decoded tasks data %d bytes to %s", n, destination)
+	return err
+}
@@ -0,0 +1,167 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package storage
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	// EncryptionAESGCM encrypts every piece with AES-256-GCM, the random nonce is prepended to the sealed piece
+	EncryptionAESGCM = "aes-gcm"
+
+	// encryptionKeySize is the key size of AES-256
+	encryptionKeySize = 32
+)
+
+var (
+	ErrEncryptionKeyMismatch = errors.New("encryption key mismatch")
+	ErrCiphertextTooShort    = errors.New("ciphertext too short")
+)
+
+// KeyProvider returns the master key, every task is encrypted by its own data key,
+// and the data key is sealed by the master key in task metadata
+type KeyProvider func() ([]byte, error)
+
+// NewFileKeyProvider returns the KeyProvider which reads the base64 encoded master key from file
+func NewFileKeyProvider(path string) KeyProvider {
+	return func() ([]byte, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return DecodeEncryptionKey(strings.TrimSpace(string(data)))
+	}
+}
+
+// NewCachedKeyProvider returns the KeyProvider which keeps the master key of provider in memory only,
+// provider is called again until it returns a valid master key
+func NewCachedKeyProvider(provider KeyProvider) KeyProvider {
+	var (
+		mu  sync.Mutex
+		key []byte
+	)
+	return func() ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if key != nil {
+			return key, nil
+		}
+
+		k, err := provider()
+		if err != nil {
+			return nil, err
+		}
+		if len(k) != encryptionKeySize {
+			return nil, fmt.Errorf("encryption key must be %d bytes, but got %d bytes", encryptionKeySize, len(k))
+		}
+		key = k
+		return key, nil
+	}
+}
+
+// DecodeEncryptionKey decodes the base64 encoded master key
+func DecodeEncryptionKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != encryptionKeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes, but got %d bytes", encryptionKeySize, len(key))
+	}
+	return key, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// newTaskCipher generates the data key of task, returns the cipher and the data key sealed by master key
+func newTaskCipher(master []byte) (cipher.AEAD, []byte, error) {
+	masterAEAD, err := newAEAD(master)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := make([]byte, encryptionKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, nil, err
+	}
+	sealed, err := seal(masterAEAD, nil, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return aead, sealed, nil
+}
+
+// openTaskCipher opens the data key sealed by master key, returns the cipher of task
+func openTaskCipher(master []byte, sealed []byte) (cipher.AEAD, error) {
+	masterAEAD, err := newAEAD(master)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := open(masterAEAD, nil, sealed)
+	if err != nil {
+		return nil, ErrEncryptionKeyMismatch
+	}
+	return newAEAD(key)
+}
+
+// seal encrypts and authenticates plaintext with additional data, the nonce is prepended to the result
+func seal(aead cipher.AEAD, additionalData, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts and authenticates the data sealed by seal
+func open(aead cipher.AEAD, additionalData, data []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, ErrCiphertextTooShort
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+// pieceAdditionalData binds the sealed piece to its num, so the pieces can not be swapped in data file
+func pieceAdditionalData(num int32) []byte {
+	ad := make([]byte, 4)
+	binary.BigEndian.PutUint32(ad, uint32(num))
+	return ad
+}
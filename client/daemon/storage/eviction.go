@@ -0,0 +1,114 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"d7y.io/dragonfly/v2/client/config"
+)
+
+const (
+	// EvictionReasonExpired is the reason of the task not accessed in TaskExpireTime
+	EvictionReasonExpired = "expired"
+	// EvictionReasonInvalid is the reason of the task with invalid digest
+	EvictionReasonInvalid = "invalid"
+	// EvictionReasonQuota is the reason of the tasks exceeding DiskGCThreshold
+	EvictionReasonQuota = "quota"
+	// EvictionReasonGroupQuota is the reason of the tasks of group exceeding its quota
+	EvictionReasonGroupQuota = "group_quota"
+	// EvictionReasonDiskUsage is the reason of the disk usage exceeding DiskGCThresholdPercent
+	EvictionReasonDiskUsage = "disk_usage"
+)
+
+// AccessStats is the access statistics of task recorded when the task is accessed
+type AccessStats struct {
+	// LastAccess is the last access time of task
+	LastAccess time.Time
+	// AccessCount is the count of task level accesses, that is reading the whole task or storing it
+	// to the destination, the reads and writes of pieces are not counted
+	AccessCount int64
+	// Size is the size of task data on disk
+	Size int64
+}
+
+// EvictionPolicy decides the order of evicting tasks when the disk quota or usage threshold is exceeded
+type EvictionPolicy interface {
+	// Name returns the name of eviction policy
+	Name() string
+
+	// Less reports whether the task of a should be evicted before the task of b at time now
+	Less(a, b AccessStats, now time.Time) bool
+}
+
+// NewEvictionPolicy returns the eviction policy of name, the empty name means lru policy
+func NewEvictionPolicy(name string) (EvictionPolicy, error) {
+	switch name {
+	case "", config.EvictionPolicyLRU:
+		return &lruPolicy{}, nil
+	case config.EvictionPolicyLFU:
+		return &lfuPolicy{}, nil
+	case config.EvictionPolicySizeWeightedLRU:
+		return &sizeWeightedLRUPolicy{}, nil
+	default:
+		return nil, fmt.Errorf("not support eviction policy: %s", name)
+	}
+}
+
+// lruPolicy evicts the least recently used tasks first
+type lruPolicy struct{}
+
+func (p *lruPolicy) Name() string {
+	return config.EvictionPolicyLRU
+}
+
+func (p *lruPolicy) Less(a, b AccessStats, now time.Time) bool {
+	return a.LastAccess.Before(b.LastAccess)
+}
+
+// lfuPolicy evicts the least frequently used tasks first, the least recently used task is evicted first
+// when the access counts are equal, so the frequently used tasks like hot base layers are kept longer
+type lfuPolicy struct{}
+
+func (p *lfuPolicy) Name() string {
+	return config.EvictionPolicyLFU
+}
+
+func (p *lfuPolicy) Less(a, b AccessStats, now time.Time) bool {
+	if a.AccessCount != b.AccessCount {
+		return a.AccessCount < b.AccessCount
+	}
+	return a.LastAccess.Before(b.LastAccess)
+}
+
+// sizeWeightedLRUPolicy evicts the tasks with the largest product of idle time and size first,
+// so a large task not used recently releases more space than several small tasks used recently
+type sizeWeightedLRUPolicy struct{}
+
+func (p *sizeWeightedLRUPolicy) Name() string {
+	return config.EvictionPolicySizeWeightedLRU
+}
+
+func (p *sizeWeightedLRUPolicy) Less(a, b AccessStats, now time.Time) bool {
+	sa := now.Sub(a.LastAccess).Seconds() * float64(a.Size)
+	sb := now.Sub(b.LastAccess).Seconds() * float64(b.Size)
+	if sa != sb {
+		return sa > sb
+	}
+	return a.LastAccess.Before(b.LastAccess)
+}
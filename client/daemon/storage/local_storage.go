@@ -17,13 +17,19 @@
 package storage
 
 import (
+	"bytes"
 	"context"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash"
 	"io"
 	"math"
 	"os"
 	"path"
+	"sort"
 	"sync"
 	"syscall"
 	"time"
@@ -50,27 +56,72 @@ type localTaskStore struct {
 
 	expireTime    time.Duration
 	lastAccess    atomic.Int64
+	accessCount   atomic.Int64
 	reclaimMarked atomic.Bool
 	gcCallback    func(CommonTaskRequest)
+	// deduplicate is called after digest validated, to share the data with the task of same content
+	deduplicate func(*localTaskStore)
 
 	// when digest not match, invalid will be set
 	invalid atomic.Bool
+	// dataHasher computes the sha256 digest of data for deduplication, nil when deduplication is disabled
+	dataHasher *dataHasher
 
 	// content stores tiny file which length less than 128 bytes
 	content []byte
 
+	// memory is the memory tier of tiered strategy, nil for other strategies
+	memory *memoryTier
+	// memoryPieces stores the piece data kept in memory tier, key is piece num
+	memoryPieces map[int32]*memoryPiece
+	memorySize   int64
+	// spilled indicates the data is kept on disk, no more data is written to memory tier
+	spilled bool
+
+	// encodedSize is the size of encoded data file, new pieces are appended at it
+	encodedSize int64
+	// aead encrypts the pieces with the data key of task, nil when encryption is disabled
+	aead cipher.AEAD
+
 	subtasks map[PeerTaskMetadata]*localSubTaskStore
 }
 
 var _ TaskStorageDriver = (*localTaskStore)(nil)
 var _ Reclaimer = (*localTaskStore)(nil)
 
+// storedSize returns the size of task data on disk
+func (t *localTaskStore) storedSize() int64 {
+	if t.encoded() {
+		return t.encodedSize
+	}
+	return t.ContentLength
+}
+
 func (t *localTaskStore) touch() {
 	access := time.Now().UnixNano()
 	t.lastAccess.Store(access)
 }
 
+// countAccess counts the task level access for eviction policy, that is the task is read as a whole
+// or stored to the destination, the reads and writes of pieces only touch the task
+func (t *localTaskStore) countAccess() {
+	t.accessCount.Inc()
+}
+
+// accessStats returns the access statistics of task for eviction policy
+func (t *localTaskStore) accessStats() AccessStats {
+	return AccessStats{
+		LastAccess:  time.Unix(0, t.lastAccess.Load()),
+		AccessCount: t.accessCount.Load(),
+		Size:        t.storedSize(),
+	}
+}
+
 func (t *localTaskStore) SubTask(req *RegisterSubTaskRequest) *localSubTaskStore {
+	// subtask reads and writes the data file of parent directly
+	if t.memory != nil {
+		t.memory.spill(t)
+	}
 	subtask := &localSubTaskStore{
 		parent: t,
 		Range:  req.Range,
@@ -114,16 +165,15 @@ func (t *localTaskStore) WritePiece(ctx context.Context, req *WritePieceRequest)
 	t.RUnlock()
 
 	start := time.Now().UnixNano()
-	file, err := os.OpenFile(t.DataFilePath, os.O_RDWR, defaultFileMode)
-	if err != nil {
-		return 0, err
-	}
-	defer file.Close()
-	if _, err = file.Seek(req.Range.Start, io.SeekStart); err != nil {
-		return 0, err
+	var (
+		n   int64
+		err error
+	)
+	if t.memory != nil {
+		n, err = t.writeMemory(req)
+	} else {
+		n, err = t.writeData(req.Num, req.Range.Start, req.Reader, req.Range.Length)
 	}
-
-	n, err := io.Copy(file, io.LimitReader(req.Reader, req.Range.Length))
 	if err != nil {
 		return n, err
 	}
@@ -166,17 +216,197 @@ func (t *localTaskStore) WritePiece(ctx context.Context, req *WritePieceRequest)
 	t.Debugf("wrote %d bytes to file %s, piece %d, start %d, length: %d",
 		n, t.DataFilePath, req.Num, req.Range.Start, req.Range.Length)
 	t.Lock()
-	defer t.Unlock()
 	// double check
 	if _, ok := t.Pieces[req.Num]; ok {
+		t.Unlock()
 		return n, nil
 	}
 	req.PieceMetadata.Cost = uint64(time.Now().UnixNano() - start)
 	t.Pieces[req.Num] = req.PieceMetadata
 	t.genMetadata(n, req)
+	t.Unlock()
+
+	if t.dataHasher != nil {
+		// the piece is hashed by the writer which is hashing already
+		t.hashData(false)
+	}
+	return n, nil
+}
+
+// writeData writes the piece data to disk, the data is encoded when compression or encryption is enabled
+func (t *localTaskStore) writeData(num int32, offset int64, reader io.Reader, length int64) (int64, error) {
+	if t.encoded() {
+		return t.writeEncoded(num, reader, length)
+	}
+	return t.writeFile(offset, reader, length)
+}
+
+func (t *localTaskStore) writeFile(offset int64, reader io.Reader, length int64) (int64, error) {
+	file, err := os.OpenFile(t.DataFilePath, os.O_RDWR, defaultFileMode)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	if _, err = file.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return io.Copy(file, io.LimitReader(reader, length))
+}
+
+// writeMemory writes the piece data to memory tier, and falls back to disk when memory tier is not available
+func (t *localTaskStore) writeMemory(req *WritePieceRequest) (int64, error) {
+	t.RLock()
+	spilled := t.spilled
+	t.RUnlock()
+	if spilled || !t.memory.reserve(t, req.Range.Length) {
+		return t.writeData(req.Num, req.Range.Start, req.Reader, req.Range.Length)
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, req.Range.Length))
+	n, err := io.Copy(buf, io.LimitReader(req.Reader, req.Range.Length))
+	// keep nothing for failed piece, the length is checked by caller
+	if err != nil || n == 0 || (n != req.Range.Length && !req.UnknownLength) {
+		t.memory.release(req.Range.Length)
+		return n, err
+	}
+
+	t.Lock()
+	if t.spilled {
+		t.Unlock()
+		t.memory.release(req.Range.Length)
+		return t.writeData(req.Num, req.Range.Start, buf, n)
+	}
+	if _, ok := t.memoryPieces[req.Num]; ok {
+		t.Unlock()
+		t.memory.release(req.Range.Length)
+		return n, nil
+	}
+	t.memoryPieces[req.Num] = &memoryPiece{
+		start: req.Range.Start,
+		data:  buf.Bytes(),
+	}
+	t.memorySize += n
+	t.Unlock()
+
+	if n < req.Range.Length {
+		t.memory.release(req.Range.Length - n)
+	}
 	return n, nil
 }
 
+// readMemory returns the reader of piece num, or the reader of range when num is -1,
+// ok is false when the data is not all in memory tier
+func (t *localTaskStore) readMemory(num int32, rg clientutil.Range) (io.Reader, bool) {
+	t.RLock()
+	defer t.RUnlock()
+	if len(t.memoryPieces) == 0 {
+		return nil, false
+	}
+
+	if num != -1 {
+		piece, ok := t.memoryPieces[num]
+		if !ok {
+			return nil, false
+		}
+		if _, ok := t.Pieces[num]; !ok {
+			return nil, false
+		}
+		return bytes.NewReader(piece.data), true
+	}
+
+	// the content length is unknown
+	if rg.Length <= 0 {
+		return nil, false
+	}
+
+	pieces := make([]*memoryPiece, 0, len(t.memoryPieces))
+	for _, piece := range t.memoryPieces {
+		pieces = append(pieces, piece)
+	}
+	sort.Slice(pieces, func(i, j int) bool {
+		return pieces[i].start < pieces[j].start
+	})
+
+	var (
+		readers []io.Reader
+		pos     = rg.Start
+		end     = rg.Start + rg.Length
+	)
+	for _, piece := range pieces {
+		if pos >= end {
+			break
+		}
+		pieceEnd := piece.start + int64(len(piece.data))
+		if pieceEnd <= pos {
+			continue
+		}
+		// the data is not continuous in memory
+		if piece.start > pos {
+			return nil, false
+		}
+		to := pieceEnd
+		if to > end {
+			to = end
+		}
+		readers = append(readers, bytes.NewReader(piece.data[pos-piece.start:to-piece.start]))
+		pos = to
+	}
+	if pos < end {
+		return nil, false
+	}
+	return io.MultiReader(readers...), true
+}
+
+// readRangeFromMemory reads the range from memory tier, when the range is not all in memory,
+// spills the data to disk, and the caller reads it from data file
+func (t *localTaskStore) readRangeFromMemory(rg clientutil.Range) (io.ReadCloser, bool) {
+	if t.memory == nil {
+		return nil, false
+	}
+	if r, ok := t.readMemory(-1, rg); ok {
+		return io.NopCloser(r), true
+	}
+
+	t.RLock()
+	inMemory := t.memorySize > 0
+	t.RUnlock()
+	if inMemory {
+		t.memory.spill(t)
+	}
+	return nil, false
+}
+
+// spillData writes the pieces in memory tier to data file, returns the size of released memory
+func (t *localTaskStore) spillData() (int64, error) {
+	t.Lock()
+	defer t.Unlock()
+	t.spilled = true
+	if len(t.memoryPieces) == 0 {
+		return 0, nil
+	}
+
+	file, err := os.OpenFile(t.DataFilePath, os.O_RDWR, defaultFileMode)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	for num, piece := range t.memoryPieces {
+		if t.encoded() {
+			err = t.appendEncodedLocked(file, num, piece.data)
+		} else {
+			_, err = file.WriteAt(piece.data, piece.start)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	freed := t.memorySize
+	t.memoryPieces = nil
+	t.memorySize = 0
+	return freed, nil
+}
+
 func (t *localTaskStore) genMetadata(n int64, req *WritePieceRequest) {
 	if req.GenMetadata == nil {
 		return
@@ -224,6 +454,23 @@ func (t *localTaskStore) UpdateTask(ctx context.Context, req *UpdateTaskRequest)
 }
 
 func (t *localTaskStore) ValidateDigest(*PeerTaskMetadata) error {
+	if err := t.validateDigest(); err != nil {
+		return err
+	}
+	if t.dataHasher != nil {
+		// the pieces are hashed when they are written, only the pieces not hashed yet are read here
+		t.hashData(true)
+		if err := t.saveMetadata(); err != nil {
+			t.Warnf("save data digest error: %s", err)
+		}
+	}
+	if t.deduplicate != nil {
+		t.deduplicate(t)
+	}
+	return nil
+}
+
+func (t *localTaskStore) validateDigest() error {
 	t.Lock()
 	defer t.Unlock()
 	if t.persistentMetadata.PieceMd5Sign == "" {
@@ -262,6 +509,41 @@ func (t *localTaskStore) ReadPiece(ctx context.Context, req *ReadPieceRequest) (
 	}
 
 	t.touch()
+	if t.memory != nil {
+		if req.Num != -1 {
+			if r, ok := t.readMemory(req.Num, req.Range); ok {
+				rc := io.NopCloser(r)
+				return rc, rc, nil
+			}
+		} else if rc, ok := t.readRangeFromMemory(req.Range); ok {
+			return rc, rc, nil
+		}
+	}
+
+	if t.encoded() {
+		if req.Num == -1 {
+			rc, err := t.readEncodedRange(req.Range)
+			if err != nil {
+				return nil, nil, err
+			}
+			return rc, rc, nil
+		}
+
+		t.RLock()
+		_, ok := t.Pieces[req.Num]
+		t.RUnlock()
+		if !ok {
+			t.Errorf("invalid piece num: %d", req.Num)
+			return nil, nil, ErrPieceNotFound
+		}
+		r, err := t.readEncodedPiece(req.Num)
+		if err != nil {
+			return nil, nil, err
+		}
+		rc := io.NopCloser(r)
+		return rc, rc, nil
+	}
+
 	file, err := os.Open(t.DataFilePath)
 	if err != nil {
 		return nil, nil, err
@@ -297,6 +579,24 @@ func (t *localTaskStore) ReadAllPieces(ctx context.Context, req *ReadAllPiecesRe
 	}
 
 	t.touch()
+	t.countAccess()
+	if t.memory != nil {
+		rg := clientutil.Range{Start: 0, Length: t.ContentLength}
+		if req.Range != nil {
+			rg = *req.Range
+		}
+		if rc, ok := t.readRangeFromMemory(rg); ok {
+			return rc, nil
+		}
+	}
+
+	if t.encoded() {
+		rg := clientutil.Range{Start: 0, Length: t.ContentLength}
+		if req.Range != nil {
+			rg = *req.Range
+		}
+		return t.readEncodedRange(rg)
+	}
 
 	// who call ReadPiece, who close the io.ReadCloser
 	file, err := os.Open(t.DataFilePath)
@@ -346,6 +646,21 @@ func (t *localTaskStore) Store(ctx context.Context, req *StoreRequest) error {
 	if req.MetadataOnly {
 		return nil
 	}
+	t.countAccess()
+
+	// the destination is linked or copied from data file
+	if t.memory != nil {
+		t.memory.spill(t)
+	}
+
+	// the encoded data file can not be linked
+	if t.encoded() {
+		if _, err := os.Stat(req.Destination); err == nil {
+			t.Infof("destination file %q exists, purge it first", req.Destination)
+			os.Remove(req.Destination)
+		}
+		return t.storeEncoded(req.Destination)
+	}
 
 	if req.OriginalOffset {
 		return hardlink(t.SugaredLoggerOnWith, req.Destination, t.DataFilePath)
@@ -464,12 +779,67 @@ func (t *localTaskStore) CanReclaim() bool {
 	if t.invalid.Load() {
 		return true
 	}
+	if t.isPinned() {
+		t.Debugf("reclaim check, task is pinned")
+		return false
+	}
 	access := time.Unix(0, t.lastAccess.Load())
 	reclaim := access.Add(t.expireTime).Before(time.Now())
 	t.Debugf("reclaim check, last access: %v, reclaim: %v", access, reclaim)
 	return reclaim
 }
 
+// pin keeps the task from being reclaimed until the given time, the pinned time is only extended
+func (t *localTaskStore) pin(until time.Time) error {
+	t.Lock()
+	if !until.After(t.PinnedUntil) {
+		t.Unlock()
+		return nil
+	}
+	t.PinnedUntil = until
+	done := t.Done
+	t.Unlock()
+	t.Infof("task is pinned until %v", until)
+
+	// metadata of unfinished task will be saved when it is done
+	if !done {
+		return nil
+	}
+	return t.saveMetadata()
+}
+
+// setPinned sets the pin flag of task, the pinned task is kept until it is unpinned,
+// unpinning also clears the pinned time
+func (t *localTaskStore) setPinned(pinned bool) error {
+	t.Lock()
+	t.Pinned = pinned
+	if !pinned {
+		t.PinnedUntil = time.Time{}
+	}
+	done := t.Done
+	t.Unlock()
+	t.Infof("task pin flag is set to %v", pinned)
+
+	// metadata of unfinished task will be saved when it is done
+	if !done {
+		return nil
+	}
+	return t.saveMetadata()
+}
+
+func (t *localTaskStore) pinnedUntil() time.Time {
+	t.RLock()
+	defer t.RUnlock()
+	return t.PinnedUntil
+}
+
+// isPinned indicates whether the task is pinned by flag or pinned and not expired
+func (t *localTaskStore) isPinned() bool {
+	t.RLock()
+	defer t.RUnlock()
+	return t.Pinned || time.Now().Before(t.PinnedUntil)
+}
+
 // MarkReclaim will try to invoke gcCallback (normal leave peer task)
 func (t *localTaskStore) MarkReclaim() {
 	if t.reclaimMarked.Load() {
@@ -501,6 +871,9 @@ func (t *localTaskStore) MarkReclaim() {
 
 func (t *localTaskStore) Reclaim() error {
 	t.Infof("start gc task data")
+	if t.memory != nil {
+		t.memory.remove(t)
+	}
 	err := t.reclaimData()
 	if err != nil && !os.IsNotExist(err) {
 		return err
@@ -583,6 +956,11 @@ func (t *localTaskStore) reclaimMeta() error {
 func (t *localTaskStore) saveMetadata() error {
 	t.Lock()
 	defer t.Unlock()
+	// the data in memory tier is lost after restart, persist the metadata after the data spills to disk
+	if t.memorySize > 0 {
+		return nil
+	}
+	t.AccessCount = t.accessCount.Load()
 	data, err := json.Marshal(t.persistentMetadata)
 	if err != nil {
 		return err
@@ -703,3 +1081,128 @@ func hardlink(log *logger.SugaredLoggerOnWith, dst, src string) error {
 	err = fmt.Errorf("target file %q exists, with different inode with underlay data %q", dst, src)
 	return err
 }
+
+// dataHasher computes the sha256 digest of data incrementally in the order of pieces,
+// the pieces written out of order are hashed after all the pieces before them are written
+type dataHasher struct {
+	sync.Mutex
+	// hashing is set when a writer is hashing, the other writers skip hashing instead of waiting
+	hashing atomic.Bool
+	hash    hash.Hash
+	// next is the num of the next piece to hash
+	next int32
+	// failed is set when reading data failed, the digest is not available any more
+	failed bool
+}
+
+func newDataHasher() *dataHasher {
+	return &dataHasher{
+		hash: sha256.New(),
+	}
+}
+
+// hashData hashes the written pieces following the hashed ones, and sets the data digest after all
+// pieces are hashed. The pieces are read from the data file just after writing, so they are in page cache.
+// When wait is false, it returns at once if the data is being hashed by others.
+func (t *localTaskStore) hashData(wait bool) {
+	h := t.dataHasher
+	if !h.hashing.CAS(false, true) {
+		if !wait {
+			return
+		}
+		h.Lock()
+		defer h.Unlock()
+	} else {
+		defer h.hashing.Store(false)
+		h.Lock()
+		defer h.Unlock()
+	}
+	if h.failed {
+		return
+	}
+
+	var file *os.File
+	defer func() {
+		if file != nil {
+			file.Close()
+		}
+	}()
+	for {
+		t.RLock()
+		piece, ok := t.Pieces[h.next]
+		total := t.TotalPieces
+		done := t.DataDigest != ""
+		t.RUnlock()
+		if done {
+			return
+		}
+
+		if total > 0 && h.next >= total {
+			encoded := digest.NewDigest(digest.AlgorithmSHA256, hex.EncodeToString(h.hash.Sum(nil))).String()
+			t.Lock()
+			t.DataDigest = encoded
+			t.Unlock()
+			t.Debugf("data digest: %s", encoded)
+			return
+		}
+
+		if !ok {
+			return
+		}
+
+		if file == nil {
+			var err error
+			if file, err = os.Open(t.DataFilePath); err != nil {
+				t.Warnf("open data to hash error: %s", err)
+				h.failed = true
+				return
+			}
+		}
+
+		if _, err := io.Copy(h.hash, io.NewSectionReader(file, piece.Range.Start, piece.Range.Length)); err != nil {
+			t.Warnf("hash piece %d error: %s", h.next, err)
+			h.failed = true
+			return
+		}
+		h.next++
+	}
+}
+
+// linkData replaces dst with a hard link to src, the old data of dst is released after that
+func linkData(dst, src string) error {
+	dstStat, err := os.Stat(dst)
+	if err != nil {
+		return err
+	}
+	srcStat, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if os.SameFile(dstStat, srcStat) {
+		return nil
+	}
+
+	// link to a temporary file first, then rename it to dst atomically
+	tmp := dst + ".link"
+	if err := os.Link(src, tmp); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// dataFileID returns the device and inode of data file, hard links of the same data have the same id
+func dataFileID(file string) ([2]uint64, bool) {
+	stat, err := os.Stat(file)
+	if err != nil {
+		return [2]uint64{}, false
+	}
+	sys, ok := stat.Sys().(*syscall.Stat_t)
+	if !ok {
+		return [2]uint64{}, false
+	}
+	return [2]uint64{uint64(sys.Dev), uint64(sys.Ino)}, true
+}
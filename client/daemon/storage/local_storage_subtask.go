@@ -168,6 +168,7 @@ func (t *localSubTaskStore) ReadAllPieces(ctx context.Context, req *ReadAllPiece
 	}
 
 	t.parent.touch()
+	t.parent.countAccess()
 
 	// who call ReadPiece, who close the io.ReadCloser
 	file, err := os.Open(t.parent.DataFilePath)
@@ -273,6 +274,7 @@ func (t *localSubTaskStore) Store(ctx context.Context, req *StoreRequest) error
 	if req.MetadataOnly {
 		return nil
 	}
+	t.parent.countAccess()
 
 	if req.OriginalOffset {
 		return hardlink(t.SugaredLoggerOnWith, req.Destination, t.parent.DataFilePath)
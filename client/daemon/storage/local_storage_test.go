@@ -20,7 +20,11 @@ import (
 	"bytes"
 	"context"
 	"crypto/md5"
+	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"math"
 	"math/rand"
@@ -29,16 +33,19 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	testifyassert "github.com/stretchr/testify/assert"
 
 	"d7y.io/dragonfly/v2/client/clientutil"
 	"d7y.io/dragonfly/v2/client/config"
+	"d7y.io/dragonfly/v2/client/daemon/metrics"
 	"d7y.io/dragonfly/v2/client/daemon/test"
 	logger "d7y.io/dragonfly/v2/internal/dflog"
 	"d7y.io/dragonfly/v2/internal/util"
 	"d7y.io/dragonfly/v2/pkg/digest"
 	"d7y.io/dragonfly/v2/pkg/rpc/base"
 	_ "d7y.io/dragonfly/v2/pkg/rpc/dfdaemon/server"
+	"d7y.io/dragonfly/v2/pkg/unit"
 )
 
 func TestMain(m *testing.M) {
@@ -540,3 +547,1195 @@ func TestLocalTaskStore_partialCompleted(t *testing.T) {
 		})
 	}
 }
+
+func TestLocalTaskStore_CanReclaim(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		lastAccess  time.Time
+		pinnedUntil time.Time
+		invalid     bool
+		reclaim     bool
+	}{
+		{
+			name:       "task expired",
+			lastAccess: time.Now().Add(-2 * time.Hour),
+			reclaim:    true,
+		},
+		{
+			name:       "task not expired",
+			lastAccess: time.Now(),
+			reclaim:    false,
+		},
+		{
+			name:        "task expired but pinned",
+			lastAccess:  time.Now().Add(-2 * time.Hour),
+			pinnedUntil: time.Now().Add(time.Hour),
+			reclaim:     false,
+		},
+		{
+			name:        "task expired and pin expired",
+			lastAccess:  time.Now().Add(-2 * time.Hour),
+			pinnedUntil: time.Now().Add(-time.Minute),
+			reclaim:     true,
+		},
+		{
+			name:        "task pinned but invalid",
+			lastAccess:  time.Now(),
+			pinnedUntil: time.Now().Add(time.Hour),
+			invalid:     true,
+			reclaim:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := testifyassert.New(t)
+			lts := &localTaskStore{
+				SugaredLoggerOnWith: logger.With("test", "localTaskStore"),
+				persistentMetadata: persistentMetadata{
+					PinnedUntil: tc.pinnedUntil,
+				},
+				expireTime: time.Hour,
+			}
+			lts.lastAccess.Store(tc.lastAccess.UnixNano())
+			lts.invalid.Store(tc.invalid)
+			assert.Equal(tc.reclaim, lts.CanReclaim())
+		})
+	}
+}
+
+func TestLocalTaskStore_pin(t *testing.T) {
+	assert := testifyassert.New(t)
+	lts := &localTaskStore{
+		SugaredLoggerOnWith: logger.With("test", "localTaskStore"),
+	}
+	assert.False(lts.isPinned())
+
+	until := time.Now().Add(time.Hour)
+	assert.Nil(lts.pin(until))
+	assert.True(lts.isPinned())
+	assert.Equal(until, lts.pinnedUntil())
+
+	// pinned time is not shortened
+	assert.Nil(lts.pin(time.Now().Add(time.Minute)))
+	assert.Equal(until, lts.pinnedUntil())
+
+	// unpin clears the pinned time too
+	assert.Nil(lts.setPinned(false))
+	assert.False(lts.isPinned())
+
+	// pinned until unpinned
+	assert.Nil(lts.setPinned(true))
+	assert.True(lts.isPinned())
+	assert.Nil(lts.setPinned(false))
+	assert.False(lts.isPinned())
+}
+
+func TestStorageManager_PinTask(t *testing.T) {
+	assert := testifyassert.New(t)
+	sm, err := NewStorageManager(config.SimpleLocalTaskStoreStrategy,
+		&config.StorageOption{
+			DataPath: t.TempDir(),
+			TaskExpireTime: clientutil.Duration{
+				Duration: time.Millisecond,
+			},
+		}, func(request CommonTaskRequest) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	meta := PeerTaskMetadata{
+		PeerID: "peer",
+		TaskID: "task",
+	}
+	ts, err := sm.RegisterTask(context.Background(), &RegisterTaskRequest{
+		PeerTaskMetadata: meta,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lts := ts.(*localTaskStore)
+	lts.Done = true
+
+	// zero ttl pins the task until it is unpinned, the pin flag is persisted
+	assert.Nil(sm.PinTask(&PinTaskRequest{PeerTaskMetadata: meta}))
+	time.Sleep(10 * time.Millisecond)
+	assert.False(lts.CanReclaim())
+	data, err := os.ReadFile(lts.metadataFilePath)
+	assert.Nil(err)
+	var metadata persistentMetadata
+	assert.Nil(json.Unmarshal(data, &metadata))
+	assert.True(metadata.Pinned)
+
+	assert.Nil(sm.UnpinTask(&meta))
+	assert.True(lts.CanReclaim())
+	data, err = os.ReadFile(lts.metadataFilePath)
+	assert.Nil(err)
+	assert.Nil(json.Unmarshal(data, &metadata))
+	assert.False(metadata.Pinned)
+
+	assert.Equal(ErrTaskNotFound, sm.UnpinTask(&PeerTaskMetadata{PeerID: "foo", TaskID: "bar"}))
+}
+
+func TestStorageManager_evictionPolicy(t *testing.T) {
+	var testCases = []struct {
+		name    string
+		policy  string
+		evicted string
+	}{
+		{
+			name:    "lru evicts the least recently used task",
+			policy:  config.EvictionPolicyLRU,
+			evicted: "old-hot",
+		},
+		{
+			name:    "default policy is lru",
+			policy:  "",
+			evicted: "old-hot",
+		},
+		{
+			name:    "lfu evicts the least frequently used task",
+			policy:  config.EvictionPolicyLFU,
+			evicted: "new-cold",
+		},
+		{
+			name:    "size weighted lru evicts the large idle task",
+			policy:  config.EvictionPolicySizeWeightedLRU,
+			evicted: "mid-large",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := testifyassert.New(t)
+			sm, err := NewStorageManager(config.SimpleLocalTaskStoreStrategy,
+				&config.StorageOption{
+					DataPath: t.TempDir(),
+					TaskExpireTime: clientutil.Duration{
+						Duration: 24 * time.Hour,
+					},
+					DiskGCThreshold: 120,
+					EvictionPolicy:  tc.policy,
+				}, func(request CommonTaskRequest) {
+				})
+			assert.Nil(err)
+			s := sm.(*storageManager)
+
+			now := time.Now()
+			tasks := map[string]*localTaskStore{}
+			for _, task := range []struct {
+				id          string
+				lastAccess  time.Time
+				accessCount int64
+				size        int64
+				pinned      bool
+			}{
+				{id: "pinned", lastAccess: now.Add(-5 * time.Hour), size: 10, pinned: true},
+				{id: "old-hot", lastAccess: now.Add(-3 * time.Hour), accessCount: 100, size: 10},
+				{id: "mid-large", lastAccess: now.Add(-2 * time.Hour), accessCount: 50, size: 100},
+				{id: "new-cold", lastAccess: now.Add(-time.Hour), accessCount: 1, size: 10},
+			} {
+				lts := &localTaskStore{
+					SugaredLoggerOnWith: logger.With("task", task.id),
+					persistentMetadata: persistentMetadata{
+						TaskID:        task.id,
+						PeerID:        task.id,
+						ContentLength: task.size,
+						Done:          true,
+					},
+					expireTime: 24 * time.Hour,
+					gcCallback: func(CommonTaskRequest) {},
+				}
+				lts.lastAccess.Store(task.lastAccess.UnixNano())
+				lts.accessCount.Store(task.accessCount)
+				if task.pinned {
+					lts.PinnedUntil = now.Add(time.Hour)
+				}
+				s.tasks.Store(PeerTaskMetadata{TaskID: task.id, PeerID: task.id}, lts)
+				tasks[task.id] = lts
+			}
+
+			_, err = s.TryGC()
+			assert.Nil(err)
+			for id, lts := range tasks {
+				assert.Equal(id == tc.evicted, lts.reclaimMarked.Load(), id)
+			}
+		})
+	}
+}
+
+func TestLocalTaskStore_accessCount(t *testing.T) {
+	assert := testifyassert.New(t)
+	testData := []byte("access count test data")
+	sm, err := NewStorageManager(config.SimpleLocalTaskStoreStrategy,
+		&config.StorageOption{
+			DataPath: t.TempDir(),
+			TaskExpireTime: clientutil.Duration{
+				Duration: time.Minute,
+			},
+		}, func(request CommonTaskRequest) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	meta := PeerTaskMetadata{
+		PeerID: "peer",
+		TaskID: "task",
+	}
+	ts, err := sm.(*storageManager).CreateTask(&RegisterTaskRequest{
+		PeerTaskMetadata: meta,
+		ContentLength:    int64(len(testData)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lts := ts.(*localTaskStore)
+
+	pieceSize := int64(len(testData)) / 2
+	for num := int32(0); num < 2; num++ {
+		start := int64(num) * pieceSize
+		end := start + pieceSize
+		if num == 1 {
+			end = int64(len(testData))
+		}
+		_, err = ts.WritePiece(context.Background(), &WritePieceRequest{
+			PeerTaskMetadata: meta,
+			PieceMetadata: PieceMetadata{
+				Num: num,
+				Md5: calcPieceMd5(testData[start:end]),
+				Range: clientutil.Range{
+					Start:  start,
+					Length: end - start,
+				},
+				Style: base.PieceStyle_PLAIN,
+			},
+			Reader: bytes.NewBuffer(testData[start:end]),
+		})
+		assert.Nil(err)
+	}
+
+	// reads and writes of pieces are not counted
+	for i := 0; i < 3; i++ {
+		_, cl, err := ts.ReadPiece(context.Background(), &ReadPieceRequest{
+			PeerTaskMetadata: meta,
+			PieceMetadata: PieceMetadata{
+				Num: 0,
+			},
+		})
+		assert.Nil(err)
+		cl.Close()
+	}
+	assert.Equal(int64(0), lts.accessStats().AccessCount)
+
+	// storing metadata only is not counted
+	assert.Nil(ts.Store(context.Background(), &StoreRequest{
+		CommonTaskRequest: CommonTaskRequest{
+			PeerID: meta.PeerID,
+			TaskID: meta.TaskID,
+		},
+		MetadataOnly: true,
+	}))
+	assert.Equal(int64(0), lts.accessStats().AccessCount)
+
+	// storing to destination is counted
+	assert.Nil(ts.Store(context.Background(), &StoreRequest{
+		CommonTaskRequest: CommonTaskRequest{
+			PeerID:      meta.PeerID,
+			TaskID:      meta.TaskID,
+			Destination: path.Join(t.TempDir(), "output"),
+		},
+	}))
+	assert.Equal(int64(1), lts.accessStats().AccessCount)
+
+	// reading the whole task is counted
+	rc, err := ts.ReadAllPieces(context.Background(), &ReadAllPiecesRequest{PeerTaskMetadata: meta})
+	assert.Nil(err)
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	assert.Nil(err)
+	assert.Equal(testData, data)
+	assert.Equal(int64(2), lts.accessStats().AccessCount)
+}
+
+func TestStorageManager_quota(t *testing.T) {
+	var testCases = []struct {
+		name            string
+		diskGCThreshold unit.Bytes
+		evicted         []string
+		usages          map[string]int64
+	}{
+		{
+			name:    "evict tasks of the group exceeding quota",
+			evicted: []string{"checkpoint-old"},
+			usages: map[string]int64{
+				"checkpoints": 60,
+				"kubelet":     10,
+			},
+		},
+		{
+			name:            "evict tasks of the group exceeding quota before all tasks",
+			diskGCThreshold: 70,
+			evicted:         []string{"checkpoint-old", "layer-old"},
+			usages: map[string]int64{
+				"checkpoints": 60,
+				"kubelet":     10,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := testifyassert.New(t)
+			sm, err := NewStorageManager(config.SimpleLocalTaskStoreStrategy,
+				&config.StorageOption{
+					DataPath: t.TempDir(),
+					TaskExpireTime: clientutil.Duration{
+						Duration: 24 * time.Hour,
+					},
+					DiskGCThreshold: tc.diskGCThreshold,
+					Quotas: []config.StorageQuotaOption{
+						{
+							Name:  "checkpoints",
+							Tag:   "ml-checkpoints",
+							Limit: 100,
+						},
+						{
+							Name:        "kubelet",
+							Application: "kubelet",
+							Limit:       100,
+						},
+					},
+				}, func(request CommonTaskRequest) {
+				})
+			assert.Nil(err)
+			s := sm.(*storageManager)
+
+			now := time.Now()
+			tasks := map[string]*localTaskStore{}
+			for _, task := range []struct {
+				id          string
+				tag         string
+				application string
+				lastAccess  time.Time
+				size        int64
+			}{
+				{id: "layer-old", application: "kubelet", lastAccess: now.Add(-5 * time.Hour), size: 10},
+				{id: "other", lastAccess: now.Add(-4 * time.Hour), size: 10},
+				{id: "checkpoint-old", tag: "ml-checkpoints", lastAccess: now.Add(-3 * time.Hour), size: 60},
+				{id: "checkpoint-new", tag: "ml-checkpoints", lastAccess: now.Add(-time.Hour), size: 60},
+			} {
+				lts := &localTaskStore{
+					SugaredLoggerOnWith: logger.With("task", task.id),
+					persistentMetadata: persistentMetadata{
+						TaskID:        task.id,
+						PeerID:        task.id,
+						ContentLength: task.size,
+						Done:          true,
+						Tag:           task.tag,
+						Application:   task.application,
+					},
+					expireTime: 24 * time.Hour,
+					gcCallback: func(CommonTaskRequest) {},
+				}
+				lts.lastAccess.Store(task.lastAccess.UnixNano())
+				s.tasks.Store(PeerTaskMetadata{TaskID: task.id, PeerID: task.id}, lts)
+				tasks[task.id] = lts
+			}
+
+			_, err = s.TryGC()
+			assert.Nil(err)
+			var evicted []string
+			for id, lts := range tasks {
+				if lts.reclaimMarked.Load() {
+					evicted = append(evicted, id)
+				}
+			}
+			assert.ElementsMatch(tc.evicted, evicted)
+			for group, size := range tc.usages {
+				assert.Equal(float64(size), testutil.ToFloat64(metrics.StorageQuotaUsageGauge.WithLabelValues(group)), group)
+			}
+		})
+	}
+}
+
+func TestStorageManager_matchQuota(t *testing.T) {
+	var testCases = []struct {
+		name        string
+		quota       config.StorageQuotaOption
+		tag         string
+		application string
+		callsystem  string
+		match       bool
+	}{
+		{
+			name:       "match callsystem",
+			quota:      config.StorageQuotaOption{Name: "sync-jobs", Callsystem: "sync-job"},
+			callsystem: "sync-job",
+			match:      true,
+		},
+		{
+			name:       "mismatch callsystem",
+			quota:      config.StorageQuotaOption{Name: "sync-jobs", Callsystem: "sync-job"},
+			callsystem: "dfget",
+			match:      false,
+		},
+		{
+			name:       "empty callsystem of quota matches any callsystem",
+			quota:      config.StorageQuotaOption{Name: "checkpoints", Tag: "ml-checkpoints"},
+			tag:        "ml-checkpoints",
+			callsystem: "sync-job",
+			match:      true,
+		},
+		{
+			name:        "all of tag, application and callsystem must match",
+			quota:       config.StorageQuotaOption{Name: "kubelet", Application: "kubelet", Callsystem: "sync-job"},
+			application: "kubelet",
+			match:       false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := testifyassert.New(t)
+			assert.Equal(tc.match, matchQuota(tc.quota, tc.tag, tc.application, tc.callsystem))
+		})
+	}
+}
+
+func TestStorageManager_quotaDeduplication(t *testing.T) {
+	var testCases = []struct {
+		name   string
+		first  string
+		second string
+		usages map[string]int64
+	}{
+		{
+			name:   "shared data is charged to the group of the first task",
+			first:  "ml-checkpoints",
+			second: "",
+			usages: map[string]int64{
+				"checkpoints": 60,
+				"others":      0,
+			},
+		},
+		{
+			name:   "shared data is not charged to the group of the deduplicated task",
+			first:  "",
+			second: "ml-checkpoints",
+			usages: map[string]int64{
+				"checkpoints": 0,
+				"others":      60,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := testifyassert.New(t)
+			dataPath := t.TempDir()
+			sm, err := NewStorageManager(config.SimpleLocalTaskStoreStrategy,
+				&config.StorageOption{
+					DataPath: dataPath,
+					TaskExpireTime: clientutil.Duration{
+						Duration: 24 * time.Hour,
+					},
+					Quotas: []config.StorageQuotaOption{
+						{
+							Name:  "checkpoints",
+							Tag:   "ml-checkpoints",
+							Limit: 100,
+						},
+						{
+							Name:        "others",
+							Application: "others",
+							Limit:       100,
+						},
+					},
+				}, func(request CommonTaskRequest) {
+				}, WithDeduplication(true))
+			assert.Nil(err)
+			s := sm.(*storageManager)
+
+			firstData := path.Join(dataPath, "first")
+			if err := os.WriteFile(firstData, bytes.Repeat([]byte{1}, 60), defaultFileMode); err != nil {
+				t.Fatal(err)
+			}
+			secondData := path.Join(dataPath, "second")
+			if err := os.Link(firstData, secondData); err != nil {
+				t.Fatal(err)
+			}
+
+			now := time.Now()
+			for _, task := range []struct {
+				id        string
+				tag       string
+				dataFile  string
+				createdAt time.Time
+			}{
+				// the second task is stored first, the order of range is not the order of creating
+				{id: "second", tag: tc.second, dataFile: secondData, createdAt: now},
+				{id: "first", tag: tc.first, dataFile: firstData, createdAt: now.Add(-time.Hour)},
+			} {
+				application := ""
+				if task.tag == "" {
+					application = "others"
+				}
+				lts := &localTaskStore{
+					SugaredLoggerOnWith: logger.With("task", task.id),
+					persistentMetadata: persistentMetadata{
+						TaskID:        task.id,
+						PeerID:        task.id,
+						ContentLength: 60,
+						DataFilePath:  task.dataFile,
+						Done:          true,
+						Tag:           task.tag,
+						Application:   application,
+						CreatedAt:     task.createdAt,
+					},
+					expireTime: 24 * time.Hour,
+					gcCallback: func(CommonTaskRequest) {},
+				}
+				lts.lastAccess.Store(now.UnixNano())
+				s.tasks.Store(PeerTaskMetadata{TaskID: task.id, PeerID: task.id}, lts)
+			}
+
+			_, err = s.TryGC()
+			assert.Nil(err)
+			for group, size := range tc.usages {
+				assert.Equal(float64(size), testutil.ToFloat64(metrics.StorageQuotaUsageGauge.WithLabelValues(group)), group)
+			}
+		})
+	}
+}
+
+func TestStorageManager_deduplication(t *testing.T) {
+	assert := testifyassert.New(t)
+	testData := []byte("test data")
+	pieceSize := 3
+	var pieceMd5s []string
+	for i := 0; i < len(testData); i += pieceSize {
+		pieceMd5s = append(pieceMd5s, calcPieceMd5(testData[i:i+pieceSize]))
+	}
+
+	sm, err := NewStorageManager(config.SimpleLocalTaskStoreStrategy,
+		&config.StorageOption{
+			DataPath: t.TempDir(),
+			TaskExpireTime: clientutil.Duration{
+				Duration: time.Minute,
+			},
+		}, func(request CommonTaskRequest) {
+		}, WithDeduplication(true))
+	assert.Nil(err)
+	s := sm.(*storageManager)
+
+	// the pieces are written in reverse order, the data is hashed after all pieces are written
+	complete := func(taskID, peerID, digestStr string) *localTaskStore {
+		ts, err := s.CreateTask(
+			&RegisterTaskRequest{
+				PeerTaskMetadata: PeerTaskMetadata{
+					PeerID: peerID,
+					TaskID: taskID,
+				},
+				ContentLength: int64(len(testData)),
+				TotalPieces:   int32(len(pieceMd5s)),
+				PieceMd5Sign:  digest.SHA256FromStrings(pieceMd5s...),
+				Digest:        digestStr,
+			})
+		assert.Nil(err)
+		for num := len(pieceMd5s) - 1; num >= 0; num-- {
+			start := num * pieceSize
+			_, err = ts.WritePiece(context.Background(), &WritePieceRequest{
+				PeerTaskMetadata: PeerTaskMetadata{
+					TaskID: taskID,
+				},
+				PieceMetadata: PieceMetadata{
+					Num: int32(num),
+					Md5: pieceMd5s[num],
+					Range: clientutil.Range{
+						Start:  int64(start),
+						Length: int64(pieceSize),
+					},
+					Style: base.PieceStyle_PLAIN,
+				},
+				Reader: bytes.NewBuffer(testData[start : start+pieceSize]),
+			})
+			assert.Nil(err)
+		}
+		assert.Nil(ts.Store(context.Background(), &StoreRequest{
+			CommonTaskRequest: CommonTaskRequest{
+				PeerID: peerID,
+				TaskID: taskID,
+			},
+			MetadataOnly: true,
+		}))
+		assert.Nil(ts.ValidateDigest(nil))
+		return ts.(*localTaskStore)
+	}
+
+	sha256Digest := fmt.Sprintf("%s:%x", digest.AlgorithmSHA256, sha256.Sum256(testData))
+	t1 := complete("task-1", "peer-1", sha256Digest)
+	t2 := complete("task-2", "peer-2", sha256Digest)
+	t3 := complete("task-3", "peer-3", "")
+	t4 := complete("task-4", "peer-4", "sha256:foo")
+
+	// the data digest is computed from the written data and persisted
+	for _, ts := range []*localTaskStore{t1, t2, t3, t4} {
+		assert.Equal(sha256Digest, ts.DataDigest)
+		bs, err := os.ReadFile(ts.metadataFilePath)
+		assert.Nil(err)
+		var metadata persistentMetadata
+		assert.Nil(json.Unmarshal(bs, &metadata))
+		assert.Equal(sha256Digest, metadata.DataDigest)
+	}
+
+	// the data of same content is stored once, even if the task has no digest
+	id1, ok := dataFileID(t1.DataFilePath)
+	assert.True(ok)
+	for _, ts := range []*localTaskStore{t2, t3} {
+		id, ok := dataFileID(ts.DataFilePath)
+		assert.True(ok)
+		assert.Equal(id1, id)
+		bs, err := os.ReadFile(ts.DataFilePath)
+		assert.Nil(err)
+		assert.Equal(testData, bs)
+	}
+
+	// the data not matching the digest of task is not shared
+	id4, ok := dataFileID(t4.DataFilePath)
+	assert.True(ok)
+	assert.NotEqual(id1, id4)
+
+	// find by digest
+	assert.Nil(s.FindCompletedTaskByDigest("task-5", ""))
+	assert.Nil(s.FindCompletedTaskByDigest("task-5", "sha256:bar"))
+	reuse := s.FindCompletedTaskByDigest("task-5", sha256Digest)
+	assert.NotNil(reuse)
+	assert.Equal("task-5", reuse.TaskID)
+	assert.Contains([]string{"peer-1", "peer-2", "peer-3", "peer-4"}, reuse.PeerID)
+	assert.Equal(int64(len(testData)), reuse.ContentLength)
+	assert.Equal(int32(len(pieceMd5s)), reuse.TotalPieces)
+
+	rc, err := s.ReadAllPieces(context.Background(), &ReadAllPiecesRequest{PeerTaskMetadata: reuse.PeerTaskMetadata})
+	assert.Nil(err)
+	bs, err := io.ReadAll(rc)
+	rc.Close()
+	assert.Nil(err)
+	assert.Equal(testData, bs)
+	assert.NotNil(s.FindCompletedTask("task-5"))
+}
+
+func TestLocalTaskStore_memoryTier(t *testing.T) {
+	testData := []byte("memory tier test data")
+	pieceSize := 8
+
+	tests := []struct {
+		name   string
+		option config.MemoryTierOption
+		expect func(t *testing.T, s *storageManager, tasks []*localTaskStore)
+	}{
+		{
+			name: "keep task data in memory",
+			option: config.MemoryTierOption{
+				Capacity: unit.KB,
+			},
+			expect: func(t *testing.T, s *storageManager, tasks []*localTaskStore) {
+				assert := testifyassert.New(t)
+				for _, ts := range tasks {
+					assert.Equal(int64(len(testData)), ts.memorySize)
+					stat, err := os.Stat(ts.DataFilePath)
+					assert.Nil(err)
+					assert.Equal(int64(0), stat.Size())
+
+					rd, cl, err := ts.ReadPiece(context.Background(), &ReadPieceRequest{
+						PieceMetadata: PieceMetadata{
+							Num: 1,
+						},
+					})
+					assert.Nil(err)
+					data, err := io.ReadAll(rd)
+					cl.Close()
+					assert.Nil(err)
+					assert.Equal(testData[pieceSize:2*pieceSize], data)
+
+					rc, err := ts.ReadAllPieces(context.Background(), &ReadAllPiecesRequest{
+						Range: &clientutil.Range{Start: 3, Length: 10},
+					})
+					assert.Nil(err)
+					data, err = io.ReadAll(rc)
+					rc.Close()
+					assert.Nil(err)
+					assert.Equal(testData[3:13], data)
+
+					// metadata is not persisted
+					assert.Nil(ts.Store(context.Background(), &StoreRequest{MetadataOnly: true}))
+					stat, err = os.Stat(ts.metadataFilePath)
+					assert.Nil(err)
+					assert.Equal(int64(0), stat.Size())
+				}
+				assert.Equal(int64(2*len(testData)), s.memoryTier.used)
+			},
+		},
+		{
+			name: "spill least recently used task to disk",
+			option: config.MemoryTierOption{
+				Capacity: unit.Bytes(len(testData) + pieceSize),
+			},
+			expect: func(t *testing.T, s *storageManager, tasks []*localTaskStore) {
+				assert := testifyassert.New(t)
+				assert.True(tasks[0].spilled)
+				assert.Equal(int64(0), tasks[0].memorySize)
+				data, err := os.ReadFile(tasks[0].DataFilePath)
+				assert.Nil(err)
+				assert.Equal(testData, data)
+
+				assert.False(tasks[1].spilled)
+				assert.Equal(int64(len(testData)), tasks[1].memorySize)
+				assert.Equal(int64(len(testData)), s.memoryTier.used)
+			},
+		},
+		{
+			name: "write large task to disk",
+			option: config.MemoryTierOption{
+				Capacity:    unit.KB,
+				MaxTaskSize: unit.Bytes(len(testData) - 1),
+			},
+			expect: func(t *testing.T, s *storageManager, tasks []*localTaskStore) {
+				assert := testifyassert.New(t)
+				for _, ts := range tasks {
+					assert.Equal(int64(0), ts.memorySize)
+					data, err := os.ReadFile(ts.DataFilePath)
+					assert.Nil(err)
+					assert.Equal(testData, data)
+				}
+				assert.Equal(int64(0), s.memoryTier.used)
+			},
+		},
+		{
+			name: "spill to disk when store to destination",
+			option: config.MemoryTierOption{
+				Capacity: unit.KB,
+			},
+			expect: func(t *testing.T, s *storageManager, tasks []*localTaskStore) {
+				assert := testifyassert.New(t)
+				dst := path.Join(t.TempDir(), "output")
+				assert.Nil(tasks[0].Store(context.Background(), &StoreRequest{
+					CommonTaskRequest: CommonTaskRequest{
+						Destination: dst,
+					},
+				}))
+				data, err := os.ReadFile(dst)
+				assert.Nil(err)
+				assert.Equal(testData, data)
+				assert.True(tasks[0].spilled)
+				assert.Equal(int64(len(testData)), s.memoryTier.used)
+
+				// metadata is persisted after spilled
+				stat, err := os.Stat(tasks[0].metadataFilePath)
+				assert.Nil(err)
+				assert.NotEqual(int64(0), stat.Size())
+
+				assert.Nil(tasks[1].Reclaim())
+				assert.Equal(int64(0), s.memoryTier.used)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sm, err := NewStorageManager(config.TieredLocalTaskStoreStrategy,
+				&config.StorageOption{
+					DataPath: t.TempDir(),
+					TaskExpireTime: clientutil.Duration{
+						Duration: time.Minute,
+					},
+					MemoryTier: tc.option,
+				}, func(request CommonTaskRequest) {
+				})
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := sm.(*storageManager)
+
+			var tasks []*localTaskStore
+			for i := 0; i < 2; i++ {
+				ts, err := s.CreateTask(&RegisterTaskRequest{
+					PeerTaskMetadata: PeerTaskMetadata{
+						PeerID: fmt.Sprintf("peer-%d", i),
+						TaskID: fmt.Sprintf("task-%d", i),
+					},
+					ContentLength: int64(len(testData)),
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				for num, start := 0, 0; start < len(testData); num, start = num+1, start+pieceSize {
+					end := start + pieceSize
+					if end > len(testData) {
+						end = len(testData)
+					}
+					if _, err := ts.WritePiece(context.Background(), &WritePieceRequest{
+						PieceMetadata: PieceMetadata{
+							Num: int32(num),
+							Md5: calcPieceMd5(testData[start:end]),
+							Range: clientutil.Range{
+								Start:  int64(start),
+								Length: int64(end - start),
+							},
+							Style: base.PieceStyle_PLAIN,
+						},
+						Reader: bytes.NewBuffer(testData[start:end]),
+					}); err != nil {
+						t.Fatal(err)
+					}
+				}
+				tasks = append(tasks, ts.(*localTaskStore))
+			}
+			tc.expect(t, s, tasks)
+		})
+	}
+}
+
+func TestLocalTaskStore_encoding(t *testing.T) {
+	testData := bytes.Repeat([]byte("encoding test data "), 100)
+	pieceSize := 512
+	key := bytes.Repeat([]byte{1}, encryptionKeySize)
+
+	tests := []struct {
+		name        string
+		strategy    config.StoreStrategy
+		option      config.MemoryTierOption
+		compression string
+		encryption  bool
+	}{
+		{
+			name:        "compress data of simple strategy",
+			strategy:    config.SimpleLocalTaskStoreStrategy,
+			compression: config.CompressionZstd,
+		},
+		{
+			name:     "compress data spilled from memory tier",
+			strategy: config.TieredLocalTaskStoreStrategy,
+			option: config.MemoryTierOption{
+				Capacity: unit.Bytes(2 * pieceSize),
+			},
+			compression: config.CompressionZstd,
+		},
+		{
+			name:       "encrypt data of simple strategy",
+			strategy:   config.SimpleLocalTaskStoreStrategy,
+			encryption: true,
+		},
+		{
+			name:     "compress and encrypt data spilled from memory tier",
+			strategy: config.TieredLocalTaskStoreStrategy,
+			option: config.MemoryTierOption{
+				Capacity: unit.Bytes(2 * pieceSize),
+			},
+			compression: config.CompressionZstd,
+			encryption:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := testifyassert.New(t)
+			option := &config.StorageOption{
+				DataPath: t.TempDir(),
+				TaskExpireTime: clientutil.Duration{
+					Duration: time.Minute,
+				},
+				MemoryTier:  tc.option,
+				Compression: tc.compression,
+			}
+			var opts []func(*storageManager) error
+			if tc.encryption {
+				opts = append(opts, WithEncryption(func() ([]byte, error) { return key, nil }))
+			}
+			sm, err := NewStorageManager(tc.strategy, option, func(request CommonTaskRequest) {}, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			meta := PeerTaskMetadata{
+				PeerID: "peer",
+				TaskID: "task",
+			}
+			ts, err := sm.(*storageManager).CreateTask(&RegisterTaskRequest{
+				PeerTaskMetadata: meta,
+				ContentLength:    int64(len(testData)),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var nums []int
+			for num := 0; num*pieceSize < len(testData); num++ {
+				nums = append(nums, num)
+			}
+			rand.Shuffle(len(nums), func(i, j int) { nums[i], nums[j] = nums[j], nums[i] })
+			for _, num := range nums {
+				start := num * pieceSize
+				end := start + pieceSize
+				if end > len(testData) {
+					end = len(testData)
+				}
+				_, err := ts.WritePiece(context.Background(), &WritePieceRequest{
+					PeerTaskMetadata: meta,
+					PieceMetadata: PieceMetadata{
+						Num: int32(num),
+						Md5: calcPieceMd5(testData[start:end]),
+						Range: clientutil.Range{
+							Start:  int64(start),
+							Length: int64(end - start),
+						},
+						Style: base.PieceStyle_PLAIN,
+					},
+					Reader: bytes.NewBuffer(testData[start:end]),
+				})
+				assert.Nil(err)
+			}
+			assert.Nil(ts.Store(context.Background(), &StoreRequest{
+				CommonTaskRequest: CommonTaskRequest{
+					PeerID:      meta.PeerID,
+					TaskID:      meta.TaskID,
+					Destination: path.Join(t.TempDir(), "output"),
+				},
+			}))
+
+			lts := ts.(*localTaskStore)
+			stat, err := os.Stat(lts.DataFilePath)
+			assert.Nil(err)
+			if tc.compression != "" {
+				assert.Less(stat.Size(), int64(len(testData)))
+			}
+			assert.Equal(stat.Size(), lts.storedSize())
+			stored, err := os.ReadFile(lts.DataFilePath)
+			assert.Nil(err)
+			if tc.encryption {
+				assert.False(bytes.Contains(stored, testData[:pieceSize]))
+				assert.NotEmpty(lts.EncryptedKey)
+			}
+
+			// reload the encoded task
+			sm, err = NewStorageManager(tc.strategy, option, func(request CommonTaskRequest) {}, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ts, ok := sm.(*storageManager).LoadTask(meta)
+			assert.True(ok)
+			assert.Equal(stat.Size(), ts.(*localTaskStore).encodedSize)
+
+			rd, cl, err := ts.ReadPiece(context.Background(), &ReadPieceRequest{
+				PeerTaskMetadata: meta,
+				PieceMetadata: PieceMetadata{
+					Num: 1,
+				},
+			})
+			assert.Nil(err)
+			data, err := io.ReadAll(rd)
+			cl.Close()
+			assert.Nil(err)
+			assert.Equal(testData[pieceSize:2*pieceSize], data)
+
+			rd, cl, err = ts.ReadPiece(context.Background(), &ReadPieceRequest{
+				PeerTaskMetadata: meta,
+				PieceMetadata: PieceMetadata{
+					Num: -1,
+					Range: clientutil.Range{
+						Start:  int64(pieceSize - 10),
+						Length: int64(pieceSize + 20),
+					},
+				},
+			})
+			assert.Nil(err)
+			data, err = io.ReadAll(rd)
+			cl.Close()
+			assert.Nil(err)
+			assert.Equal(testData[pieceSize-10:2*pieceSize+10], data)
+
+			rc, err := ts.ReadAllPieces(context.Background(), &ReadAllPiecesRequest{
+				PeerTaskMetadata: meta,
+			})
+			assert.Nil(err)
+			data, err = io.ReadAll(rc)
+			rc.Close()
+			assert.Nil(err)
+			assert.Equal(testData, data)
+
+			rd, cl, length, err := sm.ReadCompressedPiece(context.Background(), &ReadPieceRequest{
+				PeerTaskMetadata: meta,
+				PieceMetadata: PieceMetadata{
+					Num: -1,
+					Range: clientutil.Range{
+						Start:  int64(pieceSize),
+						Length: int64(pieceSize),
+					},
+				},
+			})
+			if tc.compression == "" {
+				assert.Equal(ErrPieceNotCompressed, err)
+			} else {
+				assert.Nil(err)
+				data, err = io.ReadAll(rd)
+				cl.Close()
+				assert.Nil(err)
+				assert.Equal(length, int64(len(data)))
+				data, err = zstdDecoder.DecodeAll(data, nil)
+				assert.Nil(err)
+				assert.Equal(testData[pieceSize:2*pieceSize], data)
+			}
+
+			dst := path.Join(t.TempDir(), "output")
+			assert.Nil(ts.Store(context.Background(), &StoreRequest{
+				CommonTaskRequest: CommonTaskRequest{
+					PeerID:      meta.PeerID,
+					TaskID:      meta.TaskID,
+					Destination: dst,
+				},
+				StoreDataOnly: true,
+			}))
+			data, err = os.ReadFile(dst)
+			assert.Nil(err)
+			assert.Equal(testData, data)
+		})
+	}
+}
+
+func TestStorageManager_reloadEncryption(t *testing.T) {
+	testData := []byte("encryption test data")
+	key := bytes.Repeat([]byte{1}, encryptionKeySize)
+	otherKey := bytes.Repeat([]byte{2}, encryptionKeySize)
+
+	tests := []struct {
+		name      string
+		createKey []byte
+		reloadKey []byte
+		loaded    bool
+	}{
+		{
+			name:      "reload encrypted task with same key",
+			createKey: key,
+			reloadKey: key,
+			loaded:    true,
+		},
+		{
+			name:      "reload encrypted task with other key",
+			createKey: key,
+			reloadKey: otherKey,
+			loaded:    false,
+		},
+		{
+			name:      "reload plaintext task when encryption enabled",
+			reloadKey: key,
+			loaded:    false,
+		},
+		{
+			name:      "reload encrypted task when encryption disabled",
+			createKey: key,
+			loaded:    false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := testifyassert.New(t)
+			option := &config.StorageOption{
+				DataPath: t.TempDir(),
+				TaskExpireTime: clientutil.Duration{
+					Duration: time.Minute,
+				},
+			}
+			newStorageManager := func(key []byte) *storageManager {
+				var opts []func(*storageManager) error
+				if key != nil {
+					opts = append(opts, WithEncryption(func() ([]byte, error) { return key, nil }))
+				}
+				sm, err := NewStorageManager(config.SimpleLocalTaskStoreStrategy, option, func(request CommonTaskRequest) {}, opts...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return sm.(*storageManager)
+			}
+
+			meta := PeerTaskMetadata{
+				PeerID: "peer",
+				TaskID: "task",
+			}
+			ts, err := newStorageManager(tc.createKey).CreateTask(&RegisterTaskRequest{
+				PeerTaskMetadata: meta,
+				ContentLength:    int64(len(testData)),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = ts.WritePiece(context.Background(), &WritePieceRequest{
+				PeerTaskMetadata: meta,
+				PieceMetadata: PieceMetadata{
+					Num: 0,
+					Md5: calcPieceMd5(testData),
+					Range: clientutil.Range{
+						Start:  0,
+						Length: int64(len(testData)),
+					},
+					Style: base.PieceStyle_PLAIN,
+				},
+				Reader: bytes.NewBuffer(testData),
+			})
+			assert.Nil(err)
+			assert.Nil(ts.Store(context.Background(), &StoreRequest{
+				CommonTaskRequest: CommonTaskRequest{
+					PeerID: meta.PeerID,
+					TaskID: meta.TaskID,
+				},
+				MetadataOnly: true,
+			}))
+
+			ts, ok := newStorageManager(tc.reloadKey).LoadTask(meta)
+			assert.Equal(tc.loaded, ok)
+			if !ok {
+				return
+			}
+			rd, cl, err := ts.ReadPiece(context.Background(), &ReadPieceRequest{
+				PeerTaskMetadata: meta,
+				PieceMetadata: PieceMetadata{
+					Num: 0,
+				},
+			})
+			assert.Nil(err)
+			data, err := io.ReadAll(rd)
+			cl.Close()
+			assert.Nil(err)
+			assert.Equal(testData, data)
+		})
+	}
+}
+
+func TestCachedKeyProvider(t *testing.T) {
+	assert := testifyassert.New(t)
+	key := bytes.Repeat([]byte{1}, encryptionKeySize)
+
+	var calls int
+	provider := NewCachedKeyProvider(func() ([]byte, error) {
+		calls++
+		switch calls {
+		case 1:
+			return nil, errors.New("manager is unavailable")
+		case 2:
+			return []byte("short key"), nil
+		default:
+			return key, nil
+		}
+	})
+
+	_, err := provider()
+	assert.Error(err)
+	_, err = provider()
+	assert.Error(err)
+
+	for i := 0; i < 3; i++ {
+		k, err := provider()
+		assert.Nil(err)
+		assert.Equal(key, k)
+	}
+	assert.Equal(3, calls)
+}
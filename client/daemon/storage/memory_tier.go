@@ -0,0 +1,141 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package storage
+
+import (
+	"sync"
+
+	"github.com/docker/go-units"
+
+	"d7y.io/dragonfly/v2/client/config"
+)
+
+// memoryPiece is the piece data kept in memory tier
+type memoryPiece struct {
+	start int64
+	data  []byte
+}
+
+// memoryTier bounds the memory used by the task data of tiered strategy,
+// when the capacity is exceeded, the least recently used tasks spill to disk.
+// lock order: memoryTier before localTaskStore
+type memoryTier struct {
+	sync.Mutex
+	capacity    int64
+	maxTaskSize int64
+	used        int64
+	tasks       map[*localTaskStore]struct{}
+}
+
+func newMemoryTier(opt config.MemoryTierOption) *memoryTier {
+	return &memoryTier{
+		capacity:    int64(opt.Capacity),
+		maxTaskSize: int64(opt.MaxTaskSize),
+		tasks:       map[*localTaskStore]struct{}{},
+	}
+}
+
+// reserve reserves n bytes for task t, spills other tasks when the capacity is exceeded,
+// returns false when the data of task t should be written to disk
+func (m *memoryTier) reserve(t *localTaskStore, n int64) bool {
+	m.Lock()
+	defer m.Unlock()
+	if n > m.capacity {
+		return false
+	}
+
+	t.RLock()
+	contentLength := t.ContentLength
+	t.RUnlock()
+	// the content length of streaming task is unknown, keep it in memory until the capacity is exceeded
+	if m.maxTaskSize > 0 && contentLength > m.maxTaskSize {
+		return false
+	}
+
+	for m.used+n > m.capacity {
+		victim := m.leastRecentlyUsed(t)
+		if victim == nil {
+			return false
+		}
+		m.spillLocked(victim)
+	}
+	m.used += n
+	m.tasks[t] = struct{}{}
+	return true
+}
+
+// release releases n bytes reserved but not used
+func (m *memoryTier) release(n int64) {
+	m.Lock()
+	m.used -= n
+	m.Unlock()
+}
+
+// spill writes the data in memory of task t to disk, the data of task t is kept on disk since then
+func (m *memoryTier) spill(t *localTaskStore) {
+	m.Lock()
+	defer m.Unlock()
+	m.spillLocked(t)
+}
+
+func (m *memoryTier) spillLocked(t *localTaskStore) {
+	freed, err := t.spillData()
+	m.used -= freed
+	delete(m.tasks, t)
+	if err != nil {
+		t.Errorf("spill data to disk error: %s", err)
+		t.invalid.Store(true)
+		return
+	}
+
+	if freed > 0 {
+		t.Infof("spill %s data to disk, memory tier used: %s", units.BytesSize(float64(freed)), units.BytesSize(float64(m.used)))
+	}
+	// the metadata is not persisted until data spills to disk
+	if t.Done {
+		if err := t.saveMetadata(); err != nil {
+			t.Warnf("save task metadata error: %s", err)
+		}
+	}
+}
+
+// remove drops the data in memory of task t without writing to disk, it is used when task is reclaimed
+func (m *memoryTier) remove(t *localTaskStore) {
+	m.Lock()
+	defer m.Unlock()
+	t.Lock()
+	freed := t.memorySize
+	t.memoryPieces = nil
+	t.memorySize = 0
+	t.spilled = true
+	t.Unlock()
+	m.used -= freed
+	delete(m.tasks, t)
+}
+
+func (m *memoryTier) leastRecentlyUsed(except *localTaskStore) *localTaskStore {
+	var lru *localTaskStore
+	for t := range m.tasks {
+		if t == except {
+			continue
+		}
+		if lru == nil || t.lastAccess.Load() < lru.lastAccess.Load() {
+			lru = t
+		}
+	}
+	return lru
+}
@@ -18,6 +18,7 @@ package storage
 
 import (
 	"io"
+	"time"
 
 	"d7y.io/dragonfly/v2/client/clientutil"
 	"d7y.io/dragonfly/v2/pkg/rpc/base"
@@ -36,6 +37,24 @@ type persistentMetadata struct {
 	DataFilePath  string                  `json:"dataFilePath"`
 	Done          bool                    `json:"done"`
 	Header        *source.Header          `json:"header"`
+	PinnedUntil   time.Time               `json:"pinnedUntil"`
+	// Pinned keeps the task from being reclaimed until it is unpinned
+	Pinned      bool   `json:"pinned"`
+	AccessCount int64  `json:"accessCount"`
+	Digest      string `json:"digest"`
+	Tag         string `json:"tag"`
+	Application string `json:"application"`
+	Callsystem  string `json:"callsystem"`
+	// CreatedAt is the time of creating task, the data shared by deduplicated tasks
+	// is charged to the quota group of the first created task
+	CreatedAt time.Time `json:"createdAt"`
+	// DataDigest is the sha256 digest of data computed when writing pieces, the data is deduplicated by it
+	DataDigest string `json:"dataDigest"`
+	// Compression and Encryption are the algorithms encoding the pieces, the encoded pieces are appended to data file
+	Compression   string                     `json:"compression"`
+	Encryption    string                     `json:"encryption"`
+	EncryptedKey  []byte                     `json:"encryptedKey"`
+	EncodedPieces map[int32]clientutil.Range `json:"encodedPieces"`
 }
 
 type PeerTaskMetadata struct {
@@ -65,6 +84,13 @@ type RegisterTaskRequest struct {
 	ContentLength   int64
 	TotalPieces     int32
 	PieceMd5Sign    string
+	// Digest is the whole-file digest of task, e.g. sha256:xxx
+	Digest string
+	// Tag and Application are from the url meta of task, they match the storage quota of task
+	Tag         string
+	Application string
+	// Callsystem is the caller system of task, it matches the storage quota of task too
+	Callsystem string
 }
 
 type WritePieceRequest struct {
@@ -110,6 +136,13 @@ type UpdateTaskRequest struct {
 	Header        *source.Header
 }
 
+type PinTaskRequest struct {
+	PeerTaskMetadata
+	// TTL is the duration of keeping the task from being reclaimed,
+	// zero TTL keeps the task until it is unpinned
+	TTL time.Duration
+}
+
 type ReusePeerTask struct {
 	PeerTaskMetadata
 	ContentLength int64
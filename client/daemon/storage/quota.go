@@ -0,0 +1,87 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package storage
+
+import (
+	"github.com/docker/go-units"
+
+	"d7y.io/dragonfly/v2/client/config"
+	"d7y.io/dragonfly/v2/client/daemon/metrics"
+	logger "d7y.io/dragonfly/v2/internal/dflog"
+)
+
+// quotaGroup returns the name of the first quota matching the tag, application and callsystem of task,
+// empty means the task is not limited by any quota
+func (s *storageManager) quotaGroup(t *localTaskStore) string {
+	t.RLock()
+	tag, application, callsystem := t.Tag, t.Application, t.Callsystem
+	t.RUnlock()
+	for _, quota := range s.storeOption.Quotas {
+		if matchQuota(quota, tag, application, callsystem) {
+			return quota.Name
+		}
+	}
+	return ""
+}
+
+func matchQuota(quota config.StorageQuotaOption, tag, application, callsystem string) bool {
+	if quota.Tag != "" && quota.Tag != tag {
+		return false
+	}
+	if quota.Application != "" && quota.Application != application {
+		return false
+	}
+	if quota.Callsystem != "" && quota.Callsystem != callsystem {
+		return false
+	}
+	return true
+}
+
+// quotaUsage is the disk usage of task group limited by quota, collected in every gc loop
+type quotaUsage struct {
+	size  int64
+	tasks []*localTaskStore
+}
+
+// evictQuotaGroups evicts the tasks of the groups exceeding its quota, so one group can not evict the tasks of others,
+// returns the marked tasks and the released bytes
+func (s *storageManager) evictQuotaGroups(usages map[string]*quotaUsage) ([]PeerTaskMetadata, int64) {
+	var (
+		markedTasks []PeerTaskMetadata
+		released    int64
+	)
+	for _, quota := range s.storeOption.Quotas {
+		usage, ok := usages[quota.Name]
+		if !ok {
+			usage = &quotaUsage{}
+		}
+
+		bytesExceed := usage.size - int64(quota.Limit)
+		if bytesExceed > 0 {
+			logger.Infof("quota of group %s reached, usage: %s, limit: %s, start gc tasks of group", quota.Name,
+				units.BytesSize(float64(usage.size)), units.BytesSize(float64(quota.Limit)))
+			marked, n := s.evictTasks(usage.tasks, bytesExceed, EvictionReasonGroupQuota)
+			markedTasks = append(markedTasks, marked...)
+			usage.size -= n
+			released += n
+		}
+
+		metrics.StorageQuotaUsageGauge.WithLabelValues(quota.Name).Set(float64(usage.size))
+		metrics.StorageQuotaLimitGauge.WithLabelValues(quota.Name).Set(float64(quota.Limit))
+	}
+	return markedTasks, released
+}
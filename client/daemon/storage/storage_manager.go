@@ -39,7 +39,9 @@ import (
 	"d7y.io/dragonfly/v2/client/clientutil"
 	"d7y.io/dragonfly/v2/client/config"
 	"d7y.io/dragonfly/v2/client/daemon/gc"
+	"d7y.io/dragonfly/v2/client/daemon/metrics"
 	logger "d7y.io/dragonfly/v2/internal/dflog"
+	"d7y.io/dragonfly/v2/pkg/digest"
 	"d7y.io/dragonfly/v2/pkg/rpc/base"
 )
 
@@ -99,6 +101,16 @@ type Manager interface {
 	FindCompletedSubTask(taskID string) *ReusePeerTask
 	// FindPartialCompletedTask try to find a partial completed task for fast path
 	FindPartialCompletedTask(taskID string, rg *clientutil.Range) *ReusePeerTask
+	// FindCompletedTaskByDigest try to find a completed task with the same digest,
+	// and share its data with a new task for fast path
+	FindCompletedTaskByDigest(taskID, digest string) *ReusePeerTask
+	// ReadCompressedPiece reads the compressed data of piece without decompression, returns the compressed length,
+	// ErrPieceNotCompressed is returned when the piece is not stored compressed
+	ReadCompressedPiece(ctx context.Context, req *ReadPieceRequest) (io.Reader, io.Closer, int64, error)
+	// PinTask keeps a task from being reclaimed by gc until ttl expires, or until it is unpinned when ttl is zero
+	PinTask(req *PinTaskRequest) error
+	// UnpinTask allows a pinned task to be reclaimed by gc
+	UnpinTask(req *PeerTaskMetadata) error
 	// CleanUp cleans all storage data
 	CleanUp()
 }
@@ -110,6 +122,9 @@ var (
 	ErrDigestNotSet     = errors.New("digest not set")
 	ErrInvalidDigest    = errors.New("invalid digest")
 	ErrBadRequest       = errors.New("bad request")
+
+	ErrPieceNotCompressed = errors.New("piece not compressed")
+	ErrEncodedSubTask     = errors.New("subtask of compressed or encrypted task is not supported")
 )
 
 const (
@@ -132,6 +147,14 @@ type storageManager struct {
 	dataPathStat       *syscall.Stat_t
 	gcCallback         func(CommonTaskRequest)
 	gcInterval         time.Duration
+	// memoryTier keeps the task data in memory for tiered strategy
+	memoryTier *memoryTier
+	// deduplication shares the data of tasks with same content by hard links
+	deduplication bool
+	// keyProvider provides the master key to encrypt task data, nil when encryption is disabled
+	keyProvider KeyProvider
+	// evictionPolicy decides the order of evicting tasks when disk quota or usage threshold is exceeded
+	evictionPolicy EvictionPolicy
 
 	indexRWMutex       sync.RWMutex
 	indexTask2PeerTask map[string][]*localTaskStore // key: task id, value: slice of localTaskStore
@@ -164,7 +187,7 @@ func NewStorageManager(storeStrategy config.StoreStrategy, opt *config.StorageOp
 		return nil, err
 	}
 	switch storeStrategy {
-	case config.SimpleLocalTaskStoreStrategy, config.AdvanceLocalTaskStoreStrategy:
+	case config.SimpleLocalTaskStoreStrategy, config.AdvanceLocalTaskStoreStrategy, config.TieredLocalTaskStoreStrategy:
 	case config.StoreStrategy(""):
 		storeStrategy = config.SimpleLocalTaskStoreStrategy
 	default:
@@ -188,6 +211,14 @@ func NewStorageManager(storeStrategy config.StoreStrategy, opt *config.StorageOp
 		}
 	}
 
+	if storeStrategy == config.TieredLocalTaskStoreStrategy {
+		s.memoryTier = newMemoryTier(s.storeOption.MemoryTier)
+	}
+
+	if s.evictionPolicy, err = NewEvictionPolicy(s.storeOption.EvictionPolicy); err != nil {
+		return nil, err
+	}
+
 	if err := s.ReloadPersistentTask(gcCallback); err != nil {
 		logger.Warnf("reload tasks error: %s", err)
 	}
@@ -210,6 +241,24 @@ func WithGCInterval(gcInterval time.Duration) func(*storageManager) error {
 	}
 }
 
+// WithEncryption encrypts the task data at rest, every task is encrypted by its own data key
+// which is sealed by the master key of provider
+func WithEncryption(provider KeyProvider) func(*storageManager) error {
+	return func(manager *storageManager) error {
+		manager.keyProvider = provider
+		return nil
+	}
+}
+
+// WithDeduplication stores the same content of different tasks only once,
+// it depends on the digest of pieces, so it works only when digest is calculated
+func WithDeduplication(enable bool) func(*storageManager) error {
+	return func(manager *storageManager) error {
+		manager.deduplication = enable
+		return nil
+	}
+}
+
 func (s *storageManager) RegisterTask(ctx context.Context, req *RegisterTaskRequest) (TaskStorageDriver, error) {
 	ts, ok := s.LoadTask(
 		PeerTaskMetadata{
@@ -243,6 +292,10 @@ func (s *storageManager) RegisterSubTask(ctx context.Context, req *RegisterSubTa
 	if !ok {
 		return nil, fmt.Errorf("task %s not found", req.Parent.TaskID)
 	}
+	// subtask writes the data file of parent directly
+	if t.(*localTaskStore).encoded() {
+		return nil, ErrEncodedSubTask
+	}
 
 	subtask := t.(*localTaskStore).SubTask(req)
 	s.subIndexRWMutex.Lock()
@@ -315,6 +368,22 @@ func (s *storageManager) Store(ctx context.Context, req *StoreRequest) error {
 	return t.Store(ctx, req)
 }
 
+func (s *storageManager) ReadCompressedPiece(ctx context.Context, req *ReadPieceRequest) (io.Reader, io.Closer, int64, error) {
+	t, ok := s.LoadTask(
+		PeerTaskMetadata{
+			PeerID: req.PeerID,
+			TaskID: req.TaskID,
+		})
+	if !ok {
+		return nil, nil, 0, ErrTaskNotFound
+	}
+	lts, ok := t.(*localTaskStore)
+	if !ok {
+		return nil, nil, 0, ErrPieceNotCompressed
+	}
+	return lts.readCompressedRaw(req)
+}
+
 func (s *storageManager) GetPieces(ctx context.Context, req *base.PieceTaskRequest) (*base.PiecePacket, error) {
 	t, ok := s.LoadTask(
 		PeerTaskMetadata{
@@ -381,6 +450,43 @@ func (s *storageManager) UpdateTask(ctx context.Context, req *UpdateTaskRequest)
 	return t.UpdateTask(ctx, req)
 }
 
+func (s *storageManager) PinTask(req *PinTaskRequest) error {
+	t, ok := s.LoadTask(req.PeerTaskMetadata)
+	if !ok {
+		return ErrTaskNotFound
+	}
+
+	// pin the parent task which holds the data of subtask
+	if subtask, ok := t.(*localSubTaskStore); ok {
+		t = subtask.parent
+	}
+	task, ok := t.(*localTaskStore)
+	if !ok {
+		return ErrBadRequest
+	}
+
+	if req.TTL <= 0 {
+		return task.setPinned(true)
+	}
+	return task.pin(time.Now().Add(req.TTL))
+}
+
+func (s *storageManager) UnpinTask(req *PeerTaskMetadata) error {
+	t, ok := s.LoadTask(*req)
+	if !ok {
+		return ErrTaskNotFound
+	}
+
+	if subtask, ok := t.(*localSubTaskStore); ok {
+		t = subtask.parent
+	}
+	task, ok := t.(*localTaskStore)
+	if !ok {
+		return ErrBadRequest
+	}
+	return task.setPinned(false)
+}
+
 func (s *storageManager) CreateTask(req *RegisterTaskRequest) (TaskStorageDriver, error) {
 	s.Keep()
 	logger.Debugf("init local task storage, peer id: %s, task id: %s", req.PeerID, req.TaskID)
@@ -396,6 +502,11 @@ func (s *storageManager) CreateTask(req *RegisterTaskRequest) (TaskStorageDriver
 			PieceMd5Sign:  req.PieceMd5Sign,
 			PeerID:        req.PeerID,
 			Pieces:        map[int32]PieceMetadata{},
+			Digest:        req.Digest,
+			Tag:           req.Tag,
+			Application:   req.Application,
+			Callsystem:    req.Callsystem,
+			CreatedAt:     time.Now(),
 		},
 		gcCallback:       s.gcCallback,
 		dataDir:          dataDir,
@@ -414,13 +525,21 @@ func (s *storageManager) CreateTask(req *RegisterTaskRequest) (TaskStorageDriver
 		return nil, err
 	}
 	t.metadataFile = metadata
+	if s.deduplication {
+		t.deduplicate = s.deduplicateTask
+	}
 
 	// fallback to simple strategy for proxy
-	if req.DesiredLocation == "" {
+	if req.DesiredLocation == "" && t.StoreStrategy == string(config.AdvanceLocalTaskStoreStrategy) {
 		t.StoreStrategy = string(config.SimpleLocalTaskStoreStrategy)
 	}
 	data := path.Join(dataDir, taskData)
 	switch t.StoreStrategy {
+	case string(config.TieredLocalTaskStoreStrategy):
+		// the data spills to the data file of simple strategy
+		t.memory = s.memoryTier
+		t.memoryPieces = map[int32]*memoryPiece{}
+		fallthrough
 	case string(config.SimpleLocalTaskStoreStrategy):
 		t.DataFilePath = data
 		f, err := os.OpenFile(t.DataFilePath, os.O_CREATE|os.O_RDWR, defaultFileMode)
@@ -428,6 +547,23 @@ func (s *storageManager) CreateTask(req *RegisterTaskRequest) (TaskStorageDriver
 			return nil, err
 		}
 		f.Close()
+		// the data file in data directory is encoded, the output file of advance strategy is kept as it is
+		if s.storeOption.Compression != "" {
+			t.Compression = s.storeOption.Compression
+		}
+		if s.keyProvider != nil {
+			master, err := s.keyProvider()
+			if err != nil {
+				return nil, fmt.Errorf("get encryption key error: %w", err)
+			}
+			if t.aead, t.EncryptedKey, err = newTaskCipher(master); err != nil {
+				return nil, err
+			}
+			t.Encryption = EncryptionAESGCM
+		}
+		if t.encoded() {
+			t.EncodedPieces = map[int32]clientutil.Range{}
+		}
 	case string(config.AdvanceLocalTaskStoreStrategy):
 		dir, file := path.Split(req.DesiredLocation)
 		dirStat, err := os.Stat(dir)
@@ -463,6 +599,11 @@ func (s *storageManager) CreateTask(req *RegisterTaskRequest) (TaskStorageDriver
 			}
 		}
 	}
+	// the data is hashed when writing pieces to deduplicate it, the layout of encoded data file
+	// is different from task to task
+	if s.deduplication && t.StoreStrategy == string(config.SimpleLocalTaskStoreStrategy) && !t.encoded() {
+		t.dataHasher = newDataHasher()
+	}
 	s.tasks.Store(
 		PeerTaskMetadata{
 			PeerID: req.PeerID,
@@ -514,6 +655,138 @@ func (s *storageManager) FindCompletedTask(taskID string) *ReusePeerTask {
 	return nil
 }
 
+func (s *storageManager) FindCompletedTaskByDigest(taskID, digest string) *ReusePeerTask {
+	if !s.deduplication || digest == "" {
+		return nil
+	}
+	src := s.findTask(func(t *localTaskStore) bool {
+		return t.TaskID != taskID && t.DataDigest == digest
+	})
+	if src == nil {
+		return nil
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	meta := PeerTaskMetadata{
+		PeerID: src.PeerID,
+		TaskID: taskID,
+	}
+	if _, ok := s.LoadTask(meta); ok {
+		return nil
+	}
+
+	src.RLock()
+	req := &RegisterTaskRequest{
+		PeerTaskMetadata: meta,
+		ContentLength:    src.ContentLength,
+		TotalPieces:      src.TotalPieces,
+		PieceMd5Sign:     src.PieceMd5Sign,
+		Digest:           src.Digest,
+		Tag:              src.Tag,
+		Application:      src.Application,
+		Callsystem:       src.Callsystem,
+	}
+	pieces := make(map[int32]PieceMetadata, len(src.Pieces))
+	for num, piece := range src.Pieces {
+		pieces[num] = piece
+	}
+	header := src.Header
+	dataDigest := src.DataDigest
+	src.RUnlock()
+
+	driver, err := s.CreateTask(req)
+	if err != nil {
+		logger.Errorf("create task %s/%s for digest %s error: %s", taskID, src.PeerID, digest, err)
+		return nil
+	}
+	t := driver.(*localTaskStore)
+	if err := linkData(t.DataFilePath, src.DataFilePath); err != nil {
+		t.Warnf("link data of task %s/%s error: %s", src.TaskID, src.PeerID, err)
+		s.deleteTask(meta)
+		return nil
+	}
+
+	t.Lock()
+	t.Pieces = pieces
+	t.Header = header
+	t.DataDigest = dataDigest
+	t.Done = true
+	t.Unlock()
+	if err := t.saveMetadata(); err != nil {
+		t.Errorf("save metadata error: %s", err)
+		s.deleteTask(meta)
+		return nil
+	}
+
+	t.Infof("reuse data of task %s/%s with the same digest %s", src.TaskID, src.PeerID, digest)
+	return &ReusePeerTask{
+		Storage:          t,
+		PeerTaskMetadata: meta,
+		ContentLength:    t.ContentLength,
+		TotalPieces:      t.TotalPieces,
+		Header:           t.Header,
+	}
+}
+
+// deduplicateTask replaces the data of task with a hard link to the data of another completed task
+// which has the same content, so the same content is stored only once. The content is matched by
+// the sha256 digest of data computed when writing pieces, the digest of piece md5s is not collision resistant.
+func (s *storageManager) deduplicateTask(t *localTaskStore) {
+	if t.StoreStrategy != string(config.SimpleLocalTaskStoreStrategy) || t.encoded() {
+		return
+	}
+
+	t.RLock()
+	dataDigest, contentLength := t.DataDigest, t.ContentLength
+	t.RUnlock()
+	if dataDigest == "" {
+		return
+	}
+
+	// the data not matching the digest of task is not shared
+	if t.Digest != "" && t.Digest != dataDigest {
+		if d, err := digest.Parse(t.Digest); err == nil && d.Algorithm == digest.AlgorithmSHA256 {
+			t.Warnf("data digest %s does not match the digest of task %s", dataDigest, t.Digest)
+			return
+		}
+	}
+
+	src := s.findTask(func(c *localTaskStore) bool {
+		return c != t && c.DataDigest == dataDigest && c.ContentLength == contentLength
+	})
+	if src == nil {
+		return
+	}
+
+	if err := linkData(t.DataFilePath, src.DataFilePath); err != nil {
+		t.Warnf("deduplicate data with task %s/%s error: %s", src.TaskID, src.PeerID, err)
+		return
+	}
+	t.Infof("deduplicate data with task %s/%s", src.TaskID, src.PeerID)
+}
+
+// findTask returns a completed simple strategy task which matches the given condition
+func (s *storageManager) findTask(match func(t *localTaskStore) bool) *localTaskStore {
+	s.indexRWMutex.RLock()
+	defer s.indexRWMutex.RUnlock()
+	for _, ts := range s.indexTask2PeerTask {
+		for _, t := range ts {
+			if !t.Done || t.invalid.Load() || t.reclaimMarked.Load() {
+				continue
+			}
+			// the layout of encoded data file is different from task to task
+			if t.StoreStrategy != string(config.SimpleLocalTaskStoreStrategy) || t.encoded() {
+				continue
+			}
+			if match(t) {
+				return t
+			}
+		}
+	}
+	return nil
+}
+
 func (s *storageManager) FindPartialCompletedTask(taskID string, rg *clientutil.Range) *ReusePeerTask {
 	s.indexRWMutex.RLock()
 	defer s.indexRWMutex.RUnlock()
@@ -643,6 +916,29 @@ func (s *storageManager) IsInvalid(req *PeerTaskMetadata) (bool, error) {
 	return t.IsInvalid(req)
 }
 
+// reloadEncryption opens the data key of encrypted task, the plaintext task is refused when encryption is enabled
+func (s *storageManager) reloadEncryption(t *localTaskStore) error {
+	if t.Encryption == "" {
+		if s.keyProvider != nil {
+			return fmt.Errorf("task %s/%s is not encrypted", t.TaskID, t.PeerID)
+		}
+		return nil
+	}
+	if t.Encryption != EncryptionAESGCM {
+		return fmt.Errorf("encryption %s is not supported", t.Encryption)
+	}
+	if s.keyProvider == nil {
+		return fmt.Errorf("encryption key of task %s/%s is not provided", t.TaskID, t.PeerID)
+	}
+
+	master, err := s.keyProvider()
+	if err != nil {
+		return fmt.Errorf("get encryption key error: %w", err)
+	}
+	t.aead, err = openTaskCipher(master, t.EncryptedKey)
+	return err
+}
+
 func (s *storageManager) ReloadPersistentTask(gcCallback GCCallback) error {
 	dirs, err := os.ReadDir(s.storeOption.DataPath)
 	if os.IsNotExist(err) {
@@ -710,6 +1006,19 @@ func (s *storageManager) ReloadPersistentTask(gcCallback GCCallback) error {
 					Warnf("load task from disk error: %s", err0)
 				continue
 			}
+			t.accessCount.Store(t.AccessCount)
+			if err0 = s.reloadEncryption(t); err0 != nil {
+				loadErrs = append(loadErrs, err0)
+				loadErrDirs = append(loadErrDirs, dataDir)
+				logger.With("action", "reload", "stage", "open encryption key", "taskID", taskID, "peerID", peerID).
+					Warnf("load task from disk error: %s", err0)
+				continue
+			}
+			if t.encoded() {
+				if stat, err := os.Stat(t.DataFilePath); err == nil {
+					t.encodedSize = stat.Size()
+				}
+			}
 			logger.Debugf("load task %s/%s from disk, metadata %s, last access: %v, expire time: %s",
 				t.persistentMetadata.TaskID, t.persistentMetadata.PeerID, t.metadataFilePath, time.Unix(0, t.lastAccess.Load()), t.expireTime)
 			s.tasks.Store(PeerTaskMetadata{
@@ -774,15 +1083,23 @@ func (s *storageManager) TryGC() (bool, error) {
 	// FIXME gc subtask
 	var markedTasks []PeerTaskMetadata
 	var totalNotMarkedSize int64
+	var notMarkedTasks []*localTaskStore
 	s.tasks.Range(func(key, task interface{}) bool {
 		if task.(Reclaimer).CanReclaim() {
+			reason := EvictionReasonExpired
+			if lts, ok := task.(*localTaskStore); ok {
+				if lts.invalid.Load() {
+					reason = EvictionReasonInvalid
+				}
+				s.recordEviction(lts, reason)
+			}
 			task.(Reclaimer).MarkReclaim()
 			markedTasks = append(markedTasks, key.(PeerTaskMetadata))
 		} else {
 			lts, ok := task.(*localTaskStore)
 			if ok {
 				// just calculate not reclaimed task
-				totalNotMarkedSize += lts.ContentLength
+				notMarkedTasks = append(notMarkedTasks, lts)
 				logger.Debugf("task %s/%s not reach gc time",
 					key.(PeerTaskMetadata).TaskID, key.(PeerTaskMetadata).PeerID)
 			}
@@ -790,54 +1107,66 @@ func (s *storageManager) TryGC() (bool, error) {
 		return true
 	})
 
+	// the data shared by deduplicated tasks is calculated only once,
+	// and charged to the quota group of the first created task holding it
+	sort.SliceStable(notMarkedTasks, func(i, j int) bool {
+		return notMarkedTasks[i].CreatedAt.Before(notMarkedTasks[j].CreatedAt)
+	})
+	sharedData := map[[2]uint64]bool{}
+	quotaUsages := map[string]*quotaUsage{}
+	for _, lts := range notMarkedTasks {
+		if s.deduplication {
+			if id, ok := dataFileID(lts.DataFilePath); ok {
+				if sharedData[id] {
+					continue
+				}
+				sharedData[id] = true
+			}
+		}
+		totalNotMarkedSize += lts.storedSize()
+		if group := s.quotaGroup(lts); group != "" {
+			usage, ok := quotaUsages[group]
+			if !ok {
+				usage = &quotaUsage{}
+				quotaUsages[group] = usage
+			}
+			usage.size += lts.storedSize()
+			usage.tasks = append(usage.tasks, lts)
+		}
+	}
+
+	// the groups exceeding quotas are evicted before the quota of all tasks is checked
+	groupMarkedTasks, released := s.evictQuotaGroups(quotaUsages)
+	markedTasks = append(markedTasks, groupMarkedTasks...)
+	totalNotMarkedSize -= released
+
 	quotaBytesExceed := totalNotMarkedSize - int64(s.storeOption.DiskGCThreshold)
 	quotaExceed := s.storeOption.DiskGCThreshold > 0 && quotaBytesExceed > 0
 	usageExceed, usageBytesExceed := s.diskUsageExceed()
 
 	if quotaExceed || usageExceed {
-		var bytesExceed int64
+		var (
+			bytesExceed int64
+			reason      string
+		)
 		if quotaBytesExceed > usageBytesExceed {
 			bytesExceed = quotaBytesExceed
+			reason = EvictionReasonQuota
 		} else {
 			bytesExceed = usageBytesExceed
+			reason = EvictionReasonDiskUsage
 		}
-		logger.Infof("quota threshold reached, start gc oldest task, size: %d bytes", bytesExceed)
+		logger.Infof("quota threshold reached, start gc tasks by %s policy, size: %d bytes", s.evictionPolicy.Name(), bytesExceed)
 		var tasks []*localTaskStore
 		s.tasks.Range(func(key, val interface{}) bool {
-			// skip reclaimed task
-			task, ok := val.(*localTaskStore)
-			if !ok { // skip subtask
-				return true
-			}
-			if task.reclaimMarked.Load() {
-				return true
-			}
-			// task is not done, and is active in s.gcInterval
-			// next gc loop will check it again
-			if !task.Done && time.Since(time.Unix(0, task.lastAccess.Load())) < s.gcInterval {
-				return true
+			// skip subtask
+			if task, ok := val.(*localTaskStore); ok {
+				tasks = append(tasks, task)
 			}
-			tasks = append(tasks, task)
 			return true
 		})
-		// sort by access time
-		sort.SliceStable(tasks, func(i, j int) bool {
-			return tasks[i].lastAccess.Load() < tasks[j].lastAccess.Load()
-		})
-		for _, task := range tasks {
-			task.MarkReclaim()
-			markedTasks = append(markedTasks, PeerTaskMetadata{task.PeerID, task.TaskID})
-			logger.Infof("quota threshold reached, mark task %s/%s reclaimed, last access: %s, size: %s",
-				task.TaskID, task.PeerID, time.Unix(0, task.lastAccess.Load()).Format(time.RFC3339Nano),
-				units.BytesSize(float64(task.ContentLength)))
-			bytesExceed -= task.ContentLength
-			if bytesExceed <= 0 {
-				break
-			}
-		}
-		if bytesExceed > 0 {
-			logger.Warnf("no enough tasks to gc, remind %d bytes", bytesExceed)
-		}
+		marked, _ := s.evictTasks(tasks, bytesExceed, reason)
+		markedTasks = append(markedTasks, marked...)
 	}
 
 	for _, key := range s.markedReclaimTasks {
@@ -881,6 +1210,65 @@ func (s *storageManager) TryGC() (bool, error) {
 	return true, nil
 }
 
+// evictTasks marks the tasks reclaimed in the order of eviction policy until bytesExceed bytes are released,
+// returns the marked tasks and the released bytes
+func (s *storageManager) evictTasks(tasks []*localTaskStore, bytesExceed int64, reason string) ([]PeerTaskMetadata, int64) {
+	var candidates []*localTaskStore
+	for _, task := range tasks {
+		// skip reclaimed task
+		if task.reclaimMarked.Load() {
+			continue
+		}
+		// skip pinned task until it expires
+		if task.isPinned() {
+			continue
+		}
+		// task is not done, and is active in s.gcInterval
+		// next gc loop will check it again
+		if !task.Done && time.Since(time.Unix(0, task.lastAccess.Load())) < s.gcInterval {
+			continue
+		}
+		candidates = append(candidates, task)
+	}
+
+	// sort by eviction policy, the access stats are taken once for a stable order
+	now := time.Now()
+	stats := make(map[*localTaskStore]AccessStats, len(candidates))
+	for _, task := range candidates {
+		stats[task] = task.accessStats()
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return s.evictionPolicy.Less(stats[candidates[i]], stats[candidates[j]], now)
+	})
+
+	var (
+		markedTasks []PeerTaskMetadata
+		released    int64
+	)
+	for _, task := range candidates {
+		if released >= bytesExceed {
+			break
+		}
+		s.recordEviction(task, reason)
+		task.MarkReclaim()
+		markedTasks = append(markedTasks, PeerTaskMetadata{task.PeerID, task.TaskID})
+		logger.Infof("%s threshold reached, mark task %s/%s reclaimed, last access: %s, size: %s", reason,
+			task.TaskID, task.PeerID, time.Unix(0, task.lastAccess.Load()).Format(time.RFC3339Nano),
+			units.BytesSize(float64(task.storedSize())))
+		released += task.storedSize()
+	}
+	if released < bytesExceed {
+		logger.Warnf("no enough tasks to gc, remind %d bytes", bytesExceed-released)
+	}
+	return markedTasks, released
+}
+
+// recordEviction records the eviction of task by reason in metrics
+func (s *storageManager) recordEviction(t *localTaskStore, reason string) {
+	metrics.StorageEvictionCount.WithLabelValues(reason).Add(1)
+	metrics.StorageEvictionBytesCount.WithLabelValues(reason).Add(float64(t.storedSize()))
+}
+
 func (s *storageManager) deleteTask(meta PeerTaskMetadata) error {
 	task, ok := s.LoadAndDeleteTask(meta)
 	if !ok {
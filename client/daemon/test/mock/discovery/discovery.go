@@ -0,0 +1,76 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: discovery.go
+
+// Package mock_discovery is a generated GoMock package.
+package mock_discovery
+
+import (
+	context "context"
+	reflect "reflect"
+
+	scheduler "d7y.io/dragonfly/v2/pkg/rpc/scheduler"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDiscovery is a mock of Discovery interface.
+type MockDiscovery struct {
+	ctrl     *gomock.Controller
+	recorder *MockDiscoveryMockRecorder
+}
+
+// MockDiscoveryMockRecorder is the mock recorder for MockDiscovery.
+type MockDiscoveryMockRecorder struct {
+	mock *MockDiscovery
+}
+
+// NewMockDiscovery creates a new mock instance.
+func NewMockDiscovery(ctrl *gomock.Controller) *MockDiscovery {
+	mock := &MockDiscovery{ctrl: ctrl}
+	mock.recorder = &MockDiscoveryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDiscovery) EXPECT() *MockDiscoveryMockRecorder {
+	return m.recorder
+}
+
+// FindPeers mocks base method.
+func (m *MockDiscovery) FindPeers(ctx context.Context, taskID string) []*scheduler.PeerPacket_DestPeer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPeers", ctx, taskID)
+	ret0, _ := ret[0].([]*scheduler.PeerPacket_DestPeer)
+	return ret0
+}
+
+// FindPeers indicates an expected call of FindPeers.
+func (mr *MockDiscoveryMockRecorder) FindPeers(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPeers", reflect.TypeOf((*MockDiscovery)(nil).FindPeers), ctx, taskID)
+}
+
+// Serve mocks base method.
+func (m *MockDiscovery) Serve() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Serve")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Serve indicates an expected call of Serve.
+func (mr *MockDiscoveryMockRecorder) Serve() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Serve", reflect.TypeOf((*MockDiscovery)(nil).Serve))
+}
+
+// Stop mocks base method.
+func (m *MockDiscovery) Stop() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Stop")
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockDiscoveryMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockDiscovery)(nil).Stop))
+}
@@ -327,6 +327,20 @@ func (mr *MockManagerMockRecorder) FindCompletedTask(taskID interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCompletedTask", reflect.TypeOf((*MockManager)(nil).FindCompletedTask), taskID)
 }
 
+// FindCompletedTaskByDigest mocks base method.
+func (m *MockManager) FindCompletedTaskByDigest(taskID, digest string) *storage.ReusePeerTask {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCompletedTaskByDigest", taskID, digest)
+	ret0, _ := ret[0].(*storage.ReusePeerTask)
+	return ret0
+}
+
+// FindCompletedTaskByDigest indicates an expected call of FindCompletedTaskByDigest.
+func (mr *MockManagerMockRecorder) FindCompletedTaskByDigest(taskID, digest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCompletedTaskByDigest", reflect.TypeOf((*MockManager)(nil).FindCompletedTaskByDigest), taskID, digest)
+}
+
 // FindPartialCompletedTask mocks base method.
 func (m *MockManager) FindPartialCompletedTask(taskID string, rg *clientutil.Range) *storage.ReusePeerTask {
 	m.ctrl.T.Helper()
@@ -413,6 +427,20 @@ func (mr *MockManagerMockRecorder) Keep() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Keep", reflect.TypeOf((*MockManager)(nil).Keep))
 }
 
+// PinTask mocks base method.
+func (m *MockManager) PinTask(req *storage.PinTaskRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinTask", req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PinTask indicates an expected call of PinTask.
+func (mr *MockManagerMockRecorder) PinTask(req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinTask", reflect.TypeOf((*MockManager)(nil).PinTask), req)
+}
+
 // ReadAllPieces mocks base method.
 func (m *MockManager) ReadAllPieces(ctx context.Context, req *storage.ReadAllPiecesRequest) (io.ReadCloser, error) {
 	m.ctrl.T.Helper()
@@ -428,6 +456,23 @@ func (mr *MockManagerMockRecorder) ReadAllPieces(ctx, req interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAllPieces", reflect.TypeOf((*MockManager)(nil).ReadAllPieces), ctx, req)
 }
 
+// ReadCompressedPiece mocks base method.
+func (m *MockManager) ReadCompressedPiece(ctx context.Context, req *storage.ReadPieceRequest) (io.Reader, io.Closer, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadCompressedPiece", ctx, req)
+	ret0, _ := ret[0].(io.Reader)
+	ret1, _ := ret[1].(io.Closer)
+	ret2, _ := ret[2].(int64)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// ReadCompressedPiece indicates an expected call of ReadCompressedPiece.
+func (mr *MockManagerMockRecorder) ReadCompressedPiece(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadCompressedPiece", reflect.TypeOf((*MockManager)(nil).ReadCompressedPiece), ctx, req)
+}
+
 // ReadPiece mocks base method.
 func (m *MockManager) ReadPiece(ctx context.Context, req *storage.ReadPieceRequest) (io.Reader, io.Closer, error) {
 	m.ctrl.T.Helper()
@@ -488,6 +533,20 @@ func (mr *MockManagerMockRecorder) Store(ctx, req interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockManager)(nil).Store), ctx, req)
 }
 
+// UnpinTask mocks base method.
+func (m *MockManager) UnpinTask(req *storage.PeerTaskMetadata) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpinTask", req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnpinTask indicates an expected call of UnpinTask.
+func (mr *MockManagerMockRecorder) UnpinTask(req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinTask", reflect.TypeOf((*MockManager)(nil).UnpinTask), req)
+}
+
 // UnregisterTask mocks base method.
 func (m *MockManager) UnregisterTask(ctx context.Context, req storage.CommonTaskRequest) error {
 	m.ctrl.T.Helper()
@@ -237,6 +237,8 @@ func (rt *transport) download(ctx context.Context, req *http.Request) (*http.Res
 	// Pick header's parameters
 	filter := nethttp.PickHeader(req.Header, config.HeaderDragonflyFilter, rt.defaultFilter)
 	tag := nethttp.PickHeader(req.Header, config.HeaderDragonflyBiz, rt.defaultBiz)
+	priority := nethttp.PickHeader(req.Header, config.HeaderDragonflyPriority, "")
+	application := nethttp.PickHeader(req.Header, config.HeaderDragonflyApplication, "")
 
 	// Delete hop-by-hop headers
 	delHopHeaders(req.Header)
@@ -244,14 +246,16 @@ func (rt *transport) download(ctx context.Context, req *http.Request) (*http.Res
 	meta.Header = nethttp.HeaderToMap(req.Header)
 	meta.Tag = tag
 	meta.Filter = filter
+	meta.Application = application
 
 	body, attr, err := rt.peerTaskManager.StartStreamTask(
 		ctx,
 		&peer.StreamTaskRequest{
-			URL:     url,
-			URLMeta: meta,
-			Range:   rg,
-			PeerID:  peerID,
+			URL:      url,
+			URLMeta:  meta,
+			Range:    rg,
+			PeerID:   peerID,
+			Priority: config.ConvertPriority(priority),
 		},
 	)
 	if err != nil {
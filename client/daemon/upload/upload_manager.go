@@ -25,6 +25,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-http-utils/headers"
@@ -175,28 +176,50 @@ func (um *uploadManager) getDownload(ctx *gin.Context) {
 		return
 	}
 
-	// Add header "Content-Length" to avoid chunked body in http client.
-	ctx.Header(headers.ContentLength, fmt.Sprintf("%d", rg[0].Length))
-	reader, closer, err := um.storageManager.ReadPiece(ctx,
-		&storage.ReadPieceRequest{
-			PeerTaskMetadata: storage.PeerTaskMetadata{
-				TaskID: taskID,
-				PeerID: peerID,
-			},
-			PieceMetadata: storage.PieceMetadata{
-				Num:   -1,
-				Range: rg[0],
-			},
-		})
-	if err != nil {
-		log.Errorf("get task data failed: %s", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"errors": err.Error()})
-		return
+	req := &storage.ReadPieceRequest{
+		PeerTaskMetadata: storage.PeerTaskMetadata{
+			TaskID: taskID,
+			PeerID: peerID,
+		},
+		PieceMetadata: storage.PieceMetadata{
+			Num:   -1,
+			Range: rg[0],
+		},
+	}
+
+	var (
+		reader io.Reader
+		closer io.Closer
+		length = rg[0].Length
+	)
+	// send the compressed piece directly when the peer accepts it
+	if strings.Contains(ctx.GetHeader(headers.AcceptEncoding), config.CompressionZstd) {
+		reader, closer, length, err = um.storageManager.ReadCompressedPiece(ctx, req)
+		if err == nil {
+			ctx.Header(headers.ContentEncoding, config.CompressionZstd)
+		} else if err != storage.ErrPieceNotCompressed {
+			log.Errorf("get compressed task data failed: %s", err)
+			ctx.JSON(http.StatusInternalServerError, gin.H{"errors": err.Error()})
+			return
+		}
+	}
+
+	if reader == nil {
+		length = rg[0].Length
+		reader, closer, err = um.storageManager.ReadPiece(ctx, req)
+		if err != nil {
+			log.Errorf("get task data failed: %s", err)
+			ctx.JSON(http.StatusInternalServerError, gin.H{"errors": err.Error()})
+			return
+		}
 	}
 	defer closer.Close()
 
+	// Add header "Content-Length" to avoid chunked body in http client.
+	ctx.Header(headers.ContentLength, fmt.Sprintf("%d", length))
+
 	if um.Limiter != nil {
-		if err = um.Limiter.WaitN(ctx, int(rg[0].Length)); err != nil {
+		if err = um.Limiter.WaitN(ctx, int(length)); err != nil {
 			log.Errorf("get limit failed: %s", err)
 			ctx.JSON(http.StatusInternalServerError, gin.H{"errors": err.Error()})
 			return
@@ -208,9 +231,9 @@ func (um *uploadManager) getDownload(ctx *gin.Context) {
 	if n, err := io.Copy(ctx.Writer, reader); err != nil {
 		log.Errorf("transfer data failed: %s", err)
 		return
-	} else if n != rg[0].Length {
+	} else if n != length {
 		log.Errorf("transferred data length not match request, request: %d, transferred: %d",
-			rg[0].Length, n)
+			length, n)
 		return
 	}
 }
@@ -27,6 +27,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/klauspost/compress/zstd"
 	testifyassert "github.com/stretchr/testify/assert"
 	"golang.org/x/time/rate"
 
@@ -55,6 +56,15 @@ func TestUploadManager_Serve(t *testing.T) {
 			return bytes.NewBuffer(testData[req.Range.Start : req.Range.Start+req.Range.Length]),
 				io.NopCloser(nil), nil
 		})
+	mockStorageManager.EXPECT().ReadCompressedPiece(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(ctx context.Context, req *storage.ReadPieceRequest) (io.Reader, io.Closer, int64, error) {
+			if req.TaskID != "task-4" {
+				return nil, nil, 0, storage.ErrPieceNotCompressed
+			}
+			encoder, _ := zstd.NewWriter(nil)
+			data := encoder.EncodeAll(testData[req.Range.Start:req.Range.Start+req.Range.Length], nil)
+			return bytes.NewBuffer(data), io.NopCloser(nil), int64(len(data)), nil
+		})
 
 	um, err := NewUploadManager(config.NewDaemonConfig(), mockStorageManager, os.TempDir(), WithLimiter(rate.NewLimiter(16*1024, 16*1024)))
 	assert.Nil(err, "NewUploadManager")
@@ -73,6 +83,8 @@ func TestUploadManager_Serve(t *testing.T) {
 		taskID          string
 		peerID          string
 		pieceRange      string
+		acceptEncoding  string
+		compressed      bool
 		targetPieceData []byte
 	}{
 		{
@@ -93,18 +105,42 @@ func TestUploadManager_Serve(t *testing.T) {
 			pieceRange:      "bytes=512-1023",
 			targetPieceData: testData[512:1024],
 		},
+		{
+			taskID:          "task-3",
+			peerID:          "peer-3",
+			pieceRange:      "bytes=0-1023",
+			acceptEncoding:  "zstd",
+			targetPieceData: testData[0:1024],
+		},
+		{
+			taskID:          "task-4",
+			peerID:          "peer-4",
+			pieceRange:      "bytes=0-1023",
+			acceptEncoding:  "zstd",
+			compressed:      true,
+			targetPieceData: testData[0:1024],
+		},
 	}
 
 	for _, tt := range tests {
 		req, _ := http.NewRequest(http.MethodGet,
 			fmt.Sprintf("http://%s/%s/%s/%s?peerId=%s", addr, "download", "666", tt.taskID, tt.peerID), nil)
 		req.Header.Add("Range", tt.pieceRange)
+		if tt.acceptEncoding != "" {
+			req.Header.Add("Accept-Encoding", tt.acceptEncoding)
+		}
 
 		resp, err := http.DefaultClient.Do(req)
 		assert.Nil(err, "get piece data")
 
 		data, _ := io.ReadAll(resp.Body)
 		resp.Body.Close()
+		assert.Equal(tt.compressed, resp.Header.Get("Content-Encoding") == "zstd")
+		if tt.compressed {
+			decoder, _ := zstd.NewReader(nil)
+			data, err = decoder.DecodeAll(data, nil)
+			assert.Nil(err, "decompress piece data")
+		}
 		assert.Equal(tt.targetPieceData, data)
 	}
 }
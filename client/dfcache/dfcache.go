@@ -166,6 +166,7 @@ func newImportRequest(cfg *config.DfcacheConfig) *dfdaemon.ImportTaskRequest {
 		UrlMeta: &base.UrlMeta{
 			Tag: cfg.Tag,
 		},
+		Pin: cfg.Pin,
 	}
 }
 
@@ -296,5 +297,6 @@ func newDeleteRequest(cfg *config.DfcacheConfig) *dfdaemon.DeleteTaskRequest {
 		UrlMeta: &base.UrlMeta{
 			Tag: cfg.Tag,
 		},
+		Unpin: cfg.Unpin,
 	}
 }
@@ -233,13 +233,15 @@ func newDownRequest(cfg *config.DfgetConfig, hdr map[string]string) *dfdaemon.Do
 		Limit:             float64(cfg.RateLimit.Limit),
 		DisableBackSource: cfg.DisableBackSource,
 		UrlMeta: &base.UrlMeta{
-			Digest: cfg.Digest,
-			Tag:    cfg.Tag,
-			Range:  rg,
-			Filter: cfg.Filter,
-			Header: hdr,
+			Digest:      cfg.Digest,
+			Tag:         cfg.Tag,
+			Range:       rg,
+			Filter:      cfg.Filter,
+			Header:      hdr,
+			Application: cfg.Application,
 		},
 		Pattern:            cfg.Pattern,
+		Priority:           config.ConvertPriority(cfg.Priority),
 		Callsystem:         cfg.CallSystem,
 		Uid:                int64(basic.UserID),
 		Gid:                int64(basic.UserGroup),
@@ -17,7 +17,9 @@
 package cmd
 
 import (
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"d7y.io/dragonfly/v2/client/config"
 	"d7y.io/dragonfly/v2/client/dfcache"
@@ -43,6 +45,12 @@ var deleteCmd = &cobra.Command{
 func initDelete() {
 	// Add the command to parent
 	rootCmd.AddCommand(deleteCmd)
+
+	flags := deleteCmd.Flags()
+	flags.BoolVar(&dfcacheConfig.Unpin, "unpin", false, "only unpin the file imported with --pin and leave it to gc instead of deleting it")
+	if err := viper.BindPFlags(flags); err != nil {
+		panic(errors.Wrap(err, "bind cache delete flags to viper"))
+	}
 }
 
 func runDelete(cfg *config.DfcacheConfig, client client.DaemonClient) error {
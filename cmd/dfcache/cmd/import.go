@@ -48,6 +48,7 @@ func initImport() {
 
 	flags := importCmd.Flags()
 	flags.StringVarP(&dfcacheConfig.Path, "input", "I", "", "import the given file into P2P network")
+	flags.BoolVar(&dfcacheConfig.Pin, "pin", false, "keep the imported file from being reclaimed by gc until it is unpinned by delete --unpin")
 	if err := viper.BindPFlags(flags); err != nil {
 		panic(errors.Wrap(err, "bind cache import flags to viper"))
 	}
@@ -148,6 +148,9 @@ func init() {
 	flagSet.String("tag", dfgetConfig.Tag,
 		"Different tags for the same url will be divided into different P2P overlay, it conflicts with --digest")
 
+	flagSet.String("application", dfgetConfig.Application,
+		"The application to which the download task belongs, it is used by quota of scheduler")
+
 	flagSet.String("filter", dfgetConfig.Filter,
 		"Filter the query parameters of the url, P2P overlay is the same one if the filtered url is same, "+
 			"in format of key&sign, which will filter 'key' and 'sign' query parameters")
@@ -159,6 +162,8 @@ func init() {
 
 	flagSet.StringP("pattern", "p", dfgetConfig.Pattern, "The downloading pattern: p2p/seed-peer/source")
 
+	flagSet.String("priority", dfgetConfig.Priority, "The downloading priority: urgent/normal/background")
+
 	flagSet.BoolP("show-progress", "b", dfgetConfig.ShowProgress, "Show progress bar, it conflicts with --console")
 
 	flagSet.String("callsystem", dfgetConfig.CallSystem, "The caller name which is mainly used for statistics and access control")
@@ -24,5 +24,13 @@ const (
 
 // Job Name
 const (
-	PreheatJob = "preheat"
+	PreheatJob         = "preheat"
+	PersistentCacheJob = "persistent_cache"
+)
+
+// Job State
+const (
+	// StateCanceled is the state of the canceled job,
+	// other states are the same as the task states of machinery.
+	StateCanceled = "CANCELED"
 )
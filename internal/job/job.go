@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"time"
 
 	"github.com/RichardKnop/machinery/v1"
@@ -35,6 +36,14 @@ const (
 	DefaultResultsExpireIn = 86400
 )
 
+const (
+	// Key prefix of the progresses of group job in result backend.
+	groupJobProgressKeyPrefix = "dragonfly:job:progress:"
+
+	// Key prefix of the canceled group job in result backend.
+	groupJobCanceledKeyPrefix = "dragonfly:job:canceled:"
+)
+
 type Config struct {
 	Host      string
 	Port      int
@@ -47,6 +56,10 @@ type Job struct {
 	Server *machinery.Server
 	Worker *machinery.Worker
 	Queue  Queue
+
+	// Redis client of result backend, it stores the progresses
+	// and the cancellations of group jobs.
+	backend *redis.Client
 }
 
 func New(cfg *Config, queue Queue) (*Job, error) {
@@ -63,11 +76,12 @@ func New(cfg *Config, queue Queue) (*Job, error) {
 	}
 
 	backend := fmt.Sprintf("redis://%s@%s:%d/%d", cfg.Password, cfg.Host, cfg.Port, cfg.BackendDB)
-	if err := ping(&redis.Options{
+	backendOptions := &redis.Options{
 		Addr:     fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
 		Password: cfg.Password,
 		DB:       cfg.BackendDB,
-	}); err != nil {
+	}
+	if err := ping(backendOptions); err != nil {
 		return nil, err
 	}
 
@@ -84,8 +98,9 @@ func New(cfg *Config, queue Queue) (*Job, error) {
 	}
 
 	return &Job{
-		Server: server,
-		Queue:  queue,
+		Server:  server,
+		Queue:   queue,
+		backend: redis.NewClient(backendOptions),
 	}, nil
 }
 
@@ -146,6 +161,69 @@ func (t *Job) GetGroupJobState(groupUUID string) (*GroupJobState, error) {
 	}, nil
 }
 
+// CancelGroupJob marks the group job as canceled, the jobs of group stop when they find the mark.
+func (t *Job) CancelGroupJob(ctx context.Context, groupUUID string) error {
+	return t.backend.Set(ctx, groupJobCanceledKeyPrefix+groupUUID, 1, DefaultResultsExpireIn*time.Second).Err()
+}
+
+// IsGroupJobCanceled returns whether the group job is canceled.
+func (t *Job) IsGroupJobCanceled(ctx context.Context, groupUUID string) (bool, error) {
+	n, err := t.backend.Exists(ctx, groupJobCanceledKeyPrefix+groupUUID).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+// SetPreheatProgress stores the progress of the preheat job in group.
+func (t *Job) SetPreheatProgress(ctx context.Context, groupUUID, jobUUID string, progress *PreheatProgress) error {
+	b, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	key := groupJobProgressKeyPrefix + groupUUID
+	pipe := t.backend.TxPipeline()
+	pipe.HSet(ctx, key, jobUUID, b)
+	pipe.Expire(ctx, key, DefaultResultsExpireIn*time.Second)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// GetPreheatProgresses returns the progresses of the preheat jobs in group.
+func (t *Job) GetPreheatProgresses(ctx context.Context, groupUUID string) ([]*PreheatProgress, error) {
+	values, err := t.backend.HGetAll(ctx, groupJobProgressKeyPrefix+groupUUID).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalPreheatProgresses(values)
+}
+
+// unmarshalPreheatProgresses unmarshals the progresses, which are sorted by url and queue.
+func unmarshalPreheatProgresses(values map[string]string) ([]*PreheatProgress, error) {
+	progresses := make([]*PreheatProgress, 0, len(values))
+	for _, value := range values {
+		progress := &PreheatProgress{}
+		if err := json.Unmarshal([]byte(value), progress); err != nil {
+			return nil, err
+		}
+
+		progresses = append(progresses, progress)
+	}
+
+	sort.Slice(progresses, func(i, j int) bool {
+		if progresses[i].URL != progresses[j].URL {
+			return progresses[i].URL < progresses[j].URL
+		}
+
+		return progresses[i].Queue < progresses[j].Queue
+	})
+
+	return progresses, nil
+}
+
 func MarshalRequest(v interface{}) ([]machineryv1tasks.Arg, error) {
 	b, err := json.Marshal(v)
 	if err != nil {
@@ -293,3 +293,55 @@ func TestUnmarshalRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalPreheatProgresses(t *testing.T) {
+	tests := []struct {
+		name   string
+		values map[string]string
+		expect func(t *testing.T, progresses []*PreheatProgress, err error)
+	}{
+		{
+			name: "unmarshal progresses sorted by url and queue",
+			values: map[string]string{
+				"bar": "{\"url\":\"http://example.com/b\",\"queue\":\"scheduler_1\",\"state\":\"SUCCESS\",\"completed_length\":10,\"content_length\":10}",
+				"baz": "{\"url\":\"http://example.com/a\",\"queue\":\"scheduler_2\",\"state\":\"STARTED\",\"completed_length\":1,\"content_length\":10}",
+				"foo": "{\"url\":\"http://example.com/a\",\"queue\":\"scheduler_1\",\"state\":\"FAILURE\",\"error\":\"foo\"}",
+			},
+			expect: func(t *testing.T, progresses []*PreheatProgress, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				assert.Equal(3, len(progresses))
+				assert.Equal(&PreheatProgress{URL: "http://example.com/a", Queue: "scheduler_1", State: "FAILURE", Error: "foo"}, progresses[0])
+				assert.Equal(&PreheatProgress{URL: "http://example.com/a", Queue: "scheduler_2", State: "STARTED", CompletedLength: 1, ContentLength: 10}, progresses[1])
+				assert.Equal(&PreheatProgress{URL: "http://example.com/b", Queue: "scheduler_1", State: "SUCCESS", CompletedLength: 10, ContentLength: 10}, progresses[2])
+			},
+		},
+		{
+			name:   "unmarshal empty values",
+			values: map[string]string{},
+			expect: func(t *testing.T, progresses []*PreheatProgress, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				assert.Empty(progresses)
+			},
+		},
+		{
+			name: "unmarshal invalid value",
+			values: map[string]string{
+				"foo": "{",
+			},
+			expect: func(t *testing.T, progresses []*PreheatProgress, err error) {
+				assert := assert.New(t)
+				assert.Error(err)
+				assert.Nil(progresses)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			progresses, err := unmarshalPreheatProgresses(tc.values)
+			tc.expect(t, progresses, err)
+		})
+	}
+}
@@ -16,6 +16,8 @@
 
 package job
 
+import "time"
+
 type PreheatRequest struct {
 	URL     string            `json:"url" validate:"required,url"`
 	Tag     string            `json:"tag" validate:"omitempty"`
@@ -26,3 +28,27 @@ type PreheatRequest struct {
 
 type PreheatResponse struct {
 }
+
+// PersistentCacheRequest pins the task of url, the task is replicated to
+// seed peers of different idcs and is not reclaimed before ttl.
+type PersistentCacheRequest struct {
+	URL          string            `json:"url" validate:"required,url"`
+	Tag          string            `json:"tag" validate:"omitempty"`
+	Digest       string            `json:"digest" validate:"omitempty"`
+	Filter       string            `json:"filter" validate:"omitempty"`
+	Headers      map[string]string `json:"headers" validate:"omitempty"`
+	ReplicaCount int32             `json:"replica_count" validate:"required,gte=1"`
+	TTL          time.Duration     `json:"ttl" validate:"required,gt=0"`
+}
+
+// PreheatProgress is the progress of preheating a url by the scheduler of queue.
+type PreheatProgress struct {
+	URL             string    `json:"url"`
+	Queue           string    `json:"queue"`
+	State           string    `json:"state"`
+	CompletedLength int64     `json:"completed_length"`
+	ContentLength   int64     `json:"content_length"`
+	SeedPeer        string    `json:"seed_peer"`
+	Error           string    `json:"error"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
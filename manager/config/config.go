@@ -18,7 +18,11 @@ package config
 
 import (
 	"crypto/tls"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/docker/go-connections/tlsconfig"
@@ -27,6 +31,11 @@ import (
 	"d7y.io/dragonfly/v2/pkg/objectstorage"
 )
 
+const (
+	// encryptionKeySize is the size of AES-256 master key
+	encryptionKeySize = 32
+)
+
 type Config struct {
 	// Base options
 	base.Options `yaml:",inline" mapstructure:",squash"`
@@ -45,6 +54,9 @@ type Config struct {
 
 	// Metrics configuration
 	Metrics *MetricsConfig `yaml:"metrics" mapstructure:"metrics"`
+
+	// Encryption configuration
+	Encryption *EncryptionConfig `yaml:"encryption" mapstructure:"encryption"`
 }
 
 type ServerConfig struct {
@@ -120,6 +132,16 @@ func (t *TLSConfig) Client() (*tls.Config, error) {
 	})
 }
 
+// Generate server tls config, the client certificate is verified by CA if given
+func (t *TLSConfig) Server() (*tls.Config, error) {
+	return tlsconfig.Server(tlsconfig.Options{
+		CAFile:     t.CA,
+		CertFile:   t.Cert,
+		KeyFile:    t.Key,
+		ClientAuth: tls.VerifyClientCertIfGiven,
+	})
+}
+
 type RedisConfig struct {
 	// Server host
 	Host string `yaml:"host" mapstructure:"host"`
@@ -183,6 +205,10 @@ type TCPListenConfig struct {
 
 	// PortRange stands listen port
 	PortRange TCPListenPortRange `yaml:"port" mapstructure:"port"`
+
+	// TLS configuration, the clients authenticated by certificate signed by CA
+	// are allowed to get the encryption key
+	TLS *TLSConfig `yaml:"tls" mapstructure:"tls"`
 }
 
 type TCPListenPortRange struct {
@@ -190,6 +216,34 @@ type TCPListenPortRange struct {
 	End   int
 }
 
+type EncryptionConfig struct {
+	// Enable distributing the encryption key of task data to clients.
+	Enable bool `yaml:"enable" mapstructure:"enable"`
+
+	// KeyFile is the file of base64 encoded 32 bytes master key,
+	// it is only served to the clients authenticated by TLS.
+	KeyFile string `yaml:"keyFile" mapstructure:"keyFile"`
+}
+
+// Load the master key from key file
+func (e *EncryptionConfig) LoadKey() ([]byte, error) {
+	data, err := os.ReadFile(e.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(key) != encryptionKeySize {
+		return nil, fmt.Errorf("invalid encryption key size %d, expect %d", len(key), encryptionKeySize)
+	}
+
+	return key, nil
+}
+
 type ObjectStorageConfig struct {
 	// Enable object storage.
 	Enable bool `yaml:"enable" mapstructure:"enable"`
@@ -252,6 +306,9 @@ func New() *Config {
 			Enable:          false,
 			EnablePeerGauge: true,
 		},
+		Encryption: &EncryptionConfig{
+			Enable: false,
+		},
 	}
 }
 
@@ -269,6 +326,16 @@ func (cfg *Config) Validate() error {
 		return errors.New("server requires parameter grpc")
 	}
 
+	if cfg.Server.GRPC.TLS != nil {
+		if cfg.Server.GRPC.TLS.Cert == "" {
+			return errors.New("tls requires parameter cert")
+		}
+
+		if cfg.Server.GRPC.TLS.Key == "" {
+			return errors.New("tls requires parameter key")
+		}
+	}
+
 	if cfg.Server.REST == nil {
 		return errors.New("server requires parameter rest")
 	}
@@ -391,5 +458,15 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	if cfg.Encryption != nil && cfg.Encryption.Enable {
+		if cfg.Encryption.KeyFile == "" {
+			return errors.New("encryption requires parameter keyFile")
+		}
+
+		if cfg.Server.GRPC.TLS == nil || cfg.Server.GRPC.TLS.CA == "" {
+			return errors.New("encryption requires parameter ca of grpc tls")
+		}
+	}
+
 	return nil
 }
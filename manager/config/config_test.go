@@ -17,7 +17,11 @@
 package config
 
 import (
+	"bytes"
+	"encoding/base64"
 	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/mitchellh/mapstructure"
@@ -41,6 +45,11 @@ func TestManagerConfig_Load(t *testing.T) {
 					Start: 65003,
 					End:   65003,
 				},
+				TLS: &TLSConfig{
+					Cert: "foo",
+					Key:  "foo",
+					CA:   "foo",
+				},
 			},
 			REST: &RestConfig{
 				Addr: ":8080",
@@ -90,6 +99,10 @@ func TestManagerConfig_Load(t *testing.T) {
 			Addr:            ":8000",
 			EnablePeerGauge: false,
 		},
+		Encryption: &EncryptionConfig{
+			Enable:  true,
+			KeyFile: "foo",
+		},
 	}
 
 	managerConfigYAML := &Config{}
@@ -105,3 +118,52 @@ func TestManagerConfig_Load(t *testing.T) {
 
 	assert.EqualValues(config, managerConfigYAML)
 }
+
+func TestEncryptionConfig_LoadKey(t *testing.T) {
+	dir := t.TempDir()
+	key := bytes.Repeat([]byte{1}, encryptionKeySize)
+
+	tests := []struct {
+		name   string
+		data   string
+		expect func(t *testing.T, k []byte, err error)
+	}{
+		{
+			name: "load key",
+			data: base64.StdEncoding.EncodeToString(key) + "\n",
+			expect: func(t *testing.T, k []byte, err error) {
+				assert := testifyassert.New(t)
+				assert.NoError(err)
+				assert.Equal(key, k)
+			},
+		},
+		{
+			name: "key is not base64 encoded",
+			data: "foo",
+			expect: func(t *testing.T, k []byte, err error) {
+				assert := testifyassert.New(t)
+				assert.Error(err)
+			},
+		},
+		{
+			name: "key size is invalid",
+			data: base64.StdEncoding.EncodeToString([]byte("foo")),
+			expect: func(t *testing.T, k []byte, err error) {
+				assert := testifyassert.New(t)
+				assert.EqualError(err, "invalid encryption key size 3, expect 32")
+			},
+		},
+	}
+
+	for i, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			keyFile := filepath.Join(dir, strconv.Itoa(i))
+			if err := os.WriteFile(keyFile, []byte(tc.data), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			k, err := (&EncryptionConfig{Enable: true, KeyFile: keyFile}).LoadKey()
+			tc.expect(t, k, err)
+		})
+	}
+}
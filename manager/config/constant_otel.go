@@ -19,13 +19,15 @@ package config
 import "go.opentelemetry.io/otel/attribute"
 
 const (
-	AttributeID          = attribute.Key("d7y.manager.id")
-	AttributePreheatType = attribute.Key("d7y.manager.preheat.type")
-	AttributePreheatURL  = attribute.Key("d7y.manager.preheat.url")
+	AttributeID                 = attribute.Key("d7y.manager.id")
+	AttributePreheatType        = attribute.Key("d7y.manager.preheat.type")
+	AttributePreheatURL         = attribute.Key("d7y.manager.preheat.url")
+	AttributePersistentCacheURL = attribute.Key("d7y.manager.persistent-cache.url")
 )
 
 const (
 	SpanPreheat          = "preheat"
+	SpanPersistentCache  = "persistent-cache"
 	SpanGetLayers        = "get-layers"
 	SpanAuthWithRegistry = "auth-with-registry"
 )
@@ -43,6 +43,20 @@ func (h *Handlers) CreateJob(ctx *gin.Context) {
 			return
 		}
 
+		ctx.JSON(http.StatusOK, job)
+	case job.PersistentCacheJob:
+		var json types.CreatePersistentCacheJobRequest
+		if err := ctx.ShouldBindBodyWith(&json, binding.JSON); err != nil {
+			ctx.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+			return
+		}
+
+		job, err := h.service.CreatePersistentCacheJob(ctx.Request.Context(), json)
+		if err != nil {
+			ctx.Error(err) // nolint: errcheck
+			return
+		}
+
 		ctx.JSON(http.StatusOK, job)
 	default:
 		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"errors": "Unknow type"})
@@ -165,3 +179,30 @@ func (h *Handlers) GetJobs(ctx *gin.Context) {
 	h.setPaginationLinkHeader(ctx, query.Page, query.PerPage, int(count))
 	ctx.JSON(http.StatusOK, jobs)
 }
+
+// @Summary Cancel Job
+// @Description Cancel by id
+// @Tags Job
+// @Accept json
+// @Produce json
+// @Param id path string true "id"
+// @Success 200 {object} model.Job
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /jobs/{id}/cancel [post]
+func (h *Handlers) CancelJob(ctx *gin.Context) {
+	var params types.JobParams
+	if err := ctx.ShouldBindUri(&params); err != nil {
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+		return
+	}
+
+	job, err := h.service.CancelJob(ctx.Request.Context(), params.ID)
+	if err != nil {
+		ctx.Error(err) // nolint: errcheck
+		return
+	}
+
+	ctx.JSON(http.StatusOK, job)
+}
@@ -0,0 +1,133 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	machineryv1tasks "github.com/RichardKnop/machinery/v1/tasks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	internaljob "d7y.io/dragonfly/v2/internal/job"
+	"d7y.io/dragonfly/v2/manager/middlewares"
+	"d7y.io/dragonfly/v2/manager/model"
+	"d7y.io/dragonfly/v2/manager/service/mocks"
+)
+
+func TestHandlers_CancelJob(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		mock   func(ms *mocks.MockServiceMockRecorder)
+		expect func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "cancel pending job",
+			path: "/jobs/1/cancel",
+			mock: func(ms *mocks.MockServiceMockRecorder) {
+				ms.CancelJob(gomock.Any(), uint(1)).Return(&model.Job{
+					Model: model.Model{ID: 1},
+					State: internaljob.StateCanceled,
+				}, nil).Times(1)
+			},
+			expect: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert := assert.New(t)
+				assert.Equal(w.Code, http.StatusOK)
+
+				var job model.Job
+				assert.NoError(json.Unmarshal(w.Body.Bytes(), &job))
+				assert.Equal(job.ID, uint(1))
+				assert.Equal(job.State, internaljob.StateCanceled)
+			},
+		},
+		{
+			name: "cancel finished job",
+			path: "/jobs/1/cancel",
+			mock: func(ms *mocks.MockServiceMockRecorder) {
+				ms.CancelJob(gomock.Any(), uint(1)).Return(&model.Job{
+					Model: model.Model{ID: 1},
+					State: machineryv1tasks.StateSuccess,
+				}, nil).Times(1)
+			},
+			expect: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert := assert.New(t)
+				assert.Equal(w.Code, http.StatusOK)
+
+				var job model.Job
+				assert.NoError(json.Unmarshal(w.Body.Bytes(), &job))
+				assert.Equal(job.State, machineryv1tasks.StateSuccess)
+			},
+		},
+		{
+			name: "job not found",
+			path: "/jobs/1/cancel",
+			mock: func(ms *mocks.MockServiceMockRecorder) {
+				ms.CancelJob(gomock.Any(), uint(1)).Return(nil, gorm.ErrRecordNotFound).Times(1)
+			},
+			expect: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert := assert.New(t)
+				assert.Equal(w.Code, http.StatusNotFound)
+			},
+		},
+		{
+			name: "cancel job failed",
+			path: "/jobs/1/cancel",
+			mock: func(ms *mocks.MockServiceMockRecorder) {
+				ms.CancelJob(gomock.Any(), uint(1)).Return(nil, errors.New("foo")).Times(1)
+			},
+			expect: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert := assert.New(t)
+				assert.Equal(w.Code, http.StatusInternalServerError)
+			},
+		},
+		{
+			name: "invalid job id",
+			path: "/jobs/foo/cancel",
+			mock: func(ms *mocks.MockServiceMockRecorder) {},
+			expect: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert := assert.New(t)
+				assert.Equal(w.Code, http.StatusUnprocessableEntity)
+			},
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			svc := mocks.NewMockService(ctl)
+			tc.mock(svc.EXPECT())
+
+			h := New(svc)
+			r := gin.New()
+			r.Use(middlewares.Error())
+			r.POST("/jobs/:id/cancel", h.CancelJob)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tc.path, nil))
+			tc.expect(t, w)
+		})
+	}
+}
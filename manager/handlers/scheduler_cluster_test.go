@@ -0,0 +1,94 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"d7y.io/dragonfly/v2/manager/middlewares"
+	"d7y.io/dragonfly/v2/manager/model"
+	"d7y.io/dragonfly/v2/manager/service/mocks"
+)
+
+func TestHandlers_UpdateSchedulerCluster(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		mock   func(ms *mocks.MockServiceMockRecorder)
+		expect func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "update url policies",
+			body: `{"config":{"url_policies":[{"type":"cidr","pattern":"10.0.0.0/8","action":"deny"},{"type":"regex","pattern":"\\.iso$","action":"direct"}]}}`,
+			mock: func(ms *mocks.MockServiceMockRecorder) {
+				ms.UpdateSchedulerCluster(gomock.Any(), uint(1), gomock.Any()).Return(&model.SchedulerCluster{
+					Model: model.Model{ID: 1},
+				}, nil).Times(1)
+			},
+			expect: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert := assert.New(t)
+				assert.Equal(w.Code, http.StatusOK)
+			},
+		},
+		{
+			name: "invalid regex pattern",
+			body: `{"config":{"url_policies":[{"type":"regex","pattern":"(","action":"deny"}]}}`,
+			mock: func(ms *mocks.MockServiceMockRecorder) {},
+			expect: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert := assert.New(t)
+				assert.Equal(w.Code, http.StatusUnprocessableEntity)
+				assert.Contains(w.Body.String(), "url policy 0 is invalid")
+			},
+		},
+		{
+			name: "invalid cidr pattern",
+			body: `{"config":{"url_policies":[{"type":"host","pattern":"example.com","action":"allow"},{"type":"cidr","pattern":"10.0.0.0","action":"deny"}]}}`,
+			mock: func(ms *mocks.MockServiceMockRecorder) {},
+			expect: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert := assert.New(t)
+				assert.Equal(w.Code, http.StatusUnprocessableEntity)
+				assert.Contains(w.Body.String(), "url policy 1 is invalid")
+			},
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			svc := mocks.NewMockService(ctl)
+			tc.mock(svc.EXPECT())
+
+			h := New(svc)
+			r := gin.New()
+			r.Use(middlewares.Error())
+			r.PATCH("/scheduler-clusters/:id", h.UpdateSchedulerCluster)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPatch, "/scheduler-clusters/1", strings.NewReader(tc.body)))
+			tc.expect(t, w)
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 type Job struct {
 	*internaljob.Job
 	Preheat
+	PersistentCache
 }
 
 func New(cfg *config.Config) (*Job, error) {
@@ -43,9 +44,15 @@ func New(cfg *config.Config) (*Job, error) {
 		return nil, err
 	}
 
+	pc, err := newPersistentCache(j)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Job{
-		Job:     j,
-		Preheat: p,
+		Job:             j,
+		Preheat:         p,
+		PersistentCache: pc,
 	}, nil
 }
 
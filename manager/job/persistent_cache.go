@@ -0,0 +1,93 @@
+/*
+ *     Copyright 2020 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package job
+
+import (
+	"context"
+	"time"
+
+	machineryv1tasks "github.com/RichardKnop/machinery/v1/tasks"
+	"go.opentelemetry.io/otel/trace"
+
+	logger "d7y.io/dragonfly/v2/internal/dflog"
+	internaljob "d7y.io/dragonfly/v2/internal/job"
+	"d7y.io/dragonfly/v2/manager/config"
+	"d7y.io/dragonfly/v2/manager/model"
+	"d7y.io/dragonfly/v2/manager/types"
+)
+
+type PersistentCache interface {
+	CreatePersistentCache(context.Context, []model.Scheduler, types.PersistentCacheArgs) (*internaljob.GroupJobState, error)
+}
+
+type persistentCache struct {
+	job *internaljob.Job
+}
+
+func newPersistentCache(job *internaljob.Job) (PersistentCache, error) {
+	return &persistentCache{
+		job: job,
+	}, nil
+}
+
+func (p *persistentCache) CreatePersistentCache(ctx context.Context, schedulers []model.Scheduler, json types.PersistentCacheArgs) (*internaljob.GroupJobState, error) {
+	var span trace.Span
+	ctx, span = tracer.Start(ctx, config.SpanPersistentCache, trace.WithSpanKind(trace.SpanKindProducer))
+	span.SetAttributes(config.AttributePersistentCacheURL.String(json.URL))
+	defer span.End()
+
+	args, err := internaljob.MarshalRequest(&internaljob.PersistentCacheRequest{
+		URL:          json.URL,
+		Tag:          json.Tag,
+		Digest:       json.Digest,
+		Filter:       json.Filter,
+		Headers:      json.Headers,
+		ReplicaCount: json.ReplicaCount,
+		TTL:          time.Duration(json.TTL) * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Every scheduler cluster replicates the task to its own seed peers.
+	queues := getSchedulerQueues(schedulers)
+	var signatures []*machineryv1tasks.Signature
+	for _, queue := range queues {
+		signatures = append(signatures, &machineryv1tasks.Signature{
+			Name:       internaljob.PersistentCacheJob,
+			RoutingKey: queue.String(),
+			Args:       args,
+		})
+	}
+
+	group, err := machineryv1tasks.NewGroup(signatures...)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.job.Server.SendGroupWithContext(ctx, group, 0); err != nil {
+		logger.Error("create persistent cache group job failed", err)
+		return nil, err
+	}
+
+	logger.Infof("create persistent cache group job successfully, group uuid: %s, url: %s, queues: %v", group.GroupUUID, json.URL, queues)
+	return &internaljob.GroupJobState{
+		GroupUUID: group.GroupUUID,
+		State:     machineryv1tasks.StatePending,
+		CreatedAt: time.Now(),
+	}, nil
+}
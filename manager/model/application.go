@@ -16,6 +16,11 @@
 
 package model
 
+const (
+	ApplicationStateEnabled  = "enable"
+	ApplicationStateDisabled = "disable"
+)
+
 type Application struct {
 	Model
 	Name              string             `gorm:"column:name;type:varchar(256);index:uk_application_name,unique;not null;comment:name" json:"name"`
@@ -23,6 +28,7 @@ type Application struct {
 	URL               string             `gorm:"column:url;not null;comment:url" json:"url"`
 	State             string             `gorm:"column:state;type:varchar(256);default:'enable';comment:state" json:"state"`
 	BIO               string             `gorm:"column:bio;type:varchar(1024);comment:biography" json:"bio"`
+	Quota             JSONMap            `gorm:"column:quota;comment:quota" json:"quota"`
 	UserID            uint               `gorm:"comment:user id" json:"user_id"`
 	User              User               `json:"user"`
 	SeedPeerClusters  []SeedPeerCluster  `json:"seed_peer_clusters"`
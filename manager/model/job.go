@@ -24,6 +24,7 @@ type Job struct {
 	State             string             `gorm:"column:state;type:varchar(256);not null;default:'PENDING';comment:service state" json:"state"`
 	Args              JSONMap            `gorm:"column:args;not null;comment:task request args" json:"args"`
 	Result            JSONMap            `gorm:"column:result;comment:task result" json:"result"`
+	Progress          JSONMap            `gorm:"column:progress;comment:task progress" json:"progress"`
 	UserID            uint               `gorm:"column:user_id;comment:user id" json:"user_id"`
 	User              User               `json:"-"`
 	SeedPeerClusters  []SeedPeerCluster  `gorm:"many2many:job_seed_peer_cluster;" json:"seed_peer_clusters"`
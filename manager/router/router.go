@@ -217,6 +217,7 @@ func Init(cfg *config.Config, logDir string, service service.Service, enforcer *
 	job.PATCH(":id", h.UpdateJob)
 	job.GET(":id", h.GetJob)
 	job.GET("", h.GetJobs)
+	job.POST(":id/cancel", h.CancelJob)
 
 	// Compatible with the V1 preheat.
 	pv1 := r.Group("/preheats")
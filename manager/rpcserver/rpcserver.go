@@ -30,8 +30,10 @@ import (
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
 
@@ -341,6 +343,43 @@ func (s *Server) GetScheduler(ctx context.Context, req *manager.GetSchedulerRequ
 		}
 	}
 
+	// Construct applications, only the application linked to scheduler cluster
+	// by Application.SchedulerClusters is returned.
+	var applications []model.Application
+	if scheduler.SchedulerCluster.ApplicationID != 0 {
+		if err := s.db.WithContext(ctx).Find(&applications, &model.Application{
+			Model: model.Model{ID: scheduler.SchedulerCluster.ApplicationID},
+			State: model.ApplicationStateEnabled,
+		}).Error; err != nil {
+			return nil, status.Error(codes.Unknown, err.Error())
+		}
+	}
+
+	var pbApplications []*manager.Application
+	for _, application := range applications {
+		quota, err := application.Quota.MarshalJSON()
+		if err != nil {
+			return nil, status.Error(codes.DataLoss, err.Error())
+		}
+
+		pbApplications = append(pbApplications, &manager.Application{
+			Id:    uint64(application.ID),
+			Name:  application.Name,
+			Url:   application.URL,
+			Bio:   application.BIO,
+			Quota: quota,
+		})
+	}
+
+	// Count active schedulers, the limits of scheduler cluster are shared by them.
+	var activeSchedulerCount int64
+	if err := s.db.WithContext(ctx).Model(&model.Scheduler{}).Where(&model.Scheduler{
+		SchedulerClusterID: scheduler.SchedulerClusterID,
+		State:              model.SchedulerStateActive,
+	}).Count(&activeSchedulerCount).Error; err != nil {
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+
 	// Construct scheduler.
 	pbScheduler = manager.Scheduler{
 		Id:                 uint64(scheduler.ID),
@@ -353,13 +392,15 @@ func (s *Server) GetScheduler(ctx context.Context, req *manager.GetSchedulerRequ
 		State:              scheduler.State,
 		SchedulerClusterId: uint64(scheduler.SchedulerClusterID),
 		SchedulerCluster: &manager.SchedulerCluster{
-			Id:           uint64(scheduler.SchedulerCluster.ID),
-			Name:         scheduler.SchedulerCluster.Name,
-			Bio:          scheduler.SchedulerCluster.BIO,
-			Config:       schedulerClusterConfig,
-			ClientConfig: schedulerClusterClientConfig,
+			Id:                   uint64(scheduler.SchedulerCluster.ID),
+			Name:                 scheduler.SchedulerCluster.Name,
+			Bio:                  scheduler.SchedulerCluster.BIO,
+			Config:               schedulerClusterConfig,
+			ClientConfig:         schedulerClusterClientConfig,
+			ActiveSchedulerCount: uint32(activeSchedulerCount),
 		},
-		SeedPeers: pbSeedPeers,
+		SeedPeers:    pbSeedPeers,
+		Applications: pbApplications,
 	}
 
 	// Cache data.
@@ -628,6 +669,51 @@ func (s *Server) ListBuckets(ctx context.Context, req *manager.ListBucketsReques
 	return &pbListBucketsResponse, nil
 }
 
+// Get the encryption key of task data, it is never cached
+// and only served to the client authenticated by TLS.
+func (s *Server) GetEncryptionKey(ctx context.Context, req *manager.GetEncryptionKeyRequest) (*manager.EncryptionKey, error) {
+	log := logger.WithHostnameAndIP(req.HostName, req.Ip)
+
+	if s.config.Encryption == nil || !s.config.Encryption.Enable {
+		msg := "encryption is disabled"
+		log.Debug(msg)
+		return nil, status.Error(codes.NotFound, msg)
+	}
+
+	if err := authenticateTLSPeer(ctx); err != nil {
+		log.Warnf("get encryption key refused: %s", err.Error())
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	key, err := s.config.Encryption.LoadKey()
+	if err != nil {
+		log.Errorf("load encryption key failed: %s", err.Error())
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+
+	log.Info("get encryption key successfully")
+	return &manager.EncryptionKey{Key: key}, nil
+}
+
+// authenticateTLSPeer checks the peer of context is connected by TLS with verified client certificate.
+func authenticateTLSPeer(ctx context.Context) error {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return errors.New("peer not found")
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return errors.New("connection is not secured by tls")
+	}
+
+	if len(tlsInfo.State.VerifiedChains) == 0 {
+		return errors.New("client certificate is not verified")
+	}
+
+	return nil
+}
+
 // KeepAlive with manager.
 func (s *Server) KeepAlive(stream manager.Manager_KeepAliveServer) error {
 	req, err := stream.Recv()
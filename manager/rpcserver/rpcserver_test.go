@@ -0,0 +1,127 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rpcserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"d7y.io/dragonfly/v2/manager/config"
+	"d7y.io/dragonfly/v2/pkg/rpc/manager"
+)
+
+var (
+	mockEncryptionKey           = bytes.Repeat([]byte{1}, 32)
+	mockGetEncryptionKeyRequest = &manager.GetEncryptionKeyRequest{
+		SourceType: manager.SourceType_PEER_SOURCE,
+		HostName:   "foo",
+		Ip:         "127.0.0.1",
+	}
+)
+
+func newMockPeerContext(authInfo credentials.AuthInfo) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: authInfo})
+}
+
+func TestServer_GetEncryptionKey(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "encryption.key")
+	if err := os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(mockEncryptionKey)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		encryption *config.EncryptionConfig
+		ctx        context.Context
+		expect     func(t *testing.T, key *manager.EncryptionKey, err error)
+	}{
+		{
+			name:       "client is authenticated by certificate",
+			encryption: &config.EncryptionConfig{Enable: true, KeyFile: keyFile},
+			ctx: newMockPeerContext(credentials.TLSInfo{State: tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{&x509.Certificate{}}},
+			}}),
+			expect: func(t *testing.T, key *manager.EncryptionKey, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				assert.Equal(key.Key, mockEncryptionKey)
+			},
+		},
+		{
+			name:       "client certificate is not given",
+			encryption: &config.EncryptionConfig{Enable: true, KeyFile: keyFile},
+			ctx:        newMockPeerContext(credentials.TLSInfo{}),
+			expect: func(t *testing.T, key *manager.EncryptionKey, err error) {
+				assert := assert.New(t)
+				assert.Equal(status.Code(err), codes.Unauthenticated)
+				assert.Nil(key)
+			},
+		},
+		{
+			name:       "connection is insecure",
+			encryption: &config.EncryptionConfig{Enable: true, KeyFile: keyFile},
+			ctx:        newMockPeerContext(nil),
+			expect: func(t *testing.T, key *manager.EncryptionKey, err error) {
+				assert := assert.New(t)
+				assert.Equal(status.Code(err), codes.Unauthenticated)
+				assert.Nil(key)
+			},
+		},
+		{
+			name:       "peer not found",
+			encryption: &config.EncryptionConfig{Enable: true, KeyFile: keyFile},
+			ctx:        context.Background(),
+			expect: func(t *testing.T, key *manager.EncryptionKey, err error) {
+				assert := assert.New(t)
+				assert.Equal(status.Code(err), codes.Unauthenticated)
+				assert.Nil(key)
+			},
+		},
+		{
+			name:       "encryption is disabled",
+			encryption: &config.EncryptionConfig{Enable: false},
+			ctx: newMockPeerContext(credentials.TLSInfo{State: tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{&x509.Certificate{}}},
+			}}),
+			expect: func(t *testing.T, key *manager.EncryptionKey, err error) {
+				assert := assert.New(t)
+				assert.Equal(status.Code(err), codes.NotFound)
+				assert.Nil(key)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Server{config: &config.Config{Encryption: tc.encryption}}
+			key, err := s.GetEncryptionKey(tc.ctx, mockGetEncryptionKeyRequest)
+			tc.expect(t, key, err)
+		})
+	}
+}
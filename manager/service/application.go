@@ -21,9 +21,15 @@ import (
 
 	"d7y.io/dragonfly/v2/manager/model"
 	"d7y.io/dragonfly/v2/manager/types"
+	"d7y.io/dragonfly/v2/pkg/structure"
 )
 
 func (s *service) CreateApplication(ctx context.Context, json types.CreateApplicationRequest) (*model.Application, error) {
+	quota, err := structure.StructToMap(json.Quota)
+	if err != nil {
+		return nil, err
+	}
+
 	application := model.Application{
 		Name:              json.Name,
 		DownloadRateLimit: json.DownloadRateLimit,
@@ -31,6 +37,7 @@ func (s *service) CreateApplication(ctx context.Context, json types.CreateApplic
 		UserID:            json.UserID,
 		BIO:               json.BIO,
 		State:             json.State,
+		Quota:             quota,
 	}
 
 	if err := s.db.WithContext(ctx).Preload("SeedPeerClusters").Preload("SchedulerClusters").Preload("User").Create(&application).Error; err != nil {
@@ -54,6 +61,11 @@ func (s *service) DestroyApplication(ctx context.Context, id uint) error {
 }
 
 func (s *service) UpdateApplication(ctx context.Context, id uint, json types.UpdateApplicationRequest) (*model.Application, error) {
+	quota, err := structure.StructToMap(json.Quota)
+	if err != nil {
+		return nil, err
+	}
+
 	application := model.Application{}
 	if err := s.db.WithContext(ctx).Preload("SeedPeerClusters").Preload("SchedulerClusters").Preload("User").First(&application, id).Updates(model.Application{
 		Name:              json.Name,
@@ -61,6 +73,7 @@ func (s *service) UpdateApplication(ctx context.Context, id uint, json types.Upd
 		URL:               json.URL,
 		State:             json.State,
 		BIO:               json.BIO,
+		Quota:             quota,
 		UserID:            json.UserID,
 	}).Error; err != nil {
 		return nil, err
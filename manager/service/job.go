@@ -23,6 +23,7 @@ import (
 	machineryv1tasks "github.com/RichardKnop/machinery/v1/tasks"
 
 	logger "d7y.io/dragonfly/v2/internal/dflog"
+	internaljob "d7y.io/dragonfly/v2/internal/job"
 	"d7y.io/dragonfly/v2/manager/model"
 	"d7y.io/dragonfly/v2/manager/types"
 	"d7y.io/dragonfly/v2/pkg/retry"
@@ -30,14 +31,44 @@ import (
 )
 
 func (s *service) CreatePreheatJob(ctx context.Context, json types.CreatePreheatJobRequest) (*model.Job, error) {
+	schedulers, schedulerClusters, err := s.findJobSchedulers(ctx, json.SchedulerClusterIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	groupJobState, err := s.job.CreatePreheat(ctx, schedulers, json.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createJob(ctx, groupJobState, json.BIO, json.Type, json.Args, json.UserID, schedulerClusters)
+}
+
+func (s *service) CreatePersistentCacheJob(ctx context.Context, json types.CreatePersistentCacheJobRequest) (*model.Job, error) {
+	schedulers, schedulerClusters, err := s.findJobSchedulers(ctx, json.SchedulerClusterIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	groupJobState, err := s.job.CreatePersistentCache(ctx, schedulers, json.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createJob(ctx, groupJobState, json.BIO, json.Type, json.Args, json.UserID, schedulerClusters)
+}
+
+// findJobSchedulers returns an active scheduler of every scheduler cluster to run the job,
+// all of the scheduler clusters are used when schedulerClusterIDs is empty.
+func (s *service) findJobSchedulers(ctx context.Context, schedulerClusterIDs []uint) ([]model.Scheduler, []model.SchedulerCluster, error) {
 	var schedulers []model.Scheduler
 	var schedulerClusters []model.SchedulerCluster
 
-	if len(json.SchedulerClusterIDs) != 0 {
-		for _, schedulerClusterID := range json.SchedulerClusterIDs {
+	if len(schedulerClusterIDs) != 0 {
+		for _, schedulerClusterID := range schedulerClusterIDs {
 			schedulerCluster := model.SchedulerCluster{}
 			if err := s.db.WithContext(ctx).First(&schedulerCluster, schedulerClusterID).Error; err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			schedulerClusters = append(schedulerClusters, schedulerCluster)
 
@@ -46,13 +77,13 @@ func (s *service) CreatePreheatJob(ctx context.Context, json types.CreatePreheat
 				SchedulerClusterID: schedulerCluster.ID,
 				State:              model.SchedulerStateActive,
 			}).Error; err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			schedulers = append(schedulers, scheduler)
 		}
 	} else {
 		if err := s.db.WithContext(ctx).Find(&schedulerClusters).Error; err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		for _, schedulerCluster := range schedulerClusters {
@@ -68,23 +99,23 @@ func (s *service) CreatePreheatJob(ctx context.Context, json types.CreatePreheat
 		}
 	}
 
-	groupJobState, err := s.job.CreatePreheat(ctx, schedulers, json.Args)
-	if err != nil {
-		return nil, err
-	}
+	return schedulers, schedulerClusters, nil
+}
 
-	args, err := structure.StructToMap(json.Args)
+// createJob stores the group job and starts polling its state.
+func (s *service) createJob(ctx context.Context, groupJobState *internaljob.GroupJobState, bio, jobType string, jobArgs interface{}, userID uint, schedulerClusters []model.SchedulerCluster) (*model.Job, error) {
+	args, err := structure.StructToMap(jobArgs)
 	if err != nil {
 		return nil, err
 	}
 
 	job := model.Job{
 		TaskID:            groupJobState.GroupUUID,
-		BIO:               json.BIO,
-		Type:              json.Type,
+		BIO:               bio,
+		Type:              jobType,
 		State:             groupJobState.State,
 		Args:              args,
-		UserID:            json.UserID,
+		UserID:            userID,
 		SchedulerClusters: schedulerClusters,
 	}
 
@@ -107,14 +138,32 @@ func (s *service) pollingJob(ctx context.Context, id uint, taskID string) {
 			return nil, false, err
 		}
 
-		if err := s.db.WithContext(ctx).First(&job, id).Updates(model.Job{
+		// Failure of loading progress does not affect the state of job.
+		progress, err := s.getPreheatJobProgress(ctx, taskID)
+		if err != nil {
+			logger.Warnf("polling job %d and task %s progress failed: %v", id, taskID, err)
+		}
+
+		if err := s.db.WithContext(ctx).First(&job, id).Error; err != nil {
+			logger.Errorf("polling job %d and task %s store failed: %v", id, taskID, err)
+			return nil, true, err
+		}
+
+		if err := s.db.WithContext(ctx).Model(&job).Updates(model.Job{Progress: progress}).Error; err != nil {
+			logger.Errorf("polling job %d and task %s store failed: %v", id, taskID, err)
+			return nil, true, err
+		}
+
+		// Canceled job keeps its state, the condition is checked by the update,
+		// so the job canceled after it is loaded is not overwritten.
+		if err := s.db.WithContext(ctx).Model(&job).Where("state <> ?", internaljob.StateCanceled).Updates(model.Job{
 			State: groupJob.State,
 		}).Error; err != nil {
 			logger.Errorf("polling job %d and task %s store failed: %v", id, taskID, err)
 			return nil, true, err
 		}
 
-		switch job.State {
+		switch groupJob.State {
 		case machineryv1tasks.StateSuccess:
 			logger.Infof("polling job %d and task %s is finally successful", id, taskID)
 			return nil, true, nil
@@ -122,16 +171,15 @@ func (s *service) pollingJob(ctx context.Context, id uint, taskID string) {
 			logger.Errorf("polling job %d and task %s is finally failed", id, taskID)
 			return nil, true, nil
 		default:
-			return nil, false, fmt.Errorf("polling job %d and task %s status is %s", id, taskID, job.State)
+			return nil, false, fmt.Errorf("polling job %d and task %s status is %s", id, taskID, groupJob.State)
 		}
 	}, 5, 10, 120, nil); err != nil {
 		logger.Errorf("polling job %d and task %s failed %s", id, taskID, err)
 	}
 
 	// Polling timeout and failed
-	if job.State != machineryv1tasks.StateSuccess && job.State != machineryv1tasks.StateFailure {
-		job := model.Job{}
-		if err := s.db.WithContext(ctx).First(&job, id).Updates(model.Job{
+	if job.State != machineryv1tasks.StateSuccess && job.State != machineryv1tasks.StateFailure && job.State != internaljob.StateCanceled {
+		if err := s.db.WithContext(ctx).Model(&model.Job{}).Where("id = ? AND state <> ?", id, internaljob.StateCanceled).Updates(model.Job{
 			State: machineryv1tasks.StateFailure,
 		}).Error; err != nil {
 			logger.Errorf("polling job %d and task %s store failed: %v", id, taskID, err)
@@ -140,6 +188,52 @@ func (s *service) pollingJob(ctx context.Context, id uint, taskID string) {
 	}
 }
 
+// getPreheatJobProgress returns the progress of preheat job, which sums up the progresses of urls.
+func (s *service) getPreheatJobProgress(ctx context.Context, taskID string) (model.JSONMap, error) {
+	preheats, err := s.job.GetPreheatProgresses(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(preheats) == 0 {
+		return nil, nil
+	}
+
+	progress := types.PreheatJobProgress{
+		Preheats: preheats,
+	}
+	for _, preheat := range preheats {
+		progress.CompletedLength += preheat.CompletedLength
+		progress.ContentLength += preheat.ContentLength
+	}
+
+	return structure.StructToMap(progress)
+}
+
+func (s *service) CancelJob(ctx context.Context, id uint) (*model.Job, error) {
+	job := model.Job{}
+	if err := s.db.WithContext(ctx).Preload("SeedPeerClusters").Preload("SchedulerClusters").First(&job, id).Error; err != nil {
+		return nil, err
+	}
+
+	// Finished job can not be canceled.
+	if job.State == machineryv1tasks.StateSuccess || job.State == machineryv1tasks.StateFailure || job.State == internaljob.StateCanceled {
+		return &job, nil
+	}
+
+	if err := s.job.CancelGroupJob(ctx, job.TaskID); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.WithContext(ctx).Model(&job).Updates(model.Job{
+		State: internaljob.StateCanceled,
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
 func (s *service) DestroyJob(ctx context.Context, id uint) error {
 	job := model.Job{}
 	if err := s.db.WithContext(ctx).First(&job, id).Error; err != nil {
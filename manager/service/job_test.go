@@ -0,0 +1,280 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	machineryv1tasks "github.com/RichardKnop/machinery/v1/tasks"
+	"github.com/alicebob/miniredis"
+	"github.com/stretchr/testify/assert"
+	drivermysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+
+	internaljob "d7y.io/dragonfly/v2/internal/job"
+	"d7y.io/dragonfly/v2/manager/job"
+)
+
+var (
+	mockJobID     = uint(1)
+	mockGroupUUID = "group_4d8b4e5a-4b9a-4b6a-9c4e-2b2a1d7f3c11"
+	mockTaskUUID  = "task_0b7a1c55-7d7b-4f5a-9e0c-3b1d2a6f8e22"
+	mockJobFields = []string{"id", "task_id", "state"}
+)
+
+func newMockService(t *testing.T) (*service, sqlmock.Sqlmock) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(mr.Close)
+
+	port, err := strconv.Atoi(mr.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	j, err := internaljob.New(&internaljob.Config{
+		Host:      mr.Host(),
+		Port:      port,
+		BrokerDB:  1,
+		BackendDB: 2,
+	}, internaljob.GlobalQueue)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqldb, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqldb.Close() })
+
+	db, err := gorm.Open(drivermysql.New(drivermysql.Config{
+		Conn:                      sqldb,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		NamingStrategy: schema.NamingStrategy{
+			SingularTable: true,
+		},
+		Logger: gormlogger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &service{
+		db:  db,
+		job: &job.Job{Job: j},
+	}, mock
+}
+
+func mockGroupJobState(t *testing.T, j *internaljob.Job, state string) {
+	backend := j.Server.GetBackend()
+	if err := backend.InitGroup(mockGroupUUID, []string{mockTaskUUID}); err != nil {
+		t.Fatal(err)
+	}
+
+	signature := &machineryv1tasks.Signature{UUID: mockTaskUUID, GroupUUID: mockGroupUUID}
+	switch state {
+	case machineryv1tasks.StateSuccess:
+		if err := backend.SetStateSuccess(signature, nil); err != nil {
+			t.Fatal(err)
+		}
+	case machineryv1tasks.StateFailure:
+		if err := backend.SetStateFailure(signature, "foo"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestService_CancelJob(t *testing.T) {
+	tests := []struct {
+		name   string
+		mock   func(s *service, mock sqlmock.Sqlmock)
+		expect func(t *testing.T, s *service, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "cancel pending job",
+			mock: func(s *service, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM `job`").
+					WillReturnRows(sqlmock.NewRows(mockJobFields).AddRow(mockJobID, mockGroupUUID, machineryv1tasks.StatePending))
+				mock.ExpectQuery("SELECT \\* FROM `job_scheduler_cluster`").WillReturnRows(sqlmock.NewRows(nil))
+				mock.ExpectQuery("SELECT \\* FROM `job_seed_peer_cluster`").WillReturnRows(sqlmock.NewRows(nil))
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `job` SET .*`state`=").
+					WithArgs(sqlmock.AnyArg(), internaljob.StateCanceled, mockJobID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expect: func(t *testing.T, s *service, mock sqlmock.Sqlmock) {
+				assert := assert.New(t)
+				job, err := s.CancelJob(context.Background(), mockJobID)
+				assert.NoError(err)
+				assert.Equal(job.State, internaljob.StateCanceled)
+
+				canceled, err := s.job.IsGroupJobCanceled(context.Background(), mockGroupUUID)
+				assert.NoError(err)
+				assert.True(canceled)
+				assert.NoError(mock.ExpectationsWereMet())
+			},
+		},
+		{
+			name: "job is already finished",
+			mock: func(s *service, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM `job`").
+					WillReturnRows(sqlmock.NewRows(mockJobFields).AddRow(mockJobID, mockGroupUUID, machineryv1tasks.StateSuccess))
+				mock.ExpectQuery("SELECT \\* FROM `job_scheduler_cluster`").WillReturnRows(sqlmock.NewRows(nil))
+				mock.ExpectQuery("SELECT \\* FROM `job_seed_peer_cluster`").WillReturnRows(sqlmock.NewRows(nil))
+			},
+			expect: func(t *testing.T, s *service, mock sqlmock.Sqlmock) {
+				assert := assert.New(t)
+				job, err := s.CancelJob(context.Background(), mockJobID)
+				assert.NoError(err)
+				assert.Equal(job.State, machineryv1tasks.StateSuccess)
+
+				canceled, err := s.job.IsGroupJobCanceled(context.Background(), mockGroupUUID)
+				assert.NoError(err)
+				assert.False(canceled)
+				assert.NoError(mock.ExpectationsWereMet())
+			},
+		},
+		{
+			name: "job is already canceled",
+			mock: func(s *service, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM `job`").
+					WillReturnRows(sqlmock.NewRows(mockJobFields).AddRow(mockJobID, mockGroupUUID, internaljob.StateCanceled))
+				mock.ExpectQuery("SELECT \\* FROM `job_scheduler_cluster`").WillReturnRows(sqlmock.NewRows(nil))
+				mock.ExpectQuery("SELECT \\* FROM `job_seed_peer_cluster`").WillReturnRows(sqlmock.NewRows(nil))
+			},
+			expect: func(t *testing.T, s *service, mock sqlmock.Sqlmock) {
+				assert := assert.New(t)
+				job, err := s.CancelJob(context.Background(), mockJobID)
+				assert.NoError(err)
+				assert.Equal(job.State, internaljob.StateCanceled)
+				assert.NoError(mock.ExpectationsWereMet())
+			},
+		},
+		{
+			name: "job not found",
+			mock: func(s *service, mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM `job`").WillReturnRows(sqlmock.NewRows(mockJobFields))
+			},
+			expect: func(t *testing.T, s *service, mock sqlmock.Sqlmock) {
+				assert := assert.New(t)
+				_, err := s.CancelJob(context.Background(), mockJobID)
+				assert.ErrorIs(err, gorm.ErrRecordNotFound)
+				assert.NoError(mock.ExpectationsWereMet())
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, mock := newMockService(t)
+			tc.mock(s, mock)
+			tc.expect(t, s, mock)
+		})
+	}
+}
+
+func TestService_pollingJob(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    string
+		groupJob string
+		mock     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:     "job succeeded",
+			state:    machineryv1tasks.StatePending,
+			groupJob: machineryv1tasks.StateSuccess,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `job` SET `updated_at`=\\?,`progress`=\\? WHERE").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), mockJobID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `job` SET `updated_at`=\\?,`state`=\\? WHERE state <> \\? AND").
+					WithArgs(sqlmock.AnyArg(), machineryv1tasks.StateSuccess, internaljob.StateCanceled, mockJobID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:     "job failed",
+			state:    machineryv1tasks.StatePending,
+			groupJob: machineryv1tasks.StateFailure,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `job` SET `updated_at`=\\?,`progress`=\\? WHERE").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), mockJobID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `job` SET `updated_at`=\\?,`state`=\\? WHERE state <> \\? AND").
+					WithArgs(sqlmock.AnyArg(), machineryv1tasks.StateFailure, internaljob.StateCanceled, mockJobID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:     "canceled job keeps its state",
+			state:    internaljob.StateCanceled,
+			groupJob: machineryv1tasks.StateSuccess,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `job` SET `updated_at`=\\?,`progress`=\\? WHERE").
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), mockJobID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `job` SET `updated_at`=\\?,`state`=\\? WHERE state <> \\? AND").
+					WithArgs(sqlmock.AnyArg(), machineryv1tasks.StateSuccess, internaljob.StateCanceled, mockJobID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			s, mock := newMockService(t)
+			mockGroupJobState(t, s.job.Job, tc.groupJob)
+			if err := s.job.SetPreheatProgress(context.Background(), mockGroupUUID, mockTaskUUID, &internaljob.PreheatProgress{
+				CompletedLength: 1024,
+				ContentLength:   1024,
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			mock.ExpectQuery("SELECT \\* FROM `job`").
+				WillReturnRows(sqlmock.NewRows(mockJobFields).AddRow(mockJobID, mockGroupUUID, tc.state))
+			tc.mock(mock)
+
+			s.pollingJob(context.Background(), mockJobID, mockGroupUUID)
+			assert.NoError(mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -14,6 +14,8 @@
  * limitations under the License.
  */
 
+//go:generate mockgen -destination mocks/service_mock.go -source service.go -package mocks
+
 package service
 
 import (
@@ -118,10 +120,12 @@ type Service interface {
 	GetConfigs(context.Context, types.GetConfigsQuery) ([]model.Config, int64, error)
 
 	CreatePreheatJob(context.Context, types.CreatePreheatJobRequest) (*model.Job, error)
+	CreatePersistentCacheJob(context.Context, types.CreatePersistentCacheJobRequest) (*model.Job, error)
 	DestroyJob(context.Context, uint) error
 	UpdateJob(context.Context, uint, types.UpdateJobRequest) (*model.Job, error)
 	GetJob(context.Context, uint) (*model.Job, error)
 	GetJobs(context.Context, types.GetJobsQuery) ([]model.Job, int64, error)
+	CancelJob(context.Context, uint) (*model.Job, error)
 
 	CreateV1Preheat(context.Context, types.CreateV1PreheatRequest) (*types.CreateV1PreheatResponse, error)
 	GetV1Preheat(context.Context, string) (*types.GetV1PreheatResponse, error)
@@ -41,21 +41,29 @@ type DeleteSeedPeerClusterToApplicationParams struct {
 }
 
 type CreateApplicationRequest struct {
-	Name              string `json:"name" binding:"required"`
-	BIO               string `json:"bio" binding:"omitempty"`
-	URL               string `json:"url" binding:"omitempty"`
-	DownloadRateLimit uint   `json:"download_rate_limit" binding:"omitempty"`
-	State             string `json:"state" binding:"omitempty,oneof=enable disable"`
-	UserID            uint   `json:"user_id" binding:"required"`
+	Name              string            `json:"name" binding:"required"`
+	BIO               string            `json:"bio" binding:"omitempty"`
+	URL               string            `json:"url" binding:"omitempty"`
+	DownloadRateLimit uint              `json:"download_rate_limit" binding:"omitempty"`
+	State             string            `json:"state" binding:"omitempty,oneof=enable disable"`
+	Quota             *ApplicationQuota `json:"quota" binding:"omitempty"`
+	UserID            uint              `json:"user_id" binding:"required"`
 }
 
 type UpdateApplicationRequest struct {
-	Name              string `json:"name" binding:"omitempty"`
-	BIO               string `json:"bio" binding:"omitempty"`
-	URL               string `json:"url" binding:"omitempty"`
-	DownloadRateLimit uint   `json:"download_rate_limit" binding:"omitempty"`
-	State             string `json:"state" binding:"omitempty,oneof=enable disable"`
-	UserID            uint   `json:"user_id" binding:"required"`
+	Name              string            `json:"name" binding:"omitempty"`
+	BIO               string            `json:"bio" binding:"omitempty"`
+	URL               string            `json:"url" binding:"omitempty"`
+	DownloadRateLimit uint              `json:"download_rate_limit" binding:"omitempty"`
+	State             string            `json:"state" binding:"omitempty,oneof=enable disable"`
+	Quota             *ApplicationQuota `json:"quota" binding:"omitempty"`
+	UserID            uint              `json:"user_id" binding:"required"`
+}
+
+type ApplicationQuota struct {
+	MaxConcurrentTasks       uint32 `yaml:"maxConcurrentTasks" mapstructure:"maxConcurrentTasks" json:"max_concurrent_tasks" binding:"omitempty"`
+	MaxBackToSourceBandwidth uint64 `yaml:"maxBackToSourceBandwidth" mapstructure:"maxBackToSourceBandwidth" json:"max_back_to_source_bandwidth" binding:"omitempty"`
+	MaxSeedPeerTriggers      uint32 `yaml:"maxSeedPeerTriggers" mapstructure:"maxSeedPeerTriggers" json:"max_seed_peer_triggers" binding:"omitempty"`
 }
 
 type GetApplicationsQuery struct {
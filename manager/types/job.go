@@ -16,6 +16,10 @@
 
 package types
 
+import (
+	internaljob "d7y.io/dragonfly/v2/internal/job"
+)
+
 type CreateJobRequest struct {
 	BIO                 string                 `json:"bio" binding:"omitempty"`
 	Type                string                 `json:"type" binding:"required"`
@@ -37,7 +41,7 @@ type JobParams struct {
 
 type GetJobsQuery struct {
 	Type    string `form:"type" binding:"omitempty"`
-	State   string `form:"state" binding:"omitempty,oneof=PENDING RECEIVED STARTED RETRY SUCCESS FAILURE"`
+	State   string `form:"state" binding:"omitempty,oneof=PENDING RECEIVED STARTED RETRY SUCCESS FAILURE CANCELED"`
 	UserID  uint   `form:"user_id" binding:"omitempty"`
 	Page    int    `form:"page" binding:"omitempty,gte=1"`
 	PerPage int    `form:"per_page" binding:"omitempty,gte=1,lte=50"`
@@ -59,3 +63,28 @@ type PreheatArgs struct {
 	Filter  string            `json:"filter" binding:"omitempty"`
 	Headers map[string]string `json:"headers" binding:"omitempty"`
 }
+
+type CreatePersistentCacheJobRequest struct {
+	BIO                 string                 `json:"bio" binding:"omitempty"`
+	Type                string                 `json:"type" binding:"required"`
+	Args                PersistentCacheArgs    `json:"args" binding:"omitempty"`
+	Result              map[string]interface{} `json:"result" binding:"omitempty"`
+	UserID              uint                   `json:"user_id" binding:"omitempty"`
+	SchedulerClusterIDs []uint                 `json:"scheduler_cluster_ids" binding:"omitempty"`
+}
+
+type PersistentCacheArgs struct {
+	URL          string            `json:"url" binding:"required"`
+	Tag          string            `json:"tag" binding:"omitempty"`
+	Digest       string            `json:"digest" binding:"omitempty"`
+	Filter       string            `json:"filter" binding:"omitempty"`
+	Headers      map[string]string `json:"headers" binding:"omitempty"`
+	ReplicaCount int32             `json:"replica_count" binding:"required,gte=1"`
+	TTL          int64             `json:"ttl" binding:"required,gte=1"`
+}
+
+type PreheatJobProgress struct {
+	CompletedLength int64                          `json:"completed_length"`
+	ContentLength   int64                          `json:"content_length"`
+	Preheats        []*internaljob.PreheatProgress `json:"preheats"`
+}
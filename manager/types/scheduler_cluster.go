@@ -16,6 +16,12 @@
 
 package types
 
+import (
+	"fmt"
+	"net"
+	"regexp"
+)
+
 type SchedulerClusterParams struct {
 	ID uint `uri:"id" binding:"required"`
 }
@@ -54,7 +60,89 @@ type GetSchedulerClustersQuery struct {
 }
 
 type SchedulerClusterConfig struct {
-	FilterParentLimit uint32 `yaml:"filterParentLimit" mapstructure:"filterParentLimit" json:"filter_parent_limit" binding:"omitempty,gte=1,lte=100"`
+	FilterParentLimit      uint32       `yaml:"filterParentLimit" mapstructure:"filterParentLimit" json:"filter_parent_limit" binding:"omitempty,gte=1,lte=100"`
+	URLPolicies            []*URLPolicy `yaml:"urlPolicies" mapstructure:"urlPolicies" json:"url_policies" binding:"omitempty,dive"`
+	URLPolicyDefaultAction string       `yaml:"urlPolicyDefaultAction" mapstructure:"urlPolicyDefaultAction" json:"url_policy_default_action" binding:"omitempty,oneof=allow direct deny"`
+	// MaxBackToSourcePeersPerOrigin is the maximum number of concurrent back-to-source peers per origin host,
+	// it is the limit of the whole cluster, each active scheduler enforces its share divided by the number of active schedulers.
+	MaxBackToSourcePeersPerOrigin uint32 `yaml:"maxBackToSourcePeersPerOrigin" mapstructure:"maxBackToSourcePeersPerOrigin" json:"max_back_to_source_peers_per_origin" binding:"omitempty"`
+	// MaxBackToSourceBandwidthPerOrigin is the maximum back-to-source bandwidth in bytes per second per origin host,
+	// it is the limit of the whole cluster, each active scheduler enforces its share divided by the number of active schedulers.
+	MaxBackToSourceBandwidthPerOrigin uint64 `yaml:"maxBackToSourceBandwidthPerOrigin" mapstructure:"maxBackToSourceBandwidthPerOrigin" json:"max_back_to_source_bandwidth_per_origin" binding:"omitempty"`
+	ScoreExpression                   string `yaml:"scoreExpression" mapstructure:"scoreExpression" json:"score_expression" binding:"omitempty"`
+	FilterExpression                  string `yaml:"filterExpression" mapstructure:"filterExpression" json:"filter_expression" binding:"omitempty"`
+}
+
+const (
+	// URLPolicyTypeRegex matches the url by regular expression.
+	URLPolicyTypeRegex = "regex"
+
+	// URLPolicyTypeHost matches the host of url, pattern like *.example.com matches subdomains.
+	URLPolicyTypeHost = "host"
+
+	// URLPolicyTypeCIDR matches the ip of url host by cidr.
+	URLPolicyTypeCIDR = "cidr"
+)
+
+const (
+	// URLPolicyActionAllow allows the url to be downloaded through p2p.
+	URLPolicyActionAllow = "allow"
+
+	// URLPolicyActionDirect makes the url downloaded from source directly.
+	URLPolicyActionDirect = "direct"
+
+	// URLPolicyActionDeny rejects the url.
+	URLPolicyActionDeny = "deny"
+)
+
+type URLPolicy struct {
+	Type    string `yaml:"type" mapstructure:"type" json:"type" binding:"required,oneof=regex host cidr"`
+	Pattern string `yaml:"pattern" mapstructure:"pattern" json:"pattern" binding:"required"`
+	Action  string `yaml:"action" mapstructure:"action" json:"action" binding:"required,oneof=allow direct deny"`
+}
+
+// Validate checks the url policies of scheduler cluster config,
+// so the invalid policies are rejected when they are written rather than skipped by schedulers.
+func (c *SchedulerClusterConfig) Validate() error {
+	for i, policy := range c.URLPolicies {
+		if policy == nil {
+			return fmt.Errorf("url policy %d is empty", i)
+		}
+
+		if err := policy.Validate(); err != nil {
+			return fmt.Errorf("url policy %d is invalid: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the type and action of url policy, and whether the pattern can be parsed by its type.
+func (p *URLPolicy) Validate() error {
+	switch p.Type {
+	case URLPolicyTypeRegex:
+		if _, err := regexp.Compile(p.Pattern); err != nil {
+			return err
+		}
+	case URLPolicyTypeHost:
+		if p.Pattern == "" {
+			return fmt.Errorf("empty host pattern")
+		}
+	case URLPolicyTypeCIDR:
+		if _, _, err := net.ParseCIDR(p.Pattern); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid url policy type %s", p.Type)
+	}
+
+	switch p.Action {
+	case URLPolicyActionAllow, URLPolicyActionDirect, URLPolicyActionDeny:
+	default:
+		return fmt.Errorf("invalid url policy action %s", p.Action)
+	}
+
+	return nil
 }
 
 type SchedulerClusterClientConfig struct {
@@ -137,6 +137,8 @@ func (m *UrlMeta) Validate() error {
 
 	// no validation rules for Header
 
+	// no validation rules for Application
+
 	return nil
 }
 
@@ -27,6 +27,7 @@ import (
 
 var EndOfPiece = int32(1) << 30
 var BeginOfPiece = int32(-1)
+var ResumeOfPiece = int32(-2)
 
 func NewGrpcDfError(code base.Code, msg string) *base.GrpcDfError {
 	return &base.GrpcDfError{
@@ -71,6 +71,10 @@ func (m *SeedRequest) Validate() error {
 		}
 	}
 
+	// no validation rules for PersistentTtl
+
+	// no validation rules for FinishedPieces
+
 	return nil
 }
 
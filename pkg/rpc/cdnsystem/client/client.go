@@ -63,6 +63,8 @@ func GetElasticClientByAddrs(addrs []dfnet.NetAddr, opts ...grpc.DialOption) (Cd
 type CdnClient interface {
 	ObtainSeeds(ctx context.Context, sr *cdnsystem.SeedRequest, opts ...grpc.CallOption) (*PieceSeedStream, error)
 
+	ObtainSeedsByAddr(ctx context.Context, addr dfnet.NetAddr, sr *cdnsystem.SeedRequest, opts ...grpc.CallOption) (cdnsystem.Seeder_ObtainSeedsClient, error)
+
 	GetPieceTasks(ctx context.Context, addr dfnet.NetAddr, req *base.PieceTaskRequest, opts ...grpc.CallOption) (*base.PiecePacket, error)
 
 	SyncPieceTasks(ctx context.Context, addr dfnet.NetAddr, ptr *base.PieceTaskRequest, opts ...grpc.CallOption) (cdnsystem.Seeder_SyncPieceTasksClient, error)
@@ -98,6 +100,17 @@ func (cc *cdnClient) ObtainSeeds(ctx context.Context, sr *cdnsystem.SeedRequest,
 	return newPieceSeedStream(ctx, cc, sr.TaskId, sr, opts)
 }
 
+// ObtainSeedsByAddr obtains seeds from the cdn node of addr,
+// the stream will not be migrated to other cdn nodes when it fails.
+func (cc *cdnClient) ObtainSeedsByAddr(ctx context.Context, addr dfnet.NetAddr, sr *cdnsystem.SeedRequest, opts ...grpc.CallOption) (cdnsystem.Seeder_ObtainSeedsClient, error) {
+	client, err := cc.getSeederClientWithTarget(addr.GetEndpoint())
+	if err != nil {
+		return nil, err
+	}
+	cc.UpdateAccessNodeMapByServerNode(addr.GetEndpoint())
+	return client.ObtainSeeds(ctx, sr, opts...)
+}
+
 func (cc *cdnClient) GetPieceTasks(ctx context.Context, addr dfnet.NetAddr, req *base.PieceTaskRequest, opts ...grpc.CallOption) (*base.PiecePacket, error) {
 	client, err := cc.getSeederClientWithTarget(addr.GetEndpoint())
 	if err != nil {
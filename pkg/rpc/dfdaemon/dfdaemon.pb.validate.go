@@ -117,6 +117,8 @@ func (m *DownRequest) Validate() error {
 
 	// no validation rules for KeepOriginalOffset
 
+	// no validation rules for Priority
+
 	return nil
 }
 
@@ -391,6 +393,8 @@ func (m *ImportTaskRequest) Validate() error {
 		}
 	}
 
+	// no validation rules for Pin
+
 	return nil
 }
 
@@ -588,6 +592,8 @@ func (m *DeleteTaskRequest) Validate() error {
 		}
 	}
 
+	// no validation rules for Unpin
+
 	return nil
 }
 
@@ -66,6 +66,9 @@ type Client interface {
 	// List buckets configuration.
 	ListBuckets(*manager.ListBucketsRequest) (*manager.ListBucketsResponse, error)
 
+	// Get the encryption key of task data.
+	GetEncryptionKey(*manager.GetEncryptionKeyRequest) (*manager.EncryptionKey, error)
+
 	// KeepAlive with manager.
 	KeepAlive(time.Duration, *manager.KeepAliveRequest)
 
@@ -79,24 +82,27 @@ type client struct {
 	conn *grpc.ClientConn
 }
 
-// New creates manager client>
-func New(target string) (Client, error) {
+// New creates manager client, the connection is insecure
+// unless the transport credentials are given by options.
+func New(target string, opts ...grpc.DialOption) (Client, error) {
 	conn, err := grpc.Dial(
 		target,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithBlock(),
-		grpc.WithConnectParams(grpc.ConnectParams{
-			Backoff: backoff.Config{
-				BaseDelay:  backoffBaseDelay,
-				Multiplier: backoffMultiplier,
-				Jitter:     backoffJitter,
-				MaxDelay:   backoffMaxDelay,
-			},
-		}),
-		grpc.WithStreamInterceptor(grpc_middleware.ChainStreamClient(
-			grpc_prometheus.StreamClientInterceptor,
-			grpc_zap.StreamClientInterceptor(logger.GrpcLogger.Desugar()),
-		)),
+		append([]grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithBlock(),
+			grpc.WithConnectParams(grpc.ConnectParams{
+				Backoff: backoff.Config{
+					BaseDelay:  backoffBaseDelay,
+					Multiplier: backoffMultiplier,
+					Jitter:     backoffJitter,
+					MaxDelay:   backoffMaxDelay,
+				},
+			}),
+			grpc.WithStreamInterceptor(grpc_middleware.ChainStreamClient(
+				grpc_prometheus.StreamClientInterceptor,
+				grpc_zap.StreamClientInterceptor(logger.GrpcLogger.Desugar()),
+			)),
+		}, opts...)...,
 	)
 	if err != nil {
 		return nil, err
@@ -109,12 +115,12 @@ func New(target string) (Client, error) {
 }
 
 // NewWithAddrs creates manager client with addresses.
-func NewWithAddrs(netAddrs []dfnet.NetAddr) (Client, error) {
+func NewWithAddrs(netAddrs []dfnet.NetAddr, opts ...grpc.DialOption) (Client, error) {
 	for _, netAddr := range netAddrs {
 		ipReachable := reachable.New(&reachable.Config{Address: netAddr.Addr})
 		if err := ipReachable.Check(); err == nil {
 			logger.Infof("use %s address for manager grpc client", netAddr.Addr)
-			return New(netAddr.Addr)
+			return New(netAddr.Addr, opts...)
 		}
 		logger.Warnf("%s manager address can not reachable", netAddr.Addr)
 	}
@@ -170,6 +176,14 @@ func (c *client) ListBuckets(req *manager.ListBucketsRequest) (*manager.ListBuck
 	return c.ManagerClient.ListBuckets(ctx, req)
 }
 
+// Get the encryption key of task data.
+func (c *client) GetEncryptionKey(req *manager.GetEncryptionKeyRequest) (*manager.EncryptionKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	return c.ManagerClient.GetEncryptionKey(ctx, req)
+}
+
 // List acitve schedulers configuration.
 func (c *client) KeepAlive(interval time.Duration, keepalive *manager.KeepAliveRequest) {
 retry:
@@ -49,6 +49,21 @@ func (mr *MockClientMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockClient)(nil).Close))
 }
 
+// GetEncryptionKey mocks base method.
+func (m *MockClient) GetEncryptionKey(arg0 *manager.GetEncryptionKeyRequest) (*manager.EncryptionKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEncryptionKey", arg0)
+	ret0, _ := ret[0].(*manager.EncryptionKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEncryptionKey indicates an expected call of GetEncryptionKey.
+func (mr *MockClientMockRecorder) GetEncryptionKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEncryptionKey", reflect.TypeOf((*MockClient)(nil).GetEncryptionKey), arg0)
+}
+
 // GetObjectStorage mocks base method.
 func (m *MockClient) GetObjectStorage(arg0 *manager.GetObjectStorageRequest) (*manager.ObjectStorage, error) {
 	m.ctrl.T.Helper()
@@ -639,6 +639,8 @@ func (m *SchedulerCluster) Validate() error {
 		}
 	}
 
+	// no validation rules for ActiveSchedulerCount
+
 	return nil
 }
 
@@ -750,6 +752,21 @@ func (m *Scheduler) Validate() error {
 
 	// no validation rules for NetTopology
 
+	for idx, item := range m.GetApplications() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SchedulerValidationError{
+					field:  fmt.Sprintf("Applications[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	return nil
 }
 
@@ -807,6 +824,81 @@ var _ interface {
 	ErrorName() string
 } = SchedulerValidationError{}
 
+// Validate checks the field values on Application with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *Application) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	// no validation rules for Id
+
+	// no validation rules for Name
+
+	// no validation rules for Url
+
+	// no validation rules for Bio
+
+	// no validation rules for Quota
+
+	return nil
+}
+
+// ApplicationValidationError is the validation error returned by
+// Application.Validate if the designated constraints aren't met.
+type ApplicationValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ApplicationValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ApplicationValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ApplicationValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ApplicationValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ApplicationValidationError) ErrorName() string { return "ApplicationValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ApplicationValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sApplication.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ApplicationValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ApplicationValidationError{}
+
 // Validate checks the field values on GetSchedulerRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, an error is returned.
@@ -1826,6 +1918,199 @@ var _ interface {
 	ErrorName() string
 } = ListBucketsResponseValidationError{}
 
+// Validate checks the field values on EncryptionKey with the rules defined in
+// the proto definition for this message. If any rules are violated, an error is
+// returned.
+func (m *EncryptionKey) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if len(m.GetKey()) != 32 {
+		return EncryptionKeyValidationError{
+			field:  "Key",
+			reason: "value length must be 32 bytes",
+		}
+	}
+
+	return nil
+}
+
+// EncryptionKeyValidationError is the validation error returned by
+// EncryptionKey.Validate if the designated constraints aren't met.
+type EncryptionKeyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EncryptionKeyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EncryptionKeyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EncryptionKeyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EncryptionKeyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EncryptionKeyValidationError) ErrorName() string {
+	return "EncryptionKeyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e EncryptionKeyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEncryptionKey.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EncryptionKeyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EncryptionKeyValidationError{}
+
+// Validate checks the field values on GetEncryptionKeyRequest with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// an error is returned.
+func (m *GetEncryptionKeyRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if _, ok := SourceType_name[int32(m.GetSourceType())]; !ok {
+		return GetEncryptionKeyRequestValidationError{
+			field:  "SourceType",
+			reason: "value must be one of the defined enum values",
+		}
+	}
+
+	if err := m._validateHostname(m.GetHostName()); err != nil {
+		return GetEncryptionKeyRequestValidationError{
+			field:  "HostName",
+			reason: "value must be a valid hostname",
+			cause:  err,
+		}
+	}
+
+	if ip := net.ParseIP(m.GetIp()); ip == nil {
+		return GetEncryptionKeyRequestValidationError{
+			field:  "Ip",
+			reason: "value must be a valid IP address",
+		}
+	}
+
+	return nil
+}
+
+func (m *GetEncryptionKeyRequest) _validateHostname(host string) error {
+	s := strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if len(host) > 253 {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	for _, part := range strings.Split(s, ".") {
+		if l := len(part); l == 0 || l > 63 {
+			return errors.New("hostname part must be non-empty and cannot exceed 63 characters")
+		}
+
+		if part[0] == '-' {
+			return errors.New("hostname parts cannot begin with hyphens")
+		}
+
+		if part[len(part)-1] == '-' {
+			return errors.New("hostname parts cannot end with hyphens")
+		}
+
+		for _, r := range part {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Errorf("hostname parts can only contain alphanumeric characters or hyphens, got %q", string(r))
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetEncryptionKeyRequestValidationError is the validation error returned by
+// GetEncryptionKeyRequest.Validate if the designated constraints aren't met.
+type GetEncryptionKeyRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetEncryptionKeyRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetEncryptionKeyRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetEncryptionKeyRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetEncryptionKeyRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetEncryptionKeyRequestValidationError) ErrorName() string {
+	return "GetEncryptionKeyRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetEncryptionKeyRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetEncryptionKeyRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetEncryptionKeyRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetEncryptionKeyRequestValidationError{}
+
 // Validate checks the field values on KeepAliveRequest with the rules defined
 // in the proto definition for this message. If any rules are violated, an
 // error is returned.
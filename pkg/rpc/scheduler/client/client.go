@@ -56,6 +56,18 @@ func NewEndOfPiece(taskID, peerID string, finishedCount int32) *scheduler.PieceR
 	}
 }
 
+// NewResumeOfPiece creates resume of piece.
+func NewResumeOfPiece(taskID, peerID string, finishedPieces []byte) *scheduler.PieceResult {
+	return &scheduler.PieceResult{
+		TaskId:         taskID,
+		SrcPid:         peerID,
+		FinishedPieces: finishedPieces,
+		PieceInfo: &base.PieceInfo{
+			PieceNum: common.ResumeOfPiece,
+		},
+	}
+}
+
 // GetClientByAddr gets scheduler clients with net addresses.
 func GetClientByAddr(addrs []dfnet.NetAddr, opts ...grpc.DialOption) (Client, error) {
 	if len(addrs) == 0 {
@@ -79,6 +91,9 @@ type Client interface {
 	// ReportPieceResult reports piece results and receives peer packets.
 	ReportPieceResult(context.Context, *scheduler.PeerTaskRequest, ...grpc.CallOption) (scheduler.Scheduler_ReportPieceResultClient, error)
 
+	// ResumePieceResult reopens the broken stream of reporting piece results with the finished pieces bitmap.
+	ResumePieceResult(context.Context, *scheduler.PeerTaskRequest, []byte, ...grpc.CallOption) (scheduler.Scheduler_ReportPieceResultClient, error)
+
 	// ReportPeerResult reports downloading result for the peer.
 	ReportPeerResult(context.Context, *scheduler.PeerResult, ...grpc.CallOption) error
 
@@ -91,6 +106,9 @@ type Client interface {
 	// A peer announces that it has the announced task to other peers.
 	AnnounceTask(context.Context, *scheduler.AnnounceTaskRequest, ...grpc.CallOption) error
 
+	// SyncProbes reports the results of probing other hosts and returns target hosts to be probed.
+	SyncProbes(context.Context, *scheduler.SyncProbesRequest, ...grpc.CallOption) (*scheduler.SyncProbesResponse, error)
+
 	// Update grpc addresses.
 	UpdateState([]dfnet.NetAddr)
 
@@ -149,6 +167,23 @@ func (sc *client) ReportPieceResult(ctx context.Context, req *scheduler.PeerTask
 	return stream, stream.Send(NewBeginOfPiece(req.TaskId, req.PeerId))
 }
 
+// ResumePieceResult reopens the broken stream of reporting piece results with the finished pieces bitmap,
+// scheduler re-attaches the peer to the stream instead of registering a new peer.
+func (sc *client) ResumePieceResult(ctx context.Context, req *scheduler.PeerTaskRequest, finishedPieces []byte, opts ...grpc.CallOption) (scheduler.Scheduler_ReportPieceResultClient, error) {
+	client, target, err := sc.getClient(req.TaskId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := client.ReportPieceResult(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.WithTaskAndPeerID(req.TaskId, req.PeerId).Infof("resume piece result with %s request: %#v", target, req)
+	return stream, stream.Send(NewResumeOfPiece(req.TaskId, req.PeerId, finishedPieces))
+}
+
 // ReportPeerResult reports downloading result for the peer.
 func (sc *client) ReportPeerResult(ctx context.Context, req *scheduler.PeerResult, opts ...grpc.CallOption) error {
 	client, target, err := sc.getClient(req.TaskId, false)
@@ -209,3 +244,52 @@ func (sc *client) AnnounceTask(ctx context.Context, req *scheduler.AnnounceTaskR
 
 	return nil
 }
+
+// SyncProbes reports the results of probing other hosts to all schedulers,
+// because every scheduler maintains its own latency matrix of hosts.
+// The target hosts returned by schedulers are merged.
+func (sc *client) SyncProbes(ctx context.Context, req *scheduler.SyncProbesRequest, opts ...grpc.CallOption) (*scheduler.SyncProbesResponse, error) {
+	var (
+		resp      = &scheduler.SyncProbesResponse{}
+		hostIDs   = map[string]struct{}{req.Host.Id: {}}
+		succeeded bool
+		lastErr   = errors.New("scheduler addresses are empty")
+	)
+
+	for _, addr := range sc.GetState() {
+		clientConn, err := sc.Connection.GetClientConnByTarget(addr.GetEndpoint())
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		logger.Debugf("sync probes with %s request: %#v", addr.GetEndpoint(), req)
+		r, err := scheduler.NewSchedulerClient(clientConn).SyncProbes(ctx, req, opts...)
+		if err != nil {
+			logger.Warnf("sync probes with %s failed: %s", addr.GetEndpoint(), err.Error())
+			lastErr = err
+			continue
+		}
+
+		for _, target := range r.Targets {
+			if _, ok := hostIDs[target.HostId]; ok {
+				continue
+			}
+
+			hostIDs[target.HostId] = struct{}{}
+			resp.Targets = append(resp.Targets, target)
+		}
+
+		// Use the minimum interval of schedulers.
+		if r.Interval > 0 && (resp.Interval == 0 || r.Interval < resp.Interval) {
+			resp.Interval = r.Interval
+		}
+		succeeded = true
+	}
+
+	if !succeeded {
+		return nil, lastErr
+	}
+
+	return resp, nil
+}
@@ -162,6 +162,26 @@ func (mr *MockClientMockRecorder) ReportPieceResult(arg0, arg1 interface{}, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportPieceResult", reflect.TypeOf((*MockClient)(nil).ReportPieceResult), varargs...)
 }
 
+// ResumePieceResult mocks base method.
+func (m *MockClient) ResumePieceResult(arg0 context.Context, arg1 *scheduler.PeerTaskRequest, arg2 []byte, arg3 ...grpc.CallOption) (scheduler.Scheduler_ReportPieceResultClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ResumePieceResult", varargs...)
+	ret0, _ := ret[0].(scheduler.Scheduler_ReportPieceResultClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumePieceResult indicates an expected call of ResumePieceResult.
+func (mr *MockClientMockRecorder) ResumePieceResult(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumePieceResult", reflect.TypeOf((*MockClient)(nil).ResumePieceResult), varargs...)
+}
+
 // StatTask mocks base method.
 func (m *MockClient) StatTask(arg0 context.Context, arg1 *scheduler.StatTaskRequest, arg2 ...grpc.CallOption) (*scheduler.Task, error) {
 	m.ctrl.T.Helper()
@@ -182,6 +202,26 @@ func (mr *MockClientMockRecorder) StatTask(arg0, arg1 interface{}, arg2 ...inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatTask", reflect.TypeOf((*MockClient)(nil).StatTask), varargs...)
 }
 
+// SyncProbes mocks base method.
+func (m *MockClient) SyncProbes(arg0 context.Context, arg1 *scheduler.SyncProbesRequest, arg2 ...grpc.CallOption) (*scheduler.SyncProbesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SyncProbes", varargs...)
+	ret0, _ := ret[0].(*scheduler.SyncProbesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncProbes indicates an expected call of SyncProbes.
+func (mr *MockClientMockRecorder) SyncProbes(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncProbes", reflect.TypeOf((*MockClient)(nil).SyncProbes), varargs...)
+}
+
 // UpdateState mocks base method.
 func (m *MockClient) UpdateState(arg0 []dfnet.NetAddr) {
 	m.ctrl.T.Helper()
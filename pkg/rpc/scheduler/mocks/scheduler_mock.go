@@ -193,6 +193,26 @@ func (mr *MockSchedulerClientMockRecorder) StatTask(ctx, in interface{}, opts ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatTask", reflect.TypeOf((*MockSchedulerClient)(nil).StatTask), varargs...)
 }
 
+// SyncProbes mocks base method.
+func (m *MockSchedulerClient) SyncProbes(ctx context.Context, in *scheduler.SyncProbesRequest, opts ...grpc.CallOption) (*scheduler.SyncProbesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SyncProbes", varargs...)
+	ret0, _ := ret[0].(*scheduler.SyncProbesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncProbes indicates an expected call of SyncProbes.
+func (mr *MockSchedulerClientMockRecorder) SyncProbes(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncProbes", reflect.TypeOf((*MockSchedulerClient)(nil).SyncProbes), varargs...)
+}
+
 // MockScheduler_ReportPieceResultClient is a mock of Scheduler_ReportPieceResultClient interface.
 type MockScheduler_ReportPieceResultClient struct {
 	ctrl     *gomock.Controller
@@ -442,6 +462,21 @@ func (mr *MockSchedulerServerMockRecorder) StatTask(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatTask", reflect.TypeOf((*MockSchedulerServer)(nil).StatTask), arg0, arg1)
 }
 
+// SyncProbes mocks base method.
+func (m *MockSchedulerServer) SyncProbes(arg0 context.Context, arg1 *scheduler.SyncProbesRequest) (*scheduler.SyncProbesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncProbes", arg0, arg1)
+	ret0, _ := ret[0].(*scheduler.SyncProbesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncProbes indicates an expected call of SyncProbes.
+func (mr *MockSchedulerServerMockRecorder) SyncProbes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncProbes", reflect.TypeOf((*MockSchedulerServer)(nil).SyncProbes), arg0, arg1)
+}
+
 // MockScheduler_ReportPieceResultServer is a mock of Scheduler_ReportPieceResultServer interface.
 type MockScheduler_ReportPieceResultServer struct {
 	ctrl     *gomock.Controller
@@ -119,6 +119,8 @@ func (m *PeerTaskRequest) Validate() error {
 		}
 	}
 
+	// no validation rules for Priority
+
 	return nil
 }
 
@@ -577,6 +579,8 @@ func (m *PieceResult) Validate() error {
 		}
 	}
 
+	// no validation rules for FinishedPieces
+
 	return nil
 }
 
@@ -1253,6 +1257,359 @@ var _ interface {
 	ErrorName() string
 } = AnnounceTaskRequestValidationError{}
 
+// Validate checks the field values on Probe with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *Probe) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetHostId()) < 1 {
+		return ProbeValidationError{
+			field:  "HostId",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	if m.GetRtt() <= 0 {
+		return ProbeValidationError{
+			field:  "Rtt",
+			reason: "value must be greater than 0",
+		}
+	}
+
+	return nil
+}
+
+// ProbeValidationError is the validation error returned by
+// Probe.Validate if the designated constraints aren't met.
+type ProbeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ProbeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ProbeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ProbeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ProbeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ProbeValidationError) ErrorName() string { return "ProbeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ProbeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sProbe.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ProbeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ProbeValidationError{}
+
+// Validate checks the field values on SyncProbesRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *SyncProbesRequest) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if m.GetHost() == nil {
+		return SyncProbesRequestValidationError{
+			field:  "Host",
+			reason: "value is required",
+		}
+	}
+
+	if v, ok := interface{}(m.GetHost()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SyncProbesRequestValidationError{
+				field:  "Host",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetProbes() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SyncProbesRequestValidationError{
+					field:  fmt.Sprintf("Probes[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// SyncProbesRequestValidationError is the validation error returned by
+// SyncProbesRequest.Validate if the designated constraints aren't met.
+type SyncProbesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SyncProbesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SyncProbesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SyncProbesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SyncProbesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SyncProbesRequestValidationError) ErrorName() string {
+	return "SyncProbesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SyncProbesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSyncProbesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SyncProbesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SyncProbesRequestValidationError{}
+
+// Validate checks the field values on ProbeTarget with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *ProbeTarget) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	if utf8.RuneCountInString(m.GetHostId()) < 1 {
+		return ProbeTargetValidationError{
+			field:  "HostId",
+			reason: "value length must be at least 1 runes",
+		}
+	}
+
+	if ip := net.ParseIP(m.GetIp()); ip == nil {
+		return ProbeTargetValidationError{
+			field:  "Ip",
+			reason: "value must be a valid IP address",
+		}
+	}
+
+	if val := m.GetRpcPort(); val < 1024 || val >= 65535 {
+		return ProbeTargetValidationError{
+			field:  "RpcPort",
+			reason: "value must be inside range [1024, 65535)",
+		}
+	}
+
+	return nil
+}
+
+// ProbeTargetValidationError is the validation error returned by
+// ProbeTarget.Validate if the designated constraints aren't met.
+type ProbeTargetValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ProbeTargetValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ProbeTargetValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ProbeTargetValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ProbeTargetValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ProbeTargetValidationError) ErrorName() string { return "ProbeTargetValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ProbeTargetValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sProbeTarget.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ProbeTargetValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ProbeTargetValidationError{}
+
+// Validate checks the field values on SyncProbesResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, an error
+// is returned.
+func (m *SyncProbesResponse) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	for idx, item := range m.GetTargets() {
+		_, _ = idx, item
+
+		if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SyncProbesResponseValidationError{
+					field:  fmt.Sprintf("Targets[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if m.GetInterval() < 0 {
+		return SyncProbesResponseValidationError{
+			field:  "Interval",
+			reason: "value must be greater than or equal to 0",
+		}
+	}
+
+	return nil
+}
+
+// SyncProbesResponseValidationError is the validation error returned by
+// SyncProbesResponse.Validate if the designated constraints aren't met.
+type SyncProbesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SyncProbesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SyncProbesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SyncProbesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SyncProbesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SyncProbesResponseValidationError) ErrorName() string {
+	return "SyncProbesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SyncProbesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSyncProbesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SyncProbesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SyncProbesResponseValidationError{}
+
 // Validate checks the field values on PeerPacket_DestPeer with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, an error is returned.
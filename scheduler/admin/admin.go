@@ -0,0 +1,176 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+
+	"d7y.io/dragonfly/v2/scheduler/config"
+	"d7y.io/dragonfly/v2/scheduler/resource"
+)
+
+const (
+	// DAGFormatJSON is the json format of task dag.
+	DAGFormatJSON = "json"
+
+	// DAGFormatDOT is the graphviz dot format of task dag.
+	DAGFormatDOT = "dot"
+)
+
+type admin struct {
+	resource resource.Resource
+}
+
+// New returns the http server of scheduler admin api,
+// it helps operators to inspect hosts, tasks and peers in scheduler.
+func New(cfg *config.Config, resource resource.Resource) *http.Server {
+	if !cfg.Verbose {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	a := &admin{resource: resource}
+	return &http.Server{
+		Addr:    cfg.Admin.Addr,
+		Handler: a.initRouter(),
+	}
+}
+
+func (a *admin) initRouter() *gin.Engine {
+	r := gin.New()
+
+	// Middleware
+	r.Use(gin.Recovery())
+
+	// API
+	apiv1 := r.Group("/api/v1")
+
+	// Task
+	t := apiv1.Group("/tasks")
+	t.GET("", a.getTasks)
+	t.GET(":id", a.getTask)
+	t.GET(":id/peers", a.getTaskPeers)
+	t.GET(":id/dag", a.getTaskDAG)
+
+	// Host
+	h := apiv1.Group("/hosts")
+	h.GET("", a.getHosts)
+
+	return r
+}
+
+// getTasks lists all tasks.
+func (a *admin) getTasks(ctx *gin.Context) {
+	tasks := []*Task{}
+	a.resource.TaskManager().Range(func(_, value interface{}) bool {
+		if task, ok := value.(*resource.Task); ok {
+			tasks = append(tasks, newTask(task))
+		}
+		return true
+	})
+
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].CreateAt.Before(tasks[j].CreateAt) })
+	ctx.JSON(http.StatusOK, tasks)
+}
+
+// getTask returns task by id.
+func (a *admin) getTask(ctx *gin.Context) {
+	var params TaskParams
+	if err := ctx.ShouldBindUri(&params); err != nil {
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+		return
+	}
+
+	task, ok := a.resource.TaskManager().Load(params.ID)
+	if !ok {
+		ctx.JSON(http.StatusNotFound, gin.H{"errors": "task not found"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newTask(task))
+}
+
+// getTaskPeers lists peers of task.
+func (a *admin) getTaskPeers(ctx *gin.Context) {
+	var params TaskParams
+	if err := ctx.ShouldBindUri(&params); err != nil {
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+		return
+	}
+
+	task, ok := a.resource.TaskManager().Load(params.ID)
+	if !ok {
+		ctx.JSON(http.StatusNotFound, gin.H{"errors": "task not found"})
+		return
+	}
+
+	peers := []*Peer{}
+	task.Peers.Range(func(_, value interface{}) bool {
+		if peer, ok := value.(*resource.Peer); ok {
+			peers = append(peers, newPeer(peer))
+		}
+		return true
+	})
+
+	sort.Slice(peers, func(i, j int) bool { return peers[i].CreateAt.Before(peers[j].CreateAt) })
+	ctx.JSON(http.StatusOK, peers)
+}
+
+// getTaskDAG exports the parent dag of task.
+func (a *admin) getTaskDAG(ctx *gin.Context) {
+	var params TaskParams
+	if err := ctx.ShouldBindUri(&params); err != nil {
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+		return
+	}
+
+	var query DAGQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"errors": err.Error()})
+		return
+	}
+
+	task, ok := a.resource.TaskManager().Load(params.ID)
+	if !ok {
+		ctx.JSON(http.StatusNotFound, gin.H{"errors": "task not found"})
+		return
+	}
+
+	dag := newDAG(task)
+	switch query.Format {
+	case DAGFormatDOT:
+		ctx.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(dag.DOT()))
+	default:
+		ctx.JSON(http.StatusOK, dag)
+	}
+}
+
+// getHosts lists all hosts.
+func (a *admin) getHosts(ctx *gin.Context) {
+	hosts := []*Host{}
+	a.resource.HostManager().Range(func(_, value interface{}) bool {
+		if host, ok := value.(*resource.Host); ok {
+			hosts = append(hosts, newHost(host))
+		}
+		return true
+	})
+
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].ID < hosts[j].ID })
+	ctx.JSON(http.StatusOK, hosts)
+}
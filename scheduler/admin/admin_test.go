@@ -0,0 +1,286 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"d7y.io/dragonfly/v2/pkg/idgen"
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
+	rpcscheduler "d7y.io/dragonfly/v2/pkg/rpc/scheduler"
+	"d7y.io/dragonfly/v2/scheduler/resource"
+)
+
+var (
+	mockRawHost = &rpcscheduler.PeerHost{
+		Id:       idgen.HostID("hostname", 8003),
+		Ip:       "127.0.0.1",
+		RpcPort:  8003,
+		DownPort: 8001,
+		HostName: "hostname",
+		Idc:      "idc",
+	}
+
+	mockRawSeedHost = &rpcscheduler.PeerHost{
+		Id:       idgen.HostID("seed", 8003),
+		Ip:       "127.0.0.2",
+		RpcPort:  8003,
+		DownPort: 8001,
+		HostName: "seed",
+		Idc:      "idc",
+	}
+
+	mockTaskURLMeta = &base.UrlMeta{
+		Digest: "digest",
+		Tag:    "tag",
+		Range:  "range",
+		Filter: "filter",
+		Header: map[string]string{
+			"content-length": "100",
+		},
+	}
+
+	mockTaskURL    = "http://example.com/foo"
+	mockTaskID     = idgen.TaskID(mockTaskURL, mockTaskURLMeta)
+	mockPeerID     = idgen.PeerID("127.0.0.1")
+	mockSeedPeerID = idgen.SeedPeerID("127.0.0.2")
+)
+
+// newMockTask returns the task with seed peer as the parent of peer.
+func newMockTask() *resource.Task {
+	task := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta)
+	task.FSM.SetState(resource.TaskStateRunning)
+	task.TotalPieceCount.Store(2)
+	task.StorePiece(&base.PieceInfo{PieceNum: 0})
+
+	seedPeer := resource.NewPeer(mockSeedPeerID, task, resource.NewHost(mockRawSeedHost, resource.WithHostType(resource.HostTypeSuperSeed)))
+	seedPeer.FSM.SetState(resource.PeerStateBackToSource)
+	seedPeer.IsBackToSource.Store(true)
+	seedPeer.Pieces.Set(0)
+	task.StorePeer(seedPeer)
+
+	peer := resource.NewPeer(mockPeerID, task, resource.NewHost(mockRawHost))
+	peer.FSM.SetState(resource.PeerStateRunning)
+	peer.StoreParent(seedPeer)
+	task.StorePeer(peer)
+
+	return task
+}
+
+func TestAdmin_Tasks(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		mock   func(task *resource.Task, taskManager resource.TaskManager, mr *resource.MockResourceMockRecorder, mt *resource.MockTaskManagerMockRecorder)
+		expect func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "list tasks",
+			path: "/api/v1/tasks",
+			mock: func(task *resource.Task, taskManager resource.TaskManager, mr *resource.MockResourceMockRecorder, mt *resource.MockTaskManagerMockRecorder) {
+				gomock.InOrder(
+					mr.TaskManager().Return(taskManager).Times(1),
+					mt.Range(gomock.Any()).Do(func(f func(key, value interface{}) bool) {
+						f(task.ID, task)
+					}).Times(1),
+				)
+			},
+			expect: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert := assert.New(t)
+				assert.Equal(w.Code, http.StatusOK)
+
+				var tasks []*Task
+				assert.NoError(json.Unmarshal(w.Body.Bytes(), &tasks))
+				assert.Len(tasks, 1)
+				assert.Equal(tasks[0].ID, mockTaskID)
+				assert.Equal(tasks[0].State, resource.TaskStateRunning)
+				assert.Equal(tasks[0].PieceCount, 1)
+				assert.Equal(tasks[0].TotalPieceCount, int32(2))
+				assert.Equal(tasks[0].PeerCount, int32(2))
+			},
+		},
+		{
+			name: "get task",
+			path: "/api/v1/tasks/" + mockTaskID,
+			mock: func(task *resource.Task, taskManager resource.TaskManager, mr *resource.MockResourceMockRecorder, mt *resource.MockTaskManagerMockRecorder) {
+				gomock.InOrder(
+					mr.TaskManager().Return(taskManager).Times(1),
+					mt.Load(gomock.Eq(mockTaskID)).Return(task, true).Times(1),
+				)
+			},
+			expect: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert := assert.New(t)
+				assert.Equal(w.Code, http.StatusOK)
+
+				var task Task
+				assert.NoError(json.Unmarshal(w.Body.Bytes(), &task))
+				assert.Equal(task.ID, mockTaskID)
+				assert.Equal(task.URL, mockTaskURL)
+			},
+		},
+		{
+			name: "task not found",
+			path: "/api/v1/tasks/foo",
+			mock: func(task *resource.Task, taskManager resource.TaskManager, mr *resource.MockResourceMockRecorder, mt *resource.MockTaskManagerMockRecorder) {
+				gomock.InOrder(
+					mr.TaskManager().Return(taskManager).Times(1),
+					mt.Load(gomock.Eq("foo")).Return(nil, false).Times(1),
+				)
+			},
+			expect: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert := assert.New(t)
+				assert.Equal(w.Code, http.StatusNotFound)
+			},
+		},
+		{
+			name: "list peers of task",
+			path: "/api/v1/tasks/" + mockTaskID + "/peers",
+			mock: func(task *resource.Task, taskManager resource.TaskManager, mr *resource.MockResourceMockRecorder, mt *resource.MockTaskManagerMockRecorder) {
+				gomock.InOrder(
+					mr.TaskManager().Return(taskManager).Times(1),
+					mt.Load(gomock.Eq(mockTaskID)).Return(task, true).Times(1),
+				)
+			},
+			expect: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert := assert.New(t)
+				assert.Equal(w.Code, http.StatusOK)
+
+				var peers []*Peer
+				assert.NoError(json.Unmarshal(w.Body.Bytes(), &peers))
+				assert.Len(peers, 2)
+				for _, peer := range peers {
+					switch peer.ID {
+					case mockSeedPeerID:
+						assert.Equal(peer.State, resource.PeerStateBackToSource)
+						assert.Equal(peer.ChildIDs, []string{mockPeerID})
+						assert.Equal(peer.FinishedPieceCount, uint(1))
+						assert.True(peer.IsBackToSource)
+					case mockPeerID:
+						assert.Equal(peer.State, resource.PeerStateRunning)
+						assert.Equal(peer.ParentID, mockSeedPeerID)
+						assert.Empty(peer.ChildIDs)
+						assert.Equal(peer.Depth, 2)
+					default:
+						t.Errorf("unexpected peer %s", peer.ID)
+					}
+				}
+			},
+		},
+		{
+			name: "export dag of task in json",
+			path: "/api/v1/tasks/" + mockTaskID + "/dag",
+			mock: func(task *resource.Task, taskManager resource.TaskManager, mr *resource.MockResourceMockRecorder, mt *resource.MockTaskManagerMockRecorder) {
+				gomock.InOrder(
+					mr.TaskManager().Return(taskManager).Times(1),
+					mt.Load(gomock.Eq(mockTaskID)).Return(task, true).Times(1),
+				)
+			},
+			expect: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert := assert.New(t)
+				assert.Equal(w.Code, http.StatusOK)
+
+				var dag DAG
+				assert.NoError(json.Unmarshal(w.Body.Bytes(), &dag))
+				assert.Equal(dag.TaskID, mockTaskID)
+				assert.Len(dag.Nodes, 2)
+				assert.Equal(dag.Edges, []*DAGEdge{{Parent: mockSeedPeerID, Child: mockPeerID}})
+			},
+		},
+		{
+			name: "export dag of task in dot",
+			path: "/api/v1/tasks/" + mockTaskID + "/dag?format=dot",
+			mock: func(task *resource.Task, taskManager resource.TaskManager, mr *resource.MockResourceMockRecorder, mt *resource.MockTaskManagerMockRecorder) {
+				gomock.InOrder(
+					mr.TaskManager().Return(taskManager).Times(1),
+					mt.Load(gomock.Eq(mockTaskID)).Return(task, true).Times(1),
+				)
+			},
+			expect: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert := assert.New(t)
+				assert.Equal(w.Code, http.StatusOK)
+				assert.True(strings.HasPrefix(w.Header().Get("Content-Type"), "text/vnd.graphviz"))
+				assert.Contains(w.Body.String(), `"`+mockSeedPeerID+`" -> "`+mockPeerID+`";`)
+			},
+		},
+		{
+			name: "export dag of task in invalid format",
+			path: "/api/v1/tasks/" + mockTaskID + "/dag?format=foo",
+			mock: func(task *resource.Task, taskManager resource.TaskManager, mr *resource.MockResourceMockRecorder, mt *resource.MockTaskManagerMockRecorder) {
+			},
+			expect: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert := assert.New(t)
+				assert.Equal(w.Code, http.StatusUnprocessableEntity)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			res := resource.NewMockResource(ctl)
+			taskManager := resource.NewMockTaskManager(ctl)
+			tc.mock(newMockTask(), taskManager, res.EXPECT(), taskManager.EXPECT())
+
+			a := &admin{resource: res}
+			w := httptest.NewRecorder()
+			a.initRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			tc.expect(t, w)
+		})
+	}
+}
+
+func TestAdmin_Hosts(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	res := resource.NewMockResource(ctl)
+	hostManager := resource.NewMockHostManager(ctl)
+
+	host := resource.NewHost(mockRawHost, resource.WithUploadLoadLimit(10))
+	host.UploadPeerCount.Store(4)
+	host.CPURatio.Store(0.5)
+	gomock.InOrder(
+		res.EXPECT().HostManager().Return(hostManager).Times(1),
+		hostManager.EXPECT().Range(gomock.Any()).Do(func(f func(key, value interface{}) bool) {
+			f(host.ID, host)
+		}).Times(1),
+	)
+
+	a := &admin{resource: res}
+	w := httptest.NewRecorder()
+	a.initRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/hosts", nil))
+
+	assert := assert.New(t)
+	assert.Equal(w.Code, http.StatusOK)
+
+	var hosts []*Host
+	assert.NoError(json.Unmarshal(w.Body.Bytes(), &hosts))
+	assert.Len(hosts, 1)
+	assert.Equal(hosts[0].ID, mockRawHost.Id)
+	assert.Equal(hosts[0].Type, "normal")
+	assert.Equal(hosts[0].UploadLoadLimit, int32(10))
+	assert.Equal(hosts[0].UploadPeerCount, int32(4))
+	assert.Equal(hosts[0].FreeUploadLoad, int32(6))
+	assert.Equal(hosts[0].CPURatio, 0.5)
+}
@@ -0,0 +1,114 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"d7y.io/dragonfly/v2/scheduler/resource"
+)
+
+// DAG is the parent dag of peers in task.
+type DAG struct {
+	TaskID string     `json:"taskID"`
+	Nodes  []*DAGNode `json:"nodes"`
+	Edges  []*DAGEdge `json:"edges"`
+}
+
+// DAGNode is the peer in dag.
+type DAGNode struct {
+	ID                 string `json:"id"`
+	Hostname           string `json:"hostname"`
+	IP                 string `json:"ip"`
+	HostType           string `json:"hostType"`
+	State              string `json:"state"`
+	FinishedPieceCount uint   `json:"finishedPieceCount"`
+	IsBackToSource     bool   `json:"isBackToSource"`
+}
+
+// DAGEdge is the edge from parent to child.
+type DAGEdge struct {
+	Parent string `json:"parent"`
+	Child  string `json:"child"`
+}
+
+func newDAG(task *resource.Task) *DAG {
+	dag := &DAG{
+		TaskID: task.ID,
+		Nodes:  []*DAGNode{},
+		Edges:  []*DAGEdge{},
+	}
+
+	task.Peers.Range(func(_, value interface{}) bool {
+		peer, ok := value.(*resource.Peer)
+		if !ok {
+			return true
+		}
+
+		dag.Nodes = append(dag.Nodes, &DAGNode{
+			ID:                 peer.ID,
+			Hostname:           peer.Host.Hostname,
+			IP:                 peer.Host.IP,
+			HostType:           hostTypes[peer.Host.Type],
+			State:              peer.FSM.Current(),
+			FinishedPieceCount: peer.Pieces.Count(),
+			IsBackToSource:     peer.IsBackToSource.Load(),
+		})
+
+		if parent, ok := peer.LoadParent(); ok {
+			dag.Edges = append(dag.Edges, &DAGEdge{Parent: parent.ID, Child: peer.ID})
+		}
+
+		return true
+	})
+
+	sort.Slice(dag.Nodes, func(i, j int) bool { return dag.Nodes[i].ID < dag.Nodes[j].ID })
+	sort.Slice(dag.Edges, func(i, j int) bool {
+		if dag.Edges[i].Parent != dag.Edges[j].Parent {
+			return dag.Edges[i].Parent < dag.Edges[j].Parent
+		}
+		return dag.Edges[i].Child < dag.Edges[j].Child
+	})
+
+	return dag
+}
+
+// DOT returns the dag in graphviz dot format.
+func (d *DAG) DOT() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", strconv.Quote(d.TaskID))
+	b.WriteString("  node [shape=box];\n")
+	for _, node := range d.Nodes {
+		label := fmt.Sprintf("%s\n%s %s\n%s %d pieces", node.ID, node.Hostname, node.IP, node.State, node.FinishedPieceCount)
+		attrs := fmt.Sprintf("label=%s", strconv.Quote(label))
+		if node.IsBackToSource {
+			attrs += ", style=filled, fillcolor=lightgrey"
+		}
+
+		fmt.Fprintf(&b, "  %s [%s];\n", strconv.Quote(node.ID), attrs)
+	}
+
+	for _, edge := range d.Edges {
+		fmt.Fprintf(&b, "  %s -> %s;\n", strconv.Quote(edge.Parent), strconv.Quote(edge.Child))
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
@@ -0,0 +1,173 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"sort"
+	"time"
+
+	"d7y.io/dragonfly/v2/scheduler/resource"
+)
+
+type TaskParams struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+type DAGQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=json dot"`
+}
+
+// Task is the state of task in scheduler.
+type Task struct {
+	ID                    string    `json:"id"`
+	URL                   string    `json:"url"`
+	Type                  int       `json:"type"`
+	State                 string    `json:"state"`
+	ContentLength         int64     `json:"contentLength"`
+	TotalPieceCount       int32     `json:"totalPieceCount"`
+	PieceCount            int       `json:"pieceCount"`
+	PeerCount             int32     `json:"peerCount"`
+	BackToSourcePeerCount uint      `json:"backToSourcePeerCount"`
+	BackToSourceLimit     int32     `json:"backToSourceLimit"`
+	PeerFailedCount       int32     `json:"peerFailedCount"`
+	CreateAt              time.Time `json:"createAt"`
+	UpdateAt              time.Time `json:"updateAt"`
+}
+
+func newTask(task *resource.Task) *Task {
+	var pieceCount int
+	task.Pieces.Range(func(_, _ interface{}) bool {
+		pieceCount++
+		return true
+	})
+
+	return &Task{
+		ID:                    task.ID,
+		URL:                   task.URL,
+		Type:                  task.Type,
+		State:                 task.FSM.Current(),
+		ContentLength:         task.ContentLength.Load(),
+		TotalPieceCount:       task.TotalPieceCount.Load(),
+		PieceCount:            pieceCount,
+		PeerCount:             task.PeerCount.Load(),
+		BackToSourcePeerCount: task.BackToSourcePeers.Len(),
+		BackToSourceLimit:     task.BackToSourceLimit.Load(),
+		PeerFailedCount:       task.PeerFailedCount.Load(),
+		CreateAt:              task.CreateAt.Load(),
+		UpdateAt:              task.UpdateAt.Load(),
+	}
+}
+
+// Peer is the state of peer in scheduler.
+type Peer struct {
+	ID                 string    `json:"id"`
+	BizTag             string    `json:"bizTag"`
+	TaskID             string    `json:"taskID"`
+	HostID             string    `json:"hostID"`
+	State              string    `json:"state"`
+	ParentID           string    `json:"parentID,omitempty"`
+	ChildIDs           []string  `json:"childIDs"`
+	FinishedPieceCount uint      `json:"finishedPieceCount"`
+	IsBackToSource     bool      `json:"isBackToSource"`
+	Depth              int       `json:"depth"`
+	CreateAt           time.Time `json:"createAt"`
+	UpdateAt           time.Time `json:"updateAt"`
+}
+
+func newPeer(peer *resource.Peer) *Peer {
+	var parentID string
+	if parent, ok := peer.LoadParent(); ok {
+		parentID = parent.ID
+	}
+
+	childIDs := []string{}
+	peer.Children.Range(func(key, _ interface{}) bool {
+		if childID, ok := key.(string); ok {
+			childIDs = append(childIDs, childID)
+		}
+		return true
+	})
+	sort.Strings(childIDs)
+
+	return &Peer{
+		ID:                 peer.ID,
+		BizTag:             peer.BizTag,
+		TaskID:             peer.Task.ID,
+		HostID:             peer.Host.ID,
+		State:              peer.FSM.Current(),
+		ParentID:           parentID,
+		ChildIDs:           childIDs,
+		FinishedPieceCount: peer.Pieces.Count(),
+		IsBackToSource:     peer.IsBackToSource.Load(),
+		Depth:              peer.Depth(),
+		CreateAt:           peer.CreateAt.Load(),
+		UpdateAt:           peer.UpdateAt.Load(),
+	}
+}
+
+// Host is the state of host in scheduler.
+type Host struct {
+	ID              string    `json:"id"`
+	Type            string    `json:"type"`
+	IP              string    `json:"ip"`
+	Hostname        string    `json:"hostname"`
+	Port            int32     `json:"port"`
+	DownloadPort    int32     `json:"downloadPort"`
+	IDC             string    `json:"idc"`
+	NetTopology     string    `json:"netTopology"`
+	Location        string    `json:"location"`
+	PeerCount       int32     `json:"peerCount"`
+	UploadLoadLimit int32     `json:"uploadLoadLimit"`
+	UploadPeerCount int32     `json:"uploadPeerCount"`
+	FreeUploadLoad  int32     `json:"freeUploadLoad"`
+	CPURatio        float64   `json:"cpuRatio"`
+	MemRatio        float64   `json:"memRatio"`
+	DiskRatio       float64   `json:"diskRatio"`
+	CreateAt        time.Time `json:"createAt"`
+	UpdateAt        time.Time `json:"updateAt"`
+}
+
+// hostTypes is the name of host types.
+var hostTypes = map[resource.HostType]string{
+	resource.HostTypeNormal:     "normal",
+	resource.HostTypeSuperSeed:  "super",
+	resource.HostTypeStrongSeed: "strong",
+	resource.HostTypeWeakSeed:   "weak",
+}
+
+func newHost(host *resource.Host) *Host {
+	return &Host{
+		ID:              host.ID,
+		Type:            hostTypes[host.Type],
+		IP:              host.IP,
+		Hostname:        host.Hostname,
+		Port:            host.Port,
+		DownloadPort:    host.DownloadPort,
+		IDC:             host.IDC,
+		NetTopology:     host.NetTopology,
+		Location:        host.Location,
+		PeerCount:       host.PeerCount.Load(),
+		UploadLoadLimit: host.UploadLoadLimit.Load(),
+		UploadPeerCount: host.UploadPeerCount.Load(),
+		FreeUploadLoad:  host.FreeUploadLoad(),
+		CPURatio:        host.CPURatio.Load(),
+		MemRatio:        host.MemRatio.Load(),
+		DiskRatio:       host.DiskRatio.Load(),
+		CreateAt:        host.CreateAt.Load(),
+		UpdateAt:        host.UpdateAt.Load(),
+	}
+}
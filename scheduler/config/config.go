@@ -17,8 +17,11 @@
 package config
 
 import (
+	"crypto/tls"
 	"time"
 
+	"github.com/Knetic/govaluate"
+	"github.com/docker/go-connections/tlsconfig"
 	"github.com/pkg/errors"
 
 	"d7y.io/dragonfly/v2/cmd/dependency/base"
@@ -55,8 +58,17 @@ type Config struct {
 	// Storage configuration.
 	Storage *StorageConfig `yaml:"storage" mapstructure:"storage"`
 
+	// Snapshot configuration.
+	Snapshot *SnapshotConfig `yaml:"snapshot" mapstructure:"snapshot"`
+
+	// Probe configuration.
+	Probe *ProbeConfig `yaml:"probe" mapstructure:"probe"`
+
 	// Metrics configuration.
 	Metrics *MetricsConfig `yaml:"metrics" mapstructure:"metrics"`
+
+	// Admin configuration.
+	Admin *AdminConfig `yaml:"admin" mapstructure:"admin"`
 }
 
 // New default configuration.
@@ -69,19 +81,23 @@ func New() *Config {
 			Port:   8002,
 		},
 		Scheduler: &SchedulerConfig{
-			Algorithm:            "default",
-			BackSourceCount:      3,
-			RetryBackSourceLimit: 5,
-			RetryLimit:           10,
-			RetryInterval:        50 * time.Millisecond,
+			Algorithm:             "default",
+			BackSourceCount:       3,
+			RetryBackSourceLimit:  5,
+			RetryLimit:            10,
+			RetryInterval:         50 * time.Millisecond,
+			BackSourceHoldTimeout: 1 * time.Minute,
 			GC: &GCConfig{
-				PeerGCInterval: 10 * time.Minute,
-				PeerTTL:        24 * time.Hour,
-				TaskGCInterval: 10 * time.Minute,
-				TaskTTL:        24 * time.Hour,
-				HostGCInterval: 30 * time.Minute,
-				HostTTL:        48 * time.Hour,
+				PeerGCInterval:      10 * time.Minute,
+				PeerTTL:             24 * time.Hour,
+				TaskGCInterval:      10 * time.Minute,
+				TaskTTL:             24 * time.Hour,
+				HostGCInterval:      30 * time.Minute,
+				HostTTL:             48 * time.Hour,
+				EvictionInterval:    1 * time.Minute,
+				ReplicationInterval: 1 * time.Minute,
 			},
+			Expression: &ExpressionConfig{},
 		},
 		DynConfig: &DynConfig{
 			RefreshInterval: 10 * time.Second,
@@ -111,11 +127,24 @@ func New() *Config {
 			MaxSize:    storage.DefaultMaxSize,
 			MaxBackups: storage.DefaultMaxBackups,
 			BufferSize: storage.DefaultBufferSize,
+			Format:     string(storage.FormatCSV),
+		},
+		Snapshot: &SnapshotConfig{
+			Enable:   false,
+			Interval: 5 * time.Minute,
+		},
+		Probe: &ProbeConfig{
+			Enable:   true,
+			Count:    5,
+			Interval: 20 * time.Minute,
 		},
 		Metrics: &MetricsConfig{
 			Enable:         false,
 			EnablePeerHost: false,
 		},
+		Admin: &AdminConfig{
+			Enable: false,
+		},
 	}
 }
 
@@ -153,6 +182,10 @@ func (cfg *Config) Validate() error {
 		return errors.New("scheduler requires parameter retryInterval")
 	}
 
+	if cfg.Scheduler.BackSourceHoldTimeout <= 0 {
+		return errors.New("scheduler requires parameter backSourceHoldTimeout")
+	}
+
 	if cfg.Scheduler.GC == nil {
 		return errors.New("scheduler requires parameter gc")
 	}
@@ -173,6 +206,32 @@ func (cfg *Config) Validate() error {
 		return errors.New("scheduler requires parameter taskTTL")
 	}
 
+	if cfg.Scheduler.GC.MaxTasks < 0 || cfg.Scheduler.GC.MaxPeers < 0 || cfg.Scheduler.GC.MaxHosts < 0 || cfg.Scheduler.GC.MaxMemorySize < 0 {
+		return errors.New("scheduler requires non-negative parameter maxTasks, maxPeers, maxHosts and maxMemorySize")
+	}
+
+	if cfg.Scheduler.GC.EvictionEnabled() && cfg.Scheduler.GC.EvictionInterval <= 0 {
+		return errors.New("scheduler requires parameter evictionInterval")
+	}
+
+	if cfg.SeedPeer != nil && cfg.SeedPeer.Enable && cfg.Scheduler.GC.ReplicationInterval <= 0 {
+		return errors.New("scheduler requires parameter replicationInterval")
+	}
+
+	if cfg.Scheduler.Expression != nil {
+		if cfg.Scheduler.Expression.Score != "" {
+			if _, err := govaluate.NewEvaluableExpression(cfg.Scheduler.Expression.Score); err != nil {
+				return errors.Errorf("scheduler expression score is invalid: %s", err.Error())
+			}
+		}
+
+		if cfg.Scheduler.Expression.Filter != "" {
+			if _, err := govaluate.NewEvaluableExpression(cfg.Scheduler.Expression.Filter); err != nil {
+				return errors.Errorf("scheduler expression filter is invalid: %s", err.Error())
+			}
+		}
+	}
+
 	if cfg.DynConfig.RefreshInterval <= 0 {
 		return errors.New("dynconfig requires parameter refreshInterval")
 	}
@@ -189,6 +248,10 @@ func (cfg *Config) Validate() error {
 		return errors.New("manager requires parameter keepAlive interval")
 	}
 
+	if cfg.Manager.TLS != nil && cfg.Manager.TLS.CA == "" {
+		return errors.New("manager tls requires parameter ca")
+	}
+
 	if cfg.Job != nil && cfg.Job.Enable {
 		if cfg.Job.GlobalWorkerNum == 0 {
 			return errors.New("job requires parameter globalWorkerNum")
@@ -235,12 +298,48 @@ func (cfg *Config) Validate() error {
 		return errors.New("storage requires parameter bufferSize")
 	}
 
+	if cfg.Storage.Format != string(storage.FormatCSV) && cfg.Storage.Format != string(storage.FormatJSONL) {
+		return errors.New("storage requires parameter format")
+	}
+
+	for _, sink := range cfg.Storage.Sinks {
+		if sink.Type != StorageSinkTypeParquet && sink.Type != StorageSinkTypeHTTP {
+			return errors.Errorf("storage sink type %s is not supported", sink.Type)
+		}
+
+		if sink.Type == StorageSinkTypeHTTP && sink.URL == "" {
+			return errors.New("storage http sink requires parameter url")
+		}
+	}
+
+	if cfg.Snapshot != nil && cfg.Snapshot.Enable {
+		if cfg.Snapshot.Interval <= 0 {
+			return errors.New("snapshot requires parameter interval")
+		}
+	}
+
+	if cfg.Probe != nil && cfg.Probe.Enable {
+		if cfg.Probe.Count <= 0 {
+			return errors.New("probe requires parameter count")
+		}
+
+		if cfg.Probe.Interval <= 0 {
+			return errors.New("probe requires parameter interval")
+		}
+	}
+
 	if cfg.Metrics != nil && cfg.Metrics.Enable {
 		if cfg.Metrics.Addr == "" {
 			return errors.New("metrics requires parameter addr")
 		}
 	}
 
+	if cfg.Admin != nil && cfg.Admin.Enable {
+		if cfg.Admin.Addr == "" {
+			return errors.New("admin requires parameter addr")
+		}
+	}
+
 	return nil
 }
 
@@ -280,8 +379,25 @@ type SchedulerConfig struct {
 	// Retry scheduling interval.
 	RetryInterval time.Duration `yaml:"retryInterval" mapstructure:"retryInterval"`
 
+	// Maximum time that peer is held in p2p scheduling when the back-to-source limits of origin are exceeded,
+	// peer scheduling fails after the timeout.
+	BackSourceHoldTimeout time.Duration `yaml:"backSourceHoldTimeout" mapstructure:"backSourceHoldTimeout"`
+
 	// Task and peer gc configuration.
 	GC *GCConfig `yaml:"gc" mapstructure:"gc"`
+
+	// Expression configuration, it is used by the expression algorithm.
+	Expression *ExpressionConfig `yaml:"expression" mapstructure:"expression"`
+}
+
+type ExpressionConfig struct {
+	// Score is the expression to evaluate the score of parent,
+	// it is overridden by the score expression of scheduler cluster config.
+	Score string `yaml:"score" mapstructure:"score"`
+
+	// Filter is the expression to determine whether the parent can be scheduled,
+	// it is overridden by the filter expression of scheduler cluster config.
+	Filter string `yaml:"filter" mapstructure:"filter"`
 }
 
 type GCConfig struct {
@@ -302,6 +418,34 @@ type GCConfig struct {
 
 	// Host time to live.
 	HostTTL time.Duration `yaml:"hostTTL" mapstructure:"hostTTL"`
+
+	// MaxTasks is the maximum number of tasks, the least recently used tasks
+	// are evicted when it is exceeded. Zero means no limit.
+	MaxTasks int `yaml:"maxTasks" mapstructure:"maxTasks"`
+
+	// MaxPeers is the maximum number of peers, the least recently used peers
+	// are evicted when it is exceeded. Zero means no limit.
+	MaxPeers int `yaml:"maxPeers" mapstructure:"maxPeers"`
+
+	// MaxHosts is the maximum number of hosts, the least recently used hosts
+	// are evicted when it is exceeded. Zero means no limit.
+	MaxHosts int `yaml:"maxHosts" mapstructure:"maxHosts"`
+
+	// MaxMemorySize is the maximum estimated memory size in megabytes of tasks, peers and hosts,
+	// the least recently used tasks are evicted when it is exceeded. Zero means no limit.
+	MaxMemorySize int `yaml:"maxMemorySize" mapstructure:"maxMemorySize"`
+
+	// Eviction interval.
+	EvictionInterval time.Duration `yaml:"evictionInterval" mapstructure:"evictionInterval"`
+
+	// ReplicationInterval is the interval of re-replicating persistent cache tasks,
+	// when the replicas in seed peers are lost.
+	ReplicationInterval time.Duration `yaml:"replicationInterval" mapstructure:"replicationInterval"`
+}
+
+// EvictionEnabled returns whether the resources are limited by eviction.
+func (c *GCConfig) EvictionEnabled() bool {
+	return c.MaxTasks > 0 || c.MaxPeers > 0 || c.MaxHosts > 0 || c.MaxMemorySize > 0
 }
 
 type DynConfig struct {
@@ -329,6 +473,29 @@ type ManagerConfig struct {
 
 	// KeepAlive configuration.
 	KeepAlive KeepAliveConfig `yaml:"keepAlive" mapstructure:"keepAlive"`
+
+	// TLS configuration, nil means insecure connection.
+	TLS *ManagerTLSConfig `yaml:"tls" mapstructure:"tls"`
+}
+
+type ManagerTLSConfig struct {
+	// CA file path, which verifies the certificate of manager.
+	CA string `yaml:"ca" mapstructure:"ca"`
+
+	// Client certificate file path.
+	Cert string `yaml:"cert" mapstructure:"cert"`
+
+	// Client key file path.
+	Key string `yaml:"key" mapstructure:"key"`
+}
+
+// Generate client tls config.
+func (t *ManagerTLSConfig) Client() (*tls.Config, error) {
+	return tlsconfig.Client(tlsconfig.Options{
+		CAFile:   t.CA,
+		CertFile: t.Cert,
+		KeyFile:  t.Key,
+	})
 }
 
 type SeedPeerConfig struct {
@@ -368,6 +535,64 @@ type StorageConfig struct {
 	// BufferSize sets the size of buffer container,
 	// if the buffer is full, write all the records in the buffer to the file.
 	BufferSize int `yaml:"bufferSize" mapstructure:"bufferSize"`
+
+	// Format is the format of storage file, supports csv and jsonl.
+	Format string `yaml:"format" mapstructure:"format"`
+
+	// Sinks are written with the records in the buffer together with the file,
+	// records are written to sinks in background and dropped when the sink can not keep up.
+	Sinks []*StorageSinkConfig `yaml:"sinks" mapstructure:"sinks"`
+}
+
+const (
+	// StorageSinkTypeParquet writes records to parquet files.
+	StorageSinkTypeParquet = "parquet"
+
+	// StorageSinkTypeHTTP pushes records to http endpoint in json lines format.
+	StorageSinkTypeHTTP = "http"
+)
+
+type StorageSinkConfig struct {
+	// Type is the type of sink, supports parquet and http.
+	Type string `yaml:"type" mapstructure:"type"`
+
+	// Dir is the directory of parquet files,
+	// default value is the parquet directory under the data directory.
+	Dir string `yaml:"dir" mapstructure:"dir"`
+
+	// MaxBackups sets the maximum number of parquet files to retain.
+	MaxBackups int `yaml:"maxBackups" mapstructure:"maxBackups"`
+
+	// URL is the http endpoint which records are pushed to.
+	URL string `yaml:"url" mapstructure:"url"`
+
+	// Headers are the headers of push request.
+	Headers map[string]string `yaml:"headers" mapstructure:"headers"`
+
+	// Timeout is the timeout of push request.
+	Timeout time.Duration `yaml:"timeout" mapstructure:"timeout"`
+}
+
+type SnapshotConfig struct {
+	// Enable persists hosts, tasks and peers to the data directory,
+	// and restores them when scheduler starts.
+	Enable bool `yaml:"enable" mapstructure:"enable"`
+
+	// Interval is the interval of persisting snapshot,
+	// snapshot is also persisted when scheduler stops.
+	Interval time.Duration `yaml:"interval" mapstructure:"interval"`
+}
+
+type ProbeConfig struct {
+	// Enable makes dfdaemon probe the round-trip time to other hosts,
+	// and the latency between hosts is used by evaluator.
+	Enable bool `yaml:"enable" mapstructure:"enable"`
+
+	// Count is the number of hosts probed by dfdaemon in a round.
+	Count int `yaml:"count" mapstructure:"count"`
+
+	// Interval is the interval of probing.
+	Interval time.Duration `yaml:"interval" mapstructure:"interval"`
 }
 
 type RedisConfig struct {
@@ -397,3 +622,11 @@ type MetricsConfig struct {
 	// Enable peer host metrics.
 	EnablePeerHost bool `yaml:"enablePeerHost" mapstructure:"enablePeerHost"`
 }
+
+type AdminConfig struct {
+	// Enable admin service.
+	Enable bool `yaml:"enable" mapstructure:"enable"`
+
+	// Admin service address.
+	Addr string `yaml:"addr" mapstructure:"addr"`
+}
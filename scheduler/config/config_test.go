@@ -43,18 +43,29 @@ func TestConfig_Load(t *testing.T) {
 			LogDir:   "bar",
 		},
 		Scheduler: &SchedulerConfig{
-			Algorithm:            "default",
-			BackSourceCount:      3,
-			RetryBackSourceLimit: 2,
-			RetryLimit:           10,
-			RetryInterval:        1 * time.Second,
+			Algorithm:             "default",
+			BackSourceCount:       3,
+			RetryBackSourceLimit:  2,
+			RetryLimit:            10,
+			RetryInterval:         1 * time.Second,
+			BackSourceHoldTimeout: 1 * time.Minute,
 			GC: &GCConfig{
-				PeerGCInterval: 1 * time.Minute,
-				PeerTTL:        5 * time.Minute,
-				TaskGCInterval: 1 * time.Minute,
-				TaskTTL:        10 * time.Minute,
-				HostGCInterval: 1 * time.Minute,
-				HostTTL:        10 * time.Minute,
+				PeerGCInterval:      1 * time.Minute,
+				PeerTTL:             5 * time.Minute,
+				TaskGCInterval:      1 * time.Minute,
+				TaskTTL:             10 * time.Minute,
+				HostGCInterval:      1 * time.Minute,
+				HostTTL:             10 * time.Minute,
+				MaxTasks:            10000,
+				MaxPeers:            100000,
+				MaxHosts:            1000,
+				MaxMemorySize:       1024,
+				EvictionInterval:    1 * time.Minute,
+				ReplicationInterval: 1 * time.Minute,
+			},
+			Expression: &ExpressionConfig{
+				Score:  "base_score * 0.8 + (parent_idc == child_idc ? 0.2 : 0)",
+				Filter: "parent_security_domain == child_security_domain",
 			},
 		},
 		DynConfig: &DynConfig{
@@ -71,6 +82,11 @@ func TestConfig_Load(t *testing.T) {
 			KeepAlive: KeepAliveConfig{
 				Interval: 5 * time.Second,
 			},
+			TLS: &ManagerTLSConfig{
+				CA:   "foo",
+				Cert: "foo",
+				Key:  "foo",
+			},
 		},
 		SeedPeer: &SeedPeerConfig{
 			Enable: true,
@@ -92,12 +108,39 @@ func TestConfig_Load(t *testing.T) {
 			MaxSize:    1,
 			MaxBackups: 1,
 			BufferSize: 1,
+			Format:     "jsonl",
+			Sinks: []*StorageSinkConfig{
+				{
+					Type:       "parquet",
+					Dir:        "foo",
+					MaxBackups: 1,
+				},
+				{
+					Type:    "http",
+					URL:     "http://127.0.0.1:8080/records",
+					Headers: map[string]string{"foo": "bar"},
+					Timeout: 10 * time.Second,
+				},
+			},
+		},
+		Snapshot: &SnapshotConfig{
+			Enable:   true,
+			Interval: 10 * time.Second,
+		},
+		Probe: &ProbeConfig{
+			Enable:   true,
+			Count:    10,
+			Interval: 10 * time.Minute,
 		},
 		Metrics: &MetricsConfig{
 			Enable:         false,
 			Addr:           ":8000",
 			EnablePeerHost: false,
 		},
+		Admin: &AdminConfig{
+			Enable: true,
+			Addr:   ":8003",
+		},
 	}
 
 	schedulerConfigYAML := &Config{}
@@ -126,19 +169,23 @@ func TestConfig_New(t *testing.T) {
 			Port:   8002,
 		},
 		Scheduler: &SchedulerConfig{
-			Algorithm:            "default",
-			BackSourceCount:      3,
-			RetryBackSourceLimit: 5,
-			RetryLimit:           10,
-			RetryInterval:        50 * time.Millisecond,
+			Algorithm:             "default",
+			BackSourceCount:       3,
+			RetryBackSourceLimit:  5,
+			RetryLimit:            10,
+			RetryInterval:         50 * time.Millisecond,
+			BackSourceHoldTimeout: 1 * time.Minute,
 			GC: &GCConfig{
-				PeerGCInterval: 10 * time.Minute,
-				PeerTTL:        24 * time.Hour,
-				TaskGCInterval: 10 * time.Minute,
-				TaskTTL:        24 * time.Hour,
-				HostGCInterval: 30 * time.Minute,
-				HostTTL:        48 * time.Hour,
+				PeerGCInterval:      10 * time.Minute,
+				PeerTTL:             24 * time.Hour,
+				TaskGCInterval:      10 * time.Minute,
+				TaskTTL:             24 * time.Hour,
+				HostGCInterval:      30 * time.Minute,
+				HostTTL:             48 * time.Hour,
+				EvictionInterval:    1 * time.Minute,
+				ReplicationInterval: 1 * time.Minute,
 			},
+			Expression: &ExpressionConfig{},
 		},
 		DynConfig: &DynConfig{
 			RefreshInterval: 10 * time.Second,
@@ -168,10 +215,23 @@ func TestConfig_New(t *testing.T) {
 			MaxSize:    storage.DefaultMaxSize,
 			MaxBackups: storage.DefaultMaxBackups,
 			BufferSize: storage.DefaultBufferSize,
+			Format:     string(storage.FormatCSV),
+		},
+		Snapshot: &SnapshotConfig{
+			Enable:   false,
+			Interval: 5 * time.Minute,
+		},
+		Probe: &ProbeConfig{
+			Enable:   true,
+			Count:    5,
+			Interval: 20 * time.Minute,
 		},
 		Metrics: &MetricsConfig{
 			Enable:         false,
 			EnablePeerHost: false,
 		},
+		Admin: &AdminConfig{
+			Enable: false,
+		},
 	})
 }
@@ -42,6 +42,7 @@ var (
 type DynconfigData struct {
 	SeedPeers        []*SeedPeer       `yaml:"seedPeers" mapstructure:"seedPeers" json:"seed_peers"`
 	SchedulerCluster *SchedulerCluster `yaml:"schedulerCluster" mapstructure:"schedulerCluster" json:"scheduler_cluster"`
+	Applications     []*Application    `yaml:"applications" mapstructure:"applications" json:"applications"`
 }
 
 type SeedPeer struct {
@@ -75,8 +76,23 @@ type SeedPeerCluster struct {
 }
 
 type SchedulerCluster struct {
-	Config       []byte `yaml:"config" mapstructure:"config" json:"config"`
-	ClientConfig []byte `yaml:"clientConfig" mapstructure:"clientConfig" json:"client_config"`
+	Config               []byte `yaml:"config" mapstructure:"config" json:"config"`
+	ClientConfig         []byte `yaml:"clientConfig" mapstructure:"clientConfig" json:"client_config"`
+	ActiveSchedulerCount uint32 `yaml:"activeSchedulerCount" mapstructure:"activeSchedulerCount" json:"active_scheduler_count"`
+}
+
+type Application struct {
+	Name  string `yaml:"name" mapstructure:"name" json:"name"`
+	Quota []byte `yaml:"quota" mapstructure:"quota" json:"quota"`
+}
+
+func (a *Application) GetApplicationQuota() (types.ApplicationQuota, bool) {
+	var quota types.ApplicationQuota
+	if err := json.Unmarshal(a.Quota, &quota); err != nil {
+		return types.ApplicationQuota{}, false
+	}
+
+	return quota, true
 }
 
 type DynconfigInterface interface {
@@ -86,6 +102,12 @@ type DynconfigInterface interface {
 	// Get the client config.
 	GetSchedulerClusterClientConfig() (types.SchedulerClusterClientConfig, bool)
 
+	// Get the number of active schedulers in the scheduler cluster.
+	GetActiveSchedulerCount() (uint32, bool)
+
+	// Get the quota of application.
+	GetApplicationQuota(string) (types.ApplicationQuota, bool)
+
 	// Get the dynamic config from manager.
 	Get() (*DynconfigData, error)
 
@@ -148,7 +170,7 @@ func (d *dynconfig) GetSchedulerClusterConfig() (types.SchedulerClusterConfig, b
 		return types.SchedulerClusterConfig{}, false
 	}
 
-	if data.SchedulerCluster != nil {
+	if data.SchedulerCluster == nil {
 		return types.SchedulerClusterConfig{}, false
 	}
 
@@ -178,6 +200,34 @@ func (d *dynconfig) GetSchedulerClusterClientConfig() (types.SchedulerClusterCli
 	return config, true
 }
 
+func (d *dynconfig) GetActiveSchedulerCount() (uint32, bool) {
+	data, err := d.Get()
+	if err != nil {
+		return 0, false
+	}
+
+	if data.SchedulerCluster == nil || data.SchedulerCluster.ActiveSchedulerCount == 0 {
+		return 0, false
+	}
+
+	return data.SchedulerCluster.ActiveSchedulerCount, true
+}
+
+func (d *dynconfig) GetApplicationQuota(name string) (types.ApplicationQuota, bool) {
+	data, err := d.Get()
+	if err != nil {
+		return types.ApplicationQuota{}, false
+	}
+
+	for _, application := range data.Applications {
+		if application.Name == name {
+			return application.GetApplicationQuota()
+		}
+	}
+
+	return types.ApplicationQuota{}, false
+}
+
 func (d *dynconfig) Get() (*DynconfigData, error) {
 	var config DynconfigData
 	if err := d.Unmarshal(&config); err != nil {
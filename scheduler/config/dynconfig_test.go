@@ -26,6 +26,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 
+	"d7y.io/dragonfly/v2/manager/types"
 	"d7y.io/dragonfly/v2/pkg/rpc/manager"
 	"d7y.io/dragonfly/v2/pkg/rpc/manager/client/mocks"
 )
@@ -80,6 +81,36 @@ func TestDynconfig_GetManagerSourceType(t *testing.T) {
 				assert.Equal(data.SeedPeers[0].DownloadPort, int32(8003))
 			},
 		},
+		{
+			name:            "get dynconfig with applications",
+			refreshInterval: 10 * time.Second,
+			cleanFileCache: func(t *testing.T) {
+				if err := os.Remove(mockCachePath); err != nil {
+					t.Fatal(err)
+				}
+			},
+			sleep: func() {},
+			mock: func(m *mocks.MockClientMockRecorder) {
+				m.GetScheduler(gomock.Any()).Return(&manager.Scheduler{
+					Applications: []*manager.Application{
+						{
+							Name:  "foo",
+							Quota: []byte(`{"max_concurrent_tasks":10,"max_back_to_source_bandwidth":1024,"max_seed_peer_triggers":2}`),
+						},
+					},
+				}, nil).Times(1)
+			},
+			expect: func(t *testing.T, data *DynconfigData, err error) {
+				assert := assert.New(t)
+				assert.Len(data.Applications, 1)
+				assert.Equal(data.Applications[0].Name, "foo")
+				quota, ok := data.Applications[0].GetApplicationQuota()
+				assert.True(ok)
+				assert.Equal(quota.MaxConcurrentTasks, uint32(10))
+				assert.Equal(quota.MaxBackToSourceBandwidth, uint64(1024))
+				assert.Equal(quota.MaxSeedPeerTriggers, uint32(2))
+			},
+		},
 		{
 			name:            "refresh dynconfig",
 			refreshInterval: 10 * time.Millisecond,
@@ -136,3 +167,169 @@ func TestDynconfig_GetManagerSourceType(t *testing.T) {
 		})
 	}
 }
+
+func TestDynconfig_GetSchedulerClusterConfig(t *testing.T) {
+	mockCacheDir := t.TempDir()
+	mockConfig := &Config{
+		DynConfig: &DynConfig{
+			RefreshInterval: 10 * time.Second,
+		},
+		Server: &ServerConfig{
+			Host: "localhost",
+		},
+		Manager: &ManagerConfig{
+			SchedulerClusterID: 1,
+		},
+	}
+
+	tests := []struct {
+		name   string
+		mock   func(m *mocks.MockClientMockRecorder)
+		expect func(t *testing.T, config types.SchedulerClusterConfig, ok bool)
+	}{
+		{
+			name: "get scheduler cluster config",
+			mock: func(m *mocks.MockClientMockRecorder) {
+				m.GetScheduler(gomock.Any()).Return(&manager.Scheduler{
+					SchedulerCluster: &manager.SchedulerCluster{
+						Id:     1,
+						Config: []byte(`{"filter_parent_limit":10}`),
+					},
+				}, nil).Times(1)
+			},
+			expect: func(t *testing.T, config types.SchedulerClusterConfig, ok bool) {
+				assert := assert.New(t)
+				assert.True(ok)
+				assert.Equal(config.FilterParentLimit, uint32(10))
+			},
+		},
+		{
+			name: "scheduler cluster does not exist",
+			mock: func(m *mocks.MockClientMockRecorder) {
+				m.GetScheduler(gomock.Any()).Return(&manager.Scheduler{}, nil).Times(1)
+			},
+			expect: func(t *testing.T, config types.SchedulerClusterConfig, ok bool) {
+				assert := assert.New(t)
+				assert.False(ok)
+				assert.Equal(config, types.SchedulerClusterConfig{})
+			},
+		},
+		{
+			name: "scheduler cluster config is invalid",
+			mock: func(m *mocks.MockClientMockRecorder) {
+				m.GetScheduler(gomock.Any()).Return(&manager.Scheduler{
+					SchedulerCluster: &manager.SchedulerCluster{
+						Id:     1,
+						Config: []byte(`foo`),
+					},
+				}, nil).Times(1)
+			},
+			expect: func(t *testing.T, config types.SchedulerClusterConfig, ok bool) {
+				assert := assert.New(t)
+				assert.False(ok)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockManagerClient := mocks.NewMockClient(ctl)
+			tc.mock(mockManagerClient.EXPECT())
+
+			d, err := NewDynconfig(mockManagerClient, mockCacheDir, mockConfig)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config, ok := d.GetSchedulerClusterConfig()
+			tc.expect(t, config, ok)
+			if err := os.Remove(filepath.Join(mockCacheDir, cacheFileName)); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestDynconfig_GetActiveSchedulerCount(t *testing.T) {
+	mockCacheDir := t.TempDir()
+	mockConfig := &Config{
+		DynConfig: &DynConfig{
+			RefreshInterval: 10 * time.Second,
+		},
+		Server: &ServerConfig{
+			Host: "localhost",
+		},
+		Manager: &ManagerConfig{
+			SchedulerClusterID: 1,
+		},
+	}
+
+	tests := []struct {
+		name   string
+		mock   func(m *mocks.MockClientMockRecorder)
+		expect func(t *testing.T, count uint32, ok bool)
+	}{
+		{
+			name: "get active scheduler count",
+			mock: func(m *mocks.MockClientMockRecorder) {
+				m.GetScheduler(gomock.Any()).Return(&manager.Scheduler{
+					SchedulerCluster: &manager.SchedulerCluster{
+						Id:                   1,
+						ActiveSchedulerCount: 3,
+					},
+				}, nil).Times(1)
+			},
+			expect: func(t *testing.T, count uint32, ok bool) {
+				assert := assert.New(t)
+				assert.True(ok)
+				assert.Equal(count, uint32(3))
+			},
+		},
+		{
+			name: "scheduler cluster does not exist",
+			mock: func(m *mocks.MockClientMockRecorder) {
+				m.GetScheduler(gomock.Any()).Return(&manager.Scheduler{}, nil).Times(1)
+			},
+			expect: func(t *testing.T, count uint32, ok bool) {
+				assert := assert.New(t)
+				assert.False(ok)
+			},
+		},
+		{
+			name: "active scheduler count is not given",
+			mock: func(m *mocks.MockClientMockRecorder) {
+				m.GetScheduler(gomock.Any()).Return(&manager.Scheduler{
+					SchedulerCluster: &manager.SchedulerCluster{
+						Id: 1,
+					},
+				}, nil).Times(1)
+			},
+			expect: func(t *testing.T, count uint32, ok bool) {
+				assert := assert.New(t)
+				assert.False(ok)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			mockManagerClient := mocks.NewMockClient(ctl)
+			tc.mock(mockManagerClient.EXPECT())
+
+			d, err := NewDynconfig(mockManagerClient, mockCacheDir, mockConfig)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			count, ok := d.GetActiveSchedulerCount()
+			tc.expect(t, count, ok)
+			if err := os.Remove(filepath.Join(mockCacheDir, cacheFileName)); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -62,6 +62,36 @@ func (mr *MockDynconfigInterfaceMockRecorder) Get() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDynconfigInterface)(nil).Get))
 }
 
+// GetActiveSchedulerCount mocks base method.
+func (m *MockDynconfigInterface) GetActiveSchedulerCount() (uint32, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveSchedulerCount")
+	ret0, _ := ret[0].(uint32)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetActiveSchedulerCount indicates an expected call of GetActiveSchedulerCount.
+func (mr *MockDynconfigInterfaceMockRecorder) GetActiveSchedulerCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveSchedulerCount", reflect.TypeOf((*MockDynconfigInterface)(nil).GetActiveSchedulerCount))
+}
+
+// GetApplicationQuota mocks base method.
+func (m *MockDynconfigInterface) GetApplicationQuota(arg0 string) (types.ApplicationQuota, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicationQuota", arg0)
+	ret0, _ := ret[0].(types.ApplicationQuota)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetApplicationQuota indicates an expected call of GetApplicationQuota.
+func (mr *MockDynconfigInterfaceMockRecorder) GetApplicationQuota(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationQuota", reflect.TypeOf((*MockDynconfigInterface)(nil).GetApplicationQuota), arg0)
+}
+
 // GetSchedulerClusterClientConfig mocks base method.
 func (m *MockDynconfigInterface) GetSchedulerClusterClientConfig() (types.SchedulerClusterClientConfig, bool) {
 	m.ctrl.T.Helper()
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"strings"
 
+	machineryv1tasks "github.com/RichardKnop/machinery/v1/tasks"
 	"github.com/go-http-utils/headers"
 	"github.com/go-playground/validator/v10"
 
@@ -33,20 +34,29 @@ import (
 	"d7y.io/dragonfly/v2/scheduler/resource"
 )
 
+// errPreheatCanceled is returned when the preheat is canceled.
+var errPreheatCanceled = errors.New("preheat has been canceled")
+
 type Job interface {
 	Serve()
 	Stop()
 }
 
+// URLPolicyChecker checks the url with url policies of scheduler cluster.
+type URLPolicyChecker interface {
+	CheckURLPolicy(context.Context, string) error
+}
+
 type job struct {
-	globalJob    *internaljob.Job
-	schedulerJob *internaljob.Job
-	localJob     *internaljob.Job
-	resource     resource.Resource
-	config       *config.Config
+	globalJob        *internaljob.Job
+	schedulerJob     *internaljob.Job
+	localJob         *internaljob.Job
+	resource         resource.Resource
+	urlPolicyChecker URLPolicyChecker
+	config           *config.Config
 }
 
-func New(cfg *config.Config, resource resource.Resource) (Job, error) {
+func New(cfg *config.Config, resource resource.Resource, urlPolicyChecker URLPolicyChecker) (Job, error) {
 	redisConfig := &internaljob.Config{
 		Host:      cfg.Job.Redis.Host,
 		Port:      cfg.Job.Redis.Port,
@@ -83,19 +93,21 @@ func New(cfg *config.Config, resource resource.Resource) (Job, error) {
 	logger.Infof("create local job queue: %v", localQueue)
 
 	t := &job{
-		globalJob:    globalJob,
-		schedulerJob: schedulerJob,
-		localJob:     localJob,
-		resource:     resource,
-		config:       cfg,
+		globalJob:        globalJob,
+		schedulerJob:     schedulerJob,
+		localJob:         localJob,
+		resource:         resource,
+		urlPolicyChecker: urlPolicyChecker,
+		config:           cfg,
 	}
 
 	namedJobFuncs := map[string]interface{}{
-		internaljob.PreheatJob: t.preheat,
+		internaljob.PreheatJob:         t.preheat,
+		internaljob.PersistentCacheJob: t.persistentCache,
 	}
 
 	if err := localJob.RegisterJob(namedJobFuncs); err != nil {
-		logger.Errorf("register preheat job to local queue error: %s", err.Error())
+		logger.Errorf("register jobs to local queue error: %s", err.Error())
 		return nil, err
 	}
 
@@ -147,23 +159,28 @@ func (j *job) preheat(ctx context.Context, req string) error {
 		return err
 	}
 
-	urlMeta := &base.UrlMeta{
-		Header: request.Headers,
-		Tag:    request.Tag,
-		Filter: request.Filter,
-		Digest: request.Digest,
-	}
-	if request.Headers != nil {
-		if r, ok := request.Headers[headers.Range]; ok {
-			// Range in dragonfly is without "bytes=".
-			urlMeta.Range = strings.TrimLeft(r, "bytes=")
-		}
+	if err := j.urlPolicyChecker.CheckURLPolicy(ctx, request.URL); err != nil {
+		logger.Errorf("url %s preheat is rejected: %s", request.URL, err.Error())
+		return err
 	}
 
+	urlMeta := newURLMeta(request.Headers, request.Tag, request.Filter, request.Digest)
 	taskID := idgen.TaskID(request.URL, urlMeta)
+	log := logger.WithTaskIDAndURL(taskID, request.URL)
+
+	// Report progress of preheat and stop preheating when it is canceled.
+	reporter := newPreheatReporter(j.localJob, machineryv1tasks.SignatureFromContext(ctx), request.URL)
+	if reporter.isCanceled() {
+		log.Info("preheat has been canceled")
+		reporter.finish(internaljob.StateCanceled, errPreheatCanceled)
+		return errPreheatCanceled
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go reporter.watch(ctx, cancel)
 
 	// Trigger seed peer download seeds.
-	log := logger.WithTaskIDAndURL(taskID, request.URL)
 	log.Infof("preheat %s headers: %#v, tag: %s, range: %s, filter: %s, digest: %s",
 		request.URL, urlMeta.Header, urlMeta.Tag, urlMeta.Range, urlMeta.Filter, urlMeta.Digest)
 	stream, err := j.resource.SeedPeer().Client().ObtainSeeds(ctx, &cdnsystem.SeedRequest{
@@ -173,19 +190,99 @@ func (j *job) preheat(ctx context.Context, req string) error {
 	})
 	if err != nil {
 		log.Errorf("preheat failed: %s", err.Error())
+		reporter.finish(machineryv1tasks.StateFailure, err)
 		return err
 	}
 
 	for {
 		piece, err := stream.Recv()
 		if err != nil {
+			if reporter.isCanceled() {
+				log.Info("preheat has been canceled")
+				reporter.finish(internaljob.StateCanceled, errPreheatCanceled)
+				return errPreheatCanceled
+			}
+
 			log.Errorf("preheat recive piece failed: %s", err.Error())
+			reporter.finish(machineryv1tasks.StateFailure, err)
 			return err
 		}
 
+		reporter.update(piece, j.seedPeerName(piece.HostId))
 		if piece.Done == true {
 			log.Info("preheat succeeded")
+			reporter.finish(machineryv1tasks.StateSuccess, nil)
 			return nil
 		}
 	}
 }
+
+func (j *job) persistentCache(ctx context.Context, req string) error {
+	if !j.config.SeedPeer.Enable {
+		return errors.New("scheduler has disabled seed peer")
+	}
+
+	request := &internaljob.PersistentCacheRequest{}
+	if err := internaljob.UnmarshalRequest(req, request); err != nil {
+		logger.Errorf("unmarshal request err: %s, request body: %s", err.Error(), req)
+		return err
+	}
+
+	if err := validator.New().Struct(request); err != nil {
+		logger.Errorf("url %s validate failed: %s", request.URL, err.Error())
+		return err
+	}
+
+	if err := j.urlPolicyChecker.CheckURLPolicy(ctx, request.URL); err != nil {
+		logger.Errorf("url %s persistent cache is rejected: %s", request.URL, err.Error())
+		return err
+	}
+
+	urlMeta := newURLMeta(request.Headers, request.Tag, request.Filter, request.Digest)
+	taskID := idgen.TaskID(request.URL, urlMeta)
+
+	// The task may have been downloaded by peers, then it is pinned as well.
+	task, loaded := j.resource.TaskManager().LoadOrStore(resource.NewTask(taskID, request.URL, resource.TaskTypePersistentCache, urlMeta,
+		resource.WithBackToSourceLimit(int32(j.config.Scheduler.BackSourceCount)),
+		resource.WithPersistentCache(request.ReplicaCount, request.TTL)))
+	if loaded {
+		task.Persist(request.ReplicaCount, request.TTL)
+	}
+
+	task.Log.Infof("persistent cache with replica count %d and ttl %s", request.ReplicaCount, request.TTL)
+	if err := j.resource.SeedPeer().ReplicateTask(ctx, task); err != nil {
+		task.Log.Errorf("persistent cache failed: %s", err.Error())
+		return err
+	}
+
+	task.Log.Info("persistent cache succeeded")
+	return nil
+}
+
+// newURLMeta returns url meta of task by the request of job.
+func newURLMeta(header map[string]string, tag, filter, digest string) *base.UrlMeta {
+	urlMeta := &base.UrlMeta{
+		Header: header,
+		Tag:    tag,
+		Filter: filter,
+		Digest: digest,
+	}
+
+	if header != nil {
+		if r, ok := header[headers.Range]; ok {
+			// Range in dragonfly is without "bytes=".
+			urlMeta.Range = strings.TrimLeft(r, "bytes=")
+		}
+	}
+
+	return urlMeta
+}
+
+// seedPeerName returns the hostname of seed peer.
+func (j *job) seedPeerName(hostID string) string {
+	if host, ok := j.resource.HostManager().Load(hostID); ok {
+		return host.Hostname
+	}
+
+	return hostID
+}
@@ -0,0 +1,166 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package job
+
+import (
+	"context"
+	"time"
+
+	machineryv1tasks "github.com/RichardKnop/machinery/v1/tasks"
+	"go.uber.org/atomic"
+
+	logger "d7y.io/dragonfly/v2/internal/dflog"
+	internaljob "d7y.io/dragonfly/v2/internal/job"
+	"d7y.io/dragonfly/v2/pkg/rpc/cdnsystem"
+)
+
+const (
+	// Minimum interval of reporting preheat progress.
+	preheatReportInterval = 1 * time.Second
+
+	// Interval of checking whether the preheat is canceled.
+	preheatCancelCheckInterval = 2 * time.Second
+
+	// Timeout of accessing result backend.
+	backendTimeout = 5 * time.Second
+)
+
+// preheatReporter reports the progress of preheat to the result backend,
+// and watches the cancellation of the group which the preheat belongs to.
+type preheatReporter struct {
+	job       *internaljob.Job
+	signature *machineryv1tasks.Signature
+	progress  *internaljob.PreheatProgress
+	reportAt  time.Time
+	canceled  *atomic.Bool
+}
+
+// newPreheatReporter returns a new preheatReporter, the reporter does nothing
+// when the preheat is not a job of group.
+func newPreheatReporter(job *internaljob.Job, signature *machineryv1tasks.Signature, url string) *preheatReporter {
+	r := &preheatReporter{
+		job:       job,
+		signature: signature,
+		progress: &internaljob.PreheatProgress{
+			URL:   url,
+			State: machineryv1tasks.StateStarted,
+		},
+		canceled: atomic.NewBool(false),
+	}
+
+	if signature != nil {
+		r.progress.Queue = signature.RoutingKey
+	}
+
+	return r
+}
+
+// enabled returns whether the preheat is a job of group.
+func (r *preheatReporter) enabled() bool {
+	return r.signature != nil && r.signature.GroupUUID != "" && r.signature.UUID != ""
+}
+
+// isCanceled returns whether the group of preheat is canceled.
+func (r *preheatReporter) isCanceled() bool {
+	if r.canceled.Load() {
+		return true
+	}
+
+	if !r.enabled() {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), backendTimeout)
+	defer cancel()
+
+	canceled, err := r.job.IsGroupJobCanceled(ctx, r.signature.GroupUUID)
+	if err != nil {
+		logger.Warnf("check preheat group %s canceled failed: %s", r.signature.GroupUUID, err.Error())
+		return false
+	}
+
+	r.canceled.Store(canceled)
+	return canceled
+}
+
+// watch calls cancel when the group of preheat is canceled, until ctx is done.
+func (r *preheatReporter) watch(ctx context.Context, cancel context.CancelFunc) {
+	if !r.enabled() {
+		return
+	}
+
+	ticker := time.NewTicker(preheatCancelCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if r.isCanceled() {
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+// update updates the progress by the piece downloaded by seed peer,
+// and the progress is reported at most once every preheatReportInterval.
+func (r *preheatReporter) update(piece *cdnsystem.PieceSeed, seedPeer string) {
+	if piece.PieceInfo != nil {
+		r.progress.CompletedLength += int64(piece.PieceInfo.RangeSize)
+	}
+
+	if piece.ContentLength > 0 {
+		r.progress.ContentLength = piece.ContentLength
+	}
+
+	if seedPeer != "" {
+		r.progress.SeedPeer = seedPeer
+	}
+
+	if time.Since(r.reportAt) >= preheatReportInterval {
+		r.report()
+	}
+}
+
+// finish reports the final state of progress.
+func (r *preheatReporter) finish(state string, err error) {
+	r.progress.State = state
+	if err != nil {
+		r.progress.Error = err.Error()
+	}
+
+	r.report()
+}
+
+// report stores the progress to the result backend.
+func (r *preheatReporter) report() {
+	if !r.enabled() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), backendTimeout)
+	defer cancel()
+
+	r.reportAt = time.Now()
+	r.progress.UpdatedAt = r.reportAt
+	if err := r.job.SetPreheatProgress(ctx, r.signature.GroupUUID, r.signature.UUID, r.progress); err != nil {
+		logger.Warnf("report preheat %s progress failed: %s", r.progress.URL, err.Error())
+	}
+}
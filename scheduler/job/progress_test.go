@@ -0,0 +1,360 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package job
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	machineryv1tasks "github.com/RichardKnop/machinery/v1/tasks"
+	"github.com/alicebob/miniredis"
+	"github.com/stretchr/testify/assert"
+
+	internaljob "d7y.io/dragonfly/v2/internal/job"
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
+	"d7y.io/dragonfly/v2/pkg/rpc/cdnsystem"
+)
+
+var (
+	mockGroupUUID = "group_4d8b4e5a-4b9a-4b6a-9c4e-2b2a1d7f3c11"
+	mockJobUUID   = "task_0b7a1c55-7d7b-4f5a-9e0c-3b1d2a6f8e22"
+	mockURL       = "http://example.com/foo"
+	mockSignature = &machineryv1tasks.Signature{
+		UUID:       mockJobUUID,
+		GroupUUID:  mockGroupUUID,
+		RoutingKey: "scheduler_1_foo",
+	}
+)
+
+func newMockJob(t *testing.T) *internaljob.Job {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(mr.Close)
+
+	port, err := strconv.Atoi(mr.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := internaljob.New(&internaljob.Config{
+		Host:      mr.Host(),
+		Port:      port,
+		BrokerDB:  1,
+		BackendDB: 2,
+	}, internaljob.GlobalQueue)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return job
+}
+
+func loadProgress(t *testing.T, job *internaljob.Job) (*internaljob.PreheatProgress, bool) {
+	progresses, err := job.GetPreheatProgresses(context.Background(), mockGroupUUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(progresses) == 0 {
+		return nil, false
+	}
+
+	return progresses[0], true
+}
+
+func TestPreheatReporter_enabled(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature *machineryv1tasks.Signature
+		expect    bool
+	}{
+		{
+			name:      "preheat has no signature",
+			signature: nil,
+			expect:    false,
+		},
+		{
+			name:      "preheat is not a job of group",
+			signature: &machineryv1tasks.Signature{UUID: mockJobUUID},
+			expect:    false,
+		},
+		{
+			name:      "preheat is a job of group",
+			signature: mockSignature,
+			expect:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(newPreheatReporter(nil, tc.signature, mockURL).enabled(), tc.expect)
+		})
+	}
+}
+
+func TestPreheatReporter_update(t *testing.T) {
+	tests := []struct {
+		name   string
+		run    func(r *preheatReporter)
+		expect func(t *testing.T, job *internaljob.Job)
+	}{
+		{
+			name: "report the first update",
+			run: func(r *preheatReporter) {
+				r.update(&cdnsystem.PieceSeed{
+					PieceInfo:     &base.PieceInfo{RangeSize: 1024},
+					ContentLength: 4096,
+				}, "seed-peer")
+			},
+			expect: func(t *testing.T, job *internaljob.Job) {
+				assert := assert.New(t)
+				progress, ok := loadProgress(t, job)
+				assert.True(ok)
+				assert.Equal(progress.URL, mockURL)
+				assert.Equal(progress.Queue, mockSignature.RoutingKey)
+				assert.Equal(progress.State, machineryv1tasks.StateStarted)
+				assert.Equal(progress.CompletedLength, int64(1024))
+				assert.Equal(progress.ContentLength, int64(4096))
+				assert.Equal(progress.SeedPeer, "seed-peer")
+			},
+		},
+		{
+			name: "updates within report interval are throttled",
+			run: func(r *preheatReporter) {
+				r.update(&cdnsystem.PieceSeed{PieceInfo: &base.PieceInfo{RangeSize: 1024}}, "")
+				r.update(&cdnsystem.PieceSeed{PieceInfo: &base.PieceInfo{RangeSize: 1024}}, "")
+				r.update(&cdnsystem.PieceSeed{PieceInfo: &base.PieceInfo{RangeSize: 1024}}, "")
+			},
+			expect: func(t *testing.T, job *internaljob.Job) {
+				assert := assert.New(t)
+				progress, ok := loadProgress(t, job)
+				assert.True(ok)
+				assert.Equal(progress.CompletedLength, int64(1024))
+			},
+		},
+		{
+			name: "report update after report interval",
+			run: func(r *preheatReporter) {
+				r.update(&cdnsystem.PieceSeed{PieceInfo: &base.PieceInfo{RangeSize: 1024}}, "")
+				r.update(&cdnsystem.PieceSeed{PieceInfo: &base.PieceInfo{RangeSize: 1024}}, "")
+				r.reportAt = r.reportAt.Add(-preheatReportInterval)
+				r.update(&cdnsystem.PieceSeed{PieceInfo: &base.PieceInfo{RangeSize: 1024}}, "")
+			},
+			expect: func(t *testing.T, job *internaljob.Job) {
+				assert := assert.New(t)
+				progress, ok := loadProgress(t, job)
+				assert.True(ok)
+				assert.Equal(progress.CompletedLength, int64(3072))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			job := newMockJob(t)
+			tc.run(newPreheatReporter(job, mockSignature, mockURL))
+			tc.expect(t, job)
+		})
+	}
+}
+
+func TestPreheatReporter_finish(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature *machineryv1tasks.Signature
+		state     string
+		err       error
+		expect    func(t *testing.T, job *internaljob.Job)
+	}{
+		{
+			name:      "preheat succeeded",
+			signature: mockSignature,
+			state:     machineryv1tasks.StateSuccess,
+			expect: func(t *testing.T, job *internaljob.Job) {
+				assert := assert.New(t)
+				progress, ok := loadProgress(t, job)
+				assert.True(ok)
+				assert.Equal(progress.State, machineryv1tasks.StateSuccess)
+				assert.Empty(progress.Error)
+				assert.False(progress.UpdatedAt.IsZero())
+			},
+		},
+		{
+			name:      "preheat failed",
+			signature: mockSignature,
+			state:     machineryv1tasks.StateFailure,
+			err:       errors.New("foo"),
+			expect: func(t *testing.T, job *internaljob.Job) {
+				assert := assert.New(t)
+				progress, ok := loadProgress(t, job)
+				assert.True(ok)
+				assert.Equal(progress.State, machineryv1tasks.StateFailure)
+				assert.Equal(progress.Error, "foo")
+			},
+		},
+		{
+			name:      "preheat canceled",
+			signature: mockSignature,
+			state:     internaljob.StateCanceled,
+			err:       errPreheatCanceled,
+			expect: func(t *testing.T, job *internaljob.Job) {
+				assert := assert.New(t)
+				progress, ok := loadProgress(t, job)
+				assert.True(ok)
+				assert.Equal(progress.State, internaljob.StateCanceled)
+				assert.Equal(progress.Error, errPreheatCanceled.Error())
+			},
+		},
+		{
+			name:      "preheat is not a job of group",
+			signature: &machineryv1tasks.Signature{UUID: mockJobUUID},
+			state:     machineryv1tasks.StateSuccess,
+			expect: func(t *testing.T, job *internaljob.Job) {
+				assert := assert.New(t)
+				_, ok := loadProgress(t, job)
+				assert.False(ok)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			job := newMockJob(t)
+			newPreheatReporter(job, tc.signature, mockURL).finish(tc.state, tc.err)
+			tc.expect(t, job)
+		})
+	}
+}
+
+func TestPreheatReporter_isCanceled(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature *machineryv1tasks.Signature
+		mock      func(t *testing.T, job *internaljob.Job)
+		expect    func(t *testing.T, r *preheatReporter)
+	}{
+		{
+			name:      "group is not canceled",
+			signature: mockSignature,
+			mock:      func(t *testing.T, job *internaljob.Job) {},
+			expect: func(t *testing.T, r *preheatReporter) {
+				assert := assert.New(t)
+				assert.False(r.isCanceled())
+			},
+		},
+		{
+			name:      "group is canceled",
+			signature: mockSignature,
+			mock: func(t *testing.T, job *internaljob.Job) {
+				if err := job.CancelGroupJob(context.Background(), mockGroupUUID); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expect: func(t *testing.T, r *preheatReporter) {
+				assert := assert.New(t)
+				assert.True(r.isCanceled())
+				assert.True(r.canceled.Load())
+			},
+		},
+		{
+			name:      "preheat is not a job of group",
+			signature: &machineryv1tasks.Signature{GroupUUID: mockGroupUUID},
+			mock: func(t *testing.T, job *internaljob.Job) {
+				if err := job.CancelGroupJob(context.Background(), mockGroupUUID); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expect: func(t *testing.T, r *preheatReporter) {
+				assert := assert.New(t)
+				assert.False(r.isCanceled())
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			job := newMockJob(t)
+			tc.mock(t, job)
+			tc.expect(t, newPreheatReporter(job, tc.signature, mockURL))
+		})
+	}
+}
+
+func TestPreheatReporter_watch(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature *machineryv1tasks.Signature
+		mock      func(t *testing.T, job *internaljob.Job)
+		expect    func(t *testing.T, ctx context.Context, done <-chan struct{})
+	}{
+		{
+			name:      "preheat is canceled when group is canceled",
+			signature: mockSignature,
+			mock: func(t *testing.T, job *internaljob.Job) {
+				if err := job.CancelGroupJob(context.Background(), mockGroupUUID); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expect: func(t *testing.T, ctx context.Context, done <-chan struct{}) {
+				assert := assert.New(t)
+				select {
+				case <-done:
+				case <-time.After(2 * preheatCancelCheckInterval):
+					t.Fatal("watch is not returned")
+				}
+				assert.Error(ctx.Err())
+			},
+		},
+		{
+			name:      "preheat is not a job of group",
+			signature: &machineryv1tasks.Signature{UUID: mockJobUUID},
+			mock:      func(t *testing.T, job *internaljob.Job) {},
+			expect: func(t *testing.T, ctx context.Context, done <-chan struct{}) {
+				assert := assert.New(t)
+				select {
+				case <-done:
+				case <-time.After(preheatCancelCheckInterval):
+					t.Fatal("watch is not returned")
+				}
+				assert.NoError(ctx.Err())
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			job := newMockJob(t)
+			tc.mock(t, job)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			done := make(chan struct{})
+			go func() {
+				newPreheatReporter(job, tc.signature, mockURL).watch(ctx, cancel)
+				close(done)
+			}()
+
+			tc.expect(t, ctx, done)
+		})
+	}
+}
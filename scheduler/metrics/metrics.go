@@ -47,6 +47,21 @@ var (
 
 	// DownloadFailureP2PType is p2p type for download failure count metrics.
 	DownloadFailureP2PType = "p2p"
+
+	// ResourceTaskType is task type for resource and eviction metrics.
+	ResourceTaskType = "task"
+
+	// ResourcePeerType is peer type for resource and eviction metrics.
+	ResourcePeerType = "peer"
+
+	// ResourceHostType is host type for resource and eviction metrics.
+	ResourceHostType = "host"
+
+	// EvictionCountReason is the reason of eviction when the number of resources exceeds the limit.
+	EvictionCountReason = "count"
+
+	// EvictionMemoryReason is the reason of eviction when the estimated memory exceeds the limit.
+	EvictionMemoryReason = "memory"
 )
 
 // Variables declared for metrics.
@@ -79,6 +94,13 @@ var (
 		Help:      "Counter of the number of failed of the downloading.",
 	}, []string{"biz_tag", "type"})
 
+	ResumePieceResultCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: constants.MetricsNamespace,
+		Subsystem: constants.SchedulerMetricsName,
+		Name:      "resume_piece_result_total",
+		Help:      "Counter of the number of the resuming stream of piece result.",
+	}, []string{"biz_tag"})
+
 	StatTaskCount = promauto.NewCounter(prometheus.CounterOpts{
 		Namespace: constants.MetricsNamespace,
 		Subsystem: constants.SchedulerMetricsName,
@@ -156,6 +178,41 @@ var (
 		Name:      "concurrent_schedule_total",
 		Help:      "Gauge of the number of concurrent of the scheduling.",
 	})
+
+	BackToSourceHeldCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: constants.MetricsNamespace,
+		Subsystem: constants.SchedulerMetricsName,
+		Name:      "back_to_source_held_total",
+		Help:      "Counter of the number of peers held in p2p scheduling because the back-to-source limit of origin is exceeded.",
+	}, []string{"biz_tag"})
+
+	ResourceCountGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: constants.MetricsNamespace,
+		Subsystem: constants.SchedulerMetricsName,
+		Name:      "resource_count",
+		Help:      "Gauge of the number of tasks, peers and hosts.",
+	}, []string{"type"})
+
+	ResourceEstimatedMemoryGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: constants.MetricsNamespace,
+		Subsystem: constants.SchedulerMetricsName,
+		Name:      "resource_estimated_memory_bytes",
+		Help:      "Gauge of the estimated memory size of tasks, peers and hosts.",
+	})
+
+	EvictionCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: constants.MetricsNamespace,
+		Subsystem: constants.SchedulerMetricsName,
+		Name:      "eviction_total",
+		Help:      "Counter of the number of evicted tasks, peers and hosts.",
+	}, []string{"type", "reason"})
+
+	StorageSinkDroppedRecordCount = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.MetricsNamespace,
+		Subsystem: constants.SchedulerMetricsName,
+		Name:      "storage_sink_dropped_record_total",
+		Help:      "Counter of the number of records dropped because the queue of storage sink is full.",
+	})
 )
 
 func New(cfg *config.MetricsConfig, svr *grpc.Server) *http.Server {
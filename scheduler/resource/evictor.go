@@ -0,0 +1,291 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"sort"
+	"time"
+
+	logger "d7y.io/dragonfly/v2/internal/dflog"
+	pkggc "d7y.io/dragonfly/v2/pkg/gc"
+	"d7y.io/dragonfly/v2/scheduler/config"
+	"d7y.io/dragonfly/v2/scheduler/metrics"
+)
+
+const (
+	// GC eviction id.
+	GCEvictionID = "eviction"
+)
+
+const (
+	// Rough estimated memory size of task without pieces.
+	estimatedTaskSize = 4 * 1024
+
+	// Rough estimated memory size of piece stored in task.
+	estimatedPieceSize = 256
+
+	// Rough estimated memory size of peer.
+	estimatedPeerSize = 2 * 1024
+
+	// Rough estimated memory size of host.
+	estimatedHostSize = 2 * 1024
+)
+
+// evictor evicts the least recently used tasks, peers and hosts,
+// when the number or the estimated memory size of them exceeds the limits.
+type evictor struct {
+	// GC configuration.
+	config *config.GCConfig
+
+	// Host manager interface.
+	hostManager HostManager
+
+	// Task manager interface.
+	taskManager TaskManager
+
+	// Peer manager interface.
+	peerManager PeerManager
+}
+
+// newEvictor adds the evictor to gc.
+func newEvictor(cfg *config.GCConfig, gc pkggc.GC, hostManager HostManager, taskManager TaskManager, peerManager PeerManager) error {
+	return gc.Add(pkggc.Task{
+		ID:       GCEvictionID,
+		Interval: cfg.EvictionInterval,
+		Timeout:  cfg.EvictionInterval,
+		Runner: &evictor{
+			config:      cfg,
+			hostManager: hostManager,
+			taskManager: taskManager,
+			peerManager: peerManager,
+		},
+	})
+}
+
+func (e *evictor) RunGC() error {
+	var (
+		tasks []*Task
+		peers []*Peer
+		hosts []*Host
+	)
+	e.taskManager.Range(func(_, value interface{}) bool {
+		if task, ok := value.(*Task); ok {
+			tasks = append(tasks, task)
+		}
+
+		return true
+	})
+
+	e.peerManager.Range(func(_, value interface{}) bool {
+		if peer, ok := value.(*Peer); ok {
+			peers = append(peers, peer)
+		}
+
+		return true
+	})
+
+	e.hostManager.Range(func(_, value interface{}) bool {
+		if host, ok := value.(*Host); ok {
+			hosts = append(hosts, host)
+		}
+
+		return true
+	})
+
+	memory := int64(len(peers)*estimatedPeerSize + len(hosts)*estimatedHostSize)
+	for _, task := range tasks {
+		memory += estimateTaskMemory(task)
+	}
+
+	metrics.ResourceCountGauge.WithLabelValues(metrics.ResourceTaskType).Set(float64(len(tasks)))
+	metrics.ResourceCountGauge.WithLabelValues(metrics.ResourcePeerType).Set(float64(len(peers)))
+	metrics.ResourceCountGauge.WithLabelValues(metrics.ResourceHostType).Set(float64(len(hosts)))
+	metrics.ResourceEstimatedMemoryGauge.Set(float64(memory))
+
+	// Peers are evicted first, so that the tasks and hosts without peers can be evicted.
+	if e.config.MaxPeers > 0 && len(peers) > e.config.MaxPeers {
+		n := e.evictPeers(peers, len(peers)-e.config.MaxPeers)
+		memory -= int64(n * estimatedPeerSize)
+	}
+
+	taskOverflow := len(tasks) - e.config.MaxTasks
+	if e.config.MaxTasks <= 0 {
+		taskOverflow = 0
+	}
+
+	maxMemory := int64(e.config.MaxMemorySize) * 1024 * 1024
+	if taskOverflow > 0 || (maxMemory > 0 && memory > maxMemory) {
+		e.evictTasks(tasks, taskOverflow, memory, maxMemory)
+	}
+
+	if e.config.MaxHosts > 0 && len(hosts) > e.config.MaxHosts {
+		e.evictHosts(hosts, len(hosts)-e.config.MaxHosts)
+	}
+
+	return nil
+}
+
+// evictPeers evicts at most n finished peers without children except replicas, and returns the number of evicted peers.
+// The peers which have left are evicted first, then the failed and succeeded peers.
+func (e *evictor) evictPeers(peers []*Peer, n int) int {
+	var candidates []*Peer
+	for _, peer := range peers {
+		if peer.ChildCount.Load() == 0 && peerEvictionPriority(peer) >= 0 && !peer.IsReplica() {
+			candidates = append(candidates, peer)
+		}
+	}
+
+	sortByEviction(candidates, func(i int) (int, time.Time) {
+		return peerEvictionPriority(candidates[i]), candidates[i].UpdateAt.Load()
+	})
+
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+
+	for _, peer := range candidates {
+		peer.DeleteParent()
+		e.peerManager.Delete(peer.ID)
+		peer.Log.Info("peer has been evicted")
+		metrics.EvictionCount.WithLabelValues(metrics.ResourcePeerType, metrics.EvictionCountReason).Inc()
+	}
+
+	if len(candidates) < n {
+		logger.Warnf("number of peers exceeds the limit, but only %d of %d peers can be evicted", len(candidates), n)
+	}
+
+	return len(candidates)
+}
+
+// evictTasks evicts the tasks without active peers except persistent cache tasks, until the overflow of tasks is evicted
+// and the estimated memory size does not exceed maxMemory.
+// The finished tasks are evicted first, then the pending tasks.
+func (e *evictor) evictTasks(tasks []*Task, overflow int, memory int64, maxMemory int64) {
+	var candidates []*Task
+	for _, task := range tasks {
+		if !task.FSM.Is(TaskStateRunning) && !task.HasActivePeer() && !task.IsPersistent() {
+			candidates = append(candidates, task)
+		}
+	}
+
+	sortByEviction(candidates, func(i int) (int, time.Time) {
+		return taskEvictionPriority(candidates[i]), candidates[i].UpdateAt.Load()
+	})
+
+	for _, task := range candidates {
+		reason := metrics.EvictionCountReason
+		if overflow <= 0 {
+			if maxMemory <= 0 || memory <= maxMemory {
+				return
+			}
+
+			reason = metrics.EvictionMemoryReason
+		}
+
+		memory -= estimateTaskMemory(task)
+		task.Peers.Range(func(_, value interface{}) bool {
+			if peer, ok := value.(*Peer); ok {
+				peer.DeleteParent()
+				e.peerManager.Delete(peer.ID)
+				memory -= estimatedPeerSize
+				metrics.EvictionCount.WithLabelValues(metrics.ResourcePeerType, reason).Inc()
+			}
+
+			return true
+		})
+
+		e.taskManager.Delete(task.ID)
+		overflow--
+		task.Log.Infof("task has been evicted because of %s limit", reason)
+		metrics.EvictionCount.WithLabelValues(metrics.ResourceTaskType, reason).Inc()
+	}
+
+	if overflow > 0 || (maxMemory > 0 && memory > maxMemory) {
+		logger.Warnf("tasks exceed the limits, but all of %d tasks without active peers have been evicted", len(candidates))
+	}
+}
+
+// evictHosts evicts at most n normal hosts without peers.
+func (e *evictor) evictHosts(hosts []*Host, n int) {
+	var candidates []*Host
+	for _, host := range hosts {
+		if host.Type == HostTypeNormal && host.PeerCount.Load() == 0 && host.UploadPeerCount.Load() == 0 {
+			candidates = append(candidates, host)
+		}
+	}
+
+	sortByEviction(candidates, func(i int) (int, time.Time) {
+		return 0, candidates[i].UpdateAt.Load()
+	})
+
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+
+	for _, host := range candidates {
+		e.hostManager.Delete(host.ID)
+		host.Log.Info("host has been evicted")
+		metrics.EvictionCount.WithLabelValues(metrics.ResourceHostType, metrics.EvictionCountReason).Inc()
+	}
+
+	if len(candidates) < n {
+		logger.Warnf("number of hosts exceeds the limit, but only %d of %d hosts can be evicted", len(candidates), n)
+	}
+}
+
+// sortByEviction sorts the candidates by priority, and then by update time,
+// the candidate with lower priority and earlier update time is evicted first.
+func sortByEviction(candidates interface{}, key func(int) (int, time.Time)) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		pi, ti := key(i)
+		pj, tj := key(j)
+		if pi != pj {
+			return pi < pj
+		}
+
+		return ti.Before(tj)
+	})
+}
+
+// peerEvictionPriority returns the eviction priority of peer,
+// negative priority means the peer can not be evicted.
+func peerEvictionPriority(peer *Peer) int {
+	switch peer.FSM.Current() {
+	case PeerStateLeave:
+		return 0
+	case PeerStateFailed:
+		return 1
+	case PeerStateSucceeded:
+		return 2
+	default:
+		return -1
+	}
+}
+
+// taskEvictionPriority returns the eviction priority of task.
+func taskEvictionPriority(task *Task) int {
+	if task.FSM.Is(TaskStateSucceeded) || task.FSM.Is(TaskStateFailed) {
+		return 0
+	}
+
+	return 1
+}
+
+// estimateTaskMemory returns the rough estimated memory size of task.
+func estimateTaskMemory(task *Task) int64 {
+	return int64(estimatedTaskSize) + int64(task.TotalPieceCount.Load())*estimatedPieceSize + int64(len(task.DirectPiece))
+}
@@ -0,0 +1,329 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"d7y.io/dragonfly/v2/pkg/gc"
+	"d7y.io/dragonfly/v2/pkg/idgen"
+	"d7y.io/dragonfly/v2/pkg/rpc/scheduler"
+	"d7y.io/dragonfly/v2/scheduler/config"
+)
+
+func newMockEvictorHost(hostname string, options ...HostOption) *Host {
+	return NewHost(&scheduler.PeerHost{
+		Id:             idgen.HostID(hostname, 8003),
+		Ip:             "127.0.0.1",
+		RpcPort:        8003,
+		DownPort:       8001,
+		HostName:       hostname,
+		SecurityDomain: "security_domain",
+		Location:       "location",
+		Idc:            "idc",
+		NetTopology:    "net_topology",
+	}, options...)
+}
+
+func TestEvictor_newEvictor(t *testing.T) {
+	tests := []struct {
+		name   string
+		mock   func(m *gc.MockGCMockRecorder)
+		expect func(t *testing.T, err error)
+	}{
+		{
+			name: "new evictor",
+			mock: func(m *gc.MockGCMockRecorder) {
+				m.Add(gomock.Any()).Return(nil).Times(1)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+			},
+		},
+		{
+			name: "new evictor failed because of gc error",
+			mock: func(m *gc.MockGCMockRecorder) {
+				m.Add(gomock.Any()).Return(errors.New("foo")).Times(1)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				assert.EqualError(err, "foo")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			gc := gc.NewMockGC(ctl)
+			tc.mock(gc.EXPECT())
+
+			tc.expect(t, newEvictor(&config.GCConfig{MaxTasks: 1, EvictionInterval: time.Minute}, gc, nil, nil, nil))
+		})
+	}
+}
+
+func TestEvictor_RunGC(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, hostManager HostManager, taskManager TaskManager, peerManager PeerManager)
+	}{
+		{
+			name: "evict peers which have left first",
+			run: func(t *testing.T, hostManager HostManager, taskManager TaskManager, peerManager PeerManager) {
+				assert := assert.New(t)
+				host := NewHost(mockRawHost)
+				task := NewTask(mockTaskID, mockTaskURL, TaskTypeNormal, mockTaskURLMeta)
+
+				succeededPeer := NewPeer(idgen.PeerID("127.0.0.1"), task, host)
+				succeededPeer.FSM.SetState(PeerStateSucceeded)
+				succeededPeer.UpdateAt.Store(time.Now().Add(-time.Hour))
+				leavePeer := NewPeer(idgen.PeerID("127.0.0.2"), task, host)
+				leavePeer.FSM.SetState(PeerStateLeave)
+				runningPeer := NewPeer(idgen.PeerID("127.0.0.3"), task, host)
+				runningPeer.FSM.SetState(PeerStateRunning)
+				runningPeer.UpdateAt.Store(time.Now().Add(-2 * time.Hour))
+				peerManager.Store(succeededPeer)
+				peerManager.Store(leavePeer)
+				peerManager.Store(runningPeer)
+
+				e := &evictor{config: &config.GCConfig{MaxPeers: 2}, hostManager: hostManager, taskManager: taskManager, peerManager: peerManager}
+				assert.NoError(e.RunGC())
+
+				_, ok := peerManager.Load(leavePeer.ID)
+				assert.False(ok)
+				_, ok = peerManager.Load(succeededPeer.ID)
+				assert.True(ok)
+				_, ok = peerManager.Load(runningPeer.ID)
+				assert.True(ok)
+				_, ok = task.LoadPeer(leavePeer.ID)
+				assert.False(ok)
+			},
+		},
+		{
+			name: "peers with children can not be evicted",
+			run: func(t *testing.T, hostManager HostManager, taskManager TaskManager, peerManager PeerManager) {
+				assert := assert.New(t)
+				host := NewHost(mockRawHost)
+				task := NewTask(mockTaskID, mockTaskURL, TaskTypeNormal, mockTaskURLMeta)
+
+				parent := NewPeer(idgen.PeerID("127.0.0.1"), task, host)
+				parent.FSM.SetState(PeerStateSucceeded)
+				child := NewPeer(idgen.PeerID("127.0.0.2"), task, host)
+				child.FSM.SetState(PeerStateRunning)
+				peerManager.Store(parent)
+				peerManager.Store(child)
+				child.ReplaceParent(parent)
+
+				e := &evictor{config: &config.GCConfig{MaxPeers: 1}, hostManager: hostManager, taskManager: taskManager, peerManager: peerManager}
+				assert.NoError(e.RunGC())
+
+				_, ok := peerManager.Load(parent.ID)
+				assert.True(ok)
+				_, ok = peerManager.Load(child.ID)
+				assert.True(ok)
+			},
+		},
+		{
+			name: "evict finished tasks first",
+			run: func(t *testing.T, hostManager HostManager, taskManager TaskManager, peerManager PeerManager) {
+				assert := assert.New(t)
+				host := NewHost(mockRawHost)
+
+				succeededTask := NewTask(idgen.TaskID("http://example.com/succeeded", mockTaskURLMeta), "http://example.com/succeeded", TaskTypeNormal, mockTaskURLMeta)
+				succeededTask.FSM.SetState(TaskStateSucceeded)
+				pendingTask := NewTask(idgen.TaskID("http://example.com/pending", mockTaskURLMeta), "http://example.com/pending", TaskTypeNormal, mockTaskURLMeta)
+				pendingTask.UpdateAt.Store(time.Now().Add(-time.Hour))
+				runningTask := NewTask(idgen.TaskID("http://example.com/running", mockTaskURLMeta), "http://example.com/running", TaskTypeNormal, mockTaskURLMeta)
+				runningTask.FSM.SetState(TaskStateRunning)
+				runningTask.UpdateAt.Store(time.Now().Add(-2 * time.Hour))
+				taskManager.Store(succeededTask)
+				taskManager.Store(pendingTask)
+				taskManager.Store(runningTask)
+
+				peer := NewPeer(mockPeerID, succeededTask, host)
+				peer.FSM.SetState(PeerStateSucceeded)
+				peerManager.Store(peer)
+
+				e := &evictor{config: &config.GCConfig{MaxTasks: 2}, hostManager: hostManager, taskManager: taskManager, peerManager: peerManager}
+				assert.NoError(e.RunGC())
+
+				_, ok := taskManager.Load(succeededTask.ID)
+				assert.False(ok)
+				_, ok = peerManager.Load(peer.ID)
+				assert.False(ok)
+				_, ok = taskManager.Load(pendingTask.ID)
+				assert.True(ok)
+				_, ok = taskManager.Load(runningTask.ID)
+				assert.True(ok)
+				assert.Equal(host.PeerCount.Load(), int32(0))
+			},
+		},
+		{
+			name: "tasks with active peers can not be evicted",
+			run: func(t *testing.T, hostManager HostManager, taskManager TaskManager, peerManager PeerManager) {
+				assert := assert.New(t)
+				host := NewHost(mockRawHost)
+				task := NewTask(mockTaskID, mockTaskURL, TaskTypeNormal, mockTaskURLMeta)
+				task.FSM.SetState(TaskStateSucceeded)
+				taskManager.Store(task)
+
+				peer := NewPeer(mockPeerID, task, host)
+				peer.FSM.SetState(PeerStateRunning)
+				peerManager.Store(peer)
+
+				e := &evictor{config: &config.GCConfig{MaxTasks: 1, MaxMemorySize: 1}, hostManager: hostManager, taskManager: taskManager, peerManager: peerManager}
+				assert.NoError(e.RunGC())
+
+				taskManager.Store(NewTask(idgen.TaskID("http://example.com/bar", mockTaskURLMeta), "http://example.com/bar", TaskTypeNormal, mockTaskURLMeta))
+				assert.NoError(e.RunGC())
+
+				_, ok := taskManager.Load(task.ID)
+				assert.True(ok)
+				_, ok = peerManager.Load(peer.ID)
+				assert.True(ok)
+			},
+		},
+		{
+			name: "persistent cache tasks and replicas can not be evicted",
+			run: func(t *testing.T, hostManager HostManager, taskManager TaskManager, peerManager PeerManager) {
+				assert := assert.New(t)
+				seedHost := NewHost(mockRawSeedHost, WithHostType(HostTypeSuperSeed))
+				task := NewTask(mockTaskID, mockTaskURL, TaskTypePersistentCache, mockTaskURLMeta, WithPersistentCache(1, time.Hour))
+				task.FSM.SetState(TaskStateSucceeded)
+				task.UpdateAt.Store(time.Now().Add(-time.Hour))
+				taskManager.Store(task)
+				taskManager.Store(NewTask(idgen.TaskID("http://example.com/bar", mockTaskURLMeta), "http://example.com/bar", TaskTypeNormal, mockTaskURLMeta))
+
+				peer := NewPeer(mockPeerID, task, seedHost)
+				peer.FSM.SetState(PeerStateSucceeded)
+				peerManager.Store(peer)
+				peerManager.Store(NewPeer(idgen.PeerID("127.0.0.2"), task, seedHost))
+
+				e := &evictor{config: &config.GCConfig{MaxTasks: 1, MaxPeers: 1}, hostManager: hostManager, taskManager: taskManager, peerManager: peerManager}
+				assert.NoError(e.RunGC())
+
+				_, ok := taskManager.Load(task.ID)
+				assert.True(ok)
+				_, ok = peerManager.Load(peer.ID)
+				assert.True(ok)
+			},
+		},
+		{
+			name: "evict tasks when estimated memory size exceeds the limit",
+			run: func(t *testing.T, hostManager HostManager, taskManager TaskManager, peerManager PeerManager) {
+				assert := assert.New(t)
+				largeTask := NewTask(idgen.TaskID("http://example.com/large", mockTaskURLMeta), "http://example.com/large", TaskTypeNormal, mockTaskURLMeta)
+				largeTask.FSM.SetState(TaskStateSucceeded)
+				largeTask.TotalPieceCount.Store(8192)
+				largeTask.UpdateAt.Store(time.Now().Add(-time.Hour))
+				smallTask := NewTask(idgen.TaskID("http://example.com/small", mockTaskURLMeta), "http://example.com/small", TaskTypeNormal, mockTaskURLMeta)
+				smallTask.FSM.SetState(TaskStateSucceeded)
+				taskManager.Store(largeTask)
+				taskManager.Store(smallTask)
+
+				e := &evictor{config: &config.GCConfig{MaxMemorySize: 1}, hostManager: hostManager, taskManager: taskManager, peerManager: peerManager}
+				assert.NoError(e.RunGC())
+
+				_, ok := taskManager.Load(largeTask.ID)
+				assert.False(ok)
+				_, ok = taskManager.Load(smallTask.ID)
+				assert.True(ok)
+			},
+		},
+		{
+			name: "tasks do not exceed the limits",
+			run: func(t *testing.T, hostManager HostManager, taskManager TaskManager, peerManager PeerManager) {
+				assert := assert.New(t)
+				task := NewTask(mockTaskID, mockTaskURL, TaskTypeNormal, mockTaskURLMeta)
+				task.FSM.SetState(TaskStateSucceeded)
+				taskManager.Store(task)
+
+				e := &evictor{config: &config.GCConfig{MaxTasks: 1, MaxMemorySize: 1}, hostManager: hostManager, taskManager: taskManager, peerManager: peerManager}
+				assert.NoError(e.RunGC())
+
+				_, ok := taskManager.Load(task.ID)
+				assert.True(ok)
+			},
+		},
+		{
+			name: "evict normal hosts without peers",
+			run: func(t *testing.T, hostManager HostManager, taskManager TaskManager, peerManager PeerManager) {
+				assert := assert.New(t)
+				idleHost := newMockEvictorHost("idle")
+				idleHost.UpdateAt.Store(time.Now().Add(-time.Hour))
+				seedHost := NewHost(mockRawSeedHost, WithHostType(HostTypeSuperSeed))
+				seedHost.UpdateAt.Store(time.Now().Add(-2 * time.Hour))
+				busyHost := newMockEvictorHost("busy")
+				busyHost.UpdateAt.Store(time.Now().Add(-3 * time.Hour))
+				recentHost := newMockEvictorHost("recent")
+				hostManager.Store(idleHost)
+				hostManager.Store(seedHost)
+				hostManager.Store(busyHost)
+				hostManager.Store(recentHost)
+
+				task := NewTask(mockTaskID, mockTaskURL, TaskTypeNormal, mockTaskURLMeta)
+				peerManager.Store(NewPeer(mockPeerID, task, busyHost))
+
+				e := &evictor{config: &config.GCConfig{MaxHosts: 3}, hostManager: hostManager, taskManager: taskManager, peerManager: peerManager}
+				assert.NoError(e.RunGC())
+
+				_, ok := hostManager.Load(idleHost.ID)
+				assert.False(ok)
+				_, ok = hostManager.Load(seedHost.ID)
+				assert.True(ok)
+				_, ok = hostManager.Load(busyHost.ID)
+				assert.True(ok)
+				_, ok = hostManager.Load(recentHost.ID)
+				assert.True(ok)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			gc := gc.NewMockGC(ctl)
+			gc.EXPECT().Add(gomock.Any()).Return(nil).Times(3)
+
+			hostManager, err := newHostManager(mockHostGCConfig, gc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			taskManager, err := newTaskManager(mockTaskGCConfig, gc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			peerManager, err := newPeerManager(mockPeerGCConfig, gc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tc.run(t, hostManager, taskManager, peerManager)
+		})
+	}
+}
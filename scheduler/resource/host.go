@@ -23,6 +23,7 @@ import (
 	"go.uber.org/atomic"
 
 	logger "d7y.io/dragonfly/v2/internal/dflog"
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
 	"d7y.io/dragonfly/v2/pkg/rpc/scheduler"
 	"d7y.io/dragonfly/v2/scheduler/config"
 )
@@ -62,6 +63,33 @@ func WithHostType(hostType HostType) HostOption {
 	}
 }
 
+const (
+	// probeSmoothingFactor is the reciprocal of weight of the new sample
+	// when smoothing the round-trip time, it is same as srtt of tcp.
+	probeSmoothingFactor = 8
+)
+
+// Probe is the round-trip time from host to another host.
+type Probe struct {
+	// RTT is the smoothed round-trip time.
+	RTT *atomic.Duration
+
+	// CreateAt is probe create time.
+	CreateAt *atomic.Time
+
+	// UpdateAt is probe update time.
+	UpdateAt *atomic.Time
+}
+
+// NewProbe returns a new probe with the first sample of round-trip time.
+func NewProbe(rtt time.Duration) *Probe {
+	return &Probe{
+		RTT:      atomic.NewDuration(rtt),
+		CreateAt: atomic.NewTime(time.Now()),
+		UpdateAt: atomic.NewTime(time.Now()),
+	}
+}
+
 type Host struct {
 	// ID is host id.
 	ID string
@@ -107,6 +135,20 @@ type Host struct {
 	// PeerCount is peer count.
 	PeerCount *atomic.Int32
 
+	// CPURatio is cpu usage ratio reported by host, range 0.0~1.0.
+	CPURatio *atomic.Float64
+
+	// MemRatio is memory usage ratio reported by host, range 0.0~1.0.
+	MemRatio *atomic.Float64
+
+	// DiskRatio is disk usage ratio reported by host, range 0.0~1.0.
+	DiskRatio *atomic.Float64
+
+	// Probes is the round-trip time to other hosts,
+	// key is host id of the probed host and value is *Probe.
+	// It is the row of host in the latency matrix of hosts.
+	Probes *sync.Map
+
 	// CreateAt is host create time.
 	CreateAt *atomic.Time
 
@@ -134,6 +176,10 @@ func NewHost(rawHost *scheduler.PeerHost, options ...HostOption) *Host {
 		UploadPeerCount: atomic.NewInt32(0),
 		Peers:           &sync.Map{},
 		PeerCount:       atomic.NewInt32(0),
+		CPURatio:        atomic.NewFloat64(0),
+		MemRatio:        atomic.NewFloat64(0),
+		DiskRatio:       atomic.NewFloat64(0),
+		Probes:          &sync.Map{},
 		CreateAt:        atomic.NewTime(time.Now()),
 		UpdateAt:        atomic.NewTime(time.Now()),
 		Log:             logger.WithHostID(rawHost.Id),
@@ -181,6 +227,47 @@ func (h *Host) DeletePeer(key string) {
 	}
 }
 
+// StoreLoad set host load reported by dfdaemon.
+func (h *Host) StoreLoad(load *base.HostLoad) {
+	h.CPURatio.Store(float64(load.CpuRatio))
+	h.MemRatio.Store(float64(load.MemRatio))
+	h.DiskRatio.Store(float64(load.DiskRatio))
+	h.UpdateAt.Store(time.Now())
+}
+
+// LoadProbe returns the smoothed round-trip time to the host.
+func (h *Host) LoadProbe(hostID string) (*Probe, bool) {
+	rawProbe, ok := h.Probes.Load(hostID)
+	if !ok {
+		return nil, false
+	}
+
+	return rawProbe.(*Probe), ok
+}
+
+// StoreProbe stores the round-trip time to the host,
+// the round-trip time is smoothed with the samples probed before.
+func (h *Host) StoreProbe(hostID string, rtt time.Duration) {
+	rawProbe, loaded := h.Probes.LoadOrStore(hostID, NewProbe(rtt))
+	if !loaded {
+		return
+	}
+
+	probe := rawProbe.(*Probe)
+	for {
+		old := probe.RTT.Load()
+		if probe.RTT.CAS(old, old+(rtt-old)/probeSmoothingFactor) {
+			break
+		}
+	}
+	probe.UpdateAt.Store(time.Now())
+}
+
+// DeleteProbe deletes the round-trip time to the host.
+func (h *Host) DeleteProbe(hostID string) {
+	h.Probes.Delete(hostID)
+}
+
 // LeavePeers set peer state to PeerStateLeave.
 func (h *Host) LeavePeers() {
 	h.Peers.Range(func(_, value interface{}) bool {
@@ -46,6 +46,10 @@ type HostManager interface {
 	// Delete deletes host for a key.
 	Delete(string)
 
+	// Range calls f sequentially for each key and host present in the map.
+	// If f returns false, range stops the iteration.
+	Range(f func(key, value interface{}) bool)
+
 	// Try to reclaim host.
 	RunGC() error
 }
@@ -102,6 +106,16 @@ func (h *hostManager) Delete(key string) {
 func (h *hostManager) RunGC() error {
 	h.Map.Range(func(_, value interface{}) bool {
 		host := value.(*Host)
+
+		// Reclaim the stale probes, including the probes to the reclaimed hosts.
+		host.Probes.Range(func(key, value interface{}) bool {
+			if time.Since(value.(*Probe).UpdateAt.Load()) > h.ttl {
+				host.DeleteProbe(key.(string))
+			}
+
+			return true
+		})
+
 		elapsed := time.Since(host.UpdateAt.Load())
 
 		if elapsed > h.ttl &&
@@ -372,6 +372,25 @@ func TestHostManager_RunGC(t *testing.T) {
 				assert.Equal(host.ID, mockSeedHost.ID)
 			},
 		},
+		{
+			name: "probes of host reclaimed",
+			mock: func(m *gc.MockGCMockRecorder) {
+				m.Add(gomock.Any()).Return(nil).Times(1)
+			},
+			expect: func(t *testing.T, hostManager HostManager, mockHost *Host, mockPeer *Peer) {
+				assert := assert.New(t)
+				mockSeedHost := NewHost(mockRawSeedHost, WithHostType(HostTypeSuperSeed))
+				mockSeedHost.StoreProbe(mockHost.ID, 10*time.Millisecond)
+				probe, _ := mockSeedHost.LoadProbe(mockHost.ID)
+				probe.UpdateAt.Store(time.Now().Add(-time.Second))
+				hostManager.Store(mockSeedHost)
+				err := hostManager.RunGC()
+				assert.NoError(err)
+
+				_, ok := mockSeedHost.LoadProbe(mockHost.ID)
+				assert.Equal(ok, false)
+			},
+		},
 	}
 
 	for _, tc := range tests {
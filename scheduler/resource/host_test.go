@@ -18,10 +18,12 @@ package resource
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"d7y.io/dragonfly/v2/pkg/idgen"
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
 	"d7y.io/dragonfly/v2/pkg/rpc/scheduler"
 	"d7y.io/dragonfly/v2/scheduler/config"
 )
@@ -76,6 +78,9 @@ func TestHost_NewHost(t *testing.T) {
 				assert.Equal(host.NetTopology, mockRawHost.NetTopology)
 				assert.Equal(host.UploadLoadLimit.Load(), int32(config.DefaultClientLoadLimit))
 				assert.Equal(host.PeerCount.Load(), int32(0))
+				assert.Equal(host.CPURatio.Load(), float64(0))
+				assert.Equal(host.MemRatio.Load(), float64(0))
+				assert.Equal(host.DiskRatio.Load(), float64(0))
 				assert.NotEqual(host.CreateAt.Load(), 0)
 				assert.NotEqual(host.UpdateAt.Load(), 0)
 				assert.NotNil(host.Log)
@@ -321,6 +326,170 @@ func TestHost_DeletePeer(t *testing.T) {
 	}
 }
 
+func TestHost_StoreLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		rawHost *scheduler.PeerHost
+		load    *base.HostLoad
+		expect  func(t *testing.T, host *Host)
+	}{
+		{
+			name:    "store host load",
+			rawHost: mockRawHost,
+			load: &base.HostLoad{
+				CpuRatio:  0.5,
+				MemRatio:  0.25,
+				DiskRatio: 0.75,
+			},
+			expect: func(t *testing.T, host *Host) {
+				assert := assert.New(t)
+				assert.Equal(host.CPURatio.Load(), float64(0.5))
+				assert.Equal(host.MemRatio.Load(), float64(0.25))
+				assert.Equal(host.DiskRatio.Load(), float64(0.75))
+			},
+		},
+		{
+			name:    "store empty host load",
+			rawHost: mockRawHost,
+			load:    &base.HostLoad{},
+			expect: func(t *testing.T, host *Host) {
+				assert := assert.New(t)
+				assert.Equal(host.CPURatio.Load(), float64(0))
+				assert.Equal(host.MemRatio.Load(), float64(0))
+				assert.Equal(host.DiskRatio.Load(), float64(0))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			host := NewHost(tc.rawHost)
+			host.StoreLoad(tc.load)
+			tc.expect(t, host)
+		})
+	}
+}
+
+func TestHost_LoadProbe(t *testing.T) {
+	tests := []struct {
+		name    string
+		rawHost *scheduler.PeerHost
+		hostID  string
+		expect  func(t *testing.T, probe *Probe, ok bool)
+	}{
+		{
+			name:    "load probe",
+			rawHost: mockRawHost,
+			hostID:  mockRawSeedHost.Id,
+			expect: func(t *testing.T, probe *Probe, ok bool) {
+				assert := assert.New(t)
+				assert.Equal(ok, true)
+				assert.Equal(probe.RTT.Load(), 10*time.Millisecond)
+			},
+		},
+		{
+			name:    "probe does not exist",
+			rawHost: mockRawHost,
+			hostID:  "foo",
+			expect: func(t *testing.T, probe *Probe, ok bool) {
+				assert := assert.New(t)
+				assert.Equal(ok, false)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			host := NewHost(tc.rawHost)
+			host.StoreProbe(mockRawSeedHost.Id, 10*time.Millisecond)
+			probe, ok := host.LoadProbe(tc.hostID)
+			tc.expect(t, probe, ok)
+		})
+	}
+}
+
+func TestHost_StoreProbe(t *testing.T) {
+	tests := []struct {
+		name    string
+		rawHost *scheduler.PeerHost
+		rtts    []time.Duration
+		expect  func(t *testing.T, host *Host)
+	}{
+		{
+			name:    "store probe",
+			rawHost: mockRawHost,
+			rtts:    []time.Duration{10 * time.Millisecond},
+			expect: func(t *testing.T, host *Host) {
+				assert := assert.New(t)
+				probe, ok := host.LoadProbe(mockRawSeedHost.Id)
+				assert.Equal(ok, true)
+				assert.Equal(probe.RTT.Load(), 10*time.Millisecond)
+			},
+		},
+		{
+			name:    "store probes and smooth round-trip time",
+			rawHost: mockRawHost,
+			rtts:    []time.Duration{10 * time.Millisecond, 18 * time.Millisecond},
+			expect: func(t *testing.T, host *Host) {
+				assert := assert.New(t)
+				probe, ok := host.LoadProbe(mockRawSeedHost.Id)
+				assert.Equal(ok, true)
+				assert.Equal(probe.RTT.Load(), 11*time.Millisecond)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			host := NewHost(tc.rawHost)
+			for _, rtt := range tc.rtts {
+				host.StoreProbe(mockRawSeedHost.Id, rtt)
+			}
+
+			tc.expect(t, host)
+		})
+	}
+}
+
+func TestHost_DeleteProbe(t *testing.T) {
+	tests := []struct {
+		name    string
+		rawHost *scheduler.PeerHost
+		hostID  string
+		expect  func(t *testing.T, host *Host)
+	}{
+		{
+			name:    "delete probe",
+			rawHost: mockRawHost,
+			hostID:  mockRawSeedHost.Id,
+			expect: func(t *testing.T, host *Host) {
+				assert := assert.New(t)
+				_, ok := host.LoadProbe(mockRawSeedHost.Id)
+				assert.Equal(ok, false)
+			},
+		},
+		{
+			name:    "delete key is empty",
+			rawHost: mockRawHost,
+			hostID:  "",
+			expect: func(t *testing.T, host *Host) {
+				assert := assert.New(t)
+				_, ok := host.LoadProbe(mockRawSeedHost.Id)
+				assert.Equal(ok, true)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			host := NewHost(tc.rawHost)
+			host.StoreProbe(mockRawSeedHost.Id, 10*time.Millisecond)
+			host.DeleteProbe(tc.hostID)
+			tc.expect(t, host)
+		})
+	}
+}
+
 func TestHost_LeavePeers(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -32,6 +32,7 @@ import (
 
 	logger "d7y.io/dragonfly/v2/internal/dflog"
 	"d7y.io/dragonfly/v2/pkg/container/set"
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
 	"d7y.io/dragonfly/v2/pkg/rpc/scheduler"
 )
 
@@ -41,6 +42,9 @@ const (
 
 	// Download tiny file timeout.
 	downloadTinyFileContextTimeout = 30 * time.Second
+
+	// Number of the recent pieces measuring bandwidth.
+	bandwidthWindowSize = 16
 )
 
 const (
@@ -98,6 +102,15 @@ const (
 	PeerEventLeave = "Leave"
 )
 
+// recentPiece is the downloaded piece measuring bandwidth.
+type recentPiece struct {
+	// size is the size of piece.
+	size uint64
+
+	// beginAt is the time of starting downloading piece.
+	beginAt time.Time
+}
+
 // PeerOption is a functional option for configuring the peer.
 type PeerOption func(p *Peer) *Peer
 
@@ -109,6 +122,14 @@ func WithBizTag(bizTag string) PeerOption {
 	}
 }
 
+// WithPriority sets peer's Priority.
+func WithPriority(priority base.Priority) PeerOption {
+	return func(p *Peer) *Peer {
+		p.Priority = priority
+		return p
+	}
+}
+
 type Peer struct {
 	// ID is peer id.
 	ID string
@@ -116,12 +137,24 @@ type Peer struct {
 	// BizTag is peer biz tag.
 	BizTag string
 
+	// Priority is peer download priority.
+	Priority base.Priority
+
 	// Pieces is piece bitset.
 	Pieces *bitset.BitSet
 
 	// pieceCosts is piece downloaded time.
 	pieceCosts []int64
 
+	// parentPieceCosts is piece downloaded time from the parent of parentPieceCostsID,
+	// it is reset when the peer downloads piece from another parent.
+	parentPieceCosts   []int64
+	parentPieceCostsID string
+
+	// recentPieces is the recent downloaded pieces measuring bandwidth.
+	recentPieces   []recentPiece
+	recentPiecesMu sync.Mutex
+
 	// Stream is grpc stream instance.
 	Stream *atomic.Value
 
@@ -181,6 +214,7 @@ func NewPeer(id string, task *Task, host *Host, options ...PeerOption) *Peer {
 	p := &Peer{
 		ID:               id,
 		BizTag:           DefaultBizTag,
+		Priority:         base.Priority_NORMAL_PRIORITY,
 		Pieces:           &bitset.BitSet{},
 		pieceCosts:       []int64{},
 		Stream:           &atomic.Value{},
@@ -463,6 +497,12 @@ func (p *Peer) isDescendant(ancestor, descendant *Peer) bool {
 	return false
 }
 
+// IsReplica returns whether the peer is a replica of persistent cache task
+// which is downloaded by seed peer.
+func (p *Peer) IsReplica() bool {
+	return p.Host.Type != HostTypeNormal && p.Task.IsPersistent() && p.FSM.Is(PeerStateSucceeded)
+}
+
 // AppendPieceCost append piece cost to costs slice.
 func (p *Peer) AppendPieceCost(cost int64) {
 	p.pieceCosts = append(p.pieceCosts, cost)
@@ -473,6 +513,72 @@ func (p *Peer) PieceCosts() []int64 {
 	return p.pieceCosts
 }
 
+// AppendParentPieceCost append cost of piece downloaded from parent to costs slice,
+// costs slice is reset when the parent is changed.
+func (p *Peer) AppendParentPieceCost(parentID string, cost int64) {
+	if p.parentPieceCostsID != parentID {
+		p.parentPieceCostsID = parentID
+		p.parentPieceCosts = []int64{}
+	}
+
+	p.parentPieceCosts = append(p.parentPieceCosts, cost)
+}
+
+// ParentPieceCosts return costs of pieces downloaded from parent.
+func (p *Peer) ParentPieceCosts(parentID string) []int64 {
+	if p.parentPieceCostsID != parentID {
+		return []int64{}
+	}
+
+	return p.parentPieceCosts
+}
+
+// AppendRecentPiece appends the downloaded piece measuring bandwidth,
+// only the latest pieces of bandwidthWindowSize are kept.
+func (p *Peer) AppendRecentPiece(size uint64, cost time.Duration) {
+	p.recentPiecesMu.Lock()
+	defer p.recentPiecesMu.Unlock()
+
+	p.recentPieces = append(p.recentPieces, recentPiece{
+		size:    size,
+		beginAt: time.Now().Add(-cost),
+	})
+
+	if len(p.recentPieces) > bandwidthWindowSize {
+		p.recentPieces = p.recentPieces[len(p.recentPieces)-bandwidthWindowSize:]
+	}
+}
+
+// Bandwidth returns the downloading bandwidth of peer, it is the size of recent
+// downloaded pieces divided by the elapsed time since the earliest of them began,
+// so the bandwidth falls when the peer stops downloading pieces.
+func (p *Peer) Bandwidth() float64 {
+	p.recentPiecesMu.Lock()
+	defer p.recentPiecesMu.Unlock()
+
+	if len(p.recentPieces) == 0 {
+		return 0
+	}
+
+	var (
+		size    uint64
+		beginAt = p.recentPieces[0].beginAt
+	)
+	for _, piece := range p.recentPieces {
+		size += piece.size
+		if piece.beginAt.Before(beginAt) {
+			beginAt = piece.beginAt
+		}
+	}
+
+	elapsed := time.Since(beginAt).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+
+	return float64(size) / elapsed
+}
+
 // LoadStream return grpc stream.
 func (p *Peer) LoadStream() (scheduler.Scheduler_ReportPieceResultServer, bool) {
 	rawStream := p.Stream.Load()
@@ -493,6 +599,29 @@ func (p *Peer) DeleteStream() {
 	p.Stream = &atomic.Value{}
 }
 
+// CompareAndDeleteStream deletes grpc stream if it is the given stream,
+// it avoids deleting the new stream when the peer resumes with the new stream.
+func (p *Peer) CompareAndDeleteStream(stream scheduler.Scheduler_ReportPieceResultServer) bool {
+	if rawStream, ok := p.LoadStream(); !ok || rawStream != stream {
+		return false
+	}
+
+	p.DeleteStream()
+	return true
+}
+
+// StorePieces merges the bitmap of finished pieces into peer's piece bitset,
+// the most significant bit of byte i/8 represents piece i.
+func (p *Peer) StorePieces(bitmap []byte) {
+	for i, b := range bitmap {
+		for j := 0; j < 8; j++ {
+			if b&(1<<uint(7-j)) != 0 {
+				p.Pieces.Set(uint(i*8 + j))
+			}
+		}
+	}
+}
+
 // DownloadTinyFile downloads tiny file from peer.
 func (p *Peer) DownloadTinyFile() ([]byte, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), downloadTinyFileContextTimeout)
@@ -46,6 +46,10 @@ type PeerManager interface {
 	// Delete deletes peer for a key.
 	Delete(string)
 
+	// Range calls f sequentially for each key and peer present in the map.
+	// If f returns false, range stops the iteration.
+	Range(f func(key, value interface{}) bool)
+
 	// Try to reclaim peer.
 	RunGC() error
 }
@@ -128,6 +132,11 @@ func (p *peerManager) RunGC() error {
 		peer := value.(*Peer)
 		elapsed := time.Since(peer.UpdateAt.Load())
 
+		// Replicas of persistent cache task are kept until the task expires.
+		if peer.IsReplica() {
+			return true
+		}
+
 		if elapsed > p.ttl && peer.ChildCount.Load() == 0 {
 			// If the status is PeerStateLeave,
 			// clear peer information.
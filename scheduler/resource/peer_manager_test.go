@@ -329,6 +329,25 @@ func TestPeerManager_RunGC(t *testing.T) {
 				assert.Equal(peer.FSM.Current(), PeerStateLeave)
 			},
 		},
+		{
+			name: "peer is replica of persistent cache task",
+			mock: func(m *gc.MockGCMockRecorder) {
+				m.Add(gomock.Any()).Return(nil).Times(1)
+			},
+			expect: func(t *testing.T, peerManager PeerManager, mockPeer *Peer) {
+				assert := assert.New(t)
+				mockPeer.Host.Type = HostTypeSuperSeed
+				mockPeer.Task.Persist(1, time.Hour)
+				peerManager.Store(mockPeer)
+				mockPeer.FSM.SetState(PeerStateSucceeded)
+				err := peerManager.RunGC()
+				assert.NoError(err)
+
+				peer, ok := peerManager.Load(mockPeer.ID)
+				assert.Equal(ok, true)
+				assert.Equal(peer.FSM.Current(), PeerStateSucceeded)
+			},
+		},
 		{
 			name: "peer reclaimed",
 			mock: func(m *gc.MockGCMockRecorder) {
@@ -24,6 +24,7 @@ import (
 	"net/url"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/go-http-utils/headers"
 	"github.com/golang/mock/gomock"
@@ -755,6 +756,107 @@ func TestPeer_PieceCosts(t *testing.T) {
 	}
 }
 
+func TestPeer_ParentPieceCosts(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect func(t *testing.T, peer *Peer)
+	}{
+		{
+			name: "parent piece costs slice is not empty",
+			expect: func(t *testing.T, peer *Peer) {
+				assert := assert.New(t)
+				peer.AppendParentPieceCost("foo", 1)
+				peer.AppendParentPieceCost("foo", 2)
+				assert.Equal(peer.ParentPieceCosts("foo"), []int64{1, 2})
+			},
+		},
+		{
+			name: "parent piece costs slice is reset when parent changes",
+			expect: func(t *testing.T, peer *Peer) {
+				assert := assert.New(t)
+				peer.AppendParentPieceCost("foo", 1)
+				peer.AppendParentPieceCost("bar", 2)
+				assert.Equal(peer.ParentPieceCosts("bar"), []int64{2})
+				assert.Equal(len(peer.ParentPieceCosts("foo")), 0)
+			},
+		},
+		{
+			name: "parent piece costs slice is empty",
+			expect: func(t *testing.T, peer *Peer) {
+				assert := assert.New(t)
+				assert.Equal(len(peer.ParentPieceCosts("foo")), 0)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockHost := NewHost(mockRawHost)
+			mockTask := NewTask(mockTaskID, mockTaskURL, TaskTypeNormal, mockTaskURLMeta, WithBackToSourceLimit(mockTaskBackToSourceLimit))
+			peer := NewPeer(mockPeerID, mockTask, mockHost)
+
+			tc.expect(t, peer)
+		})
+	}
+}
+
+func TestPeer_Bandwidth(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect func(t *testing.T, peer *Peer)
+	}{
+		{
+			name: "peer has downloaded pieces",
+			expect: func(t *testing.T, peer *Peer) {
+				assert := assert.New(t)
+				peer.AppendRecentPiece(1024, 2*time.Second)
+				peer.AppendRecentPiece(1024, time.Second)
+				assert.InDelta(peer.Bandwidth(), float64(1024), 10)
+			},
+		},
+		{
+			name: "peer stops downloading pieces",
+			expect: func(t *testing.T, peer *Peer) {
+				assert := assert.New(t)
+				peer.AppendRecentPiece(1024, time.Second)
+				peer.recentPieces[0].beginAt = time.Now().Add(-4 * time.Second)
+				assert.InDelta(peer.Bandwidth(), float64(256), 10)
+			},
+		},
+		{
+			name: "only recent pieces are measured",
+			expect: func(t *testing.T, peer *Peer) {
+				assert := assert.New(t)
+				peer.CreateAt.Store(time.Now().Add(-time.Hour))
+				peer.AppendRecentPiece(1, time.Hour)
+				for i := 0; i < bandwidthWindowSize; i++ {
+					peer.AppendRecentPiece(1024, time.Second)
+				}
+				assert.Len(peer.recentPieces, bandwidthWindowSize)
+				assert.InDelta(peer.Bandwidth(), float64(1024*bandwidthWindowSize), 100)
+			},
+		},
+		{
+			name: "peer has not downloaded pieces",
+			expect: func(t *testing.T, peer *Peer) {
+				assert := assert.New(t)
+				peer.Pieces.Set(0)
+				assert.Equal(peer.Bandwidth(), float64(0))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockHost := NewHost(mockRawHost)
+			mockTask := NewTask(mockTaskID, mockTaskURL, TaskTypeNormal, mockTaskURLMeta, WithBackToSourceLimit(mockTaskBackToSourceLimit))
+			peer := NewPeer(mockPeerID, mockTask, mockHost)
+
+			tc.expect(t, peer)
+		})
+	}
+}
+
 func TestPeer_LoadStream(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -856,6 +958,175 @@ func TestPeer_DeleteStream(t *testing.T) {
 	}
 }
 
+func TestPeer_CompareAndDeleteStream(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect func(t *testing.T, peer *Peer, stream, newStream scheduler.Scheduler_ReportPieceResultServer)
+	}{
+		{
+			name: "delete stream",
+			expect: func(t *testing.T, peer *Peer, stream, newStream scheduler.Scheduler_ReportPieceResultServer) {
+				assert := assert.New(t)
+				peer.StoreStream(stream)
+				assert.True(peer.CompareAndDeleteStream(stream))
+				_, ok := peer.LoadStream()
+				assert.False(ok)
+			},
+		},
+		{
+			name: "stream has been replaced",
+			expect: func(t *testing.T, peer *Peer, stream, newStream scheduler.Scheduler_ReportPieceResultServer) {
+				assert := assert.New(t)
+				peer.StoreStream(stream)
+				peer.StoreStream(newStream)
+				assert.False(peer.CompareAndDeleteStream(stream))
+				s, ok := peer.LoadStream()
+				assert.True(ok)
+				assert.Equal(s, newStream)
+			},
+		},
+		{
+			name: "stream does not exist",
+			expect: func(t *testing.T, peer *Peer, stream, newStream scheduler.Scheduler_ReportPieceResultServer) {
+				assert := assert.New(t)
+				assert.False(peer.CompareAndDeleteStream(stream))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			stream := mocks.NewMockScheduler_ReportPieceResultServer(ctl)
+			newStream := mocks.NewMockScheduler_ReportPieceResultServer(ctl)
+
+			mockHost := NewHost(mockRawHost)
+			mockTask := NewTask(mockTaskID, mockTaskURL, TaskTypeNormal, mockTaskURLMeta, WithBackToSourceLimit(mockTaskBackToSourceLimit))
+			peer := NewPeer(mockPeerID, mockTask, mockHost)
+			tc.expect(t, peer, stream, newStream)
+		})
+	}
+}
+
+func TestPeer_StorePieces(t *testing.T) {
+	tests := []struct {
+		name   string
+		bitmap []byte
+		expect func(t *testing.T, peer *Peer)
+	}{
+		{
+			name:   "store pieces",
+			bitmap: []byte{0xc0, 0x00, 0x01},
+			expect: func(t *testing.T, peer *Peer) {
+				assert := assert.New(t)
+				assert.Equal(peer.Pieces.Count(), uint(4))
+				assert.True(peer.Pieces.Test(0))
+				assert.True(peer.Pieces.Test(1))
+				assert.True(peer.Pieces.Test(5))
+				assert.True(peer.Pieces.Test(23))
+			},
+		},
+		{
+			name:   "bitmap is empty",
+			bitmap: []byte{},
+			expect: func(t *testing.T, peer *Peer) {
+				assert := assert.New(t)
+				assert.Equal(peer.Pieces.Count(), uint(1))
+				assert.True(peer.Pieces.Test(5))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockHost := NewHost(mockRawHost)
+			mockTask := NewTask(mockTaskID, mockTaskURL, TaskTypeNormal, mockTaskURLMeta, WithBackToSourceLimit(mockTaskBackToSourceLimit))
+			peer := NewPeer(mockPeerID, mockTask, mockHost)
+			peer.Pieces.Set(5)
+			peer.StorePieces(tc.bitmap)
+			tc.expect(t, peer)
+		})
+	}
+}
+
+func TestPeer_IsReplica(t *testing.T) {
+	tests := []struct {
+		name   string
+		run    func(peer *Peer)
+		expect func(t *testing.T, peer *Peer)
+	}{
+		{
+			name: "peer is replica",
+			run: func(peer *Peer) {
+				peer.Host.Type = HostTypeSuperSeed
+				peer.Task.Persist(1, time.Hour)
+				peer.FSM.SetState(PeerStateSucceeded)
+			},
+			expect: func(t *testing.T, peer *Peer) {
+				assert := assert.New(t)
+				assert.Equal(peer.IsReplica(), true)
+			},
+		},
+		{
+			name: "peer is not in seed host",
+			run: func(peer *Peer) {
+				peer.Task.Persist(1, time.Hour)
+				peer.FSM.SetState(PeerStateSucceeded)
+			},
+			expect: func(t *testing.T, peer *Peer) {
+				assert := assert.New(t)
+				assert.Equal(peer.IsReplica(), false)
+			},
+		},
+		{
+			name: "task of peer is not persistent",
+			run: func(peer *Peer) {
+				peer.Host.Type = HostTypeSuperSeed
+				peer.FSM.SetState(PeerStateSucceeded)
+			},
+			expect: func(t *testing.T, peer *Peer) {
+				assert := assert.New(t)
+				assert.Equal(peer.IsReplica(), false)
+			},
+		},
+		{
+			name: "peer is downloading",
+			run: func(peer *Peer) {
+				peer.Host.Type = HostTypeSuperSeed
+				peer.Task.Persist(1, time.Hour)
+				peer.FSM.SetState(PeerStateRunning)
+			},
+			expect: func(t *testing.T, peer *Peer) {
+				assert := assert.New(t)
+				assert.Equal(peer.IsReplica(), false)
+			},
+		},
+		{
+			name: "peer has left",
+			run: func(peer *Peer) {
+				peer.Host.Type = HostTypeSuperSeed
+				peer.Task.Persist(1, time.Hour)
+				peer.FSM.SetState(PeerStateLeave)
+			},
+			expect: func(t *testing.T, peer *Peer) {
+				assert := assert.New(t)
+				assert.Equal(peer.IsReplica(), false)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockHost := NewHost(mockRawHost)
+			mockTask := NewTask(mockTaskID, mockTaskURL, TaskTypePersistentCache, mockTaskURLMeta)
+			peer := NewPeer(mockPeerID, mockTask, mockHost)
+			tc.run(peer)
+			tc.expect(t, peer)
+		})
+	}
+}
+
 func TestPeer_DownloadTinyFile(t *testing.T) {
 	testData := []byte("./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz" +
 		"./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
@@ -0,0 +1,84 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+
+	"d7y.io/dragonfly/v2/pkg/container/set"
+	pkggc "d7y.io/dragonfly/v2/pkg/gc"
+	"d7y.io/dragonfly/v2/scheduler/config"
+)
+
+const (
+	// GC replication id.
+	GCReplicationID = "replication"
+)
+
+// replicator re-replicates the persistent cache tasks to seed peers,
+// when the replicas are lost because of the seed peers disappear.
+type replicator struct {
+	// Task manager interface.
+	taskManager TaskManager
+
+	// SeedPeer interface.
+	seedPeer SeedPeer
+
+	// replicating is the set of task ids which are replicating.
+	replicating set.SafeSet
+}
+
+// newReplicator adds the replicator to gc.
+func newReplicator(cfg *config.GCConfig, gc pkggc.GC, taskManager TaskManager, seedPeer SeedPeer) error {
+	return gc.Add(pkggc.Task{
+		ID:       GCReplicationID,
+		Interval: cfg.ReplicationInterval,
+		Timeout:  cfg.ReplicationInterval,
+		Runner: &replicator{
+			taskManager: taskManager,
+			seedPeer:    seedPeer,
+			replicating: set.NewSafeSet(),
+		},
+	})
+}
+
+func (r *replicator) RunGC() error {
+	r.taskManager.Range(func(_, value interface{}) bool {
+		task, ok := value.(*Task)
+		if !ok || !task.IsPersistent() {
+			return true
+		}
+
+		// The replication may take longer than the interval of gc,
+		// so the task which is replicating is skipped.
+		if !r.replicating.Add(task.ID) {
+			return true
+		}
+
+		go func() {
+			defer r.replicating.Delete(task.ID)
+
+			if err := r.seedPeer.ReplicateTask(context.Background(), task); err != nil {
+				task.Log.Errorf("replicate task failed: %s", err.Error())
+			}
+		}()
+
+		return true
+	})
+
+	return nil
+}
@@ -0,0 +1,147 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"d7y.io/dragonfly/v2/pkg/container/set"
+	"d7y.io/dragonfly/v2/pkg/gc"
+	"d7y.io/dragonfly/v2/pkg/idgen"
+	"d7y.io/dragonfly/v2/scheduler/config"
+)
+
+func TestReplicator_newReplicator(t *testing.T) {
+	tests := []struct {
+		name   string
+		mock   func(m *gc.MockGCMockRecorder)
+		expect func(t *testing.T, err error)
+	}{
+		{
+			name: "new replicator",
+			mock: func(m *gc.MockGCMockRecorder) {
+				m.Add(gomock.Any()).Return(nil).Times(1)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+			},
+		},
+		{
+			name: "new replicator failed because of gc error",
+			mock: func(m *gc.MockGCMockRecorder) {
+				m.Add(gomock.Any()).Return(errors.New("foo")).Times(1)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				assert.EqualError(err, "foo")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			gc := gc.NewMockGC(ctl)
+			tc.mock(gc.EXPECT())
+
+			tc.expect(t, newReplicator(&config.GCConfig{ReplicationInterval: time.Minute}, gc, nil, nil))
+		})
+	}
+}
+
+func TestReplicator_RunGC(t *testing.T) {
+	tests := []struct {
+		name   string
+		tasks  []*Task
+		mock   func(tasks []*Task, wg *sync.WaitGroup, ms *MockSeedPeerMockRecorder)
+		expect func(t *testing.T, r *replicator)
+	}{
+		{
+			name: "replicate persistent cache tasks",
+			tasks: []*Task{
+				NewTask(mockTaskID, mockTaskURL, TaskTypePersistentCache, mockTaskURLMeta, WithPersistentCache(1, time.Hour)),
+				NewTask(idgen.TaskID("http://example.com/bar", mockTaskURLMeta), "http://example.com/bar", TaskTypeNormal, mockTaskURLMeta),
+			},
+			mock: func(tasks []*Task, wg *sync.WaitGroup, ms *MockSeedPeerMockRecorder) {
+				wg.Add(1)
+				ms.ReplicateTask(gomock.Any(), gomock.Eq(tasks[0])).Do(func(ctx context.Context, task *Task) { wg.Done() }).Return(nil).Times(1)
+			},
+			expect: func(t *testing.T, r *replicator) {
+				assert := assert.New(t)
+				assert.NoError(r.RunGC())
+			},
+		},
+		{
+			name: "persistent cache task has expired",
+			tasks: []*Task{
+				NewTask(mockTaskID, mockTaskURL, TaskTypePersistentCache, mockTaskURLMeta, WithPersistentCache(1, -time.Second)),
+			},
+			mock: func(tasks []*Task, wg *sync.WaitGroup, ms *MockSeedPeerMockRecorder) {
+			},
+			expect: func(t *testing.T, r *replicator) {
+				assert := assert.New(t)
+				assert.NoError(r.RunGC())
+			},
+		},
+		{
+			name: "task which is replicating is skipped",
+			tasks: []*Task{
+				NewTask(mockTaskID, mockTaskURL, TaskTypePersistentCache, mockTaskURLMeta, WithPersistentCache(1, time.Hour)),
+			},
+			mock: func(tasks []*Task, wg *sync.WaitGroup, ms *MockSeedPeerMockRecorder) {
+			},
+			expect: func(t *testing.T, r *replicator) {
+				assert := assert.New(t)
+				r.replicating.Add(mockTaskID)
+				assert.NoError(r.RunGC())
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			gc := gc.NewMockGC(ctl)
+			gc.EXPECT().Add(gomock.Any()).Return(nil).Times(1)
+			seedPeer := NewMockSeedPeer(ctl)
+
+			taskManager, err := newTaskManager(mockTaskGCConfig, gc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, task := range tc.tasks {
+				taskManager.Store(task)
+			}
+
+			var wg sync.WaitGroup
+			tc.mock(tc.tasks, &wg, seedPeer.EXPECT())
+			tc.expect(t, &replicator{taskManager: taskManager, seedPeer: seedPeer, replicating: set.NewSafeSet()})
+			wg.Wait()
+		})
+	}
+}
@@ -37,6 +37,12 @@ type Resource interface {
 
 	// Task manager interface.
 	TaskManager() TaskManager
+
+	// Snapshot returns the state of hosts, tasks and peers.
+	Snapshot() *Snapshot
+
+	// Restore recovers hosts, tasks and peers from snapshot.
+	Restore(*Snapshot)
 }
 
 type resource struct {
@@ -77,6 +83,13 @@ func New(cfg *config.Config, gc gc.GC, dynconfig config.DynconfigInterface, opts
 	}
 	resource.peerManager = peerManager
 
+	// Initialize evictor when the limits of resource are configured.
+	if cfg.Scheduler.GC.EvictionEnabled() {
+		if err := newEvictor(cfg.Scheduler.GC, gc, hostManager, taskManager, peerManager); err != nil {
+			return nil, err
+		}
+	}
+
 	// Initialize seed peer interface.
 	if cfg.SeedPeer.Enable {
 		client, err := newSeedPeerClient(dynconfig, hostManager, opts...)
@@ -85,6 +98,11 @@ func New(cfg *config.Config, gc gc.GC, dynconfig config.DynconfigInterface, opts
 		}
 
 		resource.seedPeer = newSeedPeer(client, peerManager, hostManager)
+
+		// Initialize replicator of persistent cache tasks.
+		if err := newReplicator(cfg.Scheduler.GC, gc, taskManager, resource.seedPeer); err != nil {
+			return nil, err
+		}
 	}
 
 	return resource, nil
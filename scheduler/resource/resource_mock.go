@@ -61,6 +61,18 @@ func (mr *MockResourceMockRecorder) PeerManager() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerManager", reflect.TypeOf((*MockResource)(nil).PeerManager))
 }
 
+// Restore mocks base method.
+func (m *MockResource) Restore(arg0 *Snapshot) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Restore", arg0)
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockResourceMockRecorder) Restore(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockResource)(nil).Restore), arg0)
+}
+
 // SeedPeer mocks base method.
 func (m *MockResource) SeedPeer() SeedPeer {
 	m.ctrl.T.Helper()
@@ -75,6 +87,20 @@ func (mr *MockResourceMockRecorder) SeedPeer() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeedPeer", reflect.TypeOf((*MockResource)(nil).SeedPeer))
 }
 
+// Snapshot mocks base method.
+func (m *MockResource) Snapshot() *Snapshot {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot")
+	ret0, _ := ret[0].(*Snapshot)
+	return ret0
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockResourceMockRecorder) Snapshot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockResource)(nil).Snapshot))
+}
+
 // TaskManager mocks base method.
 func (m *MockResource) TaskManager() TaskManager {
 	m.ctrl.T.Helper()
@@ -46,6 +46,7 @@ func TestResource_New(t *testing.T) {
 						SeedPeers: []*config.SeedPeer{{ID: 1}},
 					}, nil).Times(1),
 					dynconfig.Register(gomock.Any()).Return().Times(1),
+					gc.Add(gomock.Any()).Return(nil).Times(1),
 				)
 			},
 			expect: func(t *testing.T, resource Resource, err error) {
@@ -109,6 +110,24 @@ func TestResource_New(t *testing.T) {
 				assert.EqualError(err, "foo")
 			},
 		},
+		{
+			name:   "new resource failed because of replicator error",
+			config: config.New(),
+			mock: func(gc *gc.MockGCMockRecorder, dynconfig *configmocks.MockDynconfigInterfaceMockRecorder) {
+				gomock.InOrder(
+					gc.Add(gomock.Any()).Return(nil).Times(3),
+					dynconfig.Get().Return(&config.DynconfigData{
+						SeedPeers: []*config.SeedPeer{{ID: 1}},
+					}, nil).Times(1),
+					dynconfig.Register(gomock.Any()).Return().Times(1),
+					gc.Add(gomock.Any()).Return(errors.New("foo")).Times(1),
+				)
+			},
+			expect: func(t *testing.T, resource Resource, err error) {
+				assert := assert.New(t)
+				assert.EqualError(err, "foo")
+			},
+		},
 		{
 			name:   "new resource faild because of seed peer list is empty",
 			config: config.New(),
@@ -119,6 +138,7 @@ func TestResource_New(t *testing.T) {
 						SeedPeers: []*config.SeedPeer{},
 					}, nil).Times(1),
 					dynconfig.Register(gomock.Any()).Return().Times(1),
+					gc.Add(gomock.Any()).Return(nil).Times(1),
 				)
 			},
 			expect: func(t *testing.T, resource Resource, err error) {
@@ -154,6 +174,66 @@ func TestResource_New(t *testing.T) {
 				assert.NoError(err)
 			},
 		},
+		{
+			name: "new resource with evictor",
+			config: &config.Config{
+				Scheduler: &config.SchedulerConfig{
+					GC: &config.GCConfig{
+						PeerGCInterval:   100,
+						PeerTTL:          1000,
+						TaskGCInterval:   100,
+						TaskTTL:          1000,
+						HostGCInterval:   100,
+						HostTTL:          1000,
+						MaxTasks:         10,
+						EvictionInterval: 100,
+					},
+				},
+				SeedPeer: &config.SeedPeerConfig{
+					Enable: false,
+				},
+			},
+			mock: func(gc *gc.MockGCMockRecorder, dynconfig *configmocks.MockDynconfigInterfaceMockRecorder) {
+				gomock.InOrder(
+					gc.Add(gomock.Any()).Return(nil).Times(4),
+				)
+			},
+			expect: func(t *testing.T, resource Resource, err error) {
+				assert := assert.New(t)
+				assert.Equal(reflect.TypeOf(resource).Elem().Name(), "resource")
+				assert.NoError(err)
+			},
+		},
+		{
+			name: "new resource failed because of evictor error",
+			config: &config.Config{
+				Scheduler: &config.SchedulerConfig{
+					GC: &config.GCConfig{
+						PeerGCInterval:   100,
+						PeerTTL:          1000,
+						TaskGCInterval:   100,
+						TaskTTL:          1000,
+						HostGCInterval:   100,
+						HostTTL:          1000,
+						MaxTasks:         10,
+						EvictionInterval: 100,
+					},
+				},
+				SeedPeer: &config.SeedPeerConfig{
+					Enable: false,
+				},
+			},
+			mock: func(gc *gc.MockGCMockRecorder, dynconfig *configmocks.MockDynconfigInterfaceMockRecorder) {
+				gomock.InOrder(
+					gc.Add(gomock.Any()).Return(nil).Times(3),
+					gc.Add(gomock.Any()).Return(errors.New("foo")).Times(1),
+				)
+			},
+			expect: func(t *testing.T, resource Resource, err error) {
+				assert := assert.New(t)
+				assert.EqualError(err, "foo")
+			},
+		},
 	}
 
 	for _, tc := range tests {
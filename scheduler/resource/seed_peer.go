@@ -20,10 +20,14 @@ package resource
 
 import (
 	"context"
+	"fmt"
+	"math/rand"
 	"time"
 
 	"github.com/pkg/errors"
 
+	"d7y.io/dragonfly/v2/pkg/dfnet"
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
 	"d7y.io/dragonfly/v2/pkg/rpc/base/common"
 	"d7y.io/dragonfly/v2/pkg/rpc/cdnsystem"
 	rpcscheduler "d7y.io/dragonfly/v2/pkg/rpc/scheduler"
@@ -40,9 +44,33 @@ const (
 	SeedPeerFailedTimeout = 30 * time.Minute
 )
 
+const (
+	// Weight of super seed peer in seed peer selection.
+	superSeedPeerWeight = 4
+
+	// Weight of strong seed peer in seed peer selection.
+	strongSeedPeerWeight = 2
+
+	// Weight of weak seed peer in seed peer selection.
+	weakSeedPeerWeight = 1
+
+	// Multiple of weight when seed peer is in the same idc as the requesting host.
+	idcAffinityWeight = 2
+
+	// Minimum multiple of weight when seed peer has no free upload load,
+	// overloaded seed peer is still a candidate and will be tried after others.
+	minFreeUploadLoadWeight = 0.01
+)
+
 type SeedPeer interface {
-	// TriggerTask triggers the seed peer to download the task.
-	TriggerTask(context.Context, *Task) (*Peer, *rpcscheduler.PeerResult, error)
+	// TriggerTask triggers the seed peer to download the task,
+	// idc is the idc of requesting host and seed peer in the same idc is preferred.
+	TriggerTask(context.Context, *Task, string) (*Peer, *rpcscheduler.PeerResult, error)
+
+	// ReplicateTask triggers the seed peers to download the persistent cache task
+	// until the number of replicas reaches the replica count of task,
+	// seed peers in the idcs without replicas are preferred.
+	ReplicateTask(context.Context, *Task) error
 
 	// Client returns grpc client of seed peer.
 	Client() SeedPeerClient
@@ -66,16 +94,204 @@ func newSeedPeer(client SeedPeerClient, peerManager PeerManager, hostManager Hos
 	}
 }
 
-// TriggerTask start to trigger seed peer task.
-func (s *seedPeer) TriggerTask(ctx context.Context, task *Task) (*Peer, *rpcscheduler.PeerResult, error) {
+// TriggerTask start to trigger seed peer task, seed peers are tried in the order
+// of weighted random selection. If a seed peer fails, the task fails over to the next seed peer,
+// which continues from the pieces obtained from the failed seed peers.
+func (s *seedPeer) TriggerTask(ctx context.Context, task *Task, idc string) (*Peer, *rpcscheduler.PeerResult, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	stream, err := s.client.ObtainSeeds(ctx, &cdnsystem.SeedRequest{
-		TaskId:  task.ID,
-		Url:     task.URL,
-		UrlMeta: task.URLMeta,
+	hosts := s.seedHosts(idc)
+	if len(hosts) == 0 {
+		return nil, nil, errors.New("can not find available seed peer")
+	}
+
+	var lastErr error
+	for _, host := range hosts {
+		peer, result, err := s.obtainSeeds(ctx, task, host)
+		if err == nil {
+			return peer, result, nil
+		}
+		task.Log.Errorf("seed peer %s obtain seeds failed: %s", host.ID, err.Error())
+		lastErr = err
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return nil, nil, lastErr
+}
+
+// ReplicateTask start to replicate persistent cache task to seed peers, the replica
+// in the host which has been removed from host manager is not counted.
+func (s *seedPeer) ReplicateTask(ctx context.Context, task *Task) error {
+	replicas := s.replicaHosts(task)
+	lack := int(task.ReplicaCount.Load()) - len(replicas)
+	if lack <= 0 {
+		return nil
+	}
+	task.Log.Infof("task has %d replicas and lacks %d replicas", len(replicas), lack)
+
+	// Seed hosts in the idcs without replicas are tried first,
+	// and only one of them is tried for each idc.
+	idcs := make(map[string]bool)
+	for _, host := range replicas {
+		idcs[host.IDC] = true
+	}
+
+	var preferredHosts, otherHosts []*Host
+	for _, host := range s.seedHosts("") {
+		if _, ok := replicas[host.ID]; ok {
+			continue
+		}
+
+		if !idcs[host.IDC] {
+			idcs[host.IDC] = true
+			preferredHosts = append(preferredHosts, host)
+			continue
+		}
+
+		otherHosts = append(otherHosts, host)
+	}
+
+	// The task which has not been downloaded is started by the replication,
+	// and it fails if none of the replicas succeeds.
+	started := task.FSM.Is(TaskStatePending) || task.FSM.Is(TaskStateFailed)
+	if started {
+		if err := task.FSM.Event(TaskEventDownload); err != nil {
+			return err
+		}
+	}
+
+	var (
+		lastErr   error
+		succeeded bool
+	)
+	for _, host := range append(preferredHosts, otherHosts...) {
+		if lack <= 0 {
+			return nil
+		}
+
+		peer, result, err := s.obtainSeeds(ctx, task, host)
+		if err != nil {
+			task.Log.Errorf("seed peer %s replicate task failed: %s", host.ID, err.Error())
+			lastErr = err
+
+			if ctx.Err() != nil {
+				break
+			}
+
+			continue
+		}
+
+		peer.Log.Infof("seed peer %s replicate task successfully", host.ID)
+		handleSeedPeerSuccess(task, peer, result)
+		succeeded = true
+		lack--
+	}
+
+	if started && !succeeded && task.FSM.Is(TaskStateRunning) {
+		if err := task.FSM.Event(TaskEventDownloadFailed); err != nil {
+			task.Log.Errorf("task fsm event failed: %s", err.Error())
+		}
+	}
+
+	if lack <= 0 {
+		return nil
+	}
+
+	if lastErr != nil {
+		return errors.Wrapf(lastErr, "task lacks %d replicas", lack)
+	}
+
+	return errors.Errorf("task lacks %d replicas, can not find enough seed peers", lack)
+}
+
+// handleSeedPeerSuccess handles the task downloaded by seed peer successfully,
+// it is the same as the handling of seed peer task triggered by scheduling,
+// the task and the seed peer are succeeded.
+func handleSeedPeerSuccess(task *Task, peer *Peer, result *rpcscheduler.PeerResult) {
+	if !task.FSM.Is(TaskStateSucceeded) {
+		if err := task.FSM.Event(TaskEventDownloadSucceeded); err != nil {
+			task.Log.Errorf("task fsm event failed: %s", err.Error())
+			return
+		}
+
+		// Update task's resource total piece count and content length.
+		task.TotalPieceCount.Store(result.TotalPieceCount)
+		task.ContentLength.Store(result.ContentLength)
+	}
+
+	if err := peer.FSM.Event(PeerEventDownloadSucceeded); err != nil {
+		peer.Log.Errorf("peer fsm event failed: %s", err.Error())
+		return
+	}
+
+	sizeScope, err := task.SizeScope()
+	if err != nil {
+		peer.Log.Errorf("get task size scope failed: %s", err.Error())
+		return
+	}
+
+	// If the task is tiny, the data is stored in task DirectPiece.
+	if sizeScope == base.SizeScope_TINY && len(task.DirectPiece) == 0 {
+		data, err := peer.DownloadTinyFile()
+		if err != nil {
+			peer.Log.Errorf("download tiny task failed: %s", err.Error())
+			return
+		}
+
+		if len(data) != int(task.ContentLength.Load()) {
+			peer.Log.Errorf("download tiny task length of data is %d, task content length is %d", len(data), task.ContentLength.Load())
+			return
+		}
+
+		task.DirectPiece = data
+	}
+}
+
+// replicaHosts returns the seed hosts which have the replicas of task.
+func (s *seedPeer) replicaHosts(task *Task) map[string]*Host {
+	hosts := make(map[string]*Host)
+	task.Peers.Range(func(_, value interface{}) bool {
+		peer, ok := value.(*Peer)
+		if !ok || !peer.IsReplica() {
+			return true
+		}
+
+		if host, ok := s.hostManager.Load(peer.Host.ID); ok {
+			hosts[host.ID] = host
+		}
+
+		return true
 	})
+
+	return hosts
+}
+
+// obtainSeeds obtains seeds from the seed peer of host.
+func (s *seedPeer) obtainSeeds(ctx context.Context, task *Task, host *Host) (*Peer, *rpcscheduler.PeerResult, error) {
+	// The pieces obtained from the previous seed peers are not sent again.
+	req := &cdnsystem.SeedRequest{
+		TaskId:         task.ID,
+		Url:            task.URL,
+		UrlMeta:        task.URLMeta,
+		FinishedPieces: task.PiecesBitmap(),
+	}
+
+	// Pin the task in storage of seed peer until persistent cache task expires,
+	// the task expired is not pinned.
+	if task.IsPersistent() {
+		if ttl := time.Until(task.ExpireAt.Load()); ttl > 0 {
+			req.PersistentTtl = uint64(ttl)
+		}
+	}
+
+	stream, err := s.client.ObtainSeedsByAddr(ctx, dfnet.NetAddr{
+		Type: dfnet.TCP,
+		Addr: fmt.Sprintf("%s:%d", host.IP, host.Port),
+	}, req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -91,6 +307,7 @@ func (s *seedPeer) TriggerTask(ctx context.Context, task *Task) (*Peer, *rpcsche
 			// If the peer initialization succeeds and the download fails,
 			// set peer status is PeerStateFailed.
 			if peer != nil {
+				peer.Log.Warnf("seed peer failed after %d pieces are obtained", peer.Pieces.Count())
 				if err := peer.FSM.Event(PeerEventDownloadFailed); err != nil {
 					return nil, nil, err
 				}
@@ -122,20 +339,95 @@ func (s *seedPeer) TriggerTask(ctx context.Context, task *Task) (*Peer, *rpcsche
 		// Handle end of piece.
 		if piece.Done {
 			peer.Log.Infof("receive end of from seed peer: %#v %#v", piece, piece.PieceInfo)
+			// The pieces obtained from the previous seed peers are not sent,
+			// but they are also stored in the seed peer.
+			peer.StorePieces(req.FinishedPieces)
 			return peer, &rpcscheduler.PeerResult{
 				TotalPieceCount: piece.TotalPieceCount,
 				ContentLength:   piece.ContentLength,
 			}, nil
 		}
 
-		// Handle piece download successfully.
+		// Handle piece download successfully. The piece may have been
+		// stored by other peers, then the stored piece is kept.
 		peer.Log.Infof("receive piece from seed peer: %#v %#v", piece, piece.PieceInfo)
 		peer.Pieces.Set(uint(piece.PieceInfo.PieceNum))
 		peer.AppendPieceCost(pkgtime.SubNano(int64(piece.EndTime), int64(piece.BeginTime)).Milliseconds())
-		task.StorePiece(piece.PieceInfo)
+		if _, loaded := task.LoadOrStorePiece(piece.PieceInfo); loaded {
+			peer.Log.Debugf("piece %d has been obtained", piece.PieceInfo.PieceNum)
+		}
 	}
 }
 
+// seedHosts returns the seed hosts in the order of weighted random selection.
+func (s *seedPeer) seedHosts(idc string) []*Host {
+	var (
+		hosts   []*Host
+		weights []float64
+	)
+	s.hostManager.Range(func(_, value interface{}) bool {
+		host, ok := value.(*Host)
+		if !ok || host.Type == HostTypeNormal {
+			return true
+		}
+
+		hosts = append(hosts, host)
+		weights = append(weights, seedHostWeight(host, idc))
+		return true
+	})
+
+	sortedHosts := make([]*Host, 0, len(hosts))
+	for len(hosts) > 0 {
+		var total float64
+		for _, weight := range weights {
+			total += weight
+		}
+
+		i := 0
+		for r := rand.Float64() * total; i < len(weights)-1; i++ {
+			if r -= weights[i]; r < 0 {
+				break
+			}
+		}
+
+		sortedHosts = append(sortedHosts, hosts[i])
+		hosts = append(hosts[:i], hosts[i+1:]...)
+		weights = append(weights[:i], weights[i+1:]...)
+	}
+
+	return sortedHosts
+}
+
+// seedHostWeight returns the weight of seed host,
+// it is calculated by seed peer type, idc affinity and free upload load.
+func seedHostWeight(host *Host, idc string) float64 {
+	var weight float64
+	switch host.Type {
+	case HostTypeSuperSeed:
+		weight = superSeedPeerWeight
+	case HostTypeStrongSeed:
+		weight = strongSeedPeerWeight
+	default:
+		weight = weakSeedPeerWeight
+	}
+
+	if idc != "" && host.IDC == idc {
+		weight *= idcAffinityWeight
+	}
+
+	limit := host.UploadLoadLimit.Load()
+	if limit <= 0 {
+		return weight
+	}
+
+	freeUploadLoad := host.FreeUploadLoad()
+	if freeUploadLoad <= 0 {
+		return weight * minFreeUploadLoadWeight
+	}
+
+	return weight * float64(freeUploadLoad) / float64(limit)
+}
+
 // Initialize seed peer.
 func (s *seedPeer) initSeedPeer(task *Task, ps *cdnsystem.PieceSeed) (*Peer, error) {
 	// Load peer from manager.
@@ -94,6 +94,26 @@ func (mr *MockSeedPeerClientMockRecorder) ObtainSeeds(ctx, sr interface{}, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObtainSeeds", reflect.TypeOf((*MockSeedPeerClient)(nil).ObtainSeeds), varargs...)
 }
 
+// ObtainSeedsByAddr mocks base method.
+func (m *MockSeedPeerClient) ObtainSeedsByAddr(ctx context.Context, addr dfnet.NetAddr, sr *cdnsystem.SeedRequest, opts ...grpc.CallOption) (cdnsystem.Seeder_ObtainSeedsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, addr, sr}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ObtainSeedsByAddr", varargs...)
+	ret0, _ := ret[0].(cdnsystem.Seeder_ObtainSeedsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ObtainSeedsByAddr indicates an expected call of ObtainSeedsByAddr.
+func (mr *MockSeedPeerClientMockRecorder) ObtainSeedsByAddr(ctx, addr, sr interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, addr, sr}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObtainSeedsByAddr", reflect.TypeOf((*MockSeedPeerClient)(nil).ObtainSeedsByAddr), varargs...)
+}
+
 // OnNotify mocks base method.
 func (m *MockSeedPeerClient) OnNotify(arg0 *config.DynconfigData) {
 	m.ctrl.T.Helper()
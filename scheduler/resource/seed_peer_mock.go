@@ -49,10 +49,24 @@ func (mr *MockSeedPeerMockRecorder) Client() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Client", reflect.TypeOf((*MockSeedPeer)(nil).Client))
 }
 
+// ReplicateTask mocks base method.
+func (m *MockSeedPeer) ReplicateTask(arg0 context.Context, arg1 *Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplicateTask", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplicateTask indicates an expected call of ReplicateTask.
+func (mr *MockSeedPeerMockRecorder) ReplicateTask(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplicateTask", reflect.TypeOf((*MockSeedPeer)(nil).ReplicateTask), arg0, arg1)
+}
+
 // TriggerTask mocks base method.
-func (m *MockSeedPeer) TriggerTask(arg0 context.Context, arg1 *Task) (*Peer, *scheduler.PeerResult, error) {
+func (m *MockSeedPeer) TriggerTask(arg0 context.Context, arg1 *Task, arg2 string) (*Peer, *scheduler.PeerResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TriggerTask", arg0, arg1)
+	ret := m.ctrl.Call(m, "TriggerTask", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*Peer)
 	ret1, _ := ret[1].(*scheduler.PeerResult)
 	ret2, _ := ret[2].(error)
@@ -60,7 +74,7 @@ func (m *MockSeedPeer) TriggerTask(arg0 context.Context, arg1 *Task) (*Peer, *sc
 }
 
 // TriggerTask indicates an expected call of TriggerTask.
-func (mr *MockSeedPeerMockRecorder) TriggerTask(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockSeedPeerMockRecorder) TriggerTask(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TriggerTask", reflect.TypeOf((*MockSeedPeer)(nil).TriggerTask), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TriggerTask", reflect.TypeOf((*MockSeedPeer)(nil).TriggerTask), arg0, arg1, arg2)
 }
@@ -19,15 +19,102 @@ package resource
 import (
 	"context"
 	"errors"
+	"io"
 	"reflect"
 	"testing"
+	"time"
 
 	gomock "github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
 
+	"d7y.io/dragonfly/v2/pkg/dfnet"
+	"d7y.io/dragonfly/v2/pkg/idgen"
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
+	"d7y.io/dragonfly/v2/pkg/rpc/base/common"
+	"d7y.io/dragonfly/v2/pkg/rpc/cdnsystem"
 	rpcscheduler "d7y.io/dragonfly/v2/pkg/rpc/scheduler"
 )
 
+var (
+	mockRawSeedHostInOtherIDC = &rpcscheduler.PeerHost{
+		Id:             idgen.HostID("hostname_seed_other", 8003),
+		Ip:             "127.0.0.2",
+		RpcPort:        8003,
+		DownPort:       8001,
+		HostName:       "hostname_seed_other",
+		SecurityDomain: "security_domain",
+		Location:       "location",
+		Idc:            "other_idc",
+		NetTopology:    "net_topology",
+	}
+
+	mockRawSeedHostInSameIDC = &rpcscheduler.PeerHost{
+		Id:             idgen.HostID("hostname_seed_same", 8003),
+		Ip:             "127.0.0.3",
+		RpcPort:        8003,
+		DownPort:       8001,
+		HostName:       "hostname_seed_same",
+		SecurityDomain: "security_domain",
+		Location:       "location",
+		Idc:            "idc",
+		NetTopology:    "net_topology",
+	}
+)
+
+// mockObtainSeedsStream is the stream returns pieces in order,
+// and returns err after all pieces are received.
+type mockObtainSeedsStream struct {
+	grpc.ClientStream
+	pieces []*cdnsystem.PieceSeed
+	err    error
+}
+
+func (s *mockObtainSeedsStream) Recv() (*cdnsystem.PieceSeed, error) {
+	if len(s.pieces) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+
+		return nil, io.EOF
+	}
+
+	piece := s.pieces[0]
+	s.pieces = s.pieces[1:]
+	return piece, nil
+}
+
+// mockPieceSeeds returns the pieces of seed peer, the last piece is done when done is true.
+func mockPieceSeeds(peerID, hostID string, pieceNums []int32, done bool) []*cdnsystem.PieceSeed {
+	pieces := []*cdnsystem.PieceSeed{
+		{
+			PeerId:    peerID,
+			HostId:    hostID,
+			PieceInfo: &base.PieceInfo{PieceNum: common.BeginOfPiece},
+		},
+	}
+
+	for _, pieceNum := range pieceNums {
+		pieces = append(pieces, &cdnsystem.PieceSeed{
+			PeerId:    peerID,
+			HostId:    hostID,
+			PieceInfo: &base.PieceInfo{PieceNum: pieceNum},
+		})
+	}
+
+	if done {
+		pieces = append(pieces, &cdnsystem.PieceSeed{
+			PeerId:          peerID,
+			HostId:          hostID,
+			Done:            true,
+			TotalPieceCount: int32(len(pieceNums)),
+			ContentLength:   int64(len(pieceNums)),
+		})
+	}
+
+	return pieces
+}
+
 func TestSeedPeer_newSeedPeer(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -58,19 +145,162 @@ func TestSeedPeer_newSeedPeer(t *testing.T) {
 func TestSeedPeer_TriggerTask(t *testing.T) {
 	tests := []struct {
 		name   string
-		mock   func(mc *MockSeedPeerClientMockRecorder)
-		expect func(t *testing.T, peer *Peer, result *rpcscheduler.PeerResult, err error)
+		hosts  []*Host
+		mock   func(seedPeerHosts []*Host, mc *MockSeedPeerClientMockRecorder, mh *MockHostManagerMockRecorder, mp *MockPeerManagerMockRecorder)
+		expect func(t *testing.T, task *Task, peer *Peer, result *rpcscheduler.PeerResult, err error)
 	}{
 		{
-			name: "start obtain seed stream failed",
-			mock: func(mc *MockSeedPeerClientMockRecorder) {
-				mc.ObtainSeeds(gomock.Any(), gomock.Any()).Return(nil, errors.New("foo")).Times(1)
+			name:  "can not find available seed peer",
+			hosts: []*Host{NewHost(mockRawHost)},
+			mock: func(seedPeerHosts []*Host, mc *MockSeedPeerClientMockRecorder, mh *MockHostManagerMockRecorder, mp *MockPeerManagerMockRecorder) {
+			},
+			expect: func(t *testing.T, task *Task, peer *Peer, result *rpcscheduler.PeerResult, err error) {
+				assert := assert.New(t)
+				assert.EqualError(err, "can not find available seed peer")
+			},
+		},
+		{
+			name:  "start obtain seed stream failed",
+			hosts: []*Host{NewHost(mockRawSeedHost, WithHostType(HostTypeSuperSeed))},
+			mock: func(seedPeerHosts []*Host, mc *MockSeedPeerClientMockRecorder, mh *MockHostManagerMockRecorder, mp *MockPeerManagerMockRecorder) {
+				mc.ObtainSeedsByAddr(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("foo")).Times(1)
 			},
-			expect: func(t *testing.T, peer *Peer, result *rpcscheduler.PeerResult, err error) {
+			expect: func(t *testing.T, task *Task, peer *Peer, result *rpcscheduler.PeerResult, err error) {
 				assert := assert.New(t)
 				assert.EqualError(err, "foo")
 			},
 		},
+		{
+			name: "start obtain seed stream failed and retry on another seed peer",
+			hosts: []*Host{
+				NewHost(mockRawSeedHost, WithHostType(HostTypeSuperSeed)),
+				NewHost(mockRawSeedHostInOtherIDC, WithHostType(HostTypeWeakSeed)),
+			},
+			mock: func(seedPeerHosts []*Host, mc *MockSeedPeerClientMockRecorder, mh *MockHostManagerMockRecorder, mp *MockPeerManagerMockRecorder) {
+				gomock.InOrder(
+					mc.ObtainSeedsByAddr(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("foo")).Times(1),
+					mc.ObtainSeedsByAddr(gomock.Any(), gomock.Any(), gomock.Any()).Return(&mockObtainSeedsStream{
+						pieces: mockPieceSeeds(mockPeerID, mockRawSeedHost.Id, []int32{0, 1}, true),
+					}, nil).Times(1),
+				)
+				mp.Load(gomock.Eq(mockPeerID)).Return(nil, false).Times(1)
+				mh.Load(gomock.Eq(mockRawSeedHost.Id)).Return(seedPeerHosts[0], true).Times(1)
+				mp.Store(gomock.Any()).Return().Times(1)
+			},
+			expect: func(t *testing.T, task *Task, peer *Peer, result *rpcscheduler.PeerResult, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				assert.Equal(peer.ID, mockPeerID)
+				assert.True(peer.FSM.Is(PeerStateRunning))
+				assert.Equal(peer.Pieces.Count(), uint(2))
+				assert.EqualValues(result, &rpcscheduler.PeerResult{
+					TotalPieceCount: 2,
+					ContentLength:   2,
+				})
+			},
+		},
+		{
+			name: "seed peer fails mid-stream and fail over to another seed peer",
+			hosts: []*Host{
+				NewHost(mockRawSeedHost, WithHostType(HostTypeSuperSeed)),
+				NewHost(mockRawSeedHostInOtherIDC, WithHostType(HostTypeWeakSeed)),
+			},
+			mock: func(seedPeerHosts []*Host, mc *MockSeedPeerClientMockRecorder, mh *MockHostManagerMockRecorder, mp *MockPeerManagerMockRecorder) {
+				gomock.InOrder(
+					mc.ObtainSeedsByAddr(gomock.Any(), gomock.Any(), gomock.Any()).Return(&mockObtainSeedsStream{
+						pieces: mockPieceSeeds("foo", mockRawSeedHost.Id, []int32{0}, false),
+						err:    errors.New("foo"),
+					}, nil).Times(1),
+					mc.ObtainSeedsByAddr(gomock.Any(), gomock.Any(), gomock.Any()).Return(&mockObtainSeedsStream{
+						pieces: mockPieceSeeds("bar", mockRawSeedHostInOtherIDC.Id, []int32{0, 1}, true),
+					}, nil).Times(1),
+				)
+				mp.Load(gomock.Any()).Return(nil, false).Times(2)
+				mh.Load(gomock.Eq(mockRawSeedHost.Id)).Return(seedPeerHosts[0], true).Times(1)
+				mh.Load(gomock.Eq(mockRawSeedHostInOtherIDC.Id)).Return(seedPeerHosts[1], true).Times(1)
+				mp.Store(gomock.Any()).Do(func(peer *Peer) { peer.Task.LoadOrStorePeer(peer) }).Return().Times(2)
+			},
+			expect: func(t *testing.T, task *Task, peer *Peer, result *rpcscheduler.PeerResult, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				assert.Equal(peer.ID, "bar")
+				assert.Equal(peer.Pieces.Count(), uint(2))
+
+				failedPeer, ok := task.LoadPeer("foo")
+				assert.True(ok)
+				assert.True(failedPeer.FSM.Is(PeerStateFailed))
+				assert.Equal(failedPeer.Pieces.Count(), uint(1))
+
+				_, ok = task.LoadPiece(0)
+				assert.True(ok)
+				_, ok = task.LoadPiece(1)
+				assert.True(ok)
+			},
+		},
+		{
+			name: "seed peer fails after obtaining pieces and another seed peer continues from them",
+			hosts: []*Host{
+				NewHost(mockRawSeedHost, WithHostType(HostTypeSuperSeed)),
+				NewHost(mockRawSeedHostInOtherIDC, WithHostType(HostTypeWeakSeed)),
+			},
+			mock: func(seedPeerHosts []*Host, mc *MockSeedPeerClientMockRecorder, mh *MockHostManagerMockRecorder, mp *MockPeerManagerMockRecorder) {
+				gomock.InOrder(
+					mc.ObtainSeedsByAddr(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, _ dfnet.NetAddr, req *cdnsystem.SeedRequest, _ ...grpc.CallOption) (cdnsystem.Seeder_ObtainSeedsClient, error) {
+							assert.Empty(t, req.FinishedPieces)
+							return &mockObtainSeedsStream{
+								pieces: mockPieceSeeds("foo", mockRawSeedHost.Id, []int32{0, 1, 2}, false),
+								err:    errors.New("foo"),
+							}, nil
+						}).Times(1),
+					mc.ObtainSeedsByAddr(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+						func(_ context.Context, _ dfnet.NetAddr, req *cdnsystem.SeedRequest, _ ...grpc.CallOption) (cdnsystem.Seeder_ObtainSeedsClient, error) {
+							// Pieces 0, 1 and 2 obtained from the failed seed peer are not asked for again.
+							assert.Equal(t, []byte{0xe0}, req.FinishedPieces)
+							return &mockObtainSeedsStream{
+								pieces: mockPieceSeeds("bar", mockRawSeedHostInOtherIDC.Id, []int32{3, 4}, true),
+							}, nil
+						}).Times(1),
+				)
+				mp.Load(gomock.Any()).Return(nil, false).Times(2)
+				mh.Load(gomock.Eq(mockRawSeedHost.Id)).Return(seedPeerHosts[0], true).Times(1)
+				mh.Load(gomock.Eq(mockRawSeedHostInOtherIDC.Id)).Return(seedPeerHosts[1], true).Times(1)
+				mp.Store(gomock.Any()).Do(func(peer *Peer) { peer.Task.LoadOrStorePeer(peer) }).Return().Times(2)
+			},
+			expect: func(t *testing.T, task *Task, peer *Peer, result *rpcscheduler.PeerResult, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				assert.Equal(peer.ID, "bar")
+				assert.Equal(peer.Pieces.Count(), uint(5))
+
+				failedPeer, ok := task.LoadPeer("foo")
+				assert.True(ok)
+				assert.True(failedPeer.FSM.Is(PeerStateFailed))
+				assert.Equal(failedPeer.Pieces.Count(), uint(3))
+
+				for i := int32(0); i < 5; i++ {
+					_, ok = task.LoadPiece(i)
+					assert.True(ok)
+				}
+			},
+		},
+		{
+			name: "all seed peers failed",
+			hosts: []*Host{
+				NewHost(mockRawSeedHost, WithHostType(HostTypeSuperSeed)),
+				NewHost(mockRawSeedHostInOtherIDC, WithHostType(HostTypeWeakSeed)),
+			},
+			mock: func(seedPeerHosts []*Host, mc *MockSeedPeerClientMockRecorder, mh *MockHostManagerMockRecorder, mp *MockPeerManagerMockRecorder) {
+				gomock.InOrder(
+					mc.ObtainSeedsByAddr(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("foo")).Times(1),
+					mc.ObtainSeedsByAddr(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("bar")).Times(1),
+				)
+			},
+			expect: func(t *testing.T, task *Task, peer *Peer, result *rpcscheduler.PeerResult, err error) {
+				assert := assert.New(t)
+				assert.EqualError(err, "bar")
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -80,12 +310,351 @@ func TestSeedPeer_TriggerTask(t *testing.T) {
 			hostManager := NewMockHostManager(ctl)
 			peerManager := NewMockPeerManager(ctl)
 			client := NewMockSeedPeerClient(ctl)
-			tc.mock(client.EXPECT())
+			hostManager.EXPECT().Range(gomock.Any()).Do(func(f func(key, value interface{}) bool) {
+				for _, host := range tc.hosts {
+					if !f(host.ID, host) {
+						return
+					}
+				}
+			}).Return().Times(1)
+			tc.mock(tc.hosts, client.EXPECT(), hostManager.EXPECT(), peerManager.EXPECT())
 
 			seedPeer := newSeedPeer(client, peerManager, hostManager)
 			mockTask := NewTask(mockTaskID, mockTaskURL, TaskTypeNormal, mockTaskURLMeta, WithBackToSourceLimit(mockTaskBackToSourceLimit))
-			peer, result, err := seedPeer.TriggerTask(context.Background(), mockTask)
-			tc.expect(t, peer, result, err)
+			peer, result, err := seedPeer.TriggerTask(context.Background(), mockTask, mockRawSeedHost.Idc)
+			tc.expect(t, mockTask, peer, result, err)
+		})
+	}
+}
+
+func TestSeedPeer_ReplicateTask(t *testing.T) {
+	tests := []struct {
+		name         string
+		hosts        []*Host
+		replicaCount int32
+		mock         func(task *Task, seedPeerHosts []*Host, mc *MockSeedPeerClientMockRecorder, mh *MockHostManagerMockRecorder, mp *MockPeerManagerMockRecorder)
+		expect       func(t *testing.T, task *Task, err error)
+	}{
+		{
+			name:         "task has enough replicas",
+			hosts:        []*Host{NewHost(mockRawSeedHost, WithHostType(HostTypeSuperSeed))},
+			replicaCount: 1,
+			mock: func(task *Task, seedPeerHosts []*Host, mc *MockSeedPeerClientMockRecorder, mh *MockHostManagerMockRecorder, mp *MockPeerManagerMockRecorder) {
+				peer := NewPeer(mockPeerID, task, seedPeerHosts[0])
+				peer.FSM.SetState(PeerStateSucceeded)
+				task.StorePeer(peer)
+				mh.Load(gomock.Eq(mockRawSeedHost.Id)).Return(seedPeerHosts[0], true).Times(1)
+			},
+			expect: func(t *testing.T, task *Task, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+			},
+		},
+		{
+			name: "replicate task to seed peer in the idc without replicas first",
+			hosts: []*Host{
+				NewHost(mockRawSeedHost, WithHostType(HostTypeSuperSeed)),
+				NewHost(mockRawSeedHostInSameIDC, WithHostType(HostTypeSuperSeed)),
+				NewHost(mockRawSeedHostInOtherIDC, WithHostType(HostTypeWeakSeed)),
+			},
+			replicaCount: 2,
+			mock: func(task *Task, seedPeerHosts []*Host, mc *MockSeedPeerClientMockRecorder, mh *MockHostManagerMockRecorder, mp *MockPeerManagerMockRecorder) {
+				peer := NewPeer(mockPeerID, task, seedPeerHosts[0])
+				peer.FSM.SetState(PeerStateSucceeded)
+				task.StorePeer(peer)
+				mh.Load(gomock.Eq(mockRawSeedHost.Id)).Return(seedPeerHosts[0], true).Times(1)
+				mh.Range(gomock.Any()).Do(func(f func(key, value interface{}) bool) {
+					for _, host := range seedPeerHosts {
+						if !f(host.ID, host) {
+							return
+						}
+					}
+				}).Return().Times(1)
+				mc.ObtainSeedsByAddr(gomock.Any(), gomock.Eq(dfnet.NetAddr{Type: dfnet.TCP, Addr: "127.0.0.2:8003"}), gomock.Any()).Do(
+					func(ctx context.Context, addr dfnet.NetAddr, req *cdnsystem.SeedRequest, opts ...grpc.CallOption) {
+						assert.True(t, req.PersistentTtl > 0)
+					}).Return(&mockObtainSeedsStream{
+					pieces: mockPieceSeeds("bar", mockRawSeedHostInOtherIDC.Id, []int32{0}, true),
+				}, nil).Times(1)
+				mp.Load(gomock.Eq("bar")).Return(nil, false).Times(1)
+				mh.Load(gomock.Eq(mockRawSeedHostInOtherIDC.Id)).Return(seedPeerHosts[2], true).Times(1)
+				mp.Store(gomock.Any()).Do(func(peer *Peer) { task.StorePeer(peer) }).Return().Times(1)
+			},
+			expect: func(t *testing.T, task *Task, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				assert.True(task.FSM.Is(TaskStateSucceeded))
+				assert.Equal(task.TotalPieceCount.Load(), int32(1))
+				assert.Equal(task.ContentLength.Load(), int64(1))
+
+				peer, ok := task.LoadPeer("bar")
+				assert.True(ok)
+				assert.True(peer.FSM.Is(PeerStateSucceeded))
+				assert.True(peer.IsReplica())
+			},
+		},
+		{
+			name:         "replica which is downloading is not counted",
+			hosts:        []*Host{NewHost(mockRawSeedHost, WithHostType(HostTypeSuperSeed))},
+			replicaCount: 1,
+			mock: func(task *Task, seedPeerHosts []*Host, mc *MockSeedPeerClientMockRecorder, mh *MockHostManagerMockRecorder, mp *MockPeerManagerMockRecorder) {
+				peer := NewPeer(mockPeerID, task, seedPeerHosts[0])
+				peer.FSM.SetState(PeerStateRunning)
+				task.StorePeer(peer)
+				mh.Range(gomock.Any()).Do(func(f func(key, value interface{}) bool) {
+					for _, host := range seedPeerHosts {
+						if !f(host.ID, host) {
+							return
+						}
+					}
+				}).Return().Times(1)
+				mc.ObtainSeedsByAddr(gomock.Any(), gomock.Any(), gomock.Any()).Return(&mockObtainSeedsStream{
+					pieces: mockPieceSeeds("bar", mockRawSeedHost.Id, []int32{0}, true),
+				}, nil).Times(1)
+				mp.Load(gomock.Eq("bar")).Return(nil, false).Times(1)
+				mh.Load(gomock.Eq(mockRawSeedHost.Id)).Return(seedPeerHosts[0], true).Times(1)
+				mp.Store(gomock.Any()).Return().Times(1)
+			},
+			expect: func(t *testing.T, task *Task, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				assert.True(task.FSM.Is(TaskStateSucceeded))
+			},
+		},
+		{
+			name:         "replica host disappears",
+			hosts:        []*Host{NewHost(mockRawSeedHostInOtherIDC, WithHostType(HostTypeWeakSeed))},
+			replicaCount: 1,
+			mock: func(task *Task, seedPeerHosts []*Host, mc *MockSeedPeerClientMockRecorder, mh *MockHostManagerMockRecorder, mp *MockPeerManagerMockRecorder) {
+				peer := NewPeer(mockPeerID, task, NewHost(mockRawSeedHost, WithHostType(HostTypeSuperSeed)))
+				peer.FSM.SetState(PeerStateSucceeded)
+				task.StorePeer(peer)
+				mh.Load(gomock.Eq(mockRawSeedHost.Id)).Return(nil, false).Times(1)
+				mh.Range(gomock.Any()).Do(func(f func(key, value interface{}) bool) {
+					for _, host := range seedPeerHosts {
+						if !f(host.ID, host) {
+							return
+						}
+					}
+				}).Return().Times(1)
+				mc.ObtainSeedsByAddr(gomock.Any(), gomock.Any(), gomock.Any()).Return(&mockObtainSeedsStream{
+					pieces: mockPieceSeeds("bar", mockRawSeedHostInOtherIDC.Id, []int32{0}, true),
+				}, nil).Times(1)
+				mp.Load(gomock.Eq("bar")).Return(nil, false).Times(1)
+				mh.Load(gomock.Eq(mockRawSeedHostInOtherIDC.Id)).Return(seedPeerHosts[0], true).Times(1)
+				mp.Store(gomock.Any()).Return().Times(1)
+			},
+			expect: func(t *testing.T, task *Task, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+			},
+		},
+		{
+			name:         "replicate task failed",
+			hosts:        []*Host{NewHost(mockRawSeedHost, WithHostType(HostTypeSuperSeed))},
+			replicaCount: 1,
+			mock: func(task *Task, seedPeerHosts []*Host, mc *MockSeedPeerClientMockRecorder, mh *MockHostManagerMockRecorder, mp *MockPeerManagerMockRecorder) {
+				mh.Range(gomock.Any()).Do(func(f func(key, value interface{}) bool) {
+					for _, host := range seedPeerHosts {
+						if !f(host.ID, host) {
+							return
+						}
+					}
+				}).Return().Times(1)
+				mc.ObtainSeedsByAddr(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("foo")).Times(1)
+			},
+			expect: func(t *testing.T, task *Task, err error) {
+				assert := assert.New(t)
+				assert.EqualError(err, "task lacks 1 replicas: foo")
+				assert.True(task.FSM.Is(TaskStateFailed))
+			},
+		},
+		{
+			name:         "can not find enough seed peers",
+			hosts:        []*Host{NewHost(mockRawHost)},
+			replicaCount: 2,
+			mock: func(task *Task, seedPeerHosts []*Host, mc *MockSeedPeerClientMockRecorder, mh *MockHostManagerMockRecorder, mp *MockPeerManagerMockRecorder) {
+				mh.Range(gomock.Any()).Do(func(f func(key, value interface{}) bool) {
+					for _, host := range seedPeerHosts {
+						if !f(host.ID, host) {
+							return
+						}
+					}
+				}).Return().Times(1)
+			},
+			expect: func(t *testing.T, task *Task, err error) {
+				assert := assert.New(t)
+				assert.EqualError(err, "task lacks 2 replicas, can not find enough seed peers")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			hostManager := NewMockHostManager(ctl)
+			peerManager := NewMockPeerManager(ctl)
+			client := NewMockSeedPeerClient(ctl)
+
+			mockTask := NewTask(mockTaskID, mockTaskURL, TaskTypePersistentCache, mockTaskURLMeta, WithPersistentCache(tc.replicaCount, time.Hour))
+			tc.mock(mockTask, tc.hosts, client.EXPECT(), hostManager.EXPECT(), peerManager.EXPECT())
+
+			seedPeer := newSeedPeer(client, peerManager, hostManager)
+			tc.expect(t, mockTask, seedPeer.ReplicateTask(context.Background(), mockTask))
+		})
+	}
+}
+
+func TestSeedPeer_seedHosts(t *testing.T) {
+	tests := []struct {
+		name   string
+		hosts  []*Host
+		expect func(t *testing.T, hosts []*Host)
+	}{
+		{
+			name:  "hosts are empty",
+			hosts: []*Host{},
+			expect: func(t *testing.T, hosts []*Host) {
+				assert := assert.New(t)
+				assert.Empty(hosts)
+			},
+		},
+		{
+			name: "normal host is not seed host",
+			hosts: []*Host{
+				NewHost(mockRawHost),
+				NewHost(mockRawSeedHost, WithHostType(HostTypeStrongSeed)),
+			},
+			expect: func(t *testing.T, hosts []*Host) {
+				assert := assert.New(t)
+				assert.Equal(len(hosts), 1)
+				assert.Equal(hosts[0].ID, mockRawSeedHost.Id)
+			},
+		},
+		{
+			name: "all seed hosts are candidates",
+			hosts: []*Host{
+				NewHost(mockRawSeedHost, WithHostType(HostTypeSuperSeed)),
+				NewHost(mockRawSeedHostInOtherIDC, WithHostType(HostTypeWeakSeed), WithUploadLoadLimit(0)),
+			},
+			expect: func(t *testing.T, hosts []*Host) {
+				assert := assert.New(t)
+				assert.Equal(len(hosts), 2)
+				assert.ElementsMatch([]string{hosts[0].ID, hosts[1].ID}, []string{mockRawSeedHost.Id, mockRawSeedHostInOtherIDC.Id})
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			hostManager := NewMockHostManager(ctl)
+			peerManager := NewMockPeerManager(ctl)
+			client := NewMockSeedPeerClient(ctl)
+			hostManager.EXPECT().Range(gomock.Any()).Do(func(f func(key, value interface{}) bool) {
+				for _, host := range tc.hosts {
+					if !f(host.ID, host) {
+						return
+					}
+				}
+			}).Return().Times(1)
+
+			seedPeer := newSeedPeer(client, peerManager, hostManager).(*seedPeer)
+			tc.expect(t, seedPeer.seedHosts(mockRawSeedHost.Idc))
+		})
+	}
+}
+
+func TestSeedPeer_seedHostWeight(t *testing.T) {
+	tests := []struct {
+		name   string
+		host   *Host
+		idc    string
+		mock   func(host *Host)
+		expect func(t *testing.T, weight float64)
+	}{
+		{
+			name: "super seed host",
+			host: NewHost(mockRawSeedHostInOtherIDC, WithHostType(HostTypeSuperSeed)),
+			idc:  mockRawSeedHost.Idc,
+			mock: func(host *Host) {},
+			expect: func(t *testing.T, weight float64) {
+				assert := assert.New(t)
+				assert.Equal(weight, float64(superSeedPeerWeight))
+			},
+		},
+		{
+			name: "strong seed host",
+			host: NewHost(mockRawSeedHostInOtherIDC, WithHostType(HostTypeStrongSeed)),
+			idc:  mockRawSeedHost.Idc,
+			mock: func(host *Host) {},
+			expect: func(t *testing.T, weight float64) {
+				assert := assert.New(t)
+				assert.Equal(weight, float64(strongSeedPeerWeight))
+			},
+		},
+		{
+			name: "weak seed host",
+			host: NewHost(mockRawSeedHostInOtherIDC, WithHostType(HostTypeWeakSeed)),
+			idc:  mockRawSeedHost.Idc,
+			mock: func(host *Host) {},
+			expect: func(t *testing.T, weight float64) {
+				assert := assert.New(t)
+				assert.Equal(weight, float64(weakSeedPeerWeight))
+			},
+		},
+		{
+			name: "seed host is in the same idc",
+			host: NewHost(mockRawSeedHost, WithHostType(HostTypeWeakSeed)),
+			idc:  mockRawSeedHost.Idc,
+			mock: func(host *Host) {},
+			expect: func(t *testing.T, weight float64) {
+				assert := assert.New(t)
+				assert.Equal(weight, float64(weakSeedPeerWeight*idcAffinityWeight))
+			},
+		},
+		{
+			name: "idc is empty",
+			host: NewHost(mockRawSeedHost, WithHostType(HostTypeWeakSeed)),
+			idc:  "",
+			mock: func(host *Host) {},
+			expect: func(t *testing.T, weight float64) {
+				assert := assert.New(t)
+				assert.Equal(weight, float64(weakSeedPeerWeight))
+			},
+		},
+		{
+			name: "seed host has half free upload load",
+			host: NewHost(mockRawSeedHostInOtherIDC, WithHostType(HostTypeSuperSeed), WithUploadLoadLimit(10)),
+			idc:  mockRawSeedHost.Idc,
+			mock: func(host *Host) {
+				host.UploadPeerCount.Store(5)
+			},
+			expect: func(t *testing.T, weight float64) {
+				assert := assert.New(t)
+				assert.Equal(weight, float64(superSeedPeerWeight)/2)
+			},
+		},
+		{
+			name: "seed host has no free upload load",
+			host: NewHost(mockRawSeedHostInOtherIDC, WithHostType(HostTypeSuperSeed), WithUploadLoadLimit(10)),
+			idc:  mockRawSeedHost.Idc,
+			mock: func(host *Host) {
+				host.UploadPeerCount.Store(10)
+			},
+			expect: func(t *testing.T, weight float64) {
+				assert := assert.New(t)
+				assert.Equal(weight, float64(superSeedPeerWeight)*minFreeUploadLoadWeight)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock(tc.host)
+			tc.expect(t, seedHostWeight(tc.host, tc.idc))
 		})
 	}
 }
@@ -0,0 +1,339 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/bits-and-blooms/bitset"
+
+	logger "d7y.io/dragonfly/v2/internal/dflog"
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
+	"d7y.io/dragonfly/v2/pkg/rpc/scheduler"
+)
+
+// Snapshot is the state of hosts, tasks and peers.
+type Snapshot struct {
+	// Hosts is the state of hosts.
+	Hosts []*HostSnapshot `json:"hosts"`
+
+	// Tasks is the state of tasks.
+	Tasks []*TaskSnapshot `json:"tasks"`
+
+	// Peers is the state of peers.
+	Peers []*PeerSnapshot `json:"peers"`
+
+	// CreateAt is snapshot create time.
+	CreateAt time.Time `json:"createAt"`
+}
+
+// HostSnapshot is the state of host.
+type HostSnapshot struct {
+	ID              string    `json:"id"`
+	Type            HostType  `json:"type"`
+	IP              string    `json:"ip"`
+	Hostname        string    `json:"hostname"`
+	Port            int32     `json:"port"`
+	DownloadPort    int32     `json:"downloadPort"`
+	SecurityDomain  string    `json:"securityDomain"`
+	IDC             string    `json:"idc"`
+	NetTopology     string    `json:"netTopology"`
+	Location        string    `json:"location"`
+	UploadLoadLimit int32     `json:"uploadLoadLimit"`
+	CreateAt        time.Time `json:"createAt"`
+	UpdateAt        time.Time `json:"updateAt"`
+}
+
+// TaskSnapshot is the state of task.
+type TaskSnapshot struct {
+	ID                string            `json:"id"`
+	URL               string            `json:"url"`
+	Type              int               `json:"type"`
+	URLMeta           *base.UrlMeta     `json:"urlMeta"`
+	DirectPiece       []byte            `json:"directPiece"`
+	ContentLength     int64             `json:"contentLength"`
+	TotalPieceCount   int32             `json:"totalPieceCount"`
+	BackToSourceLimit int32             `json:"backToSourceLimit"`
+	State             string            `json:"state"`
+	Pieces            []*base.PieceInfo `json:"pieces"`
+	ReplicaCount      int32             `json:"replicaCount"`
+	ExpireAt          time.Time         `json:"expireAt"`
+	CreateAt          time.Time         `json:"createAt"`
+	UpdateAt          time.Time         `json:"updateAt"`
+}
+
+// PeerSnapshot is the state of peer.
+type PeerSnapshot struct {
+	ID               string         `json:"id"`
+	BizTag           string         `json:"bizTag"`
+	Priority         base.Priority  `json:"priority"`
+	TaskID           string         `json:"taskID"`
+	HostID           string         `json:"hostID"`
+	ParentID         string         `json:"parentID"`
+	State            string         `json:"state"`
+	Pieces           *bitset.BitSet `json:"pieces"`
+	PieceCosts       []int64        `json:"pieceCosts"`
+	NeedBackToSource bool           `json:"needBackToSource"`
+	IsBackToSource   bool           `json:"isBackToSource"`
+	CreateAt         time.Time      `json:"createAt"`
+	UpdateAt         time.Time      `json:"updateAt"`
+}
+
+// Snapshot returns the state of hosts, tasks and peers.
+func (r *resource) Snapshot() *Snapshot {
+	snapshot := &Snapshot{CreateAt: time.Now()}
+
+	r.hostManager.Range(func(_, value interface{}) bool {
+		host, ok := value.(*Host)
+		if !ok {
+			return true
+		}
+
+		snapshot.Hosts = append(snapshot.Hosts, &HostSnapshot{
+			ID:              host.ID,
+			Type:            host.Type,
+			IP:              host.IP,
+			Hostname:        host.Hostname,
+			Port:            host.Port,
+			DownloadPort:    host.DownloadPort,
+			SecurityDomain:  host.SecurityDomain,
+			IDC:             host.IDC,
+			NetTopology:     host.NetTopology,
+			Location:        host.Location,
+			UploadLoadLimit: host.UploadLoadLimit.Load(),
+			CreateAt:        host.CreateAt.Load(),
+			UpdateAt:        host.UpdateAt.Load(),
+		})
+		return true
+	})
+
+	r.taskManager.Range(func(_, value interface{}) bool {
+		task, ok := value.(*Task)
+		if !ok {
+			return true
+		}
+
+		var pieces []*base.PieceInfo
+		task.Pieces.Range(func(_, value interface{}) bool {
+			if piece, ok := value.(*base.PieceInfo); ok {
+				pieces = append(pieces, piece)
+			}
+			return true
+		})
+		sort.Slice(pieces, func(i, j int) bool { return pieces[i].PieceNum < pieces[j].PieceNum })
+
+		snapshot.Tasks = append(snapshot.Tasks, &TaskSnapshot{
+			ID:                task.ID,
+			URL:               task.URL,
+			Type:              task.Type,
+			URLMeta:           task.URLMeta,
+			DirectPiece:       task.DirectPiece,
+			ContentLength:     task.ContentLength.Load(),
+			TotalPieceCount:   task.TotalPieceCount.Load(),
+			BackToSourceLimit: task.BackToSourceLimit.Load(),
+			State:             task.FSM.Current(),
+			Pieces:            pieces,
+			ReplicaCount:      task.ReplicaCount.Load(),
+			ExpireAt:          task.ExpireAt.Load(),
+			CreateAt:          task.CreateAt.Load(),
+			UpdateAt:          task.UpdateAt.Load(),
+		})
+		return true
+	})
+
+	r.peerManager.Range(func(_, value interface{}) bool {
+		peer, ok := value.(*Peer)
+		if !ok {
+			return true
+		}
+
+		var parentID string
+		if parent, ok := peer.LoadParent(); ok {
+			parentID = parent.ID
+		}
+
+		snapshot.Peers = append(snapshot.Peers, &PeerSnapshot{
+			ID:               peer.ID,
+			BizTag:           peer.BizTag,
+			Priority:         peer.Priority,
+			TaskID:           peer.Task.ID,
+			HostID:           peer.Host.ID,
+			ParentID:         parentID,
+			State:            peer.FSM.Current(),
+			Pieces:           peer.Pieces.Clone(),
+			PieceCosts:       peer.PieceCosts(),
+			NeedBackToSource: peer.NeedBackToSource.Load(),
+			IsBackToSource:   peer.IsBackToSource.Load(),
+			CreateAt:         peer.CreateAt.Load(),
+			UpdateAt:         peer.UpdateAt.Load(),
+		})
+		return true
+	})
+
+	return snapshot
+}
+
+// Restore recovers hosts, tasks and peers from snapshot,
+// the existing resources with the same id will not be overwritten.
+func (r *resource) Restore(snapshot *Snapshot) {
+	for _, h := range snapshot.Hosts {
+		if _, ok := r.hostManager.Load(h.ID); ok {
+			continue
+		}
+
+		host := NewHost(&scheduler.PeerHost{
+			Id:             h.ID,
+			Ip:             h.IP,
+			HostName:       h.Hostname,
+			RpcPort:        h.Port,
+			DownPort:       h.DownloadPort,
+			SecurityDomain: h.SecurityDomain,
+			Idc:            h.IDC,
+			NetTopology:    h.NetTopology,
+			Location:       h.Location,
+		}, WithHostType(h.Type), WithUploadLoadLimit(h.UploadLoadLimit))
+		host.CreateAt.Store(h.CreateAt)
+		host.UpdateAt.Store(h.UpdateAt)
+		r.hostManager.Store(host)
+	}
+
+	for _, t := range snapshot.Tasks {
+		if _, ok := r.taskManager.Load(t.ID); ok {
+			continue
+		}
+
+		task := NewTask(t.ID, t.URL, t.Type, t.URLMeta, WithBackToSourceLimit(t.BackToSourceLimit))
+		task.DirectPiece = t.DirectPiece
+		task.ContentLength.Store(t.ContentLength)
+		task.TotalPieceCount.Store(t.TotalPieceCount)
+		task.FSM.SetState(t.State)
+		for _, piece := range t.Pieces {
+			task.StorePiece(piece)
+		}
+		task.ReplicaCount.Store(t.ReplicaCount)
+		task.ExpireAt.Store(t.ExpireAt)
+		task.CreateAt.Store(t.CreateAt)
+		task.UpdateAt.Store(t.UpdateAt)
+		r.taskManager.Store(task)
+	}
+
+	var restored []*PeerSnapshot
+	for _, p := range snapshot.Peers {
+		if _, ok := r.peerManager.Load(p.ID); ok {
+			continue
+		}
+
+		task, ok := r.taskManager.Load(p.TaskID)
+		if !ok {
+			logger.Warnf("task %s of peer %s not found in snapshot", p.TaskID, p.ID)
+			continue
+		}
+
+		host, ok := r.hostManager.Load(p.HostID)
+		if !ok {
+			logger.Warnf("host %s of peer %s not found in snapshot", p.HostID, p.ID)
+			continue
+		}
+
+		peer := NewPeer(p.ID, task, host, WithBizTag(p.BizTag), WithPriority(p.Priority))
+		if p.Pieces != nil {
+			peer.Pieces = p.Pieces
+		}
+		peer.pieceCosts = p.PieceCosts
+		peer.NeedBackToSource.Store(p.NeedBackToSource)
+		peer.IsBackToSource.Store(p.IsBackToSource)
+		peer.FSM.SetState(p.State)
+		peer.CreateAt.Store(p.CreateAt)
+		peer.UpdateAt.Store(p.UpdateAt)
+		r.peerManager.Store(peer)
+
+		// Keep the same relations as fsm callbacks.
+		switch p.State {
+		case PeerStateBackToSource:
+			task.BackToSourcePeers.Add(peer)
+			host.DeletePeer(peer.ID)
+		case PeerStateSucceeded, PeerStateFailed, PeerStateLeave:
+			host.DeletePeer(peer.ID)
+		}
+
+		restored = append(restored, p)
+	}
+
+	// Restore parents after all peers are loaded.
+	for _, p := range restored {
+		if p.ParentID == "" {
+			continue
+		}
+
+		peer, ok := r.peerManager.Load(p.ID)
+		if !ok {
+			continue
+		}
+
+		parent, ok := r.peerManager.Load(p.ParentID)
+		if !ok {
+			peer.Log.Warnf("parent %s not found in snapshot", p.ParentID)
+			continue
+		}
+
+		peer.StoreParent(parent)
+	}
+}
+
+// SaveSnapshot writes snapshot to file, the file is replaced atomically.
+func SaveSnapshot(filename string, snapshot *Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), filename)
+}
+
+// LoadSnapshot reads snapshot from file.
+func LoadSnapshot(filename string) (*Snapshot, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
@@ -0,0 +1,195 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resource
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"d7y.io/dragonfly/v2/pkg/gc"
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
+	"d7y.io/dragonfly/v2/scheduler/config"
+)
+
+func newSnapshotResource(t *testing.T, ctl *gomock.Controller) *resource {
+	gc := gc.NewMockGC(ctl)
+	gc.EXPECT().Add(gomock.Any()).Return(nil).Times(3)
+
+	cfg := config.New()
+	hostManager, err := newHostManager(cfg.Scheduler.GC, gc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	taskManager, err := newTaskManager(cfg.Scheduler.GC, gc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peerManager, err := newPeerManager(cfg.Scheduler.GC, gc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &resource{
+		hostManager: hostManager,
+		taskManager: taskManager,
+		peerManager: peerManager,
+	}
+}
+
+func TestResource_Snapshot(t *testing.T) {
+	tests := []struct {
+		name   string
+		mock   func(r *resource)
+		expect func(t *testing.T, r *resource)
+	}{
+		{
+			name: "restore running peer with parent",
+			mock: func(r *resource) {
+				host := NewHost(mockRawHost)
+				seedHost := NewHost(mockRawSeedHost, WithHostType(HostTypeSuperSeed))
+				task := NewTask(mockTaskID, mockTaskURL, TaskTypeNormal, mockTaskURLMeta, WithBackToSourceLimit(mockTaskBackToSourceLimit))
+				task.ContentLength.Store(1024)
+				task.TotalPieceCount.Store(2)
+				task.StorePiece(&base.PieceInfo{PieceNum: 1, RangeSize: 512})
+				task.StorePiece(&base.PieceInfo{PieceNum: 0, RangeSize: 512})
+				task.FSM.SetState(TaskStateRunning)
+
+				seedPeer := NewPeer(mockSeedPeerID, task, seedHost)
+				seedPeer.FSM.SetState(PeerStateBackToSource)
+				seedPeer.IsBackToSource.Store(true)
+				seedPeer.Pieces.Set(0)
+				seedPeer.Pieces.Set(1)
+
+				peer := NewPeer(mockPeerID, task, host, WithBizTag("foo"), WithPriority(base.Priority_URGENT_PRIORITY))
+				peer.FSM.SetState(PeerStateRunning)
+				peer.Pieces.Set(0)
+				peer.AppendPieceCost(10)
+				peer.StoreParent(seedPeer)
+
+				r.hostManager.Store(host)
+				r.hostManager.Store(seedHost)
+				r.taskManager.Store(task)
+				r.peerManager.Store(seedPeer)
+				r.peerManager.Store(peer)
+			},
+			expect: func(t *testing.T, r *resource) {
+				assert := assert.New(t)
+				host, ok := r.hostManager.Load(mockRawHost.Id)
+				assert.True(ok)
+				assert.Equal(host.IP, mockRawHost.Ip)
+				assert.Equal(host.PeerCount.Load(), int32(1))
+
+				seedHost, ok := r.hostManager.Load(mockRawSeedHost.Id)
+				assert.True(ok)
+				assert.Equal(seedHost.Type, HostTypeSuperSeed)
+				assert.Equal(seedHost.PeerCount.Load(), int32(0))
+
+				task, ok := r.taskManager.Load(mockTaskID)
+				assert.True(ok)
+				assert.True(task.FSM.Is(TaskStateRunning))
+				assert.Equal(task.ContentLength.Load(), int64(1024))
+				assert.Equal(task.TotalPieceCount.Load(), int32(2))
+				piece, ok := task.LoadPiece(1)
+				assert.True(ok)
+				assert.Equal(piece.RangeSize, uint32(512))
+				assert.Equal(task.PeerCount.Load(), int32(2))
+				assert.Equal(task.BackToSourcePeers.Len(), uint(1))
+
+				seedPeer, ok := r.peerManager.Load(mockSeedPeerID)
+				assert.True(ok)
+				assert.True(seedPeer.FSM.Is(PeerStateBackToSource))
+				assert.True(seedPeer.IsBackToSource.Load())
+				assert.Equal(seedPeer.Pieces.Count(), uint(2))
+
+				peer, ok := r.peerManager.Load(mockPeerID)
+				assert.True(ok)
+				assert.True(peer.FSM.Is(PeerStateRunning))
+				assert.Equal(peer.BizTag, "foo")
+				assert.Equal(peer.Priority, base.Priority_URGENT_PRIORITY)
+				assert.True(peer.Pieces.Test(0))
+				assert.Equal(peer.PieceCosts(), []int64{10})
+				parent, ok := peer.LoadParent()
+				assert.True(ok)
+				assert.Equal(parent.ID, mockSeedPeerID)
+				assert.Equal(seedPeer.ChildCount.Load(), int32(1))
+			},
+		},
+		{
+			name: "restore peer without task",
+			mock: func(r *resource) {
+				host := NewHost(mockRawHost)
+				task := NewTask(mockTaskID, mockTaskURL, TaskTypeNormal, mockTaskURLMeta)
+				peer := NewPeer(mockPeerID, task, host)
+
+				r.hostManager.Store(host)
+				r.peerManager.Store(peer)
+			},
+			expect: func(t *testing.T, r *resource) {
+				assert := assert.New(t)
+				_, ok := r.hostManager.Load(mockRawHost.Id)
+				assert.True(ok)
+				_, ok = r.peerManager.Load(mockPeerID)
+				assert.False(ok)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+
+			src := newSnapshotResource(t, ctl)
+			tc.mock(src)
+
+			filename := filepath.Join(t.TempDir(), "snapshot.json")
+			if err := SaveSnapshot(filename, src.Snapshot()); err != nil {
+				t.Fatal(err)
+			}
+
+			snapshot, err := LoadSnapshot(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dst := newSnapshotResource(t, ctl)
+			dst.Restore(snapshot)
+			tc.expect(t, dst)
+		})
+	}
+}
+
+func TestResource_LoadSnapshot(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+
+	_, err := LoadSnapshot(filepath.Join(dir, "foo"))
+	assert.True(os.IsNotExist(err))
+
+	filename := filepath.Join(dir, "snapshot.json")
+	if err := os.WriteFile(filename, []byte("foo"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = LoadSnapshot(filename)
+	assert.Error(err)
+}
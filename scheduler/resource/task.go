@@ -18,6 +18,7 @@ package resource
 
 import (
 	"errors"
+	"math"
 	"sort"
 	"sync"
 	"time"
@@ -48,6 +49,11 @@ const (
 	// dfcache task is a cache task, and the task url is fake url.
 	// It can only be used for caching and cannot be downloaded back to source.
 	TaskTypeDfcache
+
+	// TaskTypePersistentCache is persistent cache type of task,
+	// persistent cache task is replicated to seed peers in different idcs,
+	// and it is not reclaimed until it expires.
+	TaskTypePersistentCache
 )
 
 const (
@@ -85,6 +91,13 @@ func WithBackToSourceLimit(limit int32) Option {
 	}
 }
 
+// WithPersistentCache set ReplicaCount and ExpireAt for persistent cache task.
+func WithPersistentCache(replicaCount int32, ttl time.Duration) Option {
+	return func(task *Task) {
+		task.Persist(replicaCount, ttl)
+	}
+}
+
 type Task struct {
 	// ID is task id.
 	ID string
@@ -135,6 +148,23 @@ type Task struct {
 	// UpdateAt is task update time.
 	UpdateAt *atomic.Time
 
+	// ReplicaCount is the number of seed peers which
+	// have the replicas of persistent cache task.
+	ReplicaCount *atomic.Int32
+
+	// ExpireAt is the expiration time of persistent cache task,
+	// the task is not reclaimed before it.
+	ExpireAt *atomic.Time
+
+	// pieceCostCount, pieceCostMean and pieceCostM2 are the running statistics
+	// of piece costs downloaded from parents, computed by Welford's algorithm.
+	pieceCostCount int64
+	pieceCostMean  float64
+	pieceCostM2    float64
+
+	// Task mutex.
+	mu *sync.RWMutex
+
 	// Task log.
 	Log *logger.SugaredLoggerOnWith
 }
@@ -156,6 +186,9 @@ func NewTask(id, url string, taskType int, meta *base.UrlMeta, options ...Option
 		PeerFailedCount:   atomic.NewInt32(0),
 		CreateAt:          atomic.NewTime(time.Now()),
 		UpdateAt:          atomic.NewTime(time.Now()),
+		ReplicaCount:      atomic.NewInt32(0),
+		ExpireAt:          atomic.NewTime(time.Time{}),
+		mu:                &sync.RWMutex{},
 		Log:               logger.WithTaskIDAndURL(id, url),
 	}
 
@@ -245,6 +278,26 @@ func (t *Task) HasAvailablePeer() bool {
 	return hasAvailablePeer
 }
 
+// HasActivePeer returns whether there is a peer which has not finished or left.
+func (t *Task) HasActivePeer() bool {
+	var hasActivePeer bool
+	t.Peers.Range(func(_, v interface{}) bool {
+		peer, ok := v.(*Peer)
+		if !ok {
+			return true
+		}
+
+		if !peer.FSM.Is(PeerStateSucceeded) && !peer.FSM.Is(PeerStateFailed) && !peer.FSM.Is(PeerStateLeave) {
+			hasActivePeer = true
+			return false
+		}
+
+		return true
+	})
+
+	return hasActivePeer
+}
+
 // LoadSeedPeer return latest seed peer in peers sync map.
 func (t *Task) LoadSeedPeer() (*Peer, bool) {
 	var peers []*Peer
@@ -309,6 +362,51 @@ func (t *Task) DeletePiece(key int32) {
 	t.Pieces.Delete(key)
 }
 
+// PiecesBitmap returns bitmap of the stored pieces, the most significant bit
+// of byte i/8 is the first bit and bit i represents piece i.
+func (t *Task) PiecesBitmap() []byte {
+	var bitmap []byte
+	t.Pieces.Range(func(key, _ interface{}) bool {
+		num, ok := key.(int32)
+		if !ok || num < 0 {
+			return true
+		}
+
+		for int(num/8) >= len(bitmap) {
+			bitmap = append(bitmap, 0)
+		}
+
+		bitmap[num/8] |= 1 << uint(7-num%8)
+		return true
+	})
+
+	return bitmap
+}
+
+// AppendPieceCost adds the cost of piece downloaded from parent to statistics.
+func (t *Task) AppendPieceCost(cost int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pieceCostCount++
+	delta := float64(cost) - t.pieceCostMean
+	t.pieceCostMean += delta / float64(t.pieceCostCount)
+	t.pieceCostM2 += delta * (float64(cost) - t.pieceCostMean)
+}
+
+// PieceCostStatistics returns the count, mean and standard deviation
+// of piece costs downloaded from parents.
+func (t *Task) PieceCostStatistics() (int64, float64, float64) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.pieceCostCount < 2 {
+		return t.pieceCostCount, t.pieceCostMean, 0
+	}
+
+	return t.pieceCostCount, t.pieceCostMean, math.Sqrt(t.pieceCostM2 / float64(t.pieceCostCount))
+}
+
 // SizeScope return task size scope type.
 func (t *Task) SizeScope() (base.SizeScope, error) {
 	if t.ContentLength.Load() < 0 {
@@ -332,7 +430,26 @@ func (t *Task) SizeScope() (base.SizeScope, error) {
 
 // CanBackToSource represents whether peer can back-to-source.
 func (t *Task) CanBackToSource() bool {
-	return int32(t.BackToSourcePeers.Len()) < t.BackToSourceLimit.Load() && t.Type == TaskTypeNormal
+	return int32(t.BackToSourcePeers.Len()) < t.BackToSourceLimit.Load() && (t.Type == TaskTypeNormal || t.Type == TaskTypePersistentCache)
+}
+
+// Persist pins the task with replica count until ttl expires,
+// the expiration time is only extended and never shortened.
+func (t *Task) Persist(replicaCount int32, ttl time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.ReplicaCount.Store(replicaCount)
+
+	expireAt := time.Now().Add(ttl)
+	if expireAt.After(t.ExpireAt.Load()) {
+		t.ExpireAt.Store(expireAt)
+	}
+}
+
+// IsPersistent returns whether the task is pinned and has not expired.
+func (t *Task) IsPersistent() bool {
+	return t.ReplicaCount.Load() > 0 && time.Now().Before(t.ExpireAt.Load())
 }
 
 // NotifyPeers notify all peers in the task with the state code.
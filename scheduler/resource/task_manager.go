@@ -46,6 +46,10 @@ type TaskManager interface {
 	// Delete deletes task for a key.
 	Delete(string)
 
+	// Range calls f sequentially for each key and task present in the map.
+	// If f returns false, range stops the iteration.
+	Range(f func(key, value interface{}) bool)
+
 	// Try to reclaim task.
 	RunGC() error
 }
@@ -104,6 +108,11 @@ func (t *taskManager) RunGC() error {
 		task := value.(*Task)
 		elapsed := time.Since(task.UpdateAt.Load())
 
+		// Persistent cache task is kept until it expires.
+		if task.IsPersistent() {
+			return true
+		}
+
 		if elapsed > t.ttl && task.PeerCount.Load() == 0 && !task.FSM.Is(TaskStateRunning) {
 			task.Log.Info("task has been reclaimed")
 			t.Delete(task.ID)
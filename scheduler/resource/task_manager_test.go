@@ -336,6 +336,23 @@ func TestTaskManager_RunGC(t *testing.T) {
 				assert.Equal(task.ID, mockTask.ID)
 			},
 		},
+		{
+			name: "task is persistent",
+			mock: func(m *gc.MockGCMockRecorder) {
+				m.Add(gomock.Any()).Return(nil).Times(1)
+			},
+			expect: func(t *testing.T, taskManager TaskManager, mockTask *Task, mockPeer *Peer) {
+				assert := assert.New(t)
+				mockTask.Persist(1, time.Hour)
+				taskManager.Store(mockTask)
+				err := taskManager.RunGC()
+				assert.NoError(err)
+
+				task, ok := taskManager.Load(mockTask.ID)
+				assert.Equal(ok, true)
+				assert.Equal(task.ID, mockTask.ID)
+			},
+		},
 		{
 			name: "task state is TaskStateRunning",
 			mock: func(m *gc.MockGCMockRecorder) {
@@ -362,6 +362,52 @@ func TestTask_HasAvailablePeer(t *testing.T) {
 	}
 }
 
+func TestTask_HasActivePeer(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect func(t *testing.T, task *Task, mockPeer *Peer)
+	}{
+		{
+			name: "peer is running",
+			expect: func(t *testing.T, task *Task, mockPeer *Peer) {
+				assert := assert.New(t)
+				mockPeer.FSM.SetState(PeerStateRunning)
+				task.StorePeer(mockPeer)
+				assert.Equal(task.HasActivePeer(), true)
+			},
+		},
+		{
+			name: "peers have finished",
+			expect: func(t *testing.T, task *Task, mockPeer *Peer) {
+				assert := assert.New(t)
+				mockPeer.FSM.SetState(PeerStateSucceeded)
+				task.StorePeer(mockPeer)
+				peer := NewPeer(idgen.PeerID("0.0.0.0"), task, mockPeer.Host)
+				peer.FSM.SetState(PeerStateLeave)
+				task.StorePeer(peer)
+				assert.Equal(task.HasActivePeer(), false)
+			},
+		},
+		{
+			name: "peer does not exist",
+			expect: func(t *testing.T, task *Task, mockPeer *Peer) {
+				assert := assert.New(t)
+				assert.Equal(task.HasActivePeer(), false)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockHost := NewHost(mockRawHost)
+			task := NewTask(mockTaskID, mockTaskURL, TaskTypeNormal, mockTaskURLMeta, WithBackToSourceLimit(mockTaskBackToSourceLimit))
+			mockPeer := NewPeer(mockPeerID, task, mockHost)
+
+			tc.expect(t, task, mockPeer)
+		})
+	}
+}
+
 func TestTask_LoadSeedPeer(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -854,6 +900,18 @@ func TestTask_CanBackToSource(t *testing.T) {
 				assert.Equal(task.CanBackToSource(), false)
 			},
 		},
+		{
+			name:              "task type is TaskTypePersistentCache",
+			id:                mockTaskID,
+			urlMeta:           mockTaskURLMeta,
+			url:               mockTaskURL,
+			backToSourceLimit: 1,
+			expect: func(t *testing.T, task *Task) {
+				assert := assert.New(t)
+				task.Type = TaskTypePersistentCache
+				assert.Equal(task.CanBackToSource(), true)
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -864,6 +922,117 @@ func TestTask_CanBackToSource(t *testing.T) {
 	}
 }
 
+func TestTask_Persist(t *testing.T) {
+	tests := []struct {
+		name   string
+		run    func(task *Task)
+		expect func(t *testing.T, task *Task)
+	}{
+		{
+			name: "task is not persistent",
+			run:  func(task *Task) {},
+			expect: func(t *testing.T, task *Task) {
+				assert := assert.New(t)
+				assert.Equal(task.IsPersistent(), false)
+			},
+		},
+		{
+			name: "task is persistent",
+			run: func(task *Task) {
+				task.Persist(2, time.Hour)
+			},
+			expect: func(t *testing.T, task *Task) {
+				assert := assert.New(t)
+				assert.Equal(task.IsPersistent(), true)
+				assert.Equal(task.ReplicaCount.Load(), int32(2))
+			},
+		},
+		{
+			name: "task has expired",
+			run: func(task *Task) {
+				task.Persist(2, time.Hour)
+				task.ExpireAt.Store(time.Now().Add(-time.Second))
+			},
+			expect: func(t *testing.T, task *Task) {
+				assert := assert.New(t)
+				assert.Equal(task.IsPersistent(), false)
+			},
+		},
+		{
+			name: "expiration time of task is not shortened",
+			run: func(task *Task) {
+				task.Persist(2, time.Hour)
+				task.Persist(3, time.Minute)
+			},
+			expect: func(t *testing.T, task *Task) {
+				assert := assert.New(t)
+				assert.Equal(task.IsPersistent(), true)
+				assert.Equal(task.ReplicaCount.Load(), int32(3))
+				assert.True(task.ExpireAt.Load().After(time.Now().Add(time.Minute)))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			task := NewTask(mockTaskID, mockTaskURL, TaskTypePersistentCache, mockTaskURLMeta)
+			tc.run(task)
+			tc.expect(t, task)
+		})
+	}
+}
+
+func TestTask_PieceCostStatistics(t *testing.T) {
+	tests := []struct {
+		name   string
+		costs  []int64
+		expect func(t *testing.T, count int64, mean float64, stdev float64)
+	}{
+		{
+			name:  "piece costs is empty",
+			costs: []int64{},
+			expect: func(t *testing.T, count int64, mean float64, stdev float64) {
+				assert := assert.New(t)
+				assert.Equal(count, int64(0))
+				assert.Equal(mean, float64(0))
+				assert.Equal(stdev, float64(0))
+			},
+		},
+		{
+			name:  "piece costs has one cost",
+			costs: []int64{10},
+			expect: func(t *testing.T, count int64, mean float64, stdev float64) {
+				assert := assert.New(t)
+				assert.Equal(count, int64(1))
+				assert.Equal(mean, float64(10))
+				assert.Equal(stdev, float64(0))
+			},
+		},
+		{
+			name:  "piece costs has many costs",
+			costs: []int64{2, 4, 4, 4, 5, 5, 7, 9},
+			expect: func(t *testing.T, count int64, mean float64, stdev float64) {
+				assert := assert.New(t)
+				assert.Equal(count, int64(8))
+				assert.InDelta(mean, 5, 0.0001)
+				assert.InDelta(stdev, 2, 0.0001)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			task := NewTask(mockTaskID, mockTaskURL, TaskTypeNormal, mockTaskURLMeta, WithBackToSourceLimit(mockTaskBackToSourceLimit))
+			for _, cost := range tc.costs {
+				task.AppendPieceCost(cost)
+			}
+
+			count, mean, stdev := task.PieceCostStatistics()
+			tc.expect(t, count, mean, stdev)
+		})
+	}
+}
+
 func TestTask_NotifyPeers(t *testing.T) {
 	tests := []struct {
 		name string
@@ -109,3 +109,8 @@ func (s *Server) AnnounceTask(ctx context.Context, req *scheduler.AnnounceTaskRe
 func (s *Server) LeaveTask(ctx context.Context, req *scheduler.PeerTarget) (*empty.Empty, error) {
 	return new(empty.Empty), s.service.LeaveTask(ctx, req)
 }
+
+// SyncProbes handles the probes reported by dfdaemon and returns the hosts to be probed.
+func (s *Server) SyncProbes(ctx context.Context, req *scheduler.SyncProbesRequest) (*scheduler.SyncProbesResponse, error) {
+	return s.service.SyncProbes(ctx, req)
+}
@@ -21,22 +21,27 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
 	"time"
 
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	logger "d7y.io/dragonfly/v2/internal/dflog"
 	"d7y.io/dragonfly/v2/pkg/dfpath"
 	"d7y.io/dragonfly/v2/pkg/gc"
 	rpcmanager "d7y.io/dragonfly/v2/pkg/rpc/manager"
 	managerclient "d7y.io/dragonfly/v2/pkg/rpc/manager/client"
+	"d7y.io/dragonfly/v2/scheduler/admin"
 	"d7y.io/dragonfly/v2/scheduler/config"
 	"d7y.io/dragonfly/v2/scheduler/job"
 	"d7y.io/dragonfly/v2/scheduler/metrics"
 	"d7y.io/dragonfly/v2/scheduler/resource"
 	"d7y.io/dragonfly/v2/scheduler/rpcserver"
 	"d7y.io/dragonfly/v2/scheduler/scheduler"
+	"d7y.io/dragonfly/v2/scheduler/scheduler/evaluator"
 	"d7y.io/dragonfly/v2/scheduler/service"
 	"d7y.io/dragonfly/v2/scheduler/storage"
 )
@@ -45,6 +50,12 @@ const (
 	// gracefulStopTimeout specifies a time limit for
 	// grpc server to complete a graceful shutdown.
 	gracefulStopTimeout = 10 * time.Second
+
+	// GC snapshot id.
+	gcSnapshotID = "snapshot"
+
+	// snapshotFilename is the file name of resource snapshot.
+	snapshotFilename = "resource-snapshot.json"
 )
 
 type Server struct {
@@ -57,6 +68,9 @@ type Server struct {
 	// Metrics server.
 	metricsServer *http.Server
 
+	// Admin server.
+	adminServer *http.Server
+
 	// Manager client.
 	managerClient managerclient.Client
 
@@ -68,13 +82,53 @@ type Server struct {
 
 	// GC server.
 	gc gc.GC
+
+	// Storage of download records.
+	storage storage.Storage
+
+	// Resource snapshot.
+	snapshot *snapshot
+}
+
+// snapshot persists the state of resource to file.
+type snapshot struct {
+	resource resource.Resource
+	filename string
+}
+
+// RunGC persists snapshot periodically.
+func (s *snapshot) RunGC() error {
+	return s.save()
+}
+
+func (s *snapshot) save() error {
+	return resource.SaveSnapshot(s.filename, s.resource.Snapshot())
+}
+
+func (s *snapshot) restore() error {
+	rs, err := resource.LoadSnapshot(s.filename)
+	if err != nil {
+		return err
+	}
+
+	s.resource.Restore(rs)
+	logger.Infof("restore %d hosts, %d tasks and %d peers from snapshot", len(rs.Hosts), len(rs.Tasks), len(rs.Peers))
+	return nil
 }
 
 func New(ctx context.Context, cfg *config.Config, d dfpath.Dfpath) (*Server, error) {
 	s := &Server{config: cfg}
 
 	// Initialize manager client.
-	managerClient, err := managerclient.New(cfg.Manager.Addr)
+	var managerOpts []grpc.DialOption
+	if cfg.Manager.TLS != nil {
+		tlsConfig, err := cfg.Manager.TLS.Client()
+		if err != nil {
+			return nil, err
+		}
+		managerOpts = append(managerOpts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	}
+	managerClient, err := managerclient.New(cfg.Manager.Addr, managerOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -129,14 +183,72 @@ func New(ctx context.Context, cfg *config.Config, d dfpath.Dfpath) (*Server, err
 		return nil, err
 	}
 
-	// Initialize scheduler.
-	scheduler := scheduler.New(cfg.Scheduler, dynconfig, d.PluginDir())
+	// Initialize resource snapshot.
+	if cfg.Snapshot.Enable {
+		s.snapshot = &snapshot{
+			resource: resource,
+			filename: filepath.Join(d.DataDir(), snapshotFilename),
+		}
+
+		if err := s.snapshot.restore(); err != nil && !os.IsNotExist(err) {
+			logger.Errorf("restore snapshot failed: %s", err.Error())
+		}
+
+		if err := s.gc.Add(gc.Task{
+			ID:       gcSnapshotID,
+			Interval: cfg.Snapshot.Interval,
+			Timeout:  cfg.Snapshot.Interval,
+			Runner:   s.snapshot,
+		}); err != nil {
+			return nil, err
+		}
+	}
 
 	// Initialize Storage.
-	storage, err := storage.New(d.DataDir())
+	storageOptions := []storage.Option{
+		storage.WithMaxSize(cfg.Storage.MaxSize),
+		storage.WithMaxBackups(cfg.Storage.MaxBackups),
+		storage.WithBufferSize(cfg.Storage.BufferSize),
+		storage.WithFormat(storage.Format(cfg.Storage.Format)),
+		storage.WithSinkDropped(func(count int) {
+			metrics.StorageSinkDroppedRecordCount.Add(float64(count))
+		}),
+	}
+
+	for _, sinkConfig := range cfg.Storage.Sinks {
+		switch sinkConfig.Type {
+		case config.StorageSinkTypeParquet:
+			dir := sinkConfig.Dir
+			if dir == "" {
+				dir = filepath.Join(d.DataDir(), storage.ParquetFileExt)
+			}
+
+			sink, err := storage.NewParquetSink(dir, sinkConfig.MaxBackups)
+			if err != nil {
+				return nil, err
+			}
+			storageOptions = append(storageOptions, storage.WithSinks(sink))
+		case config.StorageSinkTypeHTTP:
+			httpSinkOptions := []storage.HTTPSinkOption{storage.WithHTTPSinkHeaders(sinkConfig.Headers)}
+			if sinkConfig.Timeout > 0 {
+				httpSinkOptions = append(httpSinkOptions, storage.WithHTTPSinkTimeout(sinkConfig.Timeout))
+			}
+			storageOptions = append(storageOptions, storage.WithSinks(storage.NewHTTPSink(sinkConfig.URL, httpSinkOptions...)))
+		}
+	}
+
+	storage, err := storage.New(d.DataDir(), storageOptions...)
 	if err != nil {
 		return nil, err
 	}
+	s.storage = storage
+
+	// Initialize scheduler.
+	evaluatorOptions := []evaluator.Option{evaluator.WithStorage(storage), evaluator.WithDynconfig(dynconfig)}
+	if cfg.Scheduler.Expression != nil {
+		evaluatorOptions = append(evaluatorOptions, evaluator.WithExpression(cfg.Scheduler.Expression.Score, cfg.Scheduler.Expression.Filter))
+	}
+	scheduler := scheduler.New(cfg.Scheduler, dynconfig, d.PluginDir(), evaluatorOptions...)
 
 	// Initialize scheduler service.
 	service := service.New(cfg, resource, scheduler, dynconfig, storage)
@@ -147,7 +259,7 @@ func New(ctx context.Context, cfg *config.Config, d dfpath.Dfpath) (*Server, err
 
 	// Initialize job service.
 	if cfg.Job.Enable {
-		s.job, err = job.New(cfg, resource)
+		s.job, err = job.New(cfg, resource, service)
 		if err != nil {
 			return nil, err
 		}
@@ -158,6 +270,11 @@ func New(ctx context.Context, cfg *config.Config, d dfpath.Dfpath) (*Server, err
 		s.metricsServer = metrics.New(cfg.Metrics, s.grpcServer)
 	}
 
+	// Initialize admin server.
+	if cfg.Admin.Enable {
+		s.adminServer = admin.New(cfg, resource)
+	}
+
 	return s, nil
 }
 
@@ -193,6 +310,19 @@ func (s *Server) Serve() error {
 		}()
 	}
 
+	// Started admin server.
+	if s.adminServer != nil {
+		go func() {
+			logger.Infof("started admin server at %s", s.adminServer.Addr)
+			if err := s.adminServer.ListenAndServe(); err != nil {
+				if err == http.ErrServerClosed {
+					return
+				}
+				logger.Fatalf("admin server closed unexpect: %s", err.Error())
+			}
+		}()
+	}
+
 	if s.managerClient != nil {
 		// scheduler keepalive with manager.
 		go func() {
@@ -249,6 +379,14 @@ func (s *Server) Stop() {
 		logger.Info("metrics server closed under request")
 	}
 
+	// Stop admin server.
+	if s.adminServer != nil {
+		if err := s.adminServer.Shutdown(context.Background()); err != nil {
+			logger.Errorf("admin server failed to stop: %s", err.Error())
+		}
+		logger.Info("admin server closed under request")
+	}
+
 	// Stop GRPC server.
 	stopped := make(chan struct{})
 	go func() {
@@ -264,4 +402,19 @@ func (s *Server) Stop() {
 	case <-stopped:
 		t.Stop()
 	}
+
+	// Flush the records of storage to file and sinks.
+	if err := s.storage.Close(); err != nil {
+		logger.Errorf("storage failed to close: %s", err.Error())
+	}
+	logger.Info("storage closed")
+
+	// Persist resource snapshot.
+	if s.snapshot != nil {
+		if err := s.snapshot.save(); err != nil {
+			logger.Errorf("snapshot failed to persist: %s", err.Error())
+		} else {
+			logger.Info("snapshot persisted")
+		}
+	}
 }
@@ -0,0 +1,144 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scheduler
+
+import (
+	"net/url"
+	"sync"
+
+	"d7y.io/dragonfly/v2/scheduler/resource"
+)
+
+// originUsage is the back-to-source usage of origin host,
+// it is used to limit the peers downloading back-to-source from origin host.
+type originUsage struct {
+	mu sync.Mutex
+
+	// peers is the peers downloading back-to-source from origin host.
+	peers map[string]*resource.Peer
+}
+
+// newOriginUsage returns a new originUsage.
+func newOriginUsage() *originUsage {
+	return &originUsage{
+		peers: map[string]*resource.Peer{},
+	}
+}
+
+// reclaim removes the finished peers.
+func (u *originUsage) reclaim() {
+	for id, peer := range u.peers {
+		if peer.FSM.Is(resource.PeerStateSucceeded) || peer.FSM.Is(resource.PeerStateFailed) || peer.FSM.Is(resource.PeerStateLeave) {
+			delete(u.peers, id)
+		}
+	}
+}
+
+// bandwidth returns the bandwidth of peers downloading back-to-source from origin host.
+func (u *originUsage) bandwidth() float64 {
+	var bandwidth float64
+	for _, peer := range u.peers {
+		bandwidth += peer.Bandwidth()
+	}
+
+	return bandwidth
+}
+
+// acquireBackToSource returns whether the peer can download back-to-source,
+// the peer is stored to the usage of origin host when the limits of origin host are not exceeded.
+// The limits are of the whole cluster, each scheduler instance enforces its share of the limits
+// divided by the number of active schedulers in the cluster.
+func (s *scheduler) acquireBackToSource(peer *resource.Peer) bool {
+	clusterConfig, ok := s.dynconfig.GetSchedulerClusterConfig()
+	if !ok || (clusterConfig.MaxBackToSourcePeersPerOrigin == 0 && clusterConfig.MaxBackToSourceBandwidthPerOrigin == 0) {
+		return true
+	}
+
+	schedulerCount, ok := s.dynconfig.GetActiveSchedulerCount()
+	if !ok {
+		schedulerCount = 1
+	}
+
+	origin, ok := originHost(peer.Task.URL)
+	if !ok {
+		return true
+	}
+
+	rawUsage, _ := s.originUsages.LoadOrStore(origin, newOriginUsage())
+	usage := rawUsage.(*originUsage)
+	usage.mu.Lock()
+	defer usage.mu.Unlock()
+
+	usage.reclaim()
+	if max := shareLimit(uint64(clusterConfig.MaxBackToSourcePeersPerOrigin), schedulerCount); max > 0 && len(usage.peers) >= int(max) {
+		peer.Log.Debugf("origin %s has %d back-to-source peers, exceeds max back-to-source peers %d", origin, len(usage.peers), max)
+		return false
+	}
+
+	if max := shareLimit(clusterConfig.MaxBackToSourceBandwidthPerOrigin, schedulerCount); max > 0 {
+		if bandwidth := usage.bandwidth(); bandwidth >= float64(max) {
+			peer.Log.Debugf("origin %s back-to-source bandwidth is %.0f, exceeds max back-to-source bandwidth %d", origin, bandwidth, max)
+			return false
+		}
+	}
+
+	usage.peers[peer.ID] = peer
+	return true
+}
+
+// releaseBackToSource removes the peer from the usage of origin host.
+func (s *scheduler) releaseBackToSource(peer *resource.Peer) {
+	origin, ok := originHost(peer.Task.URL)
+	if !ok {
+		return
+	}
+
+	rawUsage, ok := s.originUsages.Load(origin)
+	if !ok {
+		return
+	}
+
+	usage := rawUsage.(*originUsage)
+	usage.mu.Lock()
+	defer usage.mu.Unlock()
+	delete(usage.peers, peer.ID)
+}
+
+// shareLimit returns the share of limit of one scheduler instance, zero limit means no limit.
+// The share is rounded down and is one at least, so the limit may be exceeded
+// only when it is less than the number of schedulers.
+func shareLimit(limit uint64, schedulerCount uint32) uint64 {
+	if limit == 0 || schedulerCount <= 1 {
+		return limit
+	}
+
+	if share := limit / uint64(schedulerCount); share > 0 {
+		return share
+	}
+
+	return 1
+}
+
+// originHost returns the host of origin url.
+func originHost(rawURL string) (string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return "", false
+	}
+
+	return u.Hostname(), true
+}
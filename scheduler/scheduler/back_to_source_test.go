@@ -0,0 +1,304 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"d7y.io/dragonfly/v2/manager/types"
+	"d7y.io/dragonfly/v2/pkg/container/set"
+	"d7y.io/dragonfly/v2/pkg/idgen"
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
+	rpcscheduler "d7y.io/dragonfly/v2/pkg/rpc/scheduler"
+	rpcschedulermocks "d7y.io/dragonfly/v2/pkg/rpc/scheduler/mocks"
+	configmocks "d7y.io/dragonfly/v2/scheduler/config/mocks"
+	"d7y.io/dragonfly/v2/scheduler/resource"
+)
+
+func TestScheduler_acquireBackToSource(t *testing.T) {
+	tests := []struct {
+		name   string
+		mock   func(s *scheduler, peer *resource.Peer, otherPeer *resource.Peer, md *configmocks.MockDynconfigInterfaceMockRecorder)
+		expect func(t *testing.T, s *scheduler, ok bool)
+	}{
+		{
+			name: "scheduler cluster config not found",
+			mock: func(s *scheduler, peer *resource.Peer, otherPeer *resource.Peer, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{}, false).Times(1)
+			},
+			expect: func(t *testing.T, s *scheduler, ok bool) {
+				assert := assert.New(t)
+				assert.True(ok)
+			},
+		},
+		{
+			name: "back-to-source limits are empty",
+			mock: func(s *scheduler, peer *resource.Peer, otherPeer *resource.Peer, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{}, true).Times(1)
+			},
+			expect: func(t *testing.T, s *scheduler, ok bool) {
+				assert := assert.New(t)
+				assert.True(ok)
+			},
+		},
+		{
+			name: "back-to-source peers of origin do not exceed the limit",
+			mock: func(s *scheduler, peer *resource.Peer, otherPeer *resource.Peer, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{MaxBackToSourcePeersPerOrigin: 1}, true).Times(1)
+				md.GetActiveSchedulerCount().Return(uint32(0), false).AnyTimes()
+			},
+			expect: func(t *testing.T, s *scheduler, ok bool) {
+				assert := assert.New(t)
+				assert.True(ok)
+				usage, loaded := s.originUsages.Load("example.com")
+				assert.True(loaded)
+				assert.Len(usage.(*originUsage).peers, 1)
+			},
+		},
+		{
+			name: "back-to-source peers of origin exceed the limit",
+			mock: func(s *scheduler, peer *resource.Peer, otherPeer *resource.Peer, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{MaxBackToSourcePeersPerOrigin: 1}, true).Times(2)
+				md.GetActiveSchedulerCount().Return(uint32(0), false).AnyTimes()
+				s.acquireBackToSource(otherPeer)
+			},
+			expect: func(t *testing.T, s *scheduler, ok bool) {
+				assert := assert.New(t)
+				assert.False(ok)
+			},
+		},
+		{
+			name: "back-to-source peers of origin exceed the share of scheduler",
+			mock: func(s *scheduler, peer *resource.Peer, otherPeer *resource.Peer, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{MaxBackToSourcePeersPerOrigin: 5}, true).Times(2)
+				md.GetActiveSchedulerCount().Return(uint32(3), true).Times(2)
+				s.acquireBackToSource(otherPeer)
+			},
+			expect: func(t *testing.T, s *scheduler, ok bool) {
+				assert := assert.New(t)
+				assert.False(ok)
+			},
+		},
+		{
+			name: "back-to-source peers of origin do not exceed the share of scheduler",
+			mock: func(s *scheduler, peer *resource.Peer, otherPeer *resource.Peer, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{MaxBackToSourcePeersPerOrigin: 6}, true).Times(2)
+				md.GetActiveSchedulerCount().Return(uint32(3), true).Times(2)
+				s.acquireBackToSource(otherPeer)
+			},
+			expect: func(t *testing.T, s *scheduler, ok bool) {
+				assert := assert.New(t)
+				assert.True(ok)
+			},
+		},
+		{
+			name: "back-to-source limit is less than the number of schedulers",
+			mock: func(s *scheduler, peer *resource.Peer, otherPeer *resource.Peer, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{MaxBackToSourcePeersPerOrigin: 1}, true).Times(1)
+				md.GetActiveSchedulerCount().Return(uint32(3), true).Times(1)
+			},
+			expect: func(t *testing.T, s *scheduler, ok bool) {
+				assert := assert.New(t)
+				assert.True(ok)
+			},
+		},
+		{
+			name: "finished back-to-source peers of origin are reclaimed",
+			mock: func(s *scheduler, peer *resource.Peer, otherPeer *resource.Peer, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{MaxBackToSourcePeersPerOrigin: 1}, true).Times(2)
+				md.GetActiveSchedulerCount().Return(uint32(0), false).AnyTimes()
+				s.acquireBackToSource(otherPeer)
+				otherPeer.FSM.SetState(resource.PeerStateSucceeded)
+			},
+			expect: func(t *testing.T, s *scheduler, ok bool) {
+				assert := assert.New(t)
+				assert.True(ok)
+			},
+		},
+		{
+			name: "back-to-source bandwidth of origin exceeds the limit",
+			mock: func(s *scheduler, peer *resource.Peer, otherPeer *resource.Peer, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{MaxBackToSourceBandwidthPerOrigin: 1}, true).Times(2)
+				md.GetActiveSchedulerCount().Return(uint32(0), false).AnyTimes()
+				s.acquireBackToSource(otherPeer)
+				otherPeer.AppendRecentPiece(1024, time.Second)
+			},
+			expect: func(t *testing.T, s *scheduler, ok bool) {
+				assert := assert.New(t)
+				assert.False(ok)
+			},
+		},
+		{
+			name: "back-to-source peers of other origin are not counted",
+			mock: func(s *scheduler, peer *resource.Peer, otherPeer *resource.Peer, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{MaxBackToSourcePeersPerOrigin: 1}, true).Times(2)
+				md.GetActiveSchedulerCount().Return(uint32(0), false).AnyTimes()
+				otherPeer.Task.URL = "http://foo.com/bar"
+				s.acquireBackToSource(otherPeer)
+			},
+			expect: func(t *testing.T, s *scheduler, ok bool) {
+				assert := assert.New(t)
+				assert.True(ok)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			dynconfig := configmocks.NewMockDynconfigInterface(ctl)
+			mockHost := resource.NewHost(mockRawHost)
+			mockTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta, resource.WithBackToSourceLimit(mockTaskBackToSourceLimit))
+			peer := resource.NewPeer(mockPeerID, mockTask, mockHost)
+			mockOtherTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta, resource.WithBackToSourceLimit(mockTaskBackToSourceLimit))
+			otherPeer := resource.NewPeer(idgen.PeerID("127.0.0.2"), mockOtherTask, mockHost)
+			otherPeer.FSM.SetState(resource.PeerStateBackToSource)
+			s := New(mockSchedulerConfig, dynconfig, mockPluginDir).(*scheduler)
+
+			tc.mock(s, peer, otherPeer, dynconfig.EXPECT())
+			tc.expect(t, s, s.acquireBackToSource(peer))
+		})
+	}
+}
+
+func TestScheduler_releaseBackToSource(t *testing.T) {
+	assert := assert.New(t)
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	dynconfig := configmocks.NewMockDynconfigInterface(ctl)
+	mockHost := resource.NewHost(mockRawHost)
+	mockTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta, resource.WithBackToSourceLimit(mockTaskBackToSourceLimit))
+	peer := resource.NewPeer(mockPeerID, mockTask, mockHost)
+	s := New(mockSchedulerConfig, dynconfig, mockPluginDir).(*scheduler)
+
+	dynconfig.EXPECT().GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{MaxBackToSourcePeersPerOrigin: 1}, true).Times(2)
+	dynconfig.EXPECT().GetActiveSchedulerCount().Return(uint32(0), false).AnyTimes()
+	assert.True(s.acquireBackToSource(peer))
+	s.releaseBackToSource(peer)
+	assert.True(s.acquireBackToSource(peer))
+}
+
+func TestScheduler_ScheduleParentWithBackToSourceLimit(t *testing.T) {
+	tests := []struct {
+		name   string
+		mock   func(cancel context.CancelFunc, s *scheduler, peer *resource.Peer, seedPeer *resource.Peer, otherPeer *resource.Peer, mr *rpcschedulermocks.MockScheduler_ReportPieceResultServerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder)
+		expect func(t *testing.T, peer *resource.Peer)
+	}{
+		{
+			name: "peer is held and schedule parent successfully",
+			mock: func(cancel context.CancelFunc, s *scheduler, peer *resource.Peer, seedPeer *resource.Peer, otherPeer *resource.Peer, mr *rpcschedulermocks.MockScheduler_ReportPieceResultServerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				peer.Task.StorePeer(seedPeer)
+				seedPeer.FSM.SetState(resource.PeerStateRunning)
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{MaxBackToSourcePeersPerOrigin: 1}, true).AnyTimes()
+				md.GetActiveSchedulerCount().Return(uint32(0), false).AnyTimes()
+				md.GetSchedulerClusterClientConfig().Return(types.SchedulerClusterClientConfig{}, false).AnyTimes()
+				mr.Send(gomock.Any()).Return(nil).Times(1)
+				s.acquireBackToSource(otherPeer)
+			},
+			expect: func(t *testing.T, peer *resource.Peer) {
+				assert := assert.New(t)
+				parent, ok := peer.LoadParent()
+				assert.True(ok)
+				assert.Equal(parent.ID, mockSeedPeerID)
+				assert.True(peer.FSM.Is(resource.PeerStateRunning))
+			},
+		},
+		{
+			name: "peer is held until context was done",
+			mock: func(cancel context.CancelFunc, s *scheduler, peer *resource.Peer, seedPeer *resource.Peer, otherPeer *resource.Peer, mr *rpcschedulermocks.MockScheduler_ReportPieceResultServerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{MaxBackToSourcePeersPerOrigin: 1}, true).AnyTimes()
+				md.GetActiveSchedulerCount().Return(uint32(0), false).AnyTimes()
+				s.acquireBackToSource(otherPeer)
+				time.AfterFunc(5*mockSchedulerConfig.RetryInterval, cancel)
+			},
+			expect: func(t *testing.T, peer *resource.Peer) {
+				assert := assert.New(t)
+				_, ok := peer.LoadParent()
+				assert.False(ok)
+				assert.True(peer.FSM.Is(resource.PeerStateRunning))
+			},
+		},
+		{
+			name: "peer is held longer than hold timeout",
+			mock: func(cancel context.CancelFunc, s *scheduler, peer *resource.Peer, seedPeer *resource.Peer, otherPeer *resource.Peer, mr *rpcschedulermocks.MockScheduler_ReportPieceResultServerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				cfg := *mockSchedulerConfig
+				cfg.BackSourceHoldTimeout = 5 * mockSchedulerConfig.RetryInterval
+				s.config = &cfg
+
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{MaxBackToSourcePeersPerOrigin: 1}, true).AnyTimes()
+				md.GetActiveSchedulerCount().Return(uint32(0), false).AnyTimes()
+				mr.Send(gomock.Eq(&rpcscheduler.PeerPacket{Code: base.Code_SchedTaskStatusError})).Return(nil).Times(1)
+				s.acquireBackToSource(otherPeer)
+			},
+			expect: func(t *testing.T, peer *resource.Peer) {
+				assert := assert.New(t)
+				_, ok := peer.LoadParent()
+				assert.False(ok)
+				assert.True(peer.FSM.Is(resource.PeerStateRunning))
+			},
+		},
+		{
+			name: "peer downloads back-to-source after the slot of origin is released",
+			mock: func(cancel context.CancelFunc, s *scheduler, peer *resource.Peer, seedPeer *resource.Peer, otherPeer *resource.Peer, mr *rpcschedulermocks.MockScheduler_ReportPieceResultServerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{MaxBackToSourcePeersPerOrigin: 1}, true).AnyTimes()
+				md.GetActiveSchedulerCount().Return(uint32(0), false).AnyTimes()
+				mr.Send(gomock.Eq(&rpcscheduler.PeerPacket{Code: base.Code_SchedNeedBackSource})).Return(nil).Times(1)
+				s.acquireBackToSource(otherPeer)
+				time.AfterFunc(5*mockSchedulerConfig.RetryInterval, func() {
+					otherPeer.FSM.SetState(resource.PeerStateSucceeded)
+				})
+			},
+			expect: func(t *testing.T, peer *resource.Peer) {
+				assert := assert.New(t)
+				assert.True(peer.FSM.Is(resource.PeerStateBackToSource))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			stream := rpcschedulermocks.NewMockScheduler_ReportPieceResultServer(ctl)
+			dynconfig := configmocks.NewMockDynconfigInterface(ctl)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			mockHost := resource.NewHost(mockRawHost)
+			mockTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta, resource.WithBackToSourceLimit(mockTaskBackToSourceLimit))
+			peer := resource.NewPeer(mockPeerID, mockTask, mockHost)
+			mockSeedHost := resource.NewHost(mockRawSeedHost, resource.WithHostType(resource.HostTypeSuperSeed))
+			seedPeer := resource.NewPeer(mockSeedPeerID, mockTask, mockSeedHost)
+			otherPeer := resource.NewPeer(idgen.PeerID("127.0.0.2"), mockTask, mockHost)
+			otherPeer.FSM.SetState(resource.PeerStateBackToSource)
+			mockTask.StorePeer(peer)
+			peer.NeedBackToSource.Store(true)
+			peer.FSM.SetState(resource.PeerStateRunning)
+			peer.StoreStream(stream)
+			s := New(mockSchedulerConfig, dynconfig, mockPluginDir).(*scheduler)
+
+			tc.mock(cancel, s, peer, seedPeer, otherPeer, stream.EXPECT(), dynconfig.EXPECT())
+			s.ScheduleParent(ctx, peer, set.NewSafeSet())
+			tc.expect(t, peer)
+		})
+	}
+}
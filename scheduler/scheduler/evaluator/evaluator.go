@@ -17,7 +17,9 @@
 package evaluator
 
 import (
+	"d7y.io/dragonfly/v2/scheduler/config"
 	"d7y.io/dragonfly/v2/scheduler/resource"
+	"d7y.io/dragonfly/v2/scheduler/storage"
 )
 
 const (
@@ -29,6 +31,12 @@ const (
 
 	// PluginAlgorithm is a scheduling algorithm based on plugin extension.
 	PluginAlgorithm = "plugin"
+
+	// HistoryAlgorithm is a scheduling algorithm based on the throughput learned from records.
+	HistoryAlgorithm = "history"
+
+	// ExpressionAlgorithm is a scheduling algorithm based on the expressions of scheduling rules.
+	ExpressionAlgorithm = "expression"
 )
 
 type Evaluator interface {
@@ -39,12 +47,94 @@ type Evaluator interface {
 	IsBadNode(peer *resource.Peer) bool
 }
 
-func New(algorithm string, pluginDir string) Evaluator {
+// Filter is an optional interface of evaluator to filter the candidate parents.
+type Filter interface {
+	// IsCandidateParent determines whether the parent can be scheduled to the child.
+	IsCandidateParent(parent *resource.Peer, child *resource.Peer) bool
+}
+
+// Refresher is an optional interface of evaluator which learns from the records of storage.
+type Refresher interface {
+	Evaluator
+
+	// Refresh learns from the records created after the last refresh synchronously.
+	Refresh()
+}
+
+// Option is a functional option for configuring the evaluator.
+type Option func(o *options)
+
+type options struct {
+	// Storage of records.
+	storage storage.Storage
+
+	// Dynconfig of scheduler.
+	dynconfig config.DynconfigInterface
+
+	// Score expression.
+	scoreExpression string
+
+	// Filter expression.
+	filterExpression string
+
+	// Manual refresh receives the evaluator refreshed by caller.
+	manualRefresh func(Refresher)
+}
+
+// WithStorage sets the storage of records, it is required by HistoryAlgorithm.
+func WithStorage(storage storage.Storage) Option {
+	return func(o *options) {
+		o.storage = storage
+	}
+}
+
+// WithDynconfig sets the dynconfig, the expressions of ExpressionAlgorithm are reloaded from it.
+func WithDynconfig(dynconfig config.DynconfigInterface) Option {
+	return func(o *options) {
+		o.dynconfig = dynconfig
+	}
+}
+
+// WithExpression sets the score and filter expressions of ExpressionAlgorithm.
+func WithExpression(score, filter string) Option {
+	return func(o *options) {
+		o.scoreExpression = score
+		o.filterExpression = filter
+	}
+}
+
+// WithManualRefresh makes HistoryAlgorithm learn from records only when the caller refreshes it,
+// instead of loading records in background at most once per refresh interval,
+// e.g. the simulator refreshes it whenever the replay time advances.
+func WithManualRefresh(refresher func(Refresher)) Option {
+	return func(o *options) {
+		o.manualRefresh = refresher
+	}
+}
+
+func New(algorithm string, pluginDir string, opts ...Option) Evaluator {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	switch algorithm {
 	case PluginAlgorithm:
 		if plugin, err := LoadPlugin(pluginDir); err == nil {
 			return plugin
 		}
+	case HistoryAlgorithm:
+		if o.storage != nil {
+			if o.manualRefresh != nil {
+				eh := newEvaluatorHistory(o.storage, true)
+				o.manualRefresh(eh)
+				return eh
+			}
+
+			return NewEvaluatorHistory(o.storage)
+		}
+	case ExpressionAlgorithm:
+		return NewEvaluatorExpression(o.scoreExpression, o.filterExpression, o.dynconfig)
 	// TODO Implement MLAlgorithm.
 	case MLAlgorithm, DefaultAlgorithm:
 		return NewEvaluatorBase()
@@ -19,6 +19,7 @@ package evaluator
 import (
 	"math/big"
 	"strings"
+	"time"
 
 	"github.com/montanaflynn/stats"
 
@@ -68,6 +69,18 @@ const (
 	maxElementLen = 5
 )
 
+const (
+	// If the round-trip time between hosts is greater than or equal to
+	// maxProbeRTT, the network affinity score is minimum.
+	maxProbeRTT = 100 * time.Millisecond
+)
+
+const (
+	// If the cpu or memory usage ratio of host is greater than
+	// overloadRatio, the host is regarded as overloaded.
+	overloadRatio = 0.8
+)
+
 type evaluatorBase struct{}
 
 func NewEvaluatorBase() Evaluator {
@@ -84,12 +97,15 @@ func (eb *evaluatorBase) Evaluate(parent *resource.Peer, child *resource.Peer, t
 		return minScore
 	}
 
-	return finishedPieceWeight*calculatePieceScore(parent, child, totalPieceCount) +
+	score := finishedPieceWeight*calculatePieceScore(parent, child, totalPieceCount) +
 		freeLoadWeight*calculateFreeLoadScore(parent.Host) +
 		hostTypeAffinityWeight*calculateHostTypeAffinityScore(parent) +
 		idcAffinityWeight*calculateIDCAffinityScore(parent.Host, child.Host) +
-		netTopologyAffinityWeight*calculateMultiElementAffinityScore(parent.Host.NetTopology, child.Host.NetTopology) +
+		netTopologyAffinityWeight*calculateNetworkAffinityScore(parent.Host, child.Host) +
 		locationAffinityWeight*calculateMultiElementAffinityScore(parent.Host.Location, child.Host.Location)
+
+	// Penalize the parent whose host is overloaded.
+	return score * calculateHostLoadScore(parent.Host)
 }
 
 // calculatePieceScore 0.0~unlimited larger and better.
@@ -119,6 +135,28 @@ func calculateFreeLoadScore(host *resource.Host) float64 {
 	return minScore
 }
 
+// calculateHostLoadScore 0.0~1.0 larger and better.
+func calculateHostLoadScore(host *resource.Host) float64 {
+	// Disk usage does not affect the upload performance,
+	// so only cpu and memory usage are considered.
+	ratio := host.CPURatio.Load()
+	if memRatio := host.MemRatio.Load(); memRatio > ratio {
+		ratio = memRatio
+	}
+
+	if ratio <= overloadRatio {
+		return maxScore
+	}
+
+	if ratio >= 1 {
+		return minScore
+	}
+
+	// The score decreases linearly from maxScore to minScore
+	// when usage ratio increases from overloadRatio to 1.
+	return maxScore * (1 - ratio) / (1 - overloadRatio)
+}
+
 // calculateHostTypeAffinityScore 0.0~1.0 larger and better.
 func calculateHostTypeAffinityScore(peer *resource.Peer) float64 {
 	// When the task is downloaded for the first time,
@@ -145,6 +183,27 @@ func calculateIDCAffinityScore(dst, src *resource.Host) float64 {
 	return minScore
 }
 
+// calculateNetworkAffinityScore 0.0~1.0 larger and better.
+func calculateNetworkAffinityScore(dst, src *resource.Host) float64 {
+	// The round-trip time probed between hosts is preferred,
+	// otherwise the network topology reported by hosts is used.
+	probe, ok := src.LoadProbe(dst.ID)
+	if !ok {
+		if probe, ok = dst.LoadProbe(src.ID); !ok {
+			return calculateMultiElementAffinityScore(dst.NetTopology, src.NetTopology)
+		}
+	}
+
+	rtt := probe.RTT.Load()
+	if rtt >= maxProbeRTT {
+		return minScore
+	}
+
+	// The score decreases linearly from maxScore to minScore
+	// when round-trip time increases from 0 to maxProbeRTT.
+	return maxScore * float64(maxProbeRTT-rtt) / float64(maxProbeRTT)
+}
+
 // calculateMultiElementAffinityScore 0.0~1.0 larger and better.
 func calculateMultiElementAffinityScore(dst, src string) float64 {
 	if dst == "" || src == "" {
@@ -193,22 +252,30 @@ func (eb *evaluatorBase) IsBadNode(peer *resource.Peer) bool {
 	}
 
 	lastCost := costs[len-1]
-	mean, _ := stats.Mean(costs[:len-1]) // nolint: errcheck
+	mean, _ := stats.Mean(costs[:len-1])               // nolint: errcheck
+	stdev, _ := stats.StandardDeviation(costs[:len-1]) // nolint: errcheck
+	isBadNode := IsCostOutlier(lastCost, int64(len), mean, stdev)
+	logger.Debugf("peer %s costs mean is %.2f and standard deviation is %.2f, peer is bad node: %t",
+		peer.ID, mean, stdev, isBadNode)
+	return isBadNode
+}
+
+// IsCostOutlier determines whether the cost is an outlier of the costs,
+// count, mean and stdev are the number, mean and standard deviation of the costs.
+func IsCostOutlier(cost float64, count int64, mean float64, stdev float64) bool {
+	// Costs is not enough to be compared.
+	if count < minAvailableCostLen {
+		return false
+	}
 
 	// Download costs does not meet the normal distribution,
-	// if the last cost is twenty times more than mean, it is bad node.
-	if len < normalDistributionLen {
-		isBadNode := big.NewFloat(lastCost).Cmp(big.NewFloat(mean*20)) > 0
-		logger.Debugf("peer %s mean is %.2f and it is bad node: %t", peer.ID, mean, isBadNode)
-		return isBadNode
+	// if the cost is twenty times more than mean, it is outlier.
+	if count < normalDistributionLen {
+		return big.NewFloat(cost).Cmp(big.NewFloat(mean*20)) > 0
 	}
 
 	// Download costs satisfies the normal distribution,
-	// last cost falling outside of three-sigma effect need to be adjusted parent,
+	// cost falling outside of three-sigma effect is outlier,
 	// refer to https://en.wikipedia.org/wiki/68%E2%80%9395%E2%80%9399.7_rule.
-	stdev, _ := stats.StandardDeviation(costs[:len-1]) // nolint: errcheck
-	isBadNode := big.NewFloat(lastCost).Cmp(big.NewFloat(mean+3*stdev)) > 0
-	logger.Debugf("peer %s meet the normal distribution, costs mean is %.2f and standard deviation is %.2f, peer is bad node: %t",
-		peer.ID, mean, stdev, isBadNode)
-	return isBadNode
+	return big.NewFloat(cost).Cmp(big.NewFloat(mean+3*stdev)) > 0
 }
@@ -19,6 +19,7 @@ package evaluator
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -149,6 +150,20 @@ func TestEvaluatorBase_Evaluate(t *testing.T) {
 				assert.Equal(score, float64(0.9))
 			},
 		},
+		{
+			name:            "parent host is overloaded",
+			parent:          resource.NewPeer(idgen.PeerID("127.0.0.1"), parentMockTask, resource.NewHost(mockRawHost)),
+			child:           resource.NewPeer(idgen.PeerID("127.0.0.1"), childMockTask, childMockHost),
+			totalPieceCount: 1,
+			mock: func(parent *resource.Peer, child *resource.Peer) {
+				parent.Host.CPURatio.Store(0.9)
+				parent.Pieces.Set(0)
+			},
+			expect: func(t *testing.T, score float64) {
+				assert := assert.New(t)
+				assert.InDelta(score, float64(0.45), 0.0001)
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -314,6 +329,75 @@ func TestEvaluatorBase_calculateFreeLoadScore(t *testing.T) {
 	}
 }
 
+func TestEvaluatorBase_calculateHostLoadScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		mock   func(host *resource.Host)
+		expect func(t *testing.T, score float64)
+	}{
+		{
+			name: "host load is not reported",
+			mock: func(host *resource.Host) {},
+			expect: func(t *testing.T, score float64) {
+				assert := assert.New(t)
+				assert.Equal(score, float64(1))
+			},
+		},
+		{
+			name: "host is not overloaded",
+			mock: func(host *resource.Host) {
+				host.CPURatio.Store(0.8)
+				host.MemRatio.Store(0.5)
+				host.DiskRatio.Store(1)
+			},
+			expect: func(t *testing.T, score float64) {
+				assert := assert.New(t)
+				assert.Equal(score, float64(1))
+			},
+		},
+		{
+			name: "host cpu is overloaded",
+			mock: func(host *resource.Host) {
+				host.CPURatio.Store(0.9)
+				host.MemRatio.Store(0.5)
+			},
+			expect: func(t *testing.T, score float64) {
+				assert := assert.New(t)
+				assert.InDelta(score, float64(0.5), 0.0001)
+			},
+		},
+		{
+			name: "host memory is overloaded",
+			mock: func(host *resource.Host) {
+				host.CPURatio.Store(0.5)
+				host.MemRatio.Store(0.95)
+			},
+			expect: func(t *testing.T, score float64) {
+				assert := assert.New(t)
+				assert.InDelta(score, float64(0.25), 0.0001)
+			},
+		},
+		{
+			name: "host is fully loaded",
+			mock: func(host *resource.Host) {
+				host.CPURatio.Store(1)
+			},
+			expect: func(t *testing.T, score float64) {
+				assert := assert.New(t)
+				assert.Equal(score, float64(0))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			host := resource.NewHost(mockRawHost)
+			tc.mock(host)
+			tc.expect(t, calculateHostLoadScore(host))
+		})
+	}
+}
+
 func TestEvaluatorBase_calculateHostTypeAffinityScore(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -434,6 +518,85 @@ func TestEvaluatorBase_calculateIDCAffinityScore(t *testing.T) {
 	}
 }
 
+func TestEvaluatorBase_calculateNetworkAffinityScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		mock   func(dstHost *resource.Host, srcHost *resource.Host)
+		expect func(t *testing.T, score float64)
+	}{
+		{
+			name: "probe does not exist and net topology is the same",
+			mock: func(dstHost *resource.Host, srcHost *resource.Host) {
+			},
+			expect: func(t *testing.T, score float64) {
+				assert := assert.New(t)
+				assert.Equal(score, float64(1))
+			},
+		},
+		{
+			name: "probe does not exist and net topology is not the same",
+			mock: func(dstHost *resource.Host, srcHost *resource.Host) {
+				dstHost.NetTopology = "foo"
+			},
+			expect: func(t *testing.T, score float64) {
+				assert := assert.New(t)
+				assert.Equal(score, float64(0))
+			},
+		},
+		{
+			name: "src host probes dst host",
+			mock: func(dstHost *resource.Host, srcHost *resource.Host) {
+				srcHost.StoreProbe(dstHost.ID, 20*time.Millisecond)
+			},
+			expect: func(t *testing.T, score float64) {
+				assert := assert.New(t)
+				assert.Equal(score, float64(0.8))
+			},
+		},
+		{
+			name: "dst host probes src host",
+			mock: func(dstHost *resource.Host, srcHost *resource.Host) {
+				dstHost.StoreProbe(srcHost.ID, 50*time.Millisecond)
+			},
+			expect: func(t *testing.T, score float64) {
+				assert := assert.New(t)
+				assert.Equal(score, float64(0.5))
+			},
+		},
+		{
+			name: "probe takes precedence over net topology",
+			mock: func(dstHost *resource.Host, srcHost *resource.Host) {
+				dstHost.NetTopology = "foo"
+				srcHost.StoreProbe(dstHost.ID, 0)
+			},
+			expect: func(t *testing.T, score float64) {
+				assert := assert.New(t)
+				assert.Equal(score, float64(1))
+			},
+		},
+		{
+			name: "round-trip time exceeds the maximum",
+			mock: func(dstHost *resource.Host, srcHost *resource.Host) {
+				srcHost.StoreProbe(dstHost.ID, time.Second)
+			},
+			expect: func(t *testing.T, score float64) {
+				assert := assert.New(t)
+				assert.Equal(score, float64(0))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dstHost := resource.NewHost(mockRawHost)
+			srcHost := resource.NewHost(mockRawHost)
+			srcHost.ID = idgen.HostID("foo", 8003)
+			tc.mock(dstHost, srcHost)
+			tc.expect(t, calculateNetworkAffinityScore(dstHost, srcHost))
+		})
+	}
+}
+
 func TestEvaluatorBase_calculateMultiElementAffinityScore(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -727,3 +890,76 @@ func TestEvaluatorBase_IsBadNode(t *testing.T) {
 		})
 	}
 }
+
+func TestEvaluatorBase_IsCostOutlier(t *testing.T) {
+	tests := []struct {
+		name   string
+		cost   float64
+		count  int64
+		mean   float64
+		stdev  float64
+		expect func(t *testing.T, isOutlier bool)
+	}{
+		{
+			name:  "costs is not enough",
+			cost:  1000,
+			count: 1,
+			mean:  1,
+			stdev: 0,
+			expect: func(t *testing.T, isOutlier bool) {
+				assert := assert.New(t)
+				assert.False(isOutlier)
+			},
+		},
+		{
+			name:  "costs does not meet the normal distribution and cost is too long",
+			cost:  21,
+			count: 10,
+			mean:  1,
+			stdev: 0,
+			expect: func(t *testing.T, isOutlier bool) {
+				assert := assert.New(t)
+				assert.True(isOutlier)
+			},
+		},
+		{
+			name:  "costs does not meet the normal distribution and cost is normal",
+			cost:  20,
+			count: 10,
+			mean:  1,
+			stdev: 0,
+			expect: func(t *testing.T, isOutlier bool) {
+				assert := assert.New(t)
+				assert.False(isOutlier)
+			},
+		},
+		{
+			name:  "costs meet the normal distribution and cost is too long",
+			cost:  17,
+			count: 30,
+			mean:  10,
+			stdev: 2,
+			expect: func(t *testing.T, isOutlier bool) {
+				assert := assert.New(t)
+				assert.True(isOutlier)
+			},
+		},
+		{
+			name:  "costs meet the normal distribution and cost is normal",
+			cost:  16,
+			count: 30,
+			mean:  10,
+			stdev: 2,
+			expect: func(t *testing.T, isOutlier bool) {
+				assert := assert.New(t)
+				assert.False(isOutlier)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.expect(t, IsCostOutlier(tc.cost, tc.count, tc.mean, tc.stdev))
+		})
+	}
+}
@@ -0,0 +1,229 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluator
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/Knetic/govaluate"
+
+	logger "d7y.io/dragonfly/v2/internal/dflog"
+	"d7y.io/dragonfly/v2/manager/types"
+	"d7y.io/dragonfly/v2/scheduler/config"
+	"d7y.io/dragonfly/v2/scheduler/resource"
+)
+
+const (
+	// Round-trip time parameter of hosts without probes.
+	unknownProbeRTT = -1
+)
+
+type evaluatorExpression struct {
+	*evaluatorBase
+
+	// Expressions of scheduler config, they are used
+	// when the expressions of scheduler cluster config are empty.
+	defaultScore  string
+	defaultFilter string
+
+	mu sync.RWMutex
+
+	// Raw expressions in use.
+	rawScore  string
+	rawFilter string
+
+	// Compiled expressions in use, nil means the expression is empty.
+	score  *govaluate.EvaluableExpression
+	filter *govaluate.EvaluableExpression
+}
+
+// NewEvaluatorExpression returns an evaluator which ranks and filters parents by expressions.
+// The expressions of scheduler cluster config in dynconfig override the given expressions,
+// and they are reloaded when dynconfig is changed.
+//
+// Parameters of expressions are base_score, total_piece_count, probe_rtt in milliseconds
+// and the following attributes of parent and child with prefix parent_ and child_:
+// state, finished_piece_count, is_back_to_source, is_seed, hostname, ip, idc, location,
+// net_topology, security_domain, upload_load_limit, free_upload_load, cpu_ratio and mem_ratio.
+func NewEvaluatorExpression(score, filter string, dynconfig config.DynconfigInterface) Evaluator {
+	ee := &evaluatorExpression{
+		evaluatorBase: &evaluatorBase{},
+		defaultScore:  score,
+		defaultFilter: filter,
+	}
+	ee.update(score, filter)
+
+	if dynconfig != nil {
+		if data, err := dynconfig.Get(); err == nil {
+			ee.OnNotify(data)
+		}
+
+		dynconfig.Register(ee)
+	}
+
+	return ee
+}
+
+// OnNotify reloads the expressions of scheduler cluster config.
+func (ee *evaluatorExpression) OnNotify(data *config.DynconfigData) {
+	score, filter := ee.defaultScore, ee.defaultFilter
+	if data != nil && data.SchedulerCluster != nil {
+		var clusterConfig types.SchedulerClusterConfig
+		if err := json.Unmarshal(data.SchedulerCluster.Config, &clusterConfig); err == nil {
+			if clusterConfig.ScoreExpression != "" {
+				score = clusterConfig.ScoreExpression
+			}
+
+			if clusterConfig.FilterExpression != "" {
+				filter = clusterConfig.FilterExpression
+			}
+		}
+	}
+
+	ee.update(score, filter)
+}
+
+// update compiles the changed expressions, the invalid expression is ignored
+// and the previous expression is still in use.
+func (ee *evaluatorExpression) update(score, filter string) {
+	ee.mu.Lock()
+	defer ee.mu.Unlock()
+
+	if score != ee.rawScore {
+		if expression, err := compileExpression(score); err != nil {
+			logger.Errorf("invalid score expression %q: %s", score, err.Error())
+		} else {
+			ee.rawScore, ee.score = score, expression
+			logger.Infof("score expression has been updated to %q", score)
+		}
+	}
+
+	if filter != ee.rawFilter {
+		if expression, err := compileExpression(filter); err != nil {
+			logger.Errorf("invalid filter expression %q: %s", filter, err.Error())
+		} else {
+			ee.rawFilter, ee.filter = filter, expression
+			logger.Infof("filter expression has been updated to %q", filter)
+		}
+	}
+}
+
+// The larger the value after evaluation, the higher the priority.
+func (ee *evaluatorExpression) Evaluate(parent *resource.Peer, child *resource.Peer, totalPieceCount int32) float64 {
+	score := ee.evaluatorBase.Evaluate(parent, child, totalPieceCount)
+
+	ee.mu.RLock()
+	expression := ee.score
+	ee.mu.RUnlock()
+	if expression == nil {
+		return score
+	}
+
+	result, err := expression.Evaluate(expressionParameters(parent, child, totalPieceCount, score))
+	if err != nil {
+		logger.Debugf("evaluate score expression failed: %s", err.Error())
+		return score
+	}
+
+	switch value := result.(type) {
+	case float64:
+		return value
+	case bool:
+		if value {
+			return maxScore
+		}
+
+		return minScore
+	default:
+		logger.Debugf("score expression returns %#v, which is not a number", result)
+		return score
+	}
+}
+
+// IsCandidateParent determines whether the parent can be scheduled to the child by filter expression.
+func (ee *evaluatorExpression) IsCandidateParent(parent *resource.Peer, child *resource.Peer) bool {
+	ee.mu.RLock()
+	expression := ee.filter
+	ee.mu.RUnlock()
+	if expression == nil {
+		return true
+	}
+
+	result, err := expression.Evaluate(expressionParameters(parent, child, child.Task.TotalPieceCount.Load(), minScore))
+	if err != nil {
+		logger.Debugf("evaluate filter expression failed: %s", err.Error())
+		return true
+	}
+
+	value, ok := result.(bool)
+	if !ok {
+		logger.Debugf("filter expression returns %#v, which is not a bool", result)
+		return true
+	}
+
+	return value
+}
+
+// compileExpression compiles the expression, nil is returned when the expression is empty.
+func compileExpression(expression string) (*govaluate.EvaluableExpression, error) {
+	if expression == "" {
+		return nil, nil
+	}
+
+	return govaluate.NewEvaluableExpression(expression)
+}
+
+// expressionParameters returns the parameters of expressions.
+func expressionParameters(parent *resource.Peer, child *resource.Peer, totalPieceCount int32, baseScore float64) map[string]interface{} {
+	parameters := map[string]interface{}{
+		"base_score":        baseScore,
+		"total_piece_count": float64(totalPieceCount),
+		"probe_rtt":         float64(unknownProbeRTT),
+	}
+
+	probe, ok := child.Host.LoadProbe(parent.Host.ID)
+	if !ok {
+		probe, ok = parent.Host.LoadProbe(child.Host.ID)
+	}
+
+	if ok {
+		parameters["probe_rtt"] = float64(probe.RTT.Load().Microseconds()) / 1000
+	}
+
+	addPeerParameters(parameters, "parent_", parent)
+	addPeerParameters(parameters, "child_", child)
+	return parameters
+}
+
+// addPeerParameters adds the attributes of peer and its host to parameters with prefix.
+func addPeerParameters(parameters map[string]interface{}, prefix string, peer *resource.Peer) {
+	parameters[prefix+"state"] = peer.FSM.Current()
+	parameters[prefix+"finished_piece_count"] = float64(peer.Pieces.Count())
+	parameters[prefix+"is_back_to_source"] = peer.IsBackToSource.Load()
+	parameters[prefix+"is_seed"] = peer.Host.Type != resource.HostTypeNormal
+	parameters[prefix+"hostname"] = peer.Host.Hostname
+	parameters[prefix+"ip"] = peer.Host.IP
+	parameters[prefix+"idc"] = peer.Host.IDC
+	parameters[prefix+"location"] = peer.Host.Location
+	parameters[prefix+"net_topology"] = peer.Host.NetTopology
+	parameters[prefix+"security_domain"] = peer.Host.SecurityDomain
+	parameters[prefix+"upload_load_limit"] = float64(peer.Host.UploadLoadLimit.Load())
+	parameters[prefix+"free_upload_load"] = float64(peer.Host.FreeUploadLoad())
+	parameters[prefix+"cpu_ratio"] = peer.Host.CPURatio.Load()
+	parameters[prefix+"mem_ratio"] = peer.Host.MemRatio.Load()
+}
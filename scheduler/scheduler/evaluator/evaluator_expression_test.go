@@ -0,0 +1,287 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"d7y.io/dragonfly/v2/scheduler/config"
+	configmocks "d7y.io/dragonfly/v2/scheduler/config/mocks"
+	"d7y.io/dragonfly/v2/scheduler/resource"
+)
+
+func TestEvaluatorExpression_NewEvaluatorExpression(t *testing.T) {
+	tests := []struct {
+		name   string
+		score  string
+		filter string
+		mock   func(md *configmocks.MockDynconfigInterfaceMockRecorder)
+		expect func(t *testing.T, ee *evaluatorExpression)
+	}{
+		{
+			name:   "new evaluator expression with expressions of scheduler cluster config",
+			score:  "base_score",
+			filter: "parent_idc == child_idc",
+			mock: func(md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				gomock.InOrder(
+					md.Get().Return(&config.DynconfigData{
+						SchedulerCluster: &config.SchedulerCluster{
+							Config: []byte(`{"score_expression": "base_score * 0.5"}`),
+						},
+					}, nil).Times(1),
+					md.Register(gomock.Any()).Return().Times(1),
+				)
+			},
+			expect: func(t *testing.T, ee *evaluatorExpression) {
+				assert := assert.New(t)
+				assert.Equal(ee.rawScore, "base_score * 0.5")
+				assert.Equal(ee.rawFilter, "parent_idc == child_idc")
+				assert.NotNil(ee.score)
+				assert.NotNil(ee.filter)
+			},
+		},
+		{
+			name:   "new evaluator expression failed because of dynconfig get error",
+			score:  "base_score",
+			filter: "",
+			mock: func(md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				gomock.InOrder(
+					md.Get().Return(nil, errors.New("foo")).Times(1),
+					md.Register(gomock.Any()).Return().Times(1),
+				)
+			},
+			expect: func(t *testing.T, ee *evaluatorExpression) {
+				assert := assert.New(t)
+				assert.Equal(ee.rawScore, "base_score")
+				assert.NotNil(ee.score)
+				assert.Nil(ee.filter)
+			},
+		},
+		{
+			name:   "new evaluator expression with invalid expression",
+			score:  "base_score +",
+			filter: "",
+			mock: func(md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				gomock.InOrder(
+					md.Get().Return(&config.DynconfigData{}, nil).Times(1),
+					md.Register(gomock.Any()).Return().Times(1),
+				)
+			},
+			expect: func(t *testing.T, ee *evaluatorExpression) {
+				assert := assert.New(t)
+				assert.Equal(ee.rawScore, "")
+				assert.Nil(ee.score)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			dynconfig := configmocks.NewMockDynconfigInterface(ctl)
+			tc.mock(dynconfig.EXPECT())
+
+			e := NewEvaluatorExpression(tc.score, tc.filter, dynconfig)
+			assert.Equal(t, reflect.TypeOf(e).Elem().Name(), "evaluatorExpression")
+			tc.expect(t, e.(*evaluatorExpression))
+		})
+	}
+}
+
+func TestEvaluatorExpression_OnNotify(t *testing.T) {
+	assert := assert.New(t)
+	ee := NewEvaluatorExpression("base_score", "", nil).(*evaluatorExpression)
+
+	ee.OnNotify(&config.DynconfigData{
+		SchedulerCluster: &config.SchedulerCluster{
+			Config: []byte(`{"score_expression": "base_score * 0.5", "filter_expression": "!parent_is_seed"}`),
+		},
+	})
+	assert.Equal(ee.rawScore, "base_score * 0.5")
+	assert.Equal(ee.rawFilter, "!parent_is_seed")
+
+	// Invalid expression is ignored and the previous expression is still in use.
+	ee.OnNotify(&config.DynconfigData{
+		SchedulerCluster: &config.SchedulerCluster{
+			Config: []byte(`{"score_expression": "base_score *", "filter_expression": "!parent_is_seed"}`),
+		},
+	})
+	assert.Equal(ee.rawScore, "base_score * 0.5")
+	assert.NotNil(ee.score)
+
+	// Expressions of scheduler config are used when the expressions of scheduler cluster config are empty.
+	ee.OnNotify(&config.DynconfigData{})
+	assert.Equal(ee.rawScore, "base_score")
+	assert.Equal(ee.rawFilter, "")
+	assert.Nil(ee.filter)
+}
+
+func TestEvaluatorExpression_Evaluate(t *testing.T) {
+	tests := []struct {
+		name   string
+		score  string
+		mock   func(parent *resource.Peer, child *resource.Peer)
+		expect func(t *testing.T, score float64, baseScore float64)
+	}{
+		{
+			name:  "score expression is empty",
+			score: "",
+			mock:  func(parent *resource.Peer, child *resource.Peer) {},
+			expect: func(t *testing.T, score float64, baseScore float64) {
+				assert := assert.New(t)
+				assert.Equal(score, baseScore)
+			},
+		},
+		{
+			name:  "score expression returns number",
+			score: "base_score * 0.5 + (parent_idc == child_idc ? 0.5 : 0)",
+			mock: func(parent *resource.Peer, child *resource.Peer) {
+				child.Host.IDC = parent.Host.IDC
+			},
+			expect: func(t *testing.T, score float64, baseScore float64) {
+				assert := assert.New(t)
+				assert.Equal(score, baseScore*0.5+0.5)
+			},
+		},
+		{
+			name:  "score expression uses probe rtt",
+			score: "probe_rtt < 0 ? 0 : 100 - probe_rtt",
+			mock: func(parent *resource.Peer, child *resource.Peer) {
+				child.Host.StoreProbe(parent.Host.ID, 10*time.Millisecond)
+			},
+			expect: func(t *testing.T, score float64, baseScore float64) {
+				assert := assert.New(t)
+				assert.Equal(score, float64(90))
+			},
+		},
+		{
+			name:  "score expression returns bool",
+			score: "parent_finished_piece_count > child_finished_piece_count",
+			mock: func(parent *resource.Peer, child *resource.Peer) {
+				parent.Pieces.Set(0)
+			},
+			expect: func(t *testing.T, score float64, baseScore float64) {
+				assert := assert.New(t)
+				assert.Equal(score, float64(maxScore))
+			},
+		},
+		{
+			name:  "score expression returns string",
+			score: "parent_idc",
+			mock:  func(parent *resource.Peer, child *resource.Peer) {},
+			expect: func(t *testing.T, score float64, baseScore float64) {
+				assert := assert.New(t)
+				assert.Equal(score, baseScore)
+			},
+		},
+		{
+			name:  "score expression evaluates failed",
+			score: "foo * 2",
+			mock:  func(parent *resource.Peer, child *resource.Peer) {},
+			expect: func(t *testing.T, score float64, baseScore float64) {
+				assert := assert.New(t)
+				assert.Equal(score, baseScore)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta, resource.WithBackToSourceLimit(mockTaskBackToSourceLimit))
+			parent := resource.NewPeer(mockPeerID, mockTask, resource.NewHost(mockRawParentHost))
+			child := resource.NewPeer(mockPeerID, mockTask, resource.NewHost(mockRawChildHost))
+			tc.mock(parent, child)
+
+			e := NewEvaluatorExpression(tc.score, "", nil)
+			tc.expect(t, e.Evaluate(parent, child, 1), NewEvaluatorBase().Evaluate(parent, child, 1))
+		})
+	}
+}
+
+func TestEvaluatorExpression_IsCandidateParent(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		mock   func(parent *resource.Peer, child *resource.Peer)
+		expect func(t *testing.T, ok bool)
+	}{
+		{
+			name:   "filter expression is empty",
+			filter: "",
+			mock:   func(parent *resource.Peer, child *resource.Peer) {},
+			expect: func(t *testing.T, ok bool) {
+				assert := assert.New(t)
+				assert.True(ok)
+			},
+		},
+		{
+			name:   "parent is filtered",
+			filter: "parent_idc == child_idc",
+			mock:   func(parent *resource.Peer, child *resource.Peer) {},
+			expect: func(t *testing.T, ok bool) {
+				assert := assert.New(t)
+				assert.False(ok)
+			},
+		},
+		{
+			name:   "parent is not filtered",
+			filter: "!parent_is_seed && parent_state == 'Pending'",
+			mock:   func(parent *resource.Peer, child *resource.Peer) {},
+			expect: func(t *testing.T, ok bool) {
+				assert := assert.New(t)
+				assert.True(ok)
+			},
+		},
+		{
+			name:   "filter expression returns number",
+			filter: "parent_free_upload_load",
+			mock:   func(parent *resource.Peer, child *resource.Peer) {},
+			expect: func(t *testing.T, ok bool) {
+				assert := assert.New(t)
+				assert.True(ok)
+			},
+		},
+		{
+			name:   "filter expression evaluates failed",
+			filter: "foo > 1",
+			mock:   func(parent *resource.Peer, child *resource.Peer) {},
+			expect: func(t *testing.T, ok bool) {
+				assert := assert.New(t)
+				assert.True(ok)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta, resource.WithBackToSourceLimit(mockTaskBackToSourceLimit))
+			parent := resource.NewPeer(mockPeerID, mockTask, resource.NewHost(mockRawParentHost))
+			child := resource.NewPeer(mockPeerID, mockTask, resource.NewHost(mockRawChildHost))
+			tc.mock(parent, child)
+
+			e := NewEvaluatorExpression("", tc.filter, nil)
+			tc.expect(t, e.(Filter).IsCandidateParent(parent, child))
+		})
+	}
+}
@@ -0,0 +1,234 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluator
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+
+	logger "d7y.io/dragonfly/v2/internal/dflog"
+	"d7y.io/dragonfly/v2/scheduler/resource"
+	"d7y.io/dragonfly/v2/scheduler/storage"
+)
+
+const (
+	// Throughput weight, the remaining weight is used by the score of evaluatorBase.
+	throughputWeight = 0.5
+
+	// Throughput score of the hosts without history.
+	defaultThroughputScore = 0.5
+
+	// Smoothing factor of exponentially weighted moving average.
+	ewmaAlpha = 0.3
+
+	// Interval of loading records from storage.
+	historyRefreshInterval = 1 * time.Minute
+)
+
+// throughputEstimator estimates throughput by exponentially weighted moving average.
+type throughputEstimator struct {
+	mu     sync.RWMutex
+	values map[string]float64
+	max    float64
+}
+
+// newThroughputEstimator returns a new throughputEstimator.
+func newThroughputEstimator() *throughputEstimator {
+	return &throughputEstimator{
+		values: map[string]float64{},
+	}
+}
+
+// add updates the estimated throughput of key with samples in order.
+func (t *throughputEstimator) add(samples map[string][]float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, values := range samples {
+		for _, value := range values {
+			if old, ok := t.values[key]; ok {
+				value = ewmaAlpha*value + (1-ewmaAlpha)*old
+			}
+
+			t.values[key] = value
+		}
+	}
+
+	t.max = 0
+	for _, value := range t.values {
+		if value > t.max {
+			t.max = value
+		}
+	}
+}
+
+// score returns estimated throughput of key normalized by the maximum throughput, range 0.0~1.0.
+func (t *throughputEstimator) score(key string) (float64, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	value, ok := t.values[key]
+	if !ok || t.max <= 0 {
+		return minScore, false
+	}
+
+	return value / t.max, true
+}
+
+type evaluatorHistory struct {
+	*evaluatorBase
+
+	// Storage of records.
+	storage storage.Storage
+
+	// Throughput estimator of host pairs.
+	hostPairs *throughputEstimator
+
+	// Throughput estimator of idc pairs.
+	idcPairs *throughputEstimator
+
+	// Refreshing indicates that records are being loaded.
+	refreshing *atomic.Bool
+
+	// RefreshAt is the time of the last refresh.
+	refreshAt *atomic.Time
+
+	// Mu guards the watermark during refresh.
+	mu sync.Mutex
+
+	// Watermark is the update time of the latest learned record.
+	watermark int64
+
+	// Manual indicates that records are loaded only by Refresh.
+	manual bool
+}
+
+// NewEvaluatorHistory returns an evaluator which learns throughput from the records of storage.
+func NewEvaluatorHistory(storage storage.Storage) Evaluator {
+	return newEvaluatorHistory(storage, false)
+}
+
+func newEvaluatorHistory(storage storage.Storage, manual bool) *evaluatorHistory {
+	return &evaluatorHistory{
+		evaluatorBase: &evaluatorBase{},
+		storage:       storage,
+		hostPairs:     newThroughputEstimator(),
+		idcPairs:      newThroughputEstimator(),
+		refreshing:    atomic.NewBool(false),
+		refreshAt:     atomic.NewTime(time.Time{}),
+		manual:        manual,
+	}
+}
+
+// The larger the value after evaluation, the higher the priority.
+func (eh *evaluatorHistory) Evaluate(parent *resource.Peer, child *resource.Peer, totalPieceCount int32) float64 {
+	// Load records in background, evaluation should not be blocked by reading files.
+	if !eh.manual && time.Since(eh.refreshAt.Load()) > historyRefreshInterval && eh.refreshing.CAS(false, true) {
+		go func() {
+			defer eh.refreshing.Store(false)
+			eh.refresh()
+		}()
+	}
+
+	score := eh.evaluatorBase.Evaluate(parent, child, totalPieceCount)
+	if score == minScore {
+		return minScore
+	}
+
+	return (1-throughputWeight)*score + throughputWeight*eh.calculateThroughputScore(parent.Host, child.Host)
+}
+
+// calculateThroughputScore 0.0~1.0 larger and better.
+func (eh *evaluatorHistory) calculateThroughputScore(dst, src *resource.Host) float64 {
+	if score, ok := eh.hostPairs.score(hostPairKey(dst.Hostname, dst.IP, src.Hostname, src.IP)); ok {
+		return score
+	}
+
+	if dst.IDC != "" && src.IDC != "" {
+		if score, ok := eh.idcPairs.score(idcPairKey(dst.IDC, src.IDC)); ok {
+			return score
+		}
+	}
+
+	return defaultThroughputScore
+}
+
+// Refresh learns throughput from the records created after the last refresh synchronously.
+func (eh *evaluatorHistory) Refresh() {
+	eh.refresh()
+}
+
+// refresh learns throughput from the records created after the last refresh.
+func (eh *evaluatorHistory) refresh() {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+	eh.refreshAt.Store(time.Now())
+
+	records, err := eh.storage.List()
+	if err != nil {
+		logger.Debugf("list records failed: %s", err.Error())
+		return
+	}
+
+	var (
+		hostSamples = map[string][]float64{}
+		idcSamples  = map[string][]float64{}
+		watermark   = eh.watermark
+	)
+	for _, record := range records {
+		if record.UpdateAt <= eh.watermark {
+			continue
+		}
+
+		if record.UpdateAt > watermark {
+			watermark = record.UpdateAt
+		}
+
+		// Only the peers downloaded from parent successfully are learned.
+		if record.State != storage.PeerStateSucceeded || record.ParentID == "" ||
+			record.Cost == 0 || record.ContentLength <= 0 {
+			continue
+		}
+
+		// Throughput in bytes per millisecond.
+		throughput := float64(record.ContentLength) / float64(record.Cost)
+		key := hostPairKey(record.ParentHostname, record.ParentIP, record.Hostname, record.IP)
+		hostSamples[key] = append(hostSamples[key], throughput)
+
+		if record.ParentIDC != "" && record.IDC != "" {
+			key := idcPairKey(record.ParentIDC, record.IDC)
+			idcSamples[key] = append(idcSamples[key], throughput)
+		}
+	}
+
+	eh.watermark = watermark
+	eh.hostPairs.add(hostSamples)
+	eh.idcPairs.add(idcSamples)
+}
+
+// hostPairKey returns the key of host pair.
+func hostPairKey(dstHostname, dstIP, srcHostname, srcIP string) string {
+	return fmt.Sprintf("%s-%s:%s-%s", dstHostname, dstIP, srcHostname, srcIP)
+}
+
+// idcPairKey returns the key of idc pair.
+func idcPairKey(dst, src string) string {
+	return fmt.Sprintf("%s:%s", dst, src)
+}
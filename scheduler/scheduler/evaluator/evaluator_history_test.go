@@ -0,0 +1,244 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package evaluator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"d7y.io/dragonfly/v2/pkg/rpc/scheduler"
+	"d7y.io/dragonfly/v2/scheduler/resource"
+	"d7y.io/dragonfly/v2/scheduler/storage"
+	"d7y.io/dragonfly/v2/scheduler/storage/mocks"
+)
+
+var (
+	mockRawParentHost = &scheduler.PeerHost{
+		Id:       "parent",
+		Ip:       "127.0.0.2",
+		HostName: "parent",
+		Idc:      "idc-1",
+	}
+
+	mockRawChildHost = &scheduler.PeerHost{
+		Id:       "child",
+		Ip:       "127.0.0.3",
+		HostName: "child",
+		Idc:      "idc-2",
+	}
+
+	mockRecord = storage.Record{
+		ID:             "child-peer",
+		IP:             mockRawChildHost.Ip,
+		Hostname:       mockRawChildHost.HostName,
+		Cost:           100,
+		ContentLength:  1000,
+		IDC:            mockRawChildHost.Idc,
+		State:          storage.PeerStateSucceeded,
+		UpdateAt:       1,
+		ParentID:       "parent-peer",
+		ParentIP:       mockRawParentHost.Ip,
+		ParentHostname: mockRawParentHost.HostName,
+		ParentIDC:      mockRawParentHost.Idc,
+	}
+)
+
+func TestEvaluatorHistory_New(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	tests := []struct {
+		name    string
+		options []Option
+		expect  func(t *testing.T, e interface{})
+	}{
+		{
+			name:    "new evaluator with storage",
+			options: []Option{WithStorage(mocks.NewMockStorage(ctl))},
+			expect: func(t *testing.T, e interface{}) {
+				assert := assert.New(t)
+				assert.Equal(reflect.TypeOf(e).Elem().Name(), "evaluatorHistory")
+			},
+		},
+		{
+			name: "new evaluator without storage",
+			expect: func(t *testing.T, e interface{}) {
+				assert := assert.New(t)
+				assert.Equal(reflect.TypeOf(e).Elem().Name(), "evaluatorBase")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.expect(t, New(HistoryAlgorithm, ".", tc.options...))
+		})
+	}
+}
+
+func TestEvaluatorHistory_calculateThroughputScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		mock   func(m *mocks.MockStorageMockRecorder)
+		expect func(t *testing.T, eh *evaluatorHistory, parent, child *resource.Host)
+	}{
+		{
+			name: "list records failed",
+			mock: func(m *mocks.MockStorageMockRecorder) {
+				m.List().Return(nil, errors.New("foo")).Times(1)
+			},
+			expect: func(t *testing.T, eh *evaluatorHistory, parent, child *resource.Host) {
+				assert := assert.New(t)
+				assert.Equal(eh.calculateThroughputScore(parent, child), float64(defaultThroughputScore))
+			},
+		},
+		{
+			name: "learn from host pair",
+			mock: func(m *mocks.MockStorageMockRecorder) {
+				other := mockRecord
+				other.ParentHostname = "other"
+				other.ParentIP = "127.0.0.4"
+				other.Cost = 50
+				m.List().Return([]storage.Record{mockRecord, other}, nil).Times(1)
+			},
+			expect: func(t *testing.T, eh *evaluatorHistory, parent, child *resource.Host) {
+				assert := assert.New(t)
+				assert.Equal(eh.calculateThroughputScore(parent, child), float64(0.5))
+				assert.Equal(eh.watermark, int64(1))
+			},
+		},
+		{
+			name: "learn from idc pair",
+			mock: func(m *mocks.MockStorageMockRecorder) {
+				record := mockRecord
+				record.ParentHostname = "other"
+				record.ParentIP = "127.0.0.4"
+				m.List().Return([]storage.Record{record}, nil).Times(1)
+			},
+			expect: func(t *testing.T, eh *evaluatorHistory, parent, child *resource.Host) {
+				assert := assert.New(t)
+				assert.Equal(eh.calculateThroughputScore(parent, child), float64(1))
+			},
+		},
+		{
+			name: "records are not learned",
+			mock: func(m *mocks.MockStorageMockRecorder) {
+				failed := mockRecord
+				failed.State = storage.PeerStateFailed
+				backToSource := mockRecord
+				backToSource.ParentID = ""
+				m.List().Return([]storage.Record{failed, backToSource}, nil).Times(1)
+			},
+			expect: func(t *testing.T, eh *evaluatorHistory, parent, child *resource.Host) {
+				assert := assert.New(t)
+				assert.Equal(eh.calculateThroughputScore(parent, child), float64(defaultThroughputScore))
+			},
+		},
+		{
+			name: "learn with exponentially weighted moving average",
+			mock: func(m *mocks.MockStorageMockRecorder) {
+				slow := mockRecord
+				slow.Cost = 200
+				slow.UpdateAt = 2
+				other := mockRecord
+				other.ParentHostname = "other"
+				other.ParentIP = "127.0.0.4"
+				other.UpdateAt = 2
+				gomock.InOrder(
+					m.List().Return([]storage.Record{mockRecord}, nil).Times(1),
+					m.List().Return([]storage.Record{mockRecord, slow, other}, nil).Times(1),
+				)
+			},
+			expect: func(t *testing.T, eh *evaluatorHistory, parent, child *resource.Host) {
+				assert := assert.New(t)
+				eh.refresh()
+				assert.InDelta(eh.calculateThroughputScore(parent, child), float64(0.85), 0.0001)
+				assert.Equal(eh.watermark, int64(2))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			storage := mocks.NewMockStorage(ctl)
+			tc.mock(storage.EXPECT())
+
+			eh := NewEvaluatorHistory(storage).(*evaluatorHistory)
+			eh.refresh()
+			tc.expect(t, eh, resource.NewHost(mockRawParentHost), resource.NewHost(mockRawChildHost))
+		})
+	}
+}
+
+func TestEvaluatorHistory_Evaluate(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	storage := mocks.NewMockStorage(ctl)
+
+	mockTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta, resource.WithBackToSourceLimit(mockTaskBackToSourceLimit))
+	parent := resource.NewPeer(mockPeerID, mockTask, resource.NewHost(mockRawParentHost))
+	child := resource.NewPeer(mockPeerID, mockTask, resource.NewHost(mockRawChildHost))
+	parent.Pieces.Set(0)
+
+	// Skip loading records in background.
+	eh := NewEvaluatorHistory(storage)
+	eh.(*evaluatorHistory).refreshAt.Store(time.Now())
+
+	assert := assert.New(t)
+	assert.Equal(eh.Evaluate(parent, child, 1), 0.5*NewEvaluatorBase().Evaluate(parent, child, 1)+0.5*defaultThroughputScore)
+
+	parent.Host.SecurityDomain = "foo"
+	child.Host.SecurityDomain = "bar"
+	assert.Equal(eh.Evaluate(parent, child, 1), float64(minScore))
+}
+
+func TestEvaluatorHistory_Refresh(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	mockStorage := mocks.NewMockStorage(ctl)
+
+	mockTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta, resource.WithBackToSourceLimit(mockTaskBackToSourceLimit))
+	parent := resource.NewPeer(mockPeerID, mockTask, resource.NewHost(mockRawParentHost))
+	child := resource.NewPeer(mockPeerID, mockTask, resource.NewHost(mockRawChildHost))
+	parent.Pieces.Set(0)
+
+	var refresher Refresher
+	e := New(HistoryAlgorithm, ".", WithStorage(mockStorage), WithManualRefresh(func(r Refresher) {
+		refresher = r
+	}))
+
+	assert := assert.New(t)
+	if !assert.NotNil(refresher) {
+		return
+	}
+	assert.Equal(e, refresher)
+
+	// Records are not loaded in background.
+	before := e.Evaluate(parent, child, 1)
+	assert.Equal(before, 0.5*NewEvaluatorBase().Evaluate(parent, child, 1)+0.5*defaultThroughputScore)
+
+	mockStorage.EXPECT().List().Return([]storage.Record{mockRecord}, nil).Times(1)
+	refresher.Refresh()
+	assert.Equal(e.Evaluate(parent, child, 1), 0.5*NewEvaluatorBase().Evaluate(parent, child, 1)+0.5)
+}
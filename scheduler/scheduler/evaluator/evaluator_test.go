@@ -54,6 +54,14 @@ func TestEvaluator_New(t *testing.T) {
 				assert.Equal(reflect.TypeOf(e).Elem().Name(), "evaluatorBase")
 			},
 		},
+		{
+			name:      "new evaluator with expression algorithm",
+			algorithm: "expression",
+			expect: func(t *testing.T, e interface{}) {
+				assert := assert.New(t)
+				assert.Equal(reflect.TypeOf(e).Elem().Name(), "evaluatorExpression")
+			},
+		},
 		{
 			name:      "new evaluator with empty string",
 			algorithm: "",
@@ -21,12 +21,14 @@ package scheduler
 import (
 	"context"
 	"sort"
+	"sync"
 	"time"
 
 	"d7y.io/dragonfly/v2/pkg/container/set"
 	"d7y.io/dragonfly/v2/pkg/rpc/base"
 	rpcscheduler "d7y.io/dragonfly/v2/pkg/rpc/scheduler"
 	"d7y.io/dragonfly/v2/scheduler/config"
+	"d7y.io/dragonfly/v2/scheduler/metrics"
 	"d7y.io/dragonfly/v2/scheduler/resource"
 	"d7y.io/dragonfly/v2/scheduler/scheduler/evaluator"
 )
@@ -37,6 +39,9 @@ const (
 
 	// Default tree depth limit.
 	defaultDepthLimit = 4
+
+	// Proportion of upload load reserved from background priority peers.
+	backgroundPriorityReservedRatio = 5
 )
 
 type Scheduler interface {
@@ -59,19 +64,26 @@ type scheduler struct {
 
 	// Scheduler dynamic configuration.
 	dynconfig config.DynconfigInterface
+
+	// Back-to-source usages of origin hosts.
+	originUsages *sync.Map
 }
 
-func New(cfg *config.SchedulerConfig, dynconfig config.DynconfigInterface, pluginDir string) Scheduler {
+func New(cfg *config.SchedulerConfig, dynconfig config.DynconfigInterface, pluginDir string, options ...evaluator.Option) Scheduler {
 	return &scheduler{
-		evaluator: evaluator.New(cfg.Algorithm, pluginDir),
-		config:    cfg,
-		dynconfig: dynconfig,
+		evaluator:    evaluator.New(cfg.Algorithm, pluginDir, options...),
+		config:       cfg,
+		dynconfig:    dynconfig,
+		originUsages: &sync.Map{},
 	}
 }
 
 // ScheduleParent schedule a parent and candidates to a peer.
 func (s *scheduler) ScheduleParent(ctx context.Context, peer *resource.Peer, blocklist set.SafeSet) {
-	var n int
+	var (
+		n      int
+		heldAt time.Time
+	)
 	for {
 		select {
 		case <-ctx.Done():
@@ -91,16 +103,51 @@ func (s *scheduler) ScheduleParent(ctx context.Context, peer *resource.Peer, blo
 				return
 			}
 
+			// If the back-to-source limits of origin are exceeded,
+			// peer is held in p2p scheduling until the origin is available,
+			// and peer scheduling fails when it is held longer than the hold timeout.
+			if !s.acquireBackToSource(peer) {
+				if heldAt.IsZero() {
+					heldAt = time.Now()
+					metrics.BackToSourceHeldCount.WithLabelValues(peer.BizTag).Inc()
+					peer.Log.Info("peer is held in p2p scheduling because back-to-source limit of origin is exceeded")
+				}
+
+				if _, ok := s.NotifyAndFindParent(ctx, peer, blocklist); ok {
+					peer.Log.Info("peer is held and schedule parent successfully")
+					return
+				}
+
+				if time.Since(heldAt) >= s.config.BackSourceHoldTimeout {
+					peer.Log.Errorf("peer is held longer than %s", s.config.BackSourceHoldTimeout)
+					s.notifyScheduleFailed(peer)
+					return
+				}
+
+				// Wait to avoid hot looping.
+				timer := time.NewTimer(s.config.RetryInterval)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					peer.Log.Infof("context was done")
+					return
+				case <-timer.C:
+				}
+				continue
+			}
+
 			peer.Log.Infof("peer downloads back-to-source, scheduling %d times, peer need back-to-source %t",
 				n, needBackToSource)
 
 			// Notify peer back-to-source.
 			if err := stream.Send(&rpcscheduler.PeerPacket{Code: base.Code_SchedNeedBackSource}); err != nil {
+				s.releaseBackToSource(peer)
 				peer.Log.Errorf("send packet failed: %s", err.Error())
 				return
 			}
 
 			if err := peer.FSM.Event(resource.PeerEventDownloadFromBackToSource); err != nil {
+				s.releaseBackToSource(peer)
 				peer.Log.Errorf("peer fsm event failed: %s", err.Error())
 				return
 			}
@@ -119,18 +166,8 @@ func (s *scheduler) ScheduleParent(ctx context.Context, peer *resource.Peer, blo
 
 		// Handle peer schedule failed.
 		if n >= s.config.RetryLimit {
-			stream, ok := peer.LoadStream()
-			if !ok {
-				peer.Log.Error("load stream failed")
-				return
-			}
-
-			// Notify peer schedule failed.
-			if err := stream.Send(&rpcscheduler.PeerPacket{Code: base.Code_SchedTaskStatusError}); err != nil {
-				peer.Log.Errorf("send packet failed: %s", err.Error())
-				return
-			}
-			peer.Log.Errorf("peer scheduling exceeds the limit %d times and return code %d", s.config.RetryLimit, base.Code_SchedTaskStatusError)
+			peer.Log.Errorf("peer scheduling exceeds the limit %d times", s.config.RetryLimit)
+			s.notifyScheduleFailed(peer)
 			return
 		}
 
@@ -148,6 +185,21 @@ func (s *scheduler) ScheduleParent(ctx context.Context, peer *resource.Peer, blo
 	}
 }
 
+// notifyScheduleFailed notifies peer that scheduling is failed.
+func (s *scheduler) notifyScheduleFailed(peer *resource.Peer) {
+	stream, ok := peer.LoadStream()
+	if !ok {
+		peer.Log.Error("load stream failed")
+		return
+	}
+
+	if err := stream.Send(&rpcscheduler.PeerPacket{Code: base.Code_SchedTaskStatusError}); err != nil {
+		peer.Log.Errorf("send packet failed: %s", err.Error())
+		return
+	}
+	peer.Log.Errorf("peer scheduling failed and return code %d", base.Code_SchedTaskStatusError)
+}
+
 // NotifyAndFindParent finds parent that best matches the evaluation and notify peer.
 func (s *scheduler) NotifyAndFindParent(ctx context.Context, peer *resource.Peer, blocklist set.SafeSet) ([]*resource.Peer, bool) {
 	// Only PeerStateRunning peers need to be rescheduled,
@@ -166,13 +218,7 @@ func (s *scheduler) NotifyAndFindParent(ctx context.Context, peer *resource.Peer
 	}
 
 	// Sort candidate parents by evaluation score.
-	taskTotalPieceCount := peer.Task.TotalPieceCount.Load()
-	sort.Slice(
-		candidateParents,
-		func(i, j int) bool {
-			return s.evaluator.Evaluate(candidateParents[i], peer, taskTotalPieceCount) > s.evaluator.Evaluate(candidateParents[j], peer, taskTotalPieceCount)
-		},
-	)
+	s.sortCandidateParents(peer, candidateParents)
 
 	// Send scheduling success message.
 	stream, ok := peer.LoadStream()
@@ -210,22 +256,36 @@ func (s *scheduler) FindParent(ctx context.Context, peer *resource.Peer, blockli
 	}
 
 	// Sort candidate parents by evaluation score.
+	s.sortCandidateParents(peer, candidateParents)
+
+	peer.Log.Infof("find parent %s successful", candidateParents[0].ID)
+	return candidateParents[0], true
+}
+
+// sortCandidateParents sorts candidate parents by evaluation score. The peers of urgent priority
+// have first claim on the reserved upload load, so for the peers of other priorities, the candidate
+// parents which only have reserved upload load left are ranked behind the others.
+func (s *scheduler) sortCandidateParents(peer *resource.Peer, candidateParents []*resource.Peer) {
 	taskTotalPieceCount := peer.Task.TotalPieceCount.Load()
 	sort.Slice(
 		candidateParents,
 		func(i, j int) bool {
+			if peer.Priority != base.Priority_URGENT_PRIORITY {
+				reservedI, reservedJ := onlyReservedUploadLoad(candidateParents[i].Host), onlyReservedUploadLoad(candidateParents[j].Host)
+				if reservedI != reservedJ {
+					return reservedJ
+				}
+			}
+
 			return s.evaluator.Evaluate(candidateParents[i], peer, taskTotalPieceCount) > s.evaluator.Evaluate(candidateParents[j], peer, taskTotalPieceCount)
 		},
 	)
-
-	peer.Log.Infof("find parent %s successful", candidateParents[0].ID)
-	return candidateParents[0], true
 }
 
 // Filter the candidate parent that can be scheduled.
 func (s *scheduler) filterCandidateParents(peer *resource.Peer, blocklist set.SafeSet) []*resource.Peer {
 	filterParentLimit := config.DefaultSchedulerFilterParentLimit
-	if config, ok := s.dynconfig.GetSchedulerClusterConfig(); ok && filterParentLimit > 0 {
+	if config, ok := s.dynconfig.GetSchedulerClusterConfig(); ok && config.FilterParentLimit > 0 {
 		filterParentLimit = int(config.FilterParentLimit)
 	}
 
@@ -261,6 +321,12 @@ func (s *scheduler) filterCandidateParents(peer *resource.Peer, blocklist set.Sa
 			return true
 		}
 
+		// Candidate parent is filtered by evaluator.
+		if filter, ok := s.evaluator.(evaluator.Filter); ok && !filter.IsCandidateParent(candidateParent, peer) {
+			peer.Log.Debugf("candidate parent %s is not selected because it is filtered by evaluator", candidateParent.ID)
+			return true
+		}
+
 		// Conditions for candidate parent to be a parent:
 		// 1. candidate parent has parent.
 		// 2. candidate parent is seed peer.
@@ -302,10 +368,11 @@ func (s *scheduler) filterCandidateParents(peer *resource.Peer, blocklist set.Sa
 			return true
 		}
 
-		// Candidate parent's free upload is empty.
-		if candidateParent.Host.FreeUploadLoad() <= 0 {
-			peer.Log.Debugf("candidate parent %s is not selected because its free upload is empty, upload limit is %d, upload peer count is %d",
-				candidateParent.ID, candidateParent.Host.UploadLoadLimit.Load(), candidateParent.Host.UploadPeerCount.Load())
+		// Candidate parent's free upload is empty,
+		// part of upload load is reserved for the peers with higher priority.
+		if candidateParent.Host.FreeUploadLoad() <= reservedUploadLoad(candidateParent.Host, peer.Priority) {
+			peer.Log.Debugf("candidate parent %s is not selected because its free upload is empty, upload limit is %d, upload peer count is %d, priority is %s",
+				candidateParent.ID, candidateParent.Host.UploadLoadLimit.Load(), candidateParent.Host.UploadPeerCount.Load(), peer.Priority)
 			return true
 		}
 
@@ -318,6 +385,23 @@ func (s *scheduler) filterCandidateParents(peer *resource.Peer, blocklist set.Sa
 	return candidateParents
 }
 
+// reservedUploadLoad returns the upload load of host that peer can not use,
+// which is reserved for the peers with higher priority. Only the peers of
+// background priority are limited, normal priority is the default of existing clients.
+func reservedUploadLoad(host *resource.Host, priority base.Priority) int32 {
+	if priority == base.Priority_BACKGROUND_PRIORITY {
+		return host.UploadLoadLimit.Load() / backgroundPriorityReservedRatio
+	}
+
+	return 0
+}
+
+// onlyReservedUploadLoad returns whether the free upload load of host is all reserved
+// from background priority peers.
+func onlyReservedUploadLoad(host *resource.Host) bool {
+	return host.FreeUploadLoad() <= reservedUploadLoad(host, base.Priority_BACKGROUND_PRIORITY)
+}
+
 // Construct peer successful packet.
 func constructSuccessPeerPacket(dynconfig config.DynconfigInterface, peer *resource.Peer, parent *resource.Peer, candidateParents []*resource.Peer) *rpcscheduler.PeerPacket {
 	parallelCount := config.DefaultClientParallelCount
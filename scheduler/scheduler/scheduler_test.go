@@ -42,11 +42,12 @@ import (
 var (
 	mockPluginDir       = "plugin_dir"
 	mockSchedulerConfig = &config.SchedulerConfig{
-		RetryLimit:           2,
-		RetryBackSourceLimit: 1,
-		RetryInterval:        10 * time.Millisecond,
-		BackSourceCount:      int(mockTaskBackToSourceLimit),
-		Algorithm:            evaluator.DefaultAlgorithm,
+		RetryLimit:            2,
+		RetryBackSourceLimit:  1,
+		RetryInterval:         10 * time.Millisecond,
+		BackSourceHoldTimeout: 1 * time.Second,
+		BackSourceCount:       int(mockTaskBackToSourceLimit),
+		Algorithm:             evaluator.DefaultAlgorithm,
 	}
 	mockRawHost = &rpcscheduler.PeerHost{
 		Id:             idgen.HostID("hostname", 8003),
@@ -163,6 +164,7 @@ func TestScheduler_ScheduleParent(t *testing.T) {
 				peer.FSM.SetState(resource.PeerStateRunning)
 				peer.StoreStream(stream)
 
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{}, false).Times(1)
 				mr.Send(gomock.Eq(&rpcscheduler.PeerPacket{Code: base.Code_SchedNeedBackSource})).Return(errors.New("foo")).Times(1)
 			},
 			expect: func(t *testing.T, peer *resource.Peer) {
@@ -181,6 +183,7 @@ func TestScheduler_ScheduleParent(t *testing.T) {
 				peer.FSM.SetState(resource.PeerStateRunning)
 				peer.StoreStream(stream)
 
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{}, false).Times(1)
 				mr.Send(gomock.Eq(&rpcscheduler.PeerPacket{Code: base.Code_SchedNeedBackSource})).Return(nil).Times(1)
 			},
 			expect: func(t *testing.T, peer *resource.Peer) {
@@ -201,6 +204,7 @@ func TestScheduler_ScheduleParent(t *testing.T) {
 				task.FSM.SetState(resource.TaskStateFailed)
 				peer.StoreStream(stream)
 
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{}, false).Times(1)
 				mr.Send(gomock.Eq(&rpcscheduler.PeerPacket{Code: base.Code_SchedNeedBackSource})).Return(nil).Times(1)
 			},
 			expect: func(t *testing.T, peer *resource.Peer) {
@@ -697,6 +701,43 @@ func TestScheduler_FindParent(t *testing.T) {
 				assert.False(ok)
 			},
 		},
+		{
+			name: "parent free upload load is reserved from background peers",
+			mock: func(peer *resource.Peer, mockPeers []*resource.Peer, blocklist set.SafeSet, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				peer.Priority = base.Priority_BACKGROUND_PRIORITY
+				peer.FSM.SetState(resource.PeerStateRunning)
+				mockPeers[0].FSM.SetState(resource.PeerStateRunning)
+				mockPeers[0].IsBackToSource.Store(true)
+				peer.Task.StorePeer(mockPeers[0])
+				mockPeers[0].Host.UploadLoadLimit.Store(10)
+				mockPeers[0].Host.UploadPeerCount.Store(9)
+
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{}, false).Times(1)
+			},
+			expect: func(t *testing.T, mockPeers []*resource.Peer, parent *resource.Peer, ok bool) {
+				assert := assert.New(t)
+				assert.False(ok)
+			},
+		},
+		{
+			name: "normal peer uses reserved upload load of parent",
+			mock: func(peer *resource.Peer, mockPeers []*resource.Peer, blocklist set.SafeSet, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				peer.Priority = base.Priority_NORMAL_PRIORITY
+				peer.FSM.SetState(resource.PeerStateRunning)
+				mockPeers[0].FSM.SetState(resource.PeerStateRunning)
+				mockPeers[0].IsBackToSource.Store(true)
+				peer.Task.StorePeer(mockPeers[0])
+				mockPeers[0].Host.UploadLoadLimit.Store(10)
+				mockPeers[0].Host.UploadPeerCount.Store(9)
+
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{}, false).Times(1)
+			},
+			expect: func(t *testing.T, mockPeers []*resource.Peer, parent *resource.Peer, ok bool) {
+				assert := assert.New(t)
+				assert.True(ok)
+				assert.Equal(mockPeers[0].ID, parent.ID)
+			},
+		},
 		{
 			name: "find back-to-source parent",
 			mock: func(peer *resource.Peer, mockPeers []*resource.Peer, blocklist set.SafeSet, md *configmocks.MockDynconfigInterfaceMockRecorder) {
@@ -816,6 +857,26 @@ func TestScheduler_FindParent(t *testing.T) {
 				assert.Contains([]string{mockPeers[0].ID, mockPeers[1].ID}, parent.ID)
 			},
 		},
+		{
+			name: "find parent when filterParentLimit is not set in manager dynconfig",
+			mock: func(peer *resource.Peer, mockPeers []*resource.Peer, blocklist set.SafeSet, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				peer.FSM.SetState(resource.PeerStateRunning)
+				for i := 0; i < 3; i++ {
+					peer.Task.StorePeer(mockPeers[i])
+					blocklist.Add(mockPeers[i].ID)
+				}
+				mockPeers[3].FSM.SetState(resource.PeerStateSucceeded)
+				peer.Task.StorePeer(mockPeers[3])
+				mockPeers[3].Pieces.Set(0)
+
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{}, true).Times(1)
+			},
+			expect: func(t *testing.T, mockPeers []*resource.Peer, parent *resource.Peer, ok bool) {
+				assert := assert.New(t)
+				assert.True(ok)
+				assert.Equal(mockPeers[3].ID, parent.ID)
+			},
+		},
 		{
 			name: "exceeds the depth limit of the tree",
 			mock: func(peer *resource.Peer, mockPeers []*resource.Peer, blocklist set.SafeSet, md *configmocks.MockDynconfigInterfaceMockRecorder) {
@@ -859,6 +920,150 @@ func TestScheduler_FindParent(t *testing.T) {
 	}
 }
 
+func TestScheduler_FindParentWithFilterExpression(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	dynconfig := configmocks.NewMockDynconfigInterface(ctl)
+	mockHost := resource.NewHost(mockRawHost)
+	mockTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta, resource.WithBackToSourceLimit(mockTaskBackToSourceLimit))
+	peer := resource.NewPeer(mockPeerID, mockTask, mockHost)
+	peer.FSM.SetState(resource.PeerStateRunning)
+
+	var mockPeers []*resource.Peer
+	for i := 0; i < 2; i++ {
+		mockPeer := resource.NewPeer(idgen.PeerID(fmt.Sprintf("127.0.0.%d", i)), mockTask, mockHost)
+		mockPeer.FSM.SetState(resource.PeerStateRunning)
+		mockPeer.IsBackToSource.Store(true)
+		mockPeer.Pieces.Set(0)
+		mockTask.StorePeer(mockPeer)
+		mockPeers = append(mockPeers, mockPeer)
+	}
+
+	// The parent with more pieces is filtered by expression.
+	mockPeers[1].Pieces.Set(1)
+	mockPeers[1].Pieces.Set(2)
+
+	gomock.InOrder(
+		dynconfig.EXPECT().Get().Return(&config.DynconfigData{}, nil).Times(1),
+		dynconfig.EXPECT().Register(gomock.Any()).Return().Times(1),
+		dynconfig.EXPECT().GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{}, false).Times(1),
+	)
+
+	s := New(&config.SchedulerConfig{
+		RetryLimit:           2,
+		RetryBackSourceLimit: 1,
+		RetryInterval:        10 * time.Millisecond,
+		BackSourceCount:      int(mockTaskBackToSourceLimit),
+		Algorithm:            evaluator.ExpressionAlgorithm,
+	}, dynconfig, mockPluginDir, evaluator.WithDynconfig(dynconfig), evaluator.WithExpression("", "parent_finished_piece_count < 2"))
+
+	assert := assert.New(t)
+	parent, ok := s.FindParent(context.Background(), peer, set.NewSafeSet())
+	assert.True(ok)
+	assert.Equal(mockPeers[0].ID, parent.ID)
+}
+
+func TestScheduler_reservedUploadLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int32
+		priority base.Priority
+		expect   int32
+	}{
+		{
+			name:     "urgent priority",
+			limit:    50,
+			priority: base.Priority_URGENT_PRIORITY,
+			expect:   0,
+		},
+		{
+			name:     "normal priority",
+			limit:    50,
+			priority: base.Priority_NORMAL_PRIORITY,
+			expect:   0,
+		},
+		{
+			name:     "background priority",
+			limit:    50,
+			priority: base.Priority_BACKGROUND_PRIORITY,
+			expect:   10,
+		},
+		{
+			name:     "upload load limit is zero",
+			limit:    0,
+			priority: base.Priority_BACKGROUND_PRIORITY,
+			expect:   0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			host := resource.NewHost(mockRawHost, resource.WithUploadLoadLimit(tc.limit))
+			assert.Equal(t, tc.expect, reservedUploadLoad(host, tc.priority))
+		})
+	}
+}
+
+func TestScheduler_sortCandidateParents(t *testing.T) {
+	tests := []struct {
+		name     string
+		priority base.Priority
+		expect   func(t *testing.T, candidateParents []*resource.Peer, parents []*resource.Peer)
+	}{
+		{
+			name:     "urgent peer ranks parents by evaluation score",
+			priority: base.Priority_URGENT_PRIORITY,
+			expect: func(t *testing.T, candidateParents []*resource.Peer, parents []*resource.Peer) {
+				assert := assert.New(t)
+				assert.Equal(parents[0].ID, candidateParents[0].ID)
+			},
+		},
+		{
+			name:     "normal peer ranks parents with only reserved upload load behind",
+			priority: base.Priority_NORMAL_PRIORITY,
+			expect: func(t *testing.T, candidateParents []*resource.Peer, parents []*resource.Peer) {
+				assert := assert.New(t)
+				assert.Equal(parents[1].ID, candidateParents[0].ID)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			dynconfig := configmocks.NewMockDynconfigInterface(ctl)
+			mockTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta, resource.WithBackToSourceLimit(mockTaskBackToSourceLimit))
+			mockTask.TotalPieceCount.Store(10)
+			peer := resource.NewPeer(mockPeerID, mockTask, resource.NewHost(mockRawHost))
+			peer.Priority = tc.priority
+
+			// The first parent has more pieces, but only reserved upload load is left.
+			var parents []*resource.Peer
+			for i, uploadPeerCount := range []int32{9, 0} {
+				rawHost := &rpcscheduler.PeerHost{
+					Id:       idgen.HostID(fmt.Sprintf("hostname-%d", i), 8003),
+					Ip:       fmt.Sprintf("127.0.0.%d", i),
+					RpcPort:  8003,
+					DownPort: 8001,
+					HostName: fmt.Sprintf("hostname-%d", i),
+				}
+				parent := resource.NewPeer(idgen.PeerID(rawHost.Ip), mockTask, resource.NewHost(rawHost, resource.WithUploadLoadLimit(10)))
+				parent.Host.UploadPeerCount.Store(uploadPeerCount)
+				parents = append(parents, parent)
+			}
+			for i := uint(0); i < 10; i++ {
+				parents[0].Pieces.Set(i)
+			}
+
+			candidateParents := []*resource.Peer{parents[1], parents[0]}
+			scheduler := New(mockSchedulerConfig, dynconfig, mockPluginDir).(*scheduler)
+			scheduler.sortCandidateParents(peer, candidateParents)
+			tc.expect(t, candidateParents, parents)
+		})
+	}
+}
+
 func TestScheduler_constructSuccessPeerPacket(t *testing.T) {
 	tests := []struct {
 		name   string
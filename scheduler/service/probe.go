@@ -0,0 +1,104 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"time"
+
+	rpcscheduler "d7y.io/dragonfly/v2/pkg/rpc/scheduler"
+	"d7y.io/dragonfly/v2/scheduler/resource"
+)
+
+// SyncProbes stores the round-trip time probed by dfdaemon into the latency matrix of hosts,
+// and returns the hosts to be probed by dfdaemon in the next round.
+func (s *Service) SyncProbes(ctx context.Context, req *rpcscheduler.SyncProbesRequest) (*rpcscheduler.SyncProbesResponse, error) {
+	if s.config.Probe == nil || !s.config.Probe.Enable {
+		return &rpcscheduler.SyncProbesResponse{}, nil
+	}
+
+	host := s.registerHost(ctx, req.Host)
+	for _, probe := range req.Probes {
+		if probe.HostId == host.ID || probe.Rtt <= 0 {
+			continue
+		}
+
+		// The round-trip time is symmetric, so it is also stored to the probed host.
+		rtt := time.Duration(probe.Rtt)
+		host.StoreProbe(probe.HostId, rtt)
+		if target, ok := s.resource.HostManager().Load(probe.HostId); ok {
+			target.StoreProbe(host.ID, rtt)
+		}
+	}
+	host.Log.Infof("sync %d probes", len(req.Probes))
+
+	return &rpcscheduler.SyncProbesResponse{
+		Targets:  s.probeTargets(host),
+		Interval: int64(s.config.Probe.Interval),
+	}, nil
+}
+
+// probeTargets returns the hosts to be probed by the host,
+// the hosts which are never probed or probed earliest are preferred.
+func (s *Service) probeTargets(host *resource.Host) []*rpcscheduler.ProbeTarget {
+	var candidates []*resource.Host
+	s.resource.HostManager().Range(func(_, value interface{}) bool {
+		candidate, ok := value.(*resource.Host)
+		if !ok || candidate.ID == host.ID {
+			return true
+		}
+
+		candidates = append(candidates, candidate)
+		return true
+	})
+
+	// Shuffle candidates to spread the probes when the probed times are same.
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return probeUpdateAt(host, candidates[i]).Before(probeUpdateAt(host, candidates[j]))
+	})
+
+	if len(candidates) > s.config.Probe.Count {
+		candidates = candidates[:s.config.Probe.Count]
+	}
+
+	targets := make([]*rpcscheduler.ProbeTarget, 0, len(candidates))
+	for _, candidate := range candidates {
+		targets = append(targets, &rpcscheduler.ProbeTarget{
+			HostId:  candidate.ID,
+			Ip:      candidate.IP,
+			RpcPort: candidate.Port,
+		})
+	}
+
+	return targets
+}
+
+// probeUpdateAt returns the latest time of probing from host to target,
+// zero time is returned when the target is never probed.
+func probeUpdateAt(host *resource.Host, target *resource.Host) time.Time {
+	probe, ok := host.LoadProbe(target.ID)
+	if !ok {
+		return time.Time{}
+	}
+
+	return probe.UpdateAt.Load()
+}
@@ -0,0 +1,181 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"d7y.io/dragonfly/v2/pkg/idgen"
+	rpcscheduler "d7y.io/dragonfly/v2/pkg/rpc/scheduler"
+	"d7y.io/dragonfly/v2/scheduler/config"
+	configmocks "d7y.io/dragonfly/v2/scheduler/config/mocks"
+	"d7y.io/dragonfly/v2/scheduler/resource"
+	"d7y.io/dragonfly/v2/scheduler/scheduler/mocks"
+	storagemocks "d7y.io/dragonfly/v2/scheduler/storage/mocks"
+)
+
+var (
+	mockProbeConfig = &config.ProbeConfig{
+		Enable:   true,
+		Count:    1,
+		Interval: 10 * time.Minute,
+	}
+)
+
+func TestService_SyncProbes(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *config.ProbeConfig
+		req    *rpcscheduler.SyncProbesRequest
+		mock   func(mockHost *resource.Host, mockSeedHost *resource.Host, hostManager resource.HostManager, mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder)
+		expect func(t *testing.T, mockHost *resource.Host, mockSeedHost *resource.Host, resp *rpcscheduler.SyncProbesResponse, err error)
+	}{
+		{
+			name:   "probe is disabled",
+			config: &config.ProbeConfig{},
+			req: &rpcscheduler.SyncProbesRequest{
+				Host: mockRawHost,
+			},
+			mock: func(mockHost *resource.Host, mockSeedHost *resource.Host, hostManager resource.HostManager, mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder) {
+			},
+			expect: func(t *testing.T, mockHost *resource.Host, mockSeedHost *resource.Host, resp *rpcscheduler.SyncProbesResponse, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				assert.Empty(resp.Targets)
+				assert.Equal(resp.Interval, int64(0))
+			},
+		},
+		{
+			name:   "store probes",
+			config: mockProbeConfig,
+			req: &rpcscheduler.SyncProbesRequest{
+				Host: mockRawHost,
+				Probes: []*rpcscheduler.Probe{
+					{HostId: mockRawSeedHost.Id, Rtt: int64(10 * time.Millisecond)},
+					{HostId: mockRawHost.Id, Rtt: int64(time.Millisecond)},
+				},
+			},
+			mock: func(mockHost *resource.Host, mockSeedHost *resource.Host, hostManager resource.HostManager, mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder) {
+				mr.HostManager().Return(hostManager).AnyTimes()
+				mh.Load(gomock.Eq(mockHost.ID)).Return(mockHost, true).Times(1)
+				mh.Load(gomock.Eq(mockSeedHost.ID)).Return(mockSeedHost, true).Times(1)
+				mh.Range(gomock.Any()).Do(func(f func(key, value interface{}) bool) {
+					f(mockHost.ID, mockHost)
+					f(mockSeedHost.ID, mockSeedHost)
+				}).Times(1)
+			},
+			expect: func(t *testing.T, mockHost *resource.Host, mockSeedHost *resource.Host, resp *rpcscheduler.SyncProbesResponse, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				probe, ok := mockHost.LoadProbe(mockSeedHost.ID)
+				assert.True(ok)
+				assert.Equal(probe.RTT.Load(), 10*time.Millisecond)
+				probe, ok = mockSeedHost.LoadProbe(mockHost.ID)
+				assert.True(ok)
+				assert.Equal(probe.RTT.Load(), 10*time.Millisecond)
+				_, ok = mockHost.LoadProbe(mockHost.ID)
+				assert.False(ok)
+				assert.Len(resp.Targets, 1)
+				assert.Equal(resp.Targets[0].HostId, mockSeedHost.ID)
+				assert.Equal(resp.Targets[0].Ip, mockSeedHost.IP)
+				assert.Equal(resp.Targets[0].RpcPort, mockSeedHost.Port)
+				assert.Equal(resp.Interval, int64(10*time.Minute))
+			},
+		},
+		{
+			name:   "probed host is not found",
+			config: mockProbeConfig,
+			req: &rpcscheduler.SyncProbesRequest{
+				Host: mockRawHost,
+				Probes: []*rpcscheduler.Probe{
+					{HostId: mockRawSeedHost.Id, Rtt: int64(10 * time.Millisecond)},
+				},
+			},
+			mock: func(mockHost *resource.Host, mockSeedHost *resource.Host, hostManager resource.HostManager, mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder) {
+				mr.HostManager().Return(hostManager).AnyTimes()
+				mh.Load(gomock.Eq(mockHost.ID)).Return(mockHost, true).Times(1)
+				mh.Load(gomock.Eq(mockSeedHost.ID)).Return(nil, false).Times(1)
+				mh.Range(gomock.Any()).Do(func(f func(key, value interface{}) bool) {
+					f(mockHost.ID, mockHost)
+				}).Times(1)
+			},
+			expect: func(t *testing.T, mockHost *resource.Host, mockSeedHost *resource.Host, resp *rpcscheduler.SyncProbesResponse, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				_, ok := mockHost.LoadProbe(mockSeedHost.ID)
+				assert.True(ok)
+				assert.Empty(resp.Targets)
+			},
+		},
+		{
+			name:   "hosts which are never probed are preferred",
+			config: mockProbeConfig,
+			req: &rpcscheduler.SyncProbesRequest{
+				Host: mockRawHost,
+				Probes: []*rpcscheduler.Probe{
+					{HostId: mockRawSeedHost.Id, Rtt: int64(10 * time.Millisecond)},
+				},
+			},
+			mock: func(mockHost *resource.Host, mockSeedHost *resource.Host, hostManager resource.HostManager, mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder) {
+				mockOtherHost := resource.NewHost(&rpcscheduler.PeerHost{
+					Id:      idgen.HostID("foo", 8003),
+					Ip:      "127.0.0.2",
+					RpcPort: 8003,
+				})
+
+				mr.HostManager().Return(hostManager).AnyTimes()
+				mh.Load(gomock.Eq(mockHost.ID)).Return(mockHost, true).Times(1)
+				mh.Load(gomock.Eq(mockSeedHost.ID)).Return(mockSeedHost, true).Times(1)
+				mh.Range(gomock.Any()).Do(func(f func(key, value interface{}) bool) {
+					f(mockSeedHost.ID, mockSeedHost)
+					f(mockOtherHost.ID, mockOtherHost)
+				}).Times(1)
+			},
+			expect: func(t *testing.T, mockHost *resource.Host, mockSeedHost *resource.Host, resp *rpcscheduler.SyncProbesResponse, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				assert.Len(resp.Targets, 1)
+				assert.Equal(resp.Targets[0].HostId, idgen.HostID("foo", 8003))
+				assert.Equal(resp.Targets[0].Ip, "127.0.0.2")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			scheduler := mocks.NewMockScheduler(ctl)
+			res := resource.NewMockResource(ctl)
+			dynconfig := configmocks.NewMockDynconfigInterface(ctl)
+			storage := storagemocks.NewMockStorage(ctl)
+			hostManager := resource.NewMockHostManager(ctl)
+			svc := New(&config.Config{Scheduler: mockSchedulerConfig, Probe: tc.config}, res, scheduler, dynconfig, storage)
+
+			mockHost := resource.NewHost(mockRawHost)
+			mockSeedHost := resource.NewHost(mockRawSeedHost, resource.WithHostType(resource.HostTypeSuperSeed))
+			tc.mock(mockHost, mockSeedHost, hostManager, res.EXPECT(), hostManager.EXPECT())
+			resp, err := svc.SyncProbes(context.Background(), tc.req)
+			tc.expect(t, mockHost, mockSeedHost, resp, err)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"d7y.io/dragonfly/v2/internal/dferrors"
+	"d7y.io/dragonfly/v2/manager/types"
+	"d7y.io/dragonfly/v2/pkg/container/set"
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
+	rpcscheduler "d7y.io/dragonfly/v2/pkg/rpc/scheduler"
+	"d7y.io/dragonfly/v2/scheduler/resource"
+)
+
+// applicationUsage is the resource usage of application,
+// it is used to check the quota of application.
+type applicationUsage struct {
+	// peers is the set of peers registered by application.
+	peers set.SafeSet
+
+	// seedPeerTasks is the set of tasks which application triggered seed peer for.
+	seedPeerTasks set.SafeSet
+}
+
+// newApplicationUsage returns a new applicationUsage.
+func newApplicationUsage() *applicationUsage {
+	return &applicationUsage{
+		peers:         set.NewSafeSet(),
+		seedPeerTasks: set.NewSafeSet(),
+	}
+}
+
+// runningPeers returns the running peers,
+// and the finished peers will be removed.
+func (u *applicationUsage) runningPeers() []*resource.Peer {
+	var peers []*resource.Peer
+	for _, v := range u.peers.Values() {
+		peer, ok := v.(*resource.Peer)
+		if !ok || peer.FSM.Is(resource.PeerStateSucceeded) || peer.FSM.Is(resource.PeerStateFailed) || peer.FSM.Is(resource.PeerStateLeave) {
+			u.peers.Delete(v)
+			continue
+		}
+
+		peers = append(peers, peer)
+	}
+
+	return peers
+}
+
+// runningTaskIDs returns the set of task ids which running peers are downloading,
+// the peers of the same task are counted once, and the finished peers will be removed.
+func (u *applicationUsage) runningTaskIDs() map[string]struct{} {
+	taskIDs := make(map[string]struct{})
+	for _, peer := range u.runningPeers() {
+		taskIDs[peer.Task.ID] = struct{}{}
+	}
+
+	return taskIDs
+}
+
+// backToSourceBandwidth returns the bandwidth of peers which are downloading back-to-source,
+// and the finished peers will be removed.
+func (u *applicationUsage) backToSourceBandwidth() float64 {
+	var bandwidth float64
+	for _, peer := range u.runningPeers() {
+		if !peer.FSM.Is(resource.PeerStateBackToSource) {
+			continue
+		}
+
+		bandwidth += peer.Bandwidth()
+	}
+
+	return bandwidth
+}
+
+// runningSeedPeerTasks returns the tasks which seed peer is downloading,
+// and the finished tasks will be removed.
+func (u *applicationUsage) runningSeedPeerTasks() []*resource.Task {
+	var tasks []*resource.Task
+	for _, v := range u.seedPeerTasks.Values() {
+		task, ok := v.(*resource.Task)
+		if !ok || !(task.FSM.Is(resource.TaskStatePending) || task.FSM.Is(resource.TaskStateRunning)) {
+			u.seedPeerTasks.Delete(v)
+			continue
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks
+}
+
+// runningSeedPeerTaskCount returns the count of tasks which seed peer is downloading,
+// and the finished tasks will be removed.
+func (u *applicationUsage) runningSeedPeerTaskCount() int {
+	return len(u.runningSeedPeerTasks())
+}
+
+// loadApplicationQuota returns the quota and usage of application,
+// ok is false when the application has no quota.
+func (s *Service) loadApplicationQuota(application string) (types.ApplicationQuota, *applicationUsage, bool) {
+	if application == "" {
+		return types.ApplicationQuota{}, nil, false
+	}
+
+	quota, ok := s.dynconfig.GetApplicationQuota(application)
+	if !ok {
+		return types.ApplicationQuota{}, nil, false
+	}
+
+	usage, _ := s.applicationUsages.LoadOrStore(application, newApplicationUsage())
+	return quota, usage.(*applicationUsage), true
+}
+
+// checkTaskQuota checks whether the running tasks of application exceed max concurrent tasks,
+// the peer of task which application is already downloading is not limited.
+func (s *Service) checkTaskQuota(application, taskID string) error {
+	quota, usage, ok := s.loadApplicationQuota(application)
+	if !ok || quota.MaxConcurrentTasks == 0 {
+		return nil
+	}
+
+	taskIDs := usage.runningTaskIDs()
+	if _, ok := taskIDs[taskID]; ok {
+		return nil
+	}
+
+	if count := len(taskIDs); count >= int(quota.MaxConcurrentTasks) {
+		return dferrors.Newf(base.Code_SchedTaskQuotaExceeded, "application %s has %d running tasks, exceeds max concurrent tasks %d",
+			application, count, quota.MaxConcurrentTasks)
+	}
+
+	return nil
+}
+
+// checkTriggerQuota checks whether application can trigger seed peer or download back-to-source.
+func (s *Service) checkTriggerQuota(req *rpcscheduler.PeerTaskRequest, task *resource.Task) error {
+	application := req.UrlMeta.Application
+	quota, usage, ok := s.loadApplicationQuota(application)
+	if !ok {
+		return nil
+	}
+
+	// Seed peer downloads back-to-source without quota.
+	if host, loaded := s.resource.HostManager().Load(req.PeerHost.Id); loaded && host.Type != resource.HostTypeNormal {
+		return nil
+	}
+
+	if s.config.SeedPeer.Enable && !task.IsSeedPeerFailed() {
+		if quota.MaxSeedPeerTriggers == 0 {
+			return nil
+		}
+
+		if count := usage.runningSeedPeerTaskCount(); count >= int(quota.MaxSeedPeerTriggers) {
+			return dferrors.Newf(base.Code_SchedSeedPeerQuotaExceeded, "application %s has triggered %d seed peer tasks, exceeds max seed peer triggers %d",
+				application, count, quota.MaxSeedPeerTriggers)
+		}
+
+		return nil
+	}
+
+	if quota.MaxBackToSourceBandwidth == 0 {
+		return nil
+	}
+
+	if bandwidth := usage.backToSourceBandwidth(); bandwidth >= float64(quota.MaxBackToSourceBandwidth) {
+		return dferrors.Newf(base.Code_SchedBackSourceQuotaExceeded, "application %s back-to-source bandwidth is %.0f, exceeds max back-to-source bandwidth %d",
+			application, bandwidth, quota.MaxBackToSourceBandwidth)
+	}
+
+	return nil
+}
+
+// storeApplicationPeer stores the peer to usage of application, the finished peers are removed first,
+// so the usage does not hold the peers of quotas which never count running peers.
+func (s *Service) storeApplicationPeer(application string, peer *resource.Peer) {
+	if _, usage, ok := s.loadApplicationQuota(application); ok {
+		usage.runningPeers()
+		usage.peers.Add(peer)
+	}
+}
+
+// storeApplicationSeedPeerTask stores the task triggered seed peer to usage of application,
+// the finished tasks are removed first.
+func (s *Service) storeApplicationSeedPeerTask(application string, task *resource.Task) {
+	if _, usage, ok := s.loadApplicationQuota(application); ok {
+		usage.runningSeedPeerTasks()
+		usage.seedPeerTasks.Add(task)
+	}
+}
@@ -0,0 +1,374 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"d7y.io/dragonfly/v2/internal/dferrors"
+	"d7y.io/dragonfly/v2/manager/types"
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
+	rpcscheduler "d7y.io/dragonfly/v2/pkg/rpc/scheduler"
+	"d7y.io/dragonfly/v2/scheduler/config"
+	configmocks "d7y.io/dragonfly/v2/scheduler/config/mocks"
+	"d7y.io/dragonfly/v2/scheduler/resource"
+	"d7y.io/dragonfly/v2/scheduler/scheduler/mocks"
+	storagemocks "d7y.io/dragonfly/v2/scheduler/storage/mocks"
+)
+
+var mockApplication = "foo"
+
+func TestService_checkTaskQuota(t *testing.T) {
+	tests := []struct {
+		name        string
+		application string
+		taskID      string
+		mock        func(svc *Service, peers []*resource.Peer, md *configmocks.MockDynconfigInterfaceMockRecorder)
+		expect      func(t *testing.T, err error)
+	}{
+		{
+			name:        "application is empty",
+			application: "",
+			mock:        func(svc *Service, peers []*resource.Peer, md *configmocks.MockDynconfigInterfaceMockRecorder) {},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+			},
+		},
+		{
+			name:        "application has no quota",
+			application: mockApplication,
+			mock: func(svc *Service, peers []*resource.Peer, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetApplicationQuota(gomock.Eq(mockApplication)).Return(types.ApplicationQuota{}, false).Times(1)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+			},
+		},
+		{
+			name:        "running tasks exceed max concurrent tasks",
+			application: mockApplication,
+			taskID:      "baz",
+			mock: func(svc *Service, peers []*resource.Peer, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetApplicationQuota(gomock.Eq(mockApplication)).Return(types.ApplicationQuota{MaxConcurrentTasks: 2}, true).Times(4)
+				peers[0].FSM.SetState(resource.PeerStateRunning)
+				peers[1].FSM.SetState(resource.PeerStateBackToSource)
+				peers[2].FSM.SetState(resource.PeerStateRunning)
+				svc.storeApplicationPeer(mockApplication, peers[0])
+				svc.storeApplicationPeer(mockApplication, peers[1])
+				svc.storeApplicationPeer(mockApplication, peers[2])
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				dferr, ok := err.(*dferrors.DfError)
+				assert.True(ok)
+				assert.Equal(dferr.Code, base.Code_SchedTaskQuotaExceeded)
+			},
+		},
+		{
+			name:        "peers of the same task are counted once",
+			application: mockApplication,
+			taskID:      "baz",
+			mock: func(svc *Service, peers []*resource.Peer, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetApplicationQuota(gomock.Eq(mockApplication)).Return(types.ApplicationQuota{MaxConcurrentTasks: 2}, true).Times(3)
+				peers[0].FSM.SetState(resource.PeerStateRunning)
+				peers[1].FSM.SetState(resource.PeerStateBackToSource)
+				svc.storeApplicationPeer(mockApplication, peers[0])
+				svc.storeApplicationPeer(mockApplication, peers[1])
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+			},
+		},
+		{
+			name:        "peer of running task is not limited",
+			application: mockApplication,
+			taskID:      mockTaskID,
+			mock: func(svc *Service, peers []*resource.Peer, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetApplicationQuota(gomock.Eq(mockApplication)).Return(types.ApplicationQuota{MaxConcurrentTasks: 2}, true).Times(4)
+				peers[0].FSM.SetState(resource.PeerStateRunning)
+				peers[1].FSM.SetState(resource.PeerStateBackToSource)
+				peers[2].FSM.SetState(resource.PeerStateRunning)
+				svc.storeApplicationPeer(mockApplication, peers[0])
+				svc.storeApplicationPeer(mockApplication, peers[1])
+				svc.storeApplicationPeer(mockApplication, peers[2])
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+			},
+		},
+		{
+			name:        "finished peers are not counted",
+			application: mockApplication,
+			taskID:      "baz",
+			mock: func(svc *Service, peers []*resource.Peer, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetApplicationQuota(gomock.Eq(mockApplication)).Return(types.ApplicationQuota{MaxConcurrentTasks: 2}, true).Times(4)
+				peers[0].FSM.SetState(resource.PeerStateRunning)
+				peers[1].FSM.SetState(resource.PeerStateRunning)
+				peers[2].FSM.SetState(resource.PeerStateSucceeded)
+				svc.storeApplicationPeer(mockApplication, peers[0])
+				svc.storeApplicationPeer(mockApplication, peers[1])
+				svc.storeApplicationPeer(mockApplication, peers[2])
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			scheduler := mocks.NewMockScheduler(ctl)
+			res := resource.NewMockResource(ctl)
+			dynconfig := configmocks.NewMockDynconfigInterface(ctl)
+			storage := storagemocks.NewMockStorage(ctl)
+			svc := New(&config.Config{Scheduler: mockSchedulerConfig}, res, scheduler, dynconfig, storage)
+
+			mockHost := resource.NewHost(mockRawHost)
+			mockTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta)
+			otherTask := resource.NewTask("bar", mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta)
+			peers := []*resource.Peer{
+				resource.NewPeer("0", mockTask, mockHost),
+				resource.NewPeer("1", mockTask, mockHost),
+				resource.NewPeer("2", otherTask, mockHost),
+			}
+
+			tc.mock(svc, peers, dynconfig.EXPECT())
+			tc.expect(t, svc.checkTaskQuota(tc.application, tc.taskID))
+		})
+	}
+}
+
+func TestService_checkTriggerQuota(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *config.Config
+		mock   func(svc *Service, task *resource.Task, hostManager resource.HostManager, mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder)
+		expect func(t *testing.T, err error)
+	}{
+		{
+			name: "seed peer downloads back-to-source without quota",
+			config: &config.Config{
+				Scheduler: mockSchedulerConfig,
+				SeedPeer:  &config.SeedPeerConfig{Enable: false},
+			},
+			mock: func(svc *Service, task *resource.Task, hostManager resource.HostManager, mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				gomock.InOrder(
+					md.GetApplicationQuota(gomock.Eq(mockApplication)).Return(types.ApplicationQuota{MaxBackToSourceBandwidth: 1}, true).Times(1),
+					mr.HostManager().Return(hostManager).Times(1),
+					mh.Load(gomock.Eq(mockRawHost.Id)).Return(resource.NewHost(mockRawHost, resource.WithHostType(resource.HostTypeSuperSeed)), true).Times(1),
+				)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+			},
+		},
+		{
+			name: "seed peer triggers exceed max seed peer triggers",
+			config: &config.Config{
+				Scheduler: mockSchedulerConfig,
+				SeedPeer:  &config.SeedPeerConfig{Enable: true},
+			},
+			mock: func(svc *Service, task *resource.Task, hostManager resource.HostManager, mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetApplicationQuota(gomock.Eq(mockApplication)).Return(types.ApplicationQuota{MaxSeedPeerTriggers: 1}, true).Times(2)
+				gomock.InOrder(
+					mr.HostManager().Return(hostManager).Times(1),
+					mh.Load(gomock.Eq(mockRawHost.Id)).Return(nil, false).Times(1),
+				)
+
+				runningTask := resource.NewTask("bar", mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta)
+				runningTask.FSM.SetState(resource.TaskStateRunning)
+				svc.storeApplicationSeedPeerTask(mockApplication, runningTask)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				dferr, ok := err.(*dferrors.DfError)
+				assert.True(ok)
+				assert.Equal(dferr.Code, base.Code_SchedSeedPeerQuotaExceeded)
+			},
+		},
+		{
+			name: "finished seed peer tasks are not counted",
+			config: &config.Config{
+				Scheduler: mockSchedulerConfig,
+				SeedPeer:  &config.SeedPeerConfig{Enable: true},
+			},
+			mock: func(svc *Service, task *resource.Task, hostManager resource.HostManager, mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetApplicationQuota(gomock.Eq(mockApplication)).Return(types.ApplicationQuota{MaxSeedPeerTriggers: 1}, true).Times(2)
+				gomock.InOrder(
+					mr.HostManager().Return(hostManager).Times(1),
+					mh.Load(gomock.Eq(mockRawHost.Id)).Return(nil, false).Times(1),
+				)
+
+				succeededTask := resource.NewTask("bar", mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta)
+				succeededTask.FSM.SetState(resource.TaskStateSucceeded)
+				svc.storeApplicationSeedPeerTask(mockApplication, succeededTask)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+			},
+		},
+		{
+			name: "back-to-source bandwidth exceeds max back-to-source bandwidth",
+			config: &config.Config{
+				Scheduler: mockSchedulerConfig,
+				SeedPeer:  &config.SeedPeerConfig{Enable: false},
+			},
+			mock: func(svc *Service, task *resource.Task, hostManager resource.HostManager, mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetApplicationQuota(gomock.Eq(mockApplication)).Return(types.ApplicationQuota{MaxBackToSourceBandwidth: 1024}, true).Times(2)
+				gomock.InOrder(
+					mr.HostManager().Return(hostManager).Times(1),
+					mh.Load(gomock.Eq(mockRawHost.Id)).Return(nil, false).Times(1),
+				)
+
+				peer := resource.NewPeer(mockPeerID, task, resource.NewHost(mockRawHost))
+				peer.FSM.SetState(resource.PeerStateBackToSource)
+				peer.AppendRecentPiece(4096, time.Second)
+				svc.storeApplicationPeer(mockApplication, peer)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				dferr, ok := err.(*dferrors.DfError)
+				assert.True(ok)
+				assert.Equal(dferr.Code, base.Code_SchedBackSourceQuotaExceeded)
+			},
+		},
+		{
+			name: "back-to-source bandwidth does not exceed max back-to-source bandwidth",
+			config: &config.Config{
+				Scheduler: mockSchedulerConfig,
+				SeedPeer:  &config.SeedPeerConfig{Enable: false},
+			},
+			mock: func(svc *Service, task *resource.Task, hostManager resource.HostManager, mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetApplicationQuota(gomock.Eq(mockApplication)).Return(types.ApplicationQuota{MaxBackToSourceBandwidth: 1024}, true).Times(1)
+				gomock.InOrder(
+					mr.HostManager().Return(hostManager).Times(1),
+					mh.Load(gomock.Eq(mockRawHost.Id)).Return(nil, false).Times(1),
+				)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			scheduler := mocks.NewMockScheduler(ctl)
+			res := resource.NewMockResource(ctl)
+			dynconfig := configmocks.NewMockDynconfigInterface(ctl)
+			storage := storagemocks.NewMockStorage(ctl)
+			hostManager := resource.NewMockHostManager(ctl)
+			svc := New(tc.config, res, scheduler, dynconfig, storage)
+
+			mockTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta)
+			req := &rpcscheduler.PeerTaskRequest{
+				UrlMeta: &base.UrlMeta{
+					Application: mockApplication,
+				},
+				PeerHost: &rpcscheduler.PeerHost{
+					Id: mockRawHost.Id,
+				},
+			}
+
+			tc.mock(svc, mockTask, hostManager, res.EXPECT(), hostManager.EXPECT(), dynconfig.EXPECT())
+			tc.expect(t, svc.checkTriggerQuota(req, mockTask))
+		})
+	}
+}
+
+func TestService_storeApplicationPeer(t *testing.T) {
+	tests := []struct {
+		name   string
+		quota  types.ApplicationQuota
+		mock   func(svc *Service, peers []*resource.Peer)
+		expect func(t *testing.T, usage *applicationUsage)
+	}{
+		{
+			name:  "finished peers are removed with bandwidth quota",
+			quota: types.ApplicationQuota{MaxBackToSourceBandwidth: 1024},
+			mock: func(svc *Service, peers []*resource.Peer) {
+				peers[0].FSM.SetState(resource.PeerStateRunning)
+				svc.storeApplicationPeer(mockApplication, peers[0])
+				peers[0].FSM.SetState(resource.PeerStateSucceeded)
+				peers[1].FSM.SetState(resource.PeerStateRunning)
+				svc.storeApplicationPeer(mockApplication, peers[1])
+			},
+			expect: func(t *testing.T, usage *applicationUsage) {
+				assert := assert.New(t)
+				assert.Equal(uint(1), usage.peers.Len())
+			},
+		},
+		{
+			name:  "finished peers are removed by back-to-source bandwidth",
+			quota: types.ApplicationQuota{MaxBackToSourceBandwidth: 1024},
+			mock: func(svc *Service, peers []*resource.Peer) {
+				peers[0].FSM.SetState(resource.PeerStateBackToSource)
+				peers[1].FSM.SetState(resource.PeerStateRunning)
+				svc.storeApplicationPeer(mockApplication, peers[0])
+				svc.storeApplicationPeer(mockApplication, peers[1])
+				peers[0].FSM.SetState(resource.PeerStateFailed)
+				peers[1].FSM.SetState(resource.PeerStateLeave)
+			},
+			expect: func(t *testing.T, usage *applicationUsage) {
+				assert := assert.New(t)
+				assert.Equal(float64(0), usage.backToSourceBandwidth())
+				assert.Equal(uint(0), usage.peers.Len())
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			scheduler := mocks.NewMockScheduler(ctl)
+			res := resource.NewMockResource(ctl)
+			dynconfig := configmocks.NewMockDynconfigInterface(ctl)
+			storage := storagemocks.NewMockStorage(ctl)
+			svc := New(&config.Config{Scheduler: mockSchedulerConfig}, res, scheduler, dynconfig, storage)
+
+			dynconfig.EXPECT().GetApplicationQuota(gomock.Eq(mockApplication)).Return(tc.quota, true).AnyTimes()
+			mockHost := resource.NewHost(mockRawHost)
+			mockTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta)
+			peers := []*resource.Peer{
+				resource.NewPeer("0", mockTask, mockHost),
+				resource.NewPeer("1", mockTask, mockHost),
+			}
+
+			tc.mock(svc, peers)
+			_, usage, ok := svc.loadApplicationQuota(mockApplication)
+			assert.True(t, ok)
+			tc.expect(t, usage)
+		})
+	}
+}
@@ -20,8 +20,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
+	"github.com/montanaflynn/stats"
 	"go.opentelemetry.io/otel/trace"
 
 	"d7y.io/dragonfly/v2/internal/dferrors"
@@ -35,9 +37,20 @@ import (
 	"d7y.io/dragonfly/v2/scheduler/metrics"
 	"d7y.io/dragonfly/v2/scheduler/resource"
 	"d7y.io/dragonfly/v2/scheduler/scheduler"
+	"d7y.io/dragonfly/v2/scheduler/scheduler/evaluator"
 	"d7y.io/dragonfly/v2/scheduler/storage"
 )
 
+const (
+	// slowParentCostLen is the number of the latest pieces downloaded from parent,
+	// which are used to determine whether the parent is slow.
+	slowParentCostLen = 5
+
+	// slowParentCostRatio is the minimum ratio of the costs mean of slow parent to the costs mean of task,
+	// it avoids rescheduling when the costs are stable and the standard deviation is tiny.
+	slowParentCostRatio = 2
+)
+
 type Service struct {
 	// Resource interface.
 	resource resource.Resource
@@ -53,6 +66,12 @@ type Service struct {
 
 	// Storage interface.
 	storage storage.Storage
+
+	// Resource usages of applications.
+	applicationUsages *sync.Map
+
+	// Compiled url policies of scheduler cluster.
+	urlPolicies *urlPolicies
 }
 
 // New service instance.
@@ -65,26 +84,49 @@ func New(
 ) *Service {
 
 	return &Service{
-		resource:  resource,
-		scheduler: scheduler,
-		config:    cfg,
-		dynconfig: dynconfig,
-		storage:   storage,
+		resource:          resource,
+		scheduler:         scheduler,
+		config:            cfg,
+		dynconfig:         dynconfig,
+		storage:           storage,
+		applicationUsages: &sync.Map{},
+		urlPolicies:       newURLPolicies(),
 	}
 }
 
 // RegisterPeerTask registers peer and triggers seed peer download task.
 func (s *Service) RegisterPeerTask(ctx context.Context, req *rpcscheduler.PeerTaskRequest) (*rpcscheduler.RegisterResult, error) {
+	// Check url policies of scheduler cluster.
+	if err := s.CheckURLPolicy(ctx, req.Url); err != nil {
+		logger.Errorf("peer %s register is failed: %s", req.PeerId, err.Error())
+		return nil, err
+	}
+
+	// Check concurrent tasks quota of application.
+	if err := s.checkTaskQuota(req.UrlMeta.Application, req.TaskId); err != nil {
+		logger.Errorf("peer %s register is failed: %s", req.PeerId, err.Error())
+		return nil, err
+	}
+
 	// Register task and trigger seed peer download task.
 	task, needBackToSource, err := s.registerTask(ctx, req)
 	if err != nil {
 		msg := fmt.Sprintf("peer %s register is failed: %s", req.PeerId, err.Error())
 		logger.Error(msg)
+		if dferr, ok := err.(*dferrors.DfError); ok {
+			return nil, dferr
+		}
+
 		return nil, dferrors.New(base.Code_SchedTaskStatusError, msg)
 	}
 	host := s.registerHost(ctx, req.PeerHost)
-	peer := s.registerPeer(ctx, req.PeerId, task, host, req.UrlMeta.Tag)
+	if req.HostLoad != nil {
+		host.StoreLoad(req.HostLoad)
+	}
+
+	peer := s.registerPeer(ctx, req.PeerId, task, host, req.UrlMeta.Tag, req.Priority)
 	peer.Log.Infof("register peer task request: %#v %#v %#v", req, req.UrlMeta, req.HostLoad)
+	s.storeApplicationPeer(req.UrlMeta.Application, peer)
 
 	// When the peer registers for the first time and
 	// does not have a seed peer, it will back-to-source.
@@ -270,9 +312,14 @@ func (s *Service) ReportPieceResult(stream rpcscheduler.Scheduler_ReportPieceRes
 				return dferrors.New(base.Code_SchedPeerNotFound, msg)
 			}
 
-			// Peer setting stream.
+			// Peer setting stream, the stream may be replaced when peer resumes with a new stream.
 			peer.StoreStream(stream)
-			defer peer.DeleteStream()
+			defer peer.CompareAndDeleteStream(stream)
+		}
+
+		// Update host load reported by dfdaemon.
+		if piece.HostLoad != nil {
+			peer.Host.StoreLoad(piece.HostLoad)
 		}
 
 		if piece.PieceInfo != nil {
@@ -283,6 +330,13 @@ func (s *Service) ReportPieceResult(stream rpcscheduler.Scheduler_ReportPieceRes
 				continue
 			}
 
+			// Handle resume of piece.
+			if piece.PieceInfo.PieceNum == common.ResumeOfPiece {
+				peer.Log.Infof("receive resume of piece: %#v %#v", piece, piece.PieceInfo)
+				s.handleResumeOfPiece(ctx, peer, piece)
+				continue
+			}
+
 			// Handle end of piece.
 			if piece.PieceInfo.PieceNum == common.EndOfPiece {
 				peer.Log.Infof("receive end of piece: %#v %#v", piece, piece.PieceInfo)
@@ -407,10 +461,16 @@ func (s *Service) AnnounceTask(ctx context.Context, req *rpcscheduler.AnnounceTa
 		return dferrors.New(base.Code_BadRequest, msg)
 	}
 
+	// Check url policies of scheduler cluster.
+	if err := s.CheckURLPolicy(ctx, req.Cid); err != nil {
+		logger.WithTaskAndPeerID(taskID, peerID).Errorf("announce task is failed: %s", err.Error())
+		return err
+	}
+
 	task := resource.NewTask(taskID, req.Cid, resource.TaskTypeDfcache, req.UrlMeta)
 	task, _ = s.resource.TaskManager().LoadOrStore(task)
 	host := s.registerHost(ctx, req.PeerHost)
-	peer := s.registerPeer(ctx, peerID, task, host, req.UrlMeta.Tag)
+	peer := s.registerPeer(ctx, peerID, task, host, req.UrlMeta.Tag, base.Priority_NORMAL_PRIORITY)
 	peer.Log.Infof("announce peer task request: %#v %#v %#v %#v", req, req.UrlMeta, req.PeerHost, req.PiecePacket)
 
 	// If the task state is not TaskStateSucceeded,
@@ -522,6 +582,12 @@ func (s *Service) registerTask(ctx context.Context, req *rpcscheduler.PeerTaskRe
 		return task, false, nil
 	}
 
+	// Check the quota of application before triggering task,
+	// task is not triggered when the quota is exceeded.
+	if err := s.checkTriggerQuota(req, task); err != nil {
+		return nil, false, err
+	}
+
 	// Trigger task.
 	if err := task.FSM.Event(resource.TaskEventDownload); err != nil {
 		return nil, false, err
@@ -539,7 +605,8 @@ func (s *Service) registerTask(ctx context.Context, req *rpcscheduler.PeerTaskRe
 			return task, true, nil
 		}
 
-		go s.triggerSeedPeerTask(ctx, task)
+		s.storeApplicationSeedPeerTask(req.UrlMeta.Application, task)
+		go s.triggerSeedPeerTask(ctx, task, req.PeerHost.Idc)
 		return task, false, nil
 	}
 
@@ -568,8 +635,8 @@ func (s *Service) registerHost(ctx context.Context, rawHost *rpcscheduler.PeerHo
 }
 
 // registerPeer creates a new peer or reuses a previous peer.
-func (s *Service) registerPeer(ctx context.Context, peerID string, task *resource.Task, host *resource.Host, tag string) *resource.Peer {
-	var options []resource.PeerOption
+func (s *Service) registerPeer(ctx context.Context, peerID string, task *resource.Task, host *resource.Host, tag string, priority base.Priority) *resource.Peer {
+	options := []resource.PeerOption{resource.WithPriority(priority)}
 	if tag != "" {
 		options = append(options, resource.WithBizTag(tag))
 	}
@@ -584,11 +651,12 @@ func (s *Service) registerPeer(ctx context.Context, peerID string, task *resourc
 	return peer
 }
 
-// triggerSeedPeerTask starts to trigger seed peer task.
-func (s *Service) triggerSeedPeerTask(ctx context.Context, task *resource.Task) {
+// triggerSeedPeerTask starts to trigger seed peer task,
+// idc is the idc of host which triggers the task.
+func (s *Service) triggerSeedPeerTask(ctx context.Context, task *resource.Task, idc string) {
 	task.Log.Infof("trigger seed peer download task and task status is %s", task.FSM.Current())
 	peer, endOfPiece, err := s.resource.SeedPeer().TriggerTask(
-		trace.ContextWithSpanContext(context.Background(), trace.SpanContextFromContext(ctx)), task)
+		trace.ContextWithSpanContext(context.Background(), trace.SpanContextFromContext(ctx)), task, idc)
 	if err != nil {
 		task.Log.Errorf("trigger seed peer download task failed: %s", err.Error())
 		s.handleTaskFail(ctx, task)
@@ -632,24 +700,106 @@ func (s *Service) handleBeginOfPiece(ctx context.Context, peer *resource.Peer) {
 
 		peer.Log.Infof("schedule parent because of peer receive begin of piece")
 		s.scheduler.ScheduleParent(ctx, peer, set.NewSafeSet())
+	case resource.PeerStateRunning:
+		// When the peer reconnects, e.g. scheduler is restarted and restores peer from snapshot,
+		// keep the parent if it is still available, otherwise reschedule parent.
+		if parent, ok := peer.LoadParent(); ok {
+			if _, ok := s.resource.PeerManager().Load(parent.ID); ok &&
+				!parent.FSM.Is(resource.PeerStateFailed) && !parent.FSM.Is(resource.PeerStateLeave) {
+				peer.Log.Infof("peer reconnects and keeps parent %s", parent.ID)
+				return
+			}
+
+			peer.DeleteParent()
+		}
+
+		peer.Log.Infof("schedule parent because of peer reconnects")
+		s.scheduler.ScheduleParent(ctx, peer, set.NewSafeSet())
 	default:
 		peer.Log.Warnf("peer state is %s when receive the begin of piece", peer.FSM.Current())
 	}
 }
 
+// handleResumeOfPiece handles resume of piece, peer reconnects with a new stream
+// when the previous stream is broken, e.g. rolling restart of scheduler.
+func (s *Service) handleResumeOfPiece(ctx context.Context, peer *resource.Peer, piece *rpcscheduler.PieceResult) {
+	// Peer may download pieces when the stream is broken,
+	// or peer is restored from the stale snapshot, merge finished pieces of peer.
+	peer.StorePieces(piece.FinishedPieces)
+	peer.UpdateAt.Store(time.Now())
+	metrics.ResumePieceResultCount.WithLabelValues(peer.BizTag).Inc()
+
+	// Peer is re-attached to the stream and keeps its parent,
+	// it is the same as the begin of piece of reconnected peer.
+	s.handleBeginOfPiece(ctx, peer)
+}
+
 // handleEndOfPiece handles end of piece.
 func (s *Service) handleEndOfPiece(ctx context.Context, peer *resource.Peer) {}
 
 // handlePieceSuccess handles successful piece.
 func (s *Service) handlePieceSuccess(ctx context.Context, peer *resource.Peer, piece *rpcscheduler.PieceResult) {
 	// Update peer piece info
+	duration := pkgtime.SubNano(int64(piece.EndTime), int64(piece.BeginTime))
+	cost := duration.Milliseconds()
 	peer.Pieces.Set(uint(piece.PieceInfo.PieceNum))
-	peer.AppendPieceCost(pkgtime.SubNano(int64(piece.EndTime), int64(piece.BeginTime)).Milliseconds())
+	peer.AppendPieceCost(cost)
+	peer.AppendRecentPiece(uint64(piece.PieceInfo.RangeSize), duration)
 
 	// When the peer downloads back-to-source,
 	// piece downloads successfully updates the task piece info.
 	if peer.FSM.Is(resource.PeerStateBackToSource) {
 		peer.Task.StorePiece(piece.PieceInfo)
+		return
+	}
+
+	// Piece is downloaded from parent, update the piece costs of parent
+	// and reschedule parent if the parent is slow.
+	if piece.DstPid != "" {
+		peer.Task.AppendPieceCost(cost)
+		peer.AppendParentPieceCost(piece.DstPid, cost)
+		s.handleSlowParent(ctx, peer, piece.DstPid)
+	}
+}
+
+// handleSlowParent reschedules parent for peer without waiting for the failed piece,
+// when the pieces downloaded from parent are the outliers of pieces downloaded in the task.
+func (s *Service) handleSlowParent(ctx context.Context, peer *resource.Peer, parentID string) {
+	// Only the running peer downloading from the current parent can be rescheduled.
+	if !peer.FSM.Is(resource.PeerStateRunning) {
+		return
+	}
+
+	parent, ok := peer.LoadParent()
+	if !ok || parent.ID != parentID {
+		return
+	}
+
+	// Check the latest costs of parent once every slowParentCostLen pieces,
+	// avoid rescheduling frequently when other parents can not be found.
+	costs := peer.ParentPieceCosts(parentID)
+	if len(costs) == 0 || len(costs)%slowParentCostLen != 0 {
+		return
+	}
+
+	parentMean, _ := stats.Mean(stats.LoadRawData(costs[len(costs)-slowParentCostLen:])) // nolint: errcheck
+	count, mean, stdev := peer.Task.PieceCostStatistics()
+	if parentMean < mean*slowParentCostRatio || !evaluator.IsCostOutlier(parentMean, count, mean, stdev) {
+		return
+	}
+
+	peer.Log.Infof("schedule parent because of parent %s is slow, costs mean of parent is %.2f, costs mean of task is %.2f and standard deviation is %.2f",
+		parentID, parentMean, mean, stdev)
+	blocklist := set.NewSafeSet()
+	for _, id := range peer.BlockPeers.Values() {
+		blocklist.Add(id)
+	}
+	blocklist.Add(parentID)
+
+	// Keep the slow parent if there is no other parent,
+	// slow parent is better than downloading back-to-source.
+	if _, ok := s.scheduler.NotifyAndFindParent(ctx, peer, blocklist); !ok {
+		peer.Log.Infof("keep parent %s because can not find other parents", parentID)
 	}
 }
 
@@ -691,7 +841,7 @@ func (s *Service) handlePieceFail(ctx context.Context, peer *resource.Peer, piec
 
 		// Start trigger seed peer task.
 		if s.config.SeedPeer.Enable {
-			go s.triggerSeedPeerTask(ctx, parent.Task)
+			go s.triggerSeedPeerTask(ctx, parent.Task, peer.Host.IDC)
 		}
 	default:
 	}
@@ -829,9 +979,13 @@ func (s *Service) handleTaskFail(ctx context.Context, task *resource.Task) {
 func (s *Service) createRecord(peer *resource.Peer, peerState int, req *rpcscheduler.PeerResult) {
 	record := storage.Record{
 		ID:              peer.ID,
+		TaskID:          peer.Task.ID,
+		HostID:          peer.Host.ID,
 		IP:              peer.Host.IP,
 		Hostname:        peer.Host.Hostname,
 		BizTag:          peer.BizTag,
+		Application:     peer.Task.URLMeta.GetApplication(),
+		Priority:        int(peer.Priority),
 		Cost:            req.Cost,
 		PieceCount:      int32(peer.Pieces.Count()),
 		TotalPieceCount: peer.Task.TotalPieceCount.Load(),
@@ -842,6 +996,7 @@ func (s *Service) createRecord(peer *resource.Peer, peerState int, req *rpcsched
 		Location:        peer.Host.Location,
 		FreeUploadLoad:  peer.Host.FreeUploadLoad(),
 		State:           peerState,
+		BackToSource:    peer.IsBackToSource.Load(),
 		HostType:        int(peer.Host.Type),
 		CreateAt:        peer.CreateAt.Load().UnixNano(),
 		UpdateAt:        peer.UpdateAt.Load().UnixNano(),
@@ -849,6 +1004,7 @@ func (s *Service) createRecord(peer *resource.Peer, peerState int, req *rpcsched
 
 	if parent, ok := peer.LoadParent(); ok {
 		record.ParentID = parent.ID
+		record.ParentHostID = parent.Host.ID
 		record.ParentIP = parent.Host.IP
 		record.ParentHostname = parent.Host.Hostname
 		record.ParentBizTag = parent.BizTag
@@ -863,6 +1019,21 @@ func (s *Service) createRecord(peer *resource.Peer, peerState int, req *rpcsched
 		record.ParentUpdateAt = parent.UpdateAt.Load().UnixNano()
 	}
 
+	if costs := peer.PieceCosts(); len(costs) > 0 {
+		data := stats.LoadRawData(costs)
+		mean, _ := stats.Mean(data)          // nolint: errcheck
+		p50, _ := stats.Percentile(data, 50) // nolint: errcheck
+		p90, _ := stats.Percentile(data, 90) // nolint: errcheck
+		p99, _ := stats.Percentile(data, 99) // nolint: errcheck
+		max, _ := stats.Max(data)            // nolint: errcheck
+
+		record.PieceCostAvg = int64(mean)
+		record.PieceCostP50 = int64(p50)
+		record.PieceCostP90 = int64(p90)
+		record.PieceCostP99 = int64(p99)
+		record.PieceCostMax = int64(max)
+	}
+
 	if err := s.storage.Create(record); err != nil {
 		peer.Log.Error(err)
 	}
@@ -46,6 +46,7 @@ import (
 	"d7y.io/dragonfly/v2/scheduler/resource"
 	"d7y.io/dragonfly/v2/scheduler/scheduler"
 	"d7y.io/dragonfly/v2/scheduler/scheduler/mocks"
+	"d7y.io/dragonfly/v2/scheduler/storage"
 	storagemocks "d7y.io/dragonfly/v2/scheduler/storage/mocks"
 )
 
@@ -655,6 +656,7 @@ func TestService_RegisterPeerTask(t *testing.T) {
 			taskManager := resource.NewMockTaskManager(ctl)
 			peerManager := resource.NewMockPeerManager(ctl)
 			svc := New(&config.Config{Scheduler: mockSchedulerConfig}, res, scheduler, dynconfig, storage)
+			dynconfig.EXPECT().GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{}, false).AnyTimes()
 
 			mockHost := resource.NewHost(mockRawHost)
 			mockTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta, resource.WithBackToSourceLimit(mockTaskBackToSourceLimit))
@@ -791,6 +793,40 @@ func TestService_ReportPieceResult(t *testing.T) {
 				assert.False(ok)
 			},
 		},
+		{
+			name: "revice resume of piece",
+			mock: func(
+				mockPeer *resource.Peer,
+				res resource.Resource, peerManager resource.PeerManager,
+				mr *resource.MockResourceMockRecorder, mp *resource.MockPeerManagerMockRecorder, ms *rpcschedulermocks.MockScheduler_ReportPieceResultServerMockRecorder,
+
+			) {
+				mockPeer.FSM.SetState(resource.PeerStateBackToSource)
+				gomock.InOrder(
+					ms.Context().Return(context.Background()).Times(1),
+					ms.Recv().Return(&rpcscheduler.PieceResult{
+						SrcPid:         mockPeerID,
+						FinishedPieces: []byte{0xa0},
+						PieceInfo: &base.PieceInfo{
+							PieceNum: common.ResumeOfPiece,
+						},
+					}, nil).Times(1),
+					mr.PeerManager().Return(peerManager).Times(1),
+					mp.Load(gomock.Eq(mockPeerID)).Return(mockPeer, true).Times(1),
+					ms.Recv().Return(nil, io.EOF).Times(1),
+				)
+			},
+			expect: func(t *testing.T, peer *resource.Peer, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				assert.Equal(peer.Pieces.Count(), uint(2))
+				assert.True(peer.Pieces.Test(0))
+				assert.True(peer.Pieces.Test(2))
+				assert.True(peer.FSM.Is(resource.PeerStateBackToSource))
+				_, ok := peer.LoadStream()
+				assert.False(ok)
+			},
+		},
 		{
 			name: "revice end of piece",
 			mock: func(
@@ -1188,7 +1224,7 @@ func TestService_AnnounceTask(t *testing.T) {
 		req  *rpcscheduler.AnnounceTaskRequest
 		mock func(mockHost *resource.Host, mockTask *resource.Task, mockPeer *resource.Peer,
 			hostManager resource.HostManager, taskManager resource.TaskManager, peerManager resource.PeerManager,
-			mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, mt *resource.MockTaskManagerMockRecorder, mp *resource.MockPeerManagerMockRecorder)
+			mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, mt *resource.MockTaskManagerMockRecorder, mp *resource.MockPeerManagerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder)
 		expect func(t *testing.T, mockTask *resource.Task, mockPeer *resource.Peer, err error)
 	}{
 		{
@@ -1198,7 +1234,7 @@ func TestService_AnnounceTask(t *testing.T) {
 			},
 			mock: func(mockHost *resource.Host, mockTask *resource.Task, mockPeer *resource.Peer,
 				hostManager resource.HostManager, taskManager resource.TaskManager, peerManager resource.PeerManager,
-				mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, mt *resource.MockTaskManagerMockRecorder, mp *resource.MockPeerManagerMockRecorder) {
+				mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, mt *resource.MockTaskManagerMockRecorder, mp *resource.MockPeerManagerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder) {
 			},
 			expect: func(t *testing.T, mockTask *resource.Task, mockPeer *resource.Peer, err error) {
 				assert := assert.New(t)
@@ -1216,7 +1252,7 @@ func TestService_AnnounceTask(t *testing.T) {
 			},
 			mock: func(mockHost *resource.Host, mockTask *resource.Task, mockPeer *resource.Peer,
 				hostManager resource.HostManager, taskManager resource.TaskManager, peerManager resource.PeerManager,
-				mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, mt *resource.MockTaskManagerMockRecorder, mp *resource.MockPeerManagerMockRecorder) {
+				mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, mt *resource.MockTaskManagerMockRecorder, mp *resource.MockPeerManagerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder) {
 			},
 			expect: func(t *testing.T, mockTask *resource.Task, mockPeer *resource.Peer, err error) {
 				assert := assert.New(t)
@@ -1225,6 +1261,31 @@ func TestService_AnnounceTask(t *testing.T) {
 				assert.Equal(dferr.Code, base.Code_BadRequest)
 			},
 		},
+		{
+			name: "url is denied by url policy",
+			req: &rpcscheduler.AnnounceTaskRequest{
+				TaskId:  mockTaskID,
+				Cid:     mockCID,
+				UrlMeta: &base.UrlMeta{},
+				PiecePacket: &base.PiecePacket{
+					PieceInfos: []*base.PieceInfo{{PieceNum: 1}},
+					TotalPiece: 1,
+				},
+			},
+			mock: func(mockHost *resource.Host, mockTask *resource.Task, mockPeer *resource.Peer,
+				hostManager resource.HostManager, taskManager resource.TaskManager, peerManager resource.PeerManager,
+				mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, mt *resource.MockTaskManagerMockRecorder, mp *resource.MockPeerManagerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{
+					URLPolicyDefaultAction: types.URLPolicyActionDeny,
+				}, true).Times(1)
+			},
+			expect: func(t *testing.T, mockTask *resource.Task, mockPeer *resource.Peer, err error) {
+				assert := assert.New(t)
+				dferr, ok := err.(*dferrors.DfError)
+				assert.True(ok)
+				assert.Equal(dferr.Code, base.Code_SchedURLDenied)
+			},
+		},
 		{
 			name: "task state is TaskStateSucceeded and peer state is PeerStateSucceeded",
 			req: &rpcscheduler.AnnounceTaskRequest{
@@ -1241,10 +1302,11 @@ func TestService_AnnounceTask(t *testing.T) {
 			},
 			mock: func(mockHost *resource.Host, mockTask *resource.Task, mockPeer *resource.Peer,
 				hostManager resource.HostManager, taskManager resource.TaskManager, peerManager resource.PeerManager,
-				mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, mt *resource.MockTaskManagerMockRecorder, mp *resource.MockPeerManagerMockRecorder) {
+				mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, mt *resource.MockTaskManagerMockRecorder, mp *resource.MockPeerManagerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder) {
 				mockTask.FSM.SetState(resource.TaskStateSucceeded)
 				mockPeer.FSM.SetState(resource.PeerStateSucceeded)
 
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{}, false).Times(1)
 				gomock.InOrder(
 					mr.TaskManager().Return(taskManager).Times(1),
 					mt.LoadOrStore(gomock.Any()).Return(mockTask, true).Times(1),
@@ -1276,10 +1338,11 @@ func TestService_AnnounceTask(t *testing.T) {
 			},
 			mock: func(mockHost *resource.Host, mockTask *resource.Task, mockPeer *resource.Peer,
 				hostManager resource.HostManager, taskManager resource.TaskManager, peerManager resource.PeerManager,
-				mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, mt *resource.MockTaskManagerMockRecorder, mp *resource.MockPeerManagerMockRecorder) {
+				mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, mt *resource.MockTaskManagerMockRecorder, mp *resource.MockPeerManagerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder) {
 				mockTask.FSM.SetState(resource.TaskStatePending)
 				mockPeer.FSM.SetState(resource.PeerStateSucceeded)
 
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{}, false).Times(1)
 				gomock.InOrder(
 					mr.TaskManager().Return(taskManager).Times(1),
 					mt.LoadOrStore(gomock.Any()).Return(mockTask, true).Times(1),
@@ -1319,10 +1382,11 @@ func TestService_AnnounceTask(t *testing.T) {
 			},
 			mock: func(mockHost *resource.Host, mockTask *resource.Task, mockPeer *resource.Peer,
 				hostManager resource.HostManager, taskManager resource.TaskManager, peerManager resource.PeerManager,
-				mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, mt *resource.MockTaskManagerMockRecorder, mp *resource.MockPeerManagerMockRecorder) {
+				mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, mt *resource.MockTaskManagerMockRecorder, mp *resource.MockPeerManagerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder) {
 				mockTask.FSM.SetState(resource.TaskStateFailed)
 				mockPeer.FSM.SetState(resource.PeerStateSucceeded)
 
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{}, false).Times(1)
 				gomock.InOrder(
 					mr.TaskManager().Return(taskManager).Times(1),
 					mt.LoadOrStore(gomock.Any()).Return(mockTask, true).Times(1),
@@ -1362,10 +1426,11 @@ func TestService_AnnounceTask(t *testing.T) {
 			},
 			mock: func(mockHost *resource.Host, mockTask *resource.Task, mockPeer *resource.Peer,
 				hostManager resource.HostManager, taskManager resource.TaskManager, peerManager resource.PeerManager,
-				mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, mt *resource.MockTaskManagerMockRecorder, mp *resource.MockPeerManagerMockRecorder) {
+				mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, mt *resource.MockTaskManagerMockRecorder, mp *resource.MockPeerManagerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder) {
 				mockTask.FSM.SetState(resource.TaskStatePending)
 				mockPeer.FSM.SetState(resource.PeerStatePending)
 
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{}, false).Times(1)
 				gomock.InOrder(
 					mr.TaskManager().Return(taskManager).Times(1),
 					mt.LoadOrStore(gomock.Any()).Return(mockTask, true).Times(1),
@@ -1405,10 +1470,11 @@ func TestService_AnnounceTask(t *testing.T) {
 			},
 			mock: func(mockHost *resource.Host, mockTask *resource.Task, mockPeer *resource.Peer,
 				hostManager resource.HostManager, taskManager resource.TaskManager, peerManager resource.PeerManager,
-				mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, mt *resource.MockTaskManagerMockRecorder, mp *resource.MockPeerManagerMockRecorder) {
+				mr *resource.MockResourceMockRecorder, mh *resource.MockHostManagerMockRecorder, mt *resource.MockTaskManagerMockRecorder, mp *resource.MockPeerManagerMockRecorder, md *configmocks.MockDynconfigInterfaceMockRecorder) {
 				mockTask.FSM.SetState(resource.TaskStatePending)
 				mockPeer.FSM.SetState(resource.PeerStateReceivedNormal)
 
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{}, false).Times(1)
 				gomock.InOrder(
 					mr.TaskManager().Return(taskManager).Times(1),
 					mt.LoadOrStore(gomock.Any()).Return(mockTask, true).Times(1),
@@ -1451,7 +1517,7 @@ func TestService_AnnounceTask(t *testing.T) {
 			mockTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta, resource.WithBackToSourceLimit(mockTaskBackToSourceLimit))
 			mockPeer := resource.NewPeer(mockPeerID, mockTask, mockHost)
 
-			tc.mock(mockHost, mockTask, mockPeer, hostManager, taskManager, peerManager, res.EXPECT(), hostManager.EXPECT(), taskManager.EXPECT(), peerManager.EXPECT())
+			tc.mock(mockHost, mockTask, mockPeer, hostManager, taskManager, peerManager, res.EXPECT(), hostManager.EXPECT(), taskManager.EXPECT(), peerManager.EXPECT(), dynconfig.EXPECT())
 			tc.expect(t, mockTask, mockPeer, svc.AnnounceTask(context.Background(), tc.req))
 		})
 	}
@@ -1836,7 +1902,7 @@ func TestService_registerTask(t *testing.T) {
 					mr.HostManager().Return(hostManager).Times(1),
 					mh.Load(gomock.Any()).Return(nil, false).Times(1),
 					mr.SeedPeer().Do(func() { wg.Done() }).Return(seedPeer).Times(1),
-					mc.TriggerTask(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, task *resource.Task) { wg.Done() }).Return(mockPeer, &rpcscheduler.PeerResult{}, nil).Times(1),
+					mc.TriggerTask(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(ctx context.Context, task *resource.Task, idc string) { wg.Done() }).Return(mockPeer, &rpcscheduler.PeerResult{}, nil).Times(1),
 				)
 
 				task, needBackToSource, err := svc.registerTask(context.Background(), req)
@@ -1873,7 +1939,7 @@ func TestService_registerTask(t *testing.T) {
 					mr.HostManager().Return(hostManager).Times(1),
 					mh.Load(gomock.Any()).Return(nil, false),
 					mr.SeedPeer().Do(func() { wg.Done() }).Return(seedPeer).Times(1),
-					mc.TriggerTask(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, task *resource.Task) { wg.Done() }).Return(mockPeer, &rpcscheduler.PeerResult{}, nil).Times(1),
+					mc.TriggerTask(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(ctx context.Context, task *resource.Task, idc string) { wg.Done() }).Return(mockPeer, &rpcscheduler.PeerResult{}, nil).Times(1),
 				)
 
 				task, needBackToSource, err := svc.registerTask(context.Background(), req)
@@ -1942,7 +2008,7 @@ func TestService_registerTask(t *testing.T) {
 					mr.HostManager().Return(hostManager).Times(1),
 					mh.Load(gomock.Any()).Return(nil, false).Times(1),
 					mr.SeedPeer().Do(func() { wg.Done() }).Return(seedPeer).Times(1),
-					mc.TriggerTask(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, task *resource.Task) { wg.Done() }).Return(mockPeer, &rpcscheduler.PeerResult{}, errors.New("foo")).Times(1),
+					mc.TriggerTask(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(ctx context.Context, task *resource.Task, idc string) { wg.Done() }).Return(mockPeer, &rpcscheduler.PeerResult{}, errors.New("foo")).Times(1),
 				)
 
 				task, needBackToSource, err := svc.registerTask(context.Background(), req)
@@ -1979,7 +2045,7 @@ func TestService_registerTask(t *testing.T) {
 					mr.HostManager().Return(hostManager).Times(1),
 					mh.Load(gomock.Any()).Return(nil, false).Times(1),
 					mr.SeedPeer().Do(func() { wg.Done() }).Return(seedPeer).Times(1),
-					mc.TriggerTask(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, task *resource.Task) { wg.Done() }).Return(mockPeer, &rpcscheduler.PeerResult{}, errors.New("foo")).Times(1),
+					mc.TriggerTask(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(ctx context.Context, task *resource.Task, idc string) { wg.Done() }).Return(mockPeer, &rpcscheduler.PeerResult{}, errors.New("foo")).Times(1),
 				)
 
 				task, needBackToSource, err := svc.registerTask(context.Background(), req)
@@ -2176,7 +2242,7 @@ func TestService_triggerSeedPeerTask(t *testing.T) {
 				peer.FSM.SetState(resource.PeerStateRunning)
 				gomock.InOrder(
 					mr.SeedPeer().Return(seedPeer).Times(1),
-					mc.TriggerTask(gomock.Any(), gomock.Any()).Return(peer, &rpcscheduler.PeerResult{
+					mc.TriggerTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(peer, &rpcscheduler.PeerResult{
 						TotalPieceCount: 3,
 						ContentLength:   1024,
 					}, nil).Times(1),
@@ -2196,7 +2262,7 @@ func TestService_triggerSeedPeerTask(t *testing.T) {
 				task.FSM.SetState(resource.TaskStateRunning)
 				gomock.InOrder(
 					mr.SeedPeer().Return(seedPeer).Times(1),
-					mc.TriggerTask(gomock.Any(), gomock.Any()).Return(peer, &rpcscheduler.PeerResult{}, errors.New("foo")).Times(1),
+					mc.TriggerTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(peer, &rpcscheduler.PeerResult{}, errors.New("foo")).Times(1),
 				)
 			},
 			expect: func(t *testing.T, task *resource.Task, peer *resource.Peer) {
@@ -2221,7 +2287,7 @@ func TestService_triggerSeedPeerTask(t *testing.T) {
 			svc := New(&config.Config{Scheduler: mockSchedulerConfig}, res, scheduler, dynconfig, storage)
 
 			tc.mock(task, peer, seedPeer, res.EXPECT(), seedPeer.EXPECT())
-			svc.triggerSeedPeerTask(context.Background(), task)
+			svc.triggerSeedPeerTask(context.Background(), task, mockRawHost.Idc)
 			tc.expect(t, task, peer)
 		})
 	}
@@ -2230,12 +2296,12 @@ func TestService_triggerSeedPeerTask(t *testing.T) {
 func TestService_handleBeginOfPiece(t *testing.T) {
 	tests := []struct {
 		name   string
-		mock   func(peer *resource.Peer, scheduler *mocks.MockSchedulerMockRecorder)
+		mock   func(peer *resource.Peer, parent *resource.Peer, peerManager resource.PeerManager, scheduler *mocks.MockSchedulerMockRecorder, mr *resource.MockResourceMockRecorder, mp *resource.MockPeerManagerMockRecorder)
 		expect func(t *testing.T, peer *resource.Peer)
 	}{
 		{
 			name: "peer state is PeerStateBackToSource",
-			mock: func(peer *resource.Peer, scheduler *mocks.MockSchedulerMockRecorder) {
+			mock: func(peer *resource.Peer, parent *resource.Peer, peerManager resource.PeerManager, scheduler *mocks.MockSchedulerMockRecorder, mr *resource.MockResourceMockRecorder, mp *resource.MockPeerManagerMockRecorder) {
 				peer.FSM.SetState(resource.PeerStateBackToSource)
 			},
 			expect: func(t *testing.T, peer *resource.Peer) {
@@ -2245,7 +2311,7 @@ func TestService_handleBeginOfPiece(t *testing.T) {
 		},
 		{
 			name: "peer state is PeerStateReceivedTiny",
-			mock: func(peer *resource.Peer, scheduler *mocks.MockSchedulerMockRecorder) {
+			mock: func(peer *resource.Peer, parent *resource.Peer, peerManager resource.PeerManager, scheduler *mocks.MockSchedulerMockRecorder, mr *resource.MockResourceMockRecorder, mp *resource.MockPeerManagerMockRecorder) {
 				peer.FSM.SetState(resource.PeerStateReceivedTiny)
 			},
 			expect: func(t *testing.T, peer *resource.Peer) {
@@ -2255,7 +2321,7 @@ func TestService_handleBeginOfPiece(t *testing.T) {
 		},
 		{
 			name: "peer state is PeerStateReceivedSmall",
-			mock: func(peer *resource.Peer, scheduler *mocks.MockSchedulerMockRecorder) {
+			mock: func(peer *resource.Peer, parent *resource.Peer, peerManager resource.PeerManager, scheduler *mocks.MockSchedulerMockRecorder, mr *resource.MockResourceMockRecorder, mp *resource.MockPeerManagerMockRecorder) {
 				peer.FSM.SetState(resource.PeerStateReceivedSmall)
 			},
 			expect: func(t *testing.T, peer *resource.Peer) {
@@ -2265,7 +2331,7 @@ func TestService_handleBeginOfPiece(t *testing.T) {
 		},
 		{
 			name: "peer state is PeerStateReceivedNormal",
-			mock: func(peer *resource.Peer, scheduler *mocks.MockSchedulerMockRecorder) {
+			mock: func(peer *resource.Peer, parent *resource.Peer, peerManager resource.PeerManager, scheduler *mocks.MockSchedulerMockRecorder, mr *resource.MockResourceMockRecorder, mp *resource.MockPeerManagerMockRecorder) {
 				peer.FSM.SetState(resource.PeerStateReceivedNormal)
 				scheduler.ScheduleParent(gomock.Any(), gomock.Eq(peer), gomock.Eq(set.NewSafeSet())).Return().Times(1)
 			},
@@ -2274,9 +2340,74 @@ func TestService_handleBeginOfPiece(t *testing.T) {
 				assert.True(peer.FSM.Is(resource.PeerStateRunning))
 			},
 		},
+		{
+			name: "peer state is PeerStateRunning and parent is available",
+			mock: func(peer *resource.Peer, parent *resource.Peer, peerManager resource.PeerManager, scheduler *mocks.MockSchedulerMockRecorder, mr *resource.MockResourceMockRecorder, mp *resource.MockPeerManagerMockRecorder) {
+				peer.FSM.SetState(resource.PeerStateRunning)
+				peer.StoreParent(parent)
+				gomock.InOrder(
+					mr.PeerManager().Return(peerManager).Times(1),
+					mp.Load(gomock.Eq(parent.ID)).Return(parent, true).Times(1),
+				)
+			},
+			expect: func(t *testing.T, peer *resource.Peer) {
+				assert := assert.New(t)
+				parent, ok := peer.LoadParent()
+				assert.True(ok)
+				assert.Equal(parent.ID, mockSeedPeerID)
+				assert.True(peer.FSM.Is(resource.PeerStateRunning))
+			},
+		},
+		{
+			name: "peer state is PeerStateRunning and parent can not be found",
+			mock: func(peer *resource.Peer, parent *resource.Peer, peerManager resource.PeerManager, scheduler *mocks.MockSchedulerMockRecorder, mr *resource.MockResourceMockRecorder, mp *resource.MockPeerManagerMockRecorder) {
+				peer.FSM.SetState(resource.PeerStateRunning)
+				peer.StoreParent(parent)
+				gomock.InOrder(
+					mr.PeerManager().Return(peerManager).Times(1),
+					mp.Load(gomock.Eq(parent.ID)).Return(nil, false).Times(1),
+					scheduler.ScheduleParent(gomock.Any(), gomock.Eq(peer), gomock.Eq(set.NewSafeSet())).Return().Times(1),
+				)
+			},
+			expect: func(t *testing.T, peer *resource.Peer) {
+				assert := assert.New(t)
+				_, ok := peer.LoadParent()
+				assert.False(ok)
+				assert.True(peer.FSM.Is(resource.PeerStateRunning))
+			},
+		},
+		{
+			name: "peer state is PeerStateRunning and parent is failed",
+			mock: func(peer *resource.Peer, parent *resource.Peer, peerManager resource.PeerManager, scheduler *mocks.MockSchedulerMockRecorder, mr *resource.MockResourceMockRecorder, mp *resource.MockPeerManagerMockRecorder) {
+				peer.FSM.SetState(resource.PeerStateRunning)
+				peer.StoreParent(parent)
+				parent.FSM.SetState(resource.PeerStateFailed)
+				gomock.InOrder(
+					mr.PeerManager().Return(peerManager).Times(1),
+					mp.Load(gomock.Eq(parent.ID)).Return(parent, true).Times(1),
+					scheduler.ScheduleParent(gomock.Any(), gomock.Eq(peer), gomock.Eq(set.NewSafeSet())).Return().Times(1),
+				)
+			},
+			expect: func(t *testing.T, peer *resource.Peer) {
+				assert := assert.New(t)
+				_, ok := peer.LoadParent()
+				assert.False(ok)
+			},
+		},
+		{
+			name: "peer state is PeerStateRunning without parent",
+			mock: func(peer *resource.Peer, parent *resource.Peer, peerManager resource.PeerManager, scheduler *mocks.MockSchedulerMockRecorder, mr *resource.MockResourceMockRecorder, mp *resource.MockPeerManagerMockRecorder) {
+				peer.FSM.SetState(resource.PeerStateRunning)
+				scheduler.ScheduleParent(gomock.Any(), gomock.Eq(peer), gomock.Eq(set.NewSafeSet())).Return().Times(1)
+			},
+			expect: func(t *testing.T, peer *resource.Peer) {
+				assert := assert.New(t)
+				assert.True(peer.FSM.Is(resource.PeerStateRunning))
+			},
+		},
 		{
 			name: "peer state is PeerStateSucceeded",
-			mock: func(peer *resource.Peer, scheduler *mocks.MockSchedulerMockRecorder) {
+			mock: func(peer *resource.Peer, parent *resource.Peer, peerManager resource.PeerManager, scheduler *mocks.MockSchedulerMockRecorder, mr *resource.MockResourceMockRecorder, mp *resource.MockPeerManagerMockRecorder) {
 				peer.FSM.SetState(resource.PeerStateSucceeded)
 			},
 			expect: func(t *testing.T, peer *resource.Peer) {
@@ -2294,18 +2425,90 @@ func TestService_handleBeginOfPiece(t *testing.T) {
 			res := resource.NewMockResource(ctl)
 			dynconfig := configmocks.NewMockDynconfigInterface(ctl)
 			storage := storagemocks.NewMockStorage(ctl)
+			peerManager := resource.NewMockPeerManager(ctl)
 			mockHost := resource.NewHost(mockRawHost)
 			mockTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta, resource.WithBackToSourceLimit(mockTaskBackToSourceLimit))
 			peer := resource.NewPeer(mockPeerID, mockTask, mockHost)
+			parent := resource.NewPeer(mockSeedPeerID, mockTask, resource.NewHost(mockRawSeedHost))
 			svc := New(&config.Config{Scheduler: mockSchedulerConfig}, res, scheduler, dynconfig, storage)
 
-			tc.mock(peer, scheduler.EXPECT())
+			tc.mock(peer, parent, peerManager, scheduler.EXPECT(), res.EXPECT(), peerManager.EXPECT())
 			svc.handleBeginOfPiece(context.Background(), peer)
 			tc.expect(t, peer)
 		})
 	}
 }
 
+func TestService_handleResumeOfPiece(t *testing.T) {
+	tests := []struct {
+		name   string
+		piece  *rpcscheduler.PieceResult
+		mock   func(peer *resource.Peer, parent *resource.Peer, peerManager resource.PeerManager, scheduler *mocks.MockSchedulerMockRecorder, mr *resource.MockResourceMockRecorder, mp *resource.MockPeerManagerMockRecorder)
+		expect func(t *testing.T, peer *resource.Peer)
+	}{
+		{
+			name: "peer state is PeerStateRunning and keeps parent",
+			piece: &rpcscheduler.PieceResult{
+				FinishedPieces: []byte{0x80, 0x01},
+			},
+			mock: func(peer *resource.Peer, parent *resource.Peer, peerManager resource.PeerManager, scheduler *mocks.MockSchedulerMockRecorder, mr *resource.MockResourceMockRecorder, mp *resource.MockPeerManagerMockRecorder) {
+				peer.FSM.SetState(resource.PeerStateRunning)
+				peer.StoreParent(parent)
+				peer.Pieces.Set(1)
+				gomock.InOrder(
+					mr.PeerManager().Return(peerManager).Times(1),
+					mp.Load(gomock.Eq(parent.ID)).Return(parent, true).Times(1),
+				)
+			},
+			expect: func(t *testing.T, peer *resource.Peer) {
+				assert := assert.New(t)
+				parent, ok := peer.LoadParent()
+				assert.True(ok)
+				assert.Equal(parent.ID, mockSeedPeerID)
+				assert.True(peer.FSM.Is(resource.PeerStateRunning))
+				assert.Equal(peer.Pieces.Count(), uint(3))
+				assert.True(peer.Pieces.Test(0))
+				assert.True(peer.Pieces.Test(1))
+				assert.True(peer.Pieces.Test(15))
+			},
+		},
+		{
+			name:  "peer state is PeerStateReceivedNormal without finished pieces",
+			piece: &rpcscheduler.PieceResult{},
+			mock: func(peer *resource.Peer, parent *resource.Peer, peerManager resource.PeerManager, scheduler *mocks.MockSchedulerMockRecorder, mr *resource.MockResourceMockRecorder, mp *resource.MockPeerManagerMockRecorder) {
+				peer.FSM.SetState(resource.PeerStateReceivedNormal)
+				scheduler.ScheduleParent(gomock.Any(), gomock.Eq(peer), gomock.Eq(set.NewSafeSet())).Return().Times(1)
+			},
+			expect: func(t *testing.T, peer *resource.Peer) {
+				assert := assert.New(t)
+				assert.True(peer.FSM.Is(resource.PeerStateRunning))
+				assert.Equal(peer.Pieces.Count(), uint(0))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			scheduler := mocks.NewMockScheduler(ctl)
+			res := resource.NewMockResource(ctl)
+			dynconfig := configmocks.NewMockDynconfigInterface(ctl)
+			storage := storagemocks.NewMockStorage(ctl)
+			peerManager := resource.NewMockPeerManager(ctl)
+			mockHost := resource.NewHost(mockRawHost)
+			mockTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta, resource.WithBackToSourceLimit(mockTaskBackToSourceLimit))
+			peer := resource.NewPeer(mockPeerID, mockTask, mockHost)
+			parent := resource.NewPeer(mockSeedPeerID, mockTask, resource.NewHost(mockRawSeedHost))
+			svc := New(&config.Config{Scheduler: mockSchedulerConfig}, res, scheduler, dynconfig, storage)
+
+			tc.mock(peer, parent, peerManager, scheduler.EXPECT(), res.EXPECT(), peerManager.EXPECT())
+			svc.handleResumeOfPiece(context.Background(), peer, tc.piece)
+			tc.expect(t, peer)
+		})
+	}
+}
+
 func TestService_registerPeer(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -2349,6 +2552,30 @@ func TestService_registerPeer(t *testing.T) {
 				assert.Equal(peer.BizTag, resource.DefaultBizTag)
 			},
 		},
+		{
+			name: "peer does not exists with priority and tag",
+			req: &rpcscheduler.PeerTaskRequest{
+				PeerId: mockPeerID,
+				UrlMeta: &base.UrlMeta{
+					Tag: "foo",
+				},
+				Priority: base.Priority_URGENT_PRIORITY,
+			},
+			mock: func(mockPeer *resource.Peer, peerManager resource.PeerManager, mr *resource.MockResourceMockRecorder, mp *resource.MockPeerManagerMockRecorder) {
+				gomock.InOrder(
+					mr.PeerManager().Return(peerManager).Times(1),
+					mp.LoadOrStore(gomock.Any()).DoAndReturn(func(peer *resource.Peer) (*resource.Peer, bool) {
+						return peer, false
+					}).Times(1),
+				)
+			},
+			expect: func(t *testing.T, peer *resource.Peer) {
+				assert := assert.New(t)
+				assert.Equal(peer.ID, mockPeerID)
+				assert.Equal(peer.BizTag, "foo")
+				assert.Equal(peer.Priority, base.Priority_URGENT_PRIORITY)
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -2366,7 +2593,7 @@ func TestService_registerPeer(t *testing.T) {
 			mockPeer := resource.NewPeer(mockPeerID, mockTask, mockHost)
 
 			tc.mock(mockPeer, peerManager, res.EXPECT(), peerManager.EXPECT())
-			peer := svc.registerPeer(context.Background(), tc.req.PeerId, mockTask, mockHost, tc.req.UrlMeta.Tag)
+			peer := svc.registerPeer(context.Background(), tc.req.PeerId, mockTask, mockHost, tc.req.UrlMeta.Tag, tc.req.Priority)
 			tc.expect(t, peer)
 		})
 	}
@@ -2430,6 +2657,31 @@ func TestService_handlePieceSuccess(t *testing.T) {
 				})
 			},
 		},
+		{
+			name: "piece is downloaded from parent",
+			piece: &rpcscheduler.PieceResult{
+				DstPid: mockSeedPeerID,
+				PieceInfo: &base.PieceInfo{
+					PieceNum: 0,
+					PieceMd5: "ac32345ef819f03710e2105c81106fdd",
+				},
+				BeginTime: uint64(now.UnixNano()),
+				EndTime:   uint64(now.Add(1 * time.Millisecond).UnixNano()),
+			},
+			peer: resource.NewPeer(mockPeerID, mockTask, mockHost),
+			mock: func(peer *resource.Peer) {
+				peer.FSM.SetState(resource.PeerStateRunning)
+			},
+			expect: func(t *testing.T, peer *resource.Peer) {
+				assert := assert.New(t)
+				assert.Equal(peer.Pieces.Count(), uint(1))
+				assert.Equal(peer.PieceCosts(), []int64{1})
+				assert.Equal(peer.ParentPieceCosts(mockSeedPeerID), []int64{1})
+				count, mean, _ := peer.Task.PieceCostStatistics()
+				assert.Equal(count, int64(1))
+				assert.Equal(mean, float64(1))
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -2449,6 +2701,136 @@ func TestService_handlePieceSuccess(t *testing.T) {
 	}
 }
 
+func TestService_handleSlowParent(t *testing.T) {
+	tests := []struct {
+		name   string
+		mock   func(peer *resource.Peer, parent *resource.Peer, ms *mocks.MockSchedulerMockRecorder)
+		expect func(t *testing.T, peer *resource.Peer, parent *resource.Peer)
+	}{
+		{
+			name: "peer state is PeerStateBackToSource",
+			mock: func(peer *resource.Peer, parent *resource.Peer, ms *mocks.MockSchedulerMockRecorder) {
+				peer.FSM.SetState(resource.PeerStateBackToSource)
+				appendSlowParentPieceCosts(peer, parent)
+			},
+			expect: func(t *testing.T, peer *resource.Peer, parent *resource.Peer) {
+				assert := assert.New(t)
+				assert.Equal(len(peer.ParentPieceCosts(parent.ID)), 5)
+			},
+		},
+		{
+			name: "parent is not the current parent of peer",
+			mock: func(peer *resource.Peer, parent *resource.Peer, ms *mocks.MockSchedulerMockRecorder) {
+				peer.FSM.SetState(resource.PeerStateRunning)
+				appendSlowParentPieceCosts(peer, parent)
+			},
+			expect: func(t *testing.T, peer *resource.Peer, parent *resource.Peer) {
+				assert := assert.New(t)
+				_, ok := peer.LoadParent()
+				assert.False(ok)
+			},
+		},
+		{
+			name: "piece costs of parent is not enough",
+			mock: func(peer *resource.Peer, parent *resource.Peer, ms *mocks.MockSchedulerMockRecorder) {
+				peer.FSM.SetState(resource.PeerStateRunning)
+				peer.StoreParent(parent)
+				appendSlowParentPieceCosts(peer, parent)
+				peer.AppendParentPieceCost(parent.ID, 1000)
+			},
+			expect: func(t *testing.T, peer *resource.Peer, parent *resource.Peer) {
+				assert := assert.New(t)
+				assert.Equal(len(peer.ParentPieceCosts(parent.ID)), 6)
+			},
+		},
+		{
+			name: "parent is not slow",
+			mock: func(peer *resource.Peer, parent *resource.Peer, ms *mocks.MockSchedulerMockRecorder) {
+				peer.FSM.SetState(resource.PeerStateRunning)
+				peer.StoreParent(parent)
+				for i := 0; i < 100; i++ {
+					peer.Task.AppendPieceCost(10)
+				}
+				for i := 0; i < 5; i++ {
+					peer.Task.AppendPieceCost(12)
+					peer.AppendParentPieceCost(parent.ID, 12)
+				}
+			},
+			expect: func(t *testing.T, peer *resource.Peer, parent *resource.Peer) {
+				assert := assert.New(t)
+				p, ok := peer.LoadParent()
+				assert.True(ok)
+				assert.Equal(p.ID, parent.ID)
+			},
+		},
+		{
+			name: "parent is slow",
+			mock: func(peer *resource.Peer, parent *resource.Peer, ms *mocks.MockSchedulerMockRecorder) {
+				peer.FSM.SetState(resource.PeerStateRunning)
+				peer.StoreParent(parent)
+				peer.BlockPeers.Add("foo")
+				appendSlowParentPieceCosts(peer, parent)
+				ms.NotifyAndFindParent(gomock.Any(), gomock.Eq(peer), gomock.Any()).Do(func(ctx context.Context, peer *resource.Peer, blocklist set.SafeSet) {
+					assert := assert.New(t)
+					assert.True(blocklist.Contains(parent.ID))
+					assert.True(blocklist.Contains("foo"))
+				}).Return([]*resource.Peer{}, true).Times(1)
+			},
+			expect: func(t *testing.T, peer *resource.Peer, parent *resource.Peer) {
+				assert := assert.New(t)
+				assert.False(peer.BlockPeers.Contains(parent.ID))
+			},
+		},
+		{
+			name: "parent is slow and can not find other parents",
+			mock: func(peer *resource.Peer, parent *resource.Peer, ms *mocks.MockSchedulerMockRecorder) {
+				peer.FSM.SetState(resource.PeerStateRunning)
+				peer.StoreParent(parent)
+				appendSlowParentPieceCosts(peer, parent)
+				ms.NotifyAndFindParent(gomock.Any(), gomock.Eq(peer), gomock.Any()).Return([]*resource.Peer{}, false).Times(1)
+			},
+			expect: func(t *testing.T, peer *resource.Peer, parent *resource.Peer) {
+				assert := assert.New(t)
+				p, ok := peer.LoadParent()
+				assert.True(ok)
+				assert.Equal(p.ID, parent.ID)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			scheduler := mocks.NewMockScheduler(ctl)
+			res := resource.NewMockResource(ctl)
+			dynconfig := configmocks.NewMockDynconfigInterface(ctl)
+			storage := storagemocks.NewMockStorage(ctl)
+			svc := New(&config.Config{Scheduler: mockSchedulerConfig, Metrics: &config.MetricsConfig{EnablePeerHost: true}}, res, scheduler, dynconfig, storage)
+			mockHost := resource.NewHost(mockRawHost)
+			mockTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta, resource.WithBackToSourceLimit(mockTaskBackToSourceLimit))
+			peer := resource.NewPeer(mockPeerID, mockTask, mockHost)
+			parent := resource.NewPeer(mockSeedPeerID, mockTask, mockHost)
+
+			tc.mock(peer, parent, scheduler.EXPECT())
+			svc.handleSlowParent(context.Background(), peer, parent.ID)
+			tc.expect(t, peer, parent)
+		})
+	}
+}
+
+// appendSlowParentPieceCosts makes the pieces downloaded from parent much slower than the pieces of task.
+func appendSlowParentPieceCosts(peer *resource.Peer, parent *resource.Peer) {
+	for i := 0; i < 100; i++ {
+		peer.Task.AppendPieceCost(10)
+	}
+
+	for i := 0; i < slowParentCostLen; i++ {
+		peer.Task.AppendPieceCost(1000)
+		peer.AppendParentPieceCost(parent.ID, 1000)
+	}
+}
+
 func TestService_handlePieceFail(t *testing.T) {
 	mockHost := resource.NewHost(mockRawHost)
 	mockTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta, resource.WithBackToSourceLimit(mockTaskBackToSourceLimit))
@@ -3003,3 +3385,77 @@ func TestService_handleTaskFail(t *testing.T) {
 		})
 	}
 }
+
+func TestService_createRecord(t *testing.T) {
+	mockHost := resource.NewHost(mockRawHost)
+	mockSeedHost := resource.NewHost(mockRawSeedHost, resource.WithHostType(resource.HostTypeSuperSeed))
+	mockTask := resource.NewTask(mockTaskID, mockTaskURL, resource.TaskTypeNormal, mockTaskURLMeta, resource.WithBackToSourceLimit(mockTaskBackToSourceLimit))
+
+	tests := []struct {
+		name   string
+		peer   *resource.Peer
+		mock   func(peer *resource.Peer)
+		expect func(t *testing.T, record storage.Record)
+	}{
+		{
+			name: "peer has no parent and piece costs",
+			peer: resource.NewPeer(mockPeerID, mockTask, mockHost),
+			mock: func(peer *resource.Peer) {
+				peer.IsBackToSource.Store(true)
+			},
+			expect: func(t *testing.T, record storage.Record) {
+				assert := assert.New(t)
+				assert.Equal(record.ID, mockPeerID)
+				assert.Equal(record.TaskID, mockTaskID)
+				assert.Equal(record.HostID, mockHost.ID)
+				assert.True(record.BackToSource)
+				assert.Equal(record.ParentID, "")
+				assert.Equal(record.ParentHostID, "")
+				assert.Equal(record.PieceCostMax, int64(0))
+			},
+		},
+		{
+			name: "peer has parent and piece costs",
+			peer: resource.NewPeer(mockPeerID, mockTask, mockHost),
+			mock: func(peer *resource.Peer) {
+				peer.StoreParent(resource.NewPeer(mockSeedPeerID, mockTask, mockSeedHost))
+				for i := 1; i <= 10; i++ {
+					peer.AppendPieceCost(int64(i))
+				}
+			},
+			expect: func(t *testing.T, record storage.Record) {
+				assert := assert.New(t)
+				assert.False(record.BackToSource)
+				assert.Equal(record.ParentID, mockSeedPeerID)
+				assert.Equal(record.ParentHostID, mockSeedHost.ID)
+				assert.Equal(record.ParentHostType, int(resource.HostTypeSuperSeed))
+				assert.Equal(record.PieceCostAvg, int64(5))
+				assert.Equal(record.PieceCostP50, int64(5))
+				assert.Equal(record.PieceCostP90, int64(9))
+				assert.Equal(record.PieceCostMax, int64(10))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			scheduler := mocks.NewMockScheduler(ctl)
+			res := resource.NewMockResource(ctl)
+			dynconfig := configmocks.NewMockDynconfigInterface(ctl)
+			mockStorage := storagemocks.NewMockStorage(ctl)
+			svc := New(&config.Config{Scheduler: mockSchedulerConfig, Metrics: &config.MetricsConfig{EnablePeerHost: true}}, res, scheduler, dynconfig, mockStorage)
+
+			var record storage.Record
+			mockStorage.EXPECT().Create(gomock.Any()).DoAndReturn(func(r storage.Record) error {
+				record = r
+				return nil
+			}).Times(1)
+
+			tc.mock(tc.peer)
+			svc.createRecord(tc.peer, storage.PeerStateSucceeded, &rpcscheduler.PeerResult{})
+			tc.expect(t, record)
+		})
+	}
+}
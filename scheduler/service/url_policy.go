@@ -0,0 +1,241 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"d7y.io/dragonfly/v2/internal/dferrors"
+	logger "d7y.io/dragonfly/v2/internal/dflog"
+	"d7y.io/dragonfly/v2/manager/types"
+	"d7y.io/dragonfly/v2/pkg/cache"
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
+)
+
+const (
+	// resolvedHostExpiration is the expiration of resolved ips of url host.
+	resolvedHostExpiration = time.Minute
+
+	// resolvedHostCleanupInterval is the interval of cleaning up the expired resolved ips.
+	resolvedHostCleanupInterval = 5 * time.Minute
+)
+
+// urlPolicy is the compiled url policy.
+type urlPolicy struct {
+	*types.URLPolicy
+
+	// regexp is the compiled pattern of regex policy.
+	regexp *regexp.Regexp
+
+	// ipNet is the parsed pattern of cidr policy.
+	ipNet *net.IPNet
+}
+
+// newURLPolicy compiles the url policy, the policy is validated by manager
+// when it is written, and validated again in case of the config written before.
+func newURLPolicy(policy *types.URLPolicy) (*urlPolicy, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &urlPolicy{URLPolicy: policy}
+	switch policy.Type {
+	case types.URLPolicyTypeRegex:
+		r, err := regexp.Compile(policy.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		p.regexp = r
+	case types.URLPolicyTypeCIDR:
+		_, ipNet, err := net.ParseCIDR(policy.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		p.ipNet = ipNet
+	}
+
+	return p, nil
+}
+
+// match returns whether the url matches the policy, ips are the resolved ips of url host.
+func (p *urlPolicy) match(rawURL string, u *url.URL, ips []net.IP) bool {
+	switch p.Type {
+	case types.URLPolicyTypeRegex:
+		return p.regexp.MatchString(rawURL)
+	case types.URLPolicyTypeHost:
+		return matchHost(p.Pattern, u.Hostname())
+	case types.URLPolicyTypeCIDR:
+		// The host failed to resolve may be in the denied networks,
+		// so it matches deny policies rather than passing them.
+		if len(ips) == 0 {
+			return p.Action == types.URLPolicyActionDeny
+		}
+
+		for _, ip := range ips {
+			if p.ipNet.Contains(ip) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// matchHost returns whether the host matches the pattern,
+// pattern like *.example.com matches all subdomains of example.com.
+func matchHost(pattern, host string) bool {
+	pattern = strings.ToLower(pattern)
+	host = strings.ToLower(host)
+	if strings.HasPrefix(pattern, "*.") {
+		return strings.HasSuffix(host, pattern[1:])
+	}
+
+	return host == pattern
+}
+
+// urlPolicies caches the compiled url policies and the resolved ips of url hosts,
+// policies are compiled again when they are changed in the config of scheduler cluster.
+type urlPolicies struct {
+	mu       sync.Mutex
+	raw      []*types.URLPolicy
+	policies []*urlPolicy
+	hosts    cache.Cache
+}
+
+// newURLPolicies returns the cache of url policies.
+func newURLPolicies() *urlPolicies {
+	return &urlPolicies{
+		hosts: cache.New(resolvedHostExpiration, resolvedHostCleanupInterval),
+	}
+}
+
+// load returns the compiled url policies, the invalid policies are skipped.
+func (p *urlPolicies) load(raw []*types.URLPolicy) []*urlPolicy {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if reflect.DeepEqual(p.raw, raw) {
+		return p.policies
+	}
+
+	policies := make([]*urlPolicy, 0, len(raw))
+	for _, r := range raw {
+		policy, err := newURLPolicy(r)
+		if err != nil {
+			logger.Errorf("invalid url policy %#v: %s", r, err.Error())
+			continue
+		}
+
+		policies = append(policies, policy)
+	}
+
+	p.raw = raw
+	p.policies = policies
+	return policies
+}
+
+// CheckURLPolicy checks the url with url policies of scheduler cluster,
+// the first matched policy takes effect and the default action is used when no policy matches.
+func (s *Service) CheckURLPolicy(ctx context.Context, rawURL string) error {
+	clusterConfig, ok := s.dynconfig.GetSchedulerClusterConfig()
+	if !ok {
+		return nil
+	}
+
+	action := clusterConfig.URLPolicyDefaultAction
+	if policy, ok := s.urlPolicies.match(ctx, s.urlPolicies.load(clusterConfig.URLPolicies), rawURL); ok {
+		action = policy.Action
+	}
+
+	switch action {
+	case types.URLPolicyActionDirect:
+		return dferrors.Newf(base.Code_SchedURLDirect, "url %s must be downloaded from source directly", rawURL)
+	case types.URLPolicyActionDeny:
+		return dferrors.Newf(base.Code_SchedURLDenied, "url %s is denied", rawURL)
+	}
+
+	return nil
+}
+
+// match returns the first policy matched by url,
+// the host of url is resolved only when there is cidr policy.
+func (p *urlPolicies) match(ctx context.Context, policies []*urlPolicy, rawURL string) (*urlPolicy, bool) {
+	if len(policies) == 0 {
+		return nil, false
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		logger.Warnf("parse url %s failed: %s", rawURL, err.Error())
+		return nil, false
+	}
+
+	var (
+		ips      []net.IP
+		resolved bool
+	)
+	for _, policy := range policies {
+		if policy.Type == types.URLPolicyTypeCIDR && !resolved {
+			ips = p.resolveHost(ctx, u.Hostname())
+			resolved = true
+		}
+
+		if policy.match(rawURL, u, ips) {
+			return policy, true
+		}
+	}
+
+	return nil, false
+}
+
+// resolveHost returns the ips of host, the resolved ips are cached until they expire,
+// and the host failed to resolve is cached as well to avoid looking it up on every registration,
+// empty ips means the host is failed to resolve.
+func (p *urlPolicies) resolveHost(ctx context.Context, host string) []net.IP {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IP{ip}
+	}
+
+	if ips, ok := p.hosts.Get(host); ok {
+		return ips.([]net.IP)
+	}
+
+	var ips []net.IP
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		logger.Warnf("resolve host %s failed: %s", host, err.Error())
+
+		// The lookup is interrupted by the request, it is not the result of host.
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+
+	for _, addr := range addrs {
+		ips = append(ips, addr.IP)
+	}
+
+	p.hosts.SetDefault(host, ips)
+	return ips
+}
@@ -0,0 +1,382 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"d7y.io/dragonfly/v2/internal/dferrors"
+	"d7y.io/dragonfly/v2/manager/types"
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
+	"d7y.io/dragonfly/v2/scheduler/config"
+	configmocks "d7y.io/dragonfly/v2/scheduler/config/mocks"
+	"d7y.io/dragonfly/v2/scheduler/resource"
+	"d7y.io/dragonfly/v2/scheduler/scheduler/mocks"
+	storagemocks "d7y.io/dragonfly/v2/scheduler/storage/mocks"
+)
+
+func TestService_CheckURLPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		mock   func(md *configmocks.MockDynconfigInterfaceMockRecorder)
+		expect func(t *testing.T, err error)
+	}{
+		{
+			name: "scheduler cluster config not found",
+			url:  "http://example.com/foo",
+			mock: func(md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{}, false).Times(1)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+			},
+		},
+		{
+			name: "url policies are empty",
+			url:  "http://example.com/foo",
+			mock: func(md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{}, true).Times(1)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+			},
+		},
+		{
+			name: "url matches allow policy of host",
+			url:  "http://foo.example.com/foo",
+			mock: func(md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{
+					URLPolicies: []*types.URLPolicy{
+						{Type: types.URLPolicyTypeHost, Pattern: "*.example.com", Action: types.URLPolicyActionAllow},
+					},
+					URLPolicyDefaultAction: types.URLPolicyActionDeny,
+				}, true).Times(1)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+			},
+		},
+		{
+			name: "url matches direct policy of regex",
+			url:  "http://example.com/foo.iso",
+			mock: func(md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{
+					URLPolicies: []*types.URLPolicy{
+						{Type: types.URLPolicyTypeRegex, Pattern: `\.iso$`, Action: types.URLPolicyActionDirect},
+					},
+				}, true).Times(1)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				dferr, ok := err.(*dferrors.DfError)
+				assert.True(ok)
+				assert.Equal(dferr.Code, base.Code_SchedURLDirect)
+			},
+		},
+		{
+			name: "url matches deny policy of cidr",
+			url:  "http://10.0.0.1:8080/foo",
+			mock: func(md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{
+					URLPolicies: []*types.URLPolicy{
+						{Type: types.URLPolicyTypeCIDR, Pattern: "10.0.0.0/8", Action: types.URLPolicyActionDeny},
+					},
+				}, true).Times(1)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				dferr, ok := err.(*dferrors.DfError)
+				assert.True(ok)
+				assert.Equal(dferr.Code, base.Code_SchedURLDenied)
+			},
+		},
+		{
+			name: "host failed to resolve matches deny policy of cidr",
+			url:  "http://foo.invalid/foo",
+			mock: func(md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{
+					URLPolicies: []*types.URLPolicy{
+						{Type: types.URLPolicyTypeCIDR, Pattern: "10.0.0.0/8", Action: types.URLPolicyActionDeny},
+					},
+				}, true).Times(1)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				dferr, ok := err.(*dferrors.DfError)
+				assert.True(ok)
+				assert.Equal(dferr.Code, base.Code_SchedURLDenied)
+			},
+		},
+		{
+			name: "host failed to resolve does not match allow policy of cidr",
+			url:  "http://foo.invalid/foo",
+			mock: func(md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{
+					URLPolicies: []*types.URLPolicy{
+						{Type: types.URLPolicyTypeCIDR, Pattern: "10.0.0.0/8", Action: types.URLPolicyActionAllow},
+					},
+					URLPolicyDefaultAction: types.URLPolicyActionDirect,
+				}, true).Times(1)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				dferr, ok := err.(*dferrors.DfError)
+				assert.True(ok)
+				assert.Equal(dferr.Code, base.Code_SchedURLDirect)
+			},
+		},
+		{
+			name: "first matched policy takes effect",
+			url:  "http://10.0.0.1/foo",
+			mock: func(md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{
+					URLPolicies: []*types.URLPolicy{
+						{Type: types.URLPolicyTypeHost, Pattern: "10.0.0.1", Action: types.URLPolicyActionAllow},
+						{Type: types.URLPolicyTypeCIDR, Pattern: "10.0.0.0/8", Action: types.URLPolicyActionDeny},
+					},
+				}, true).Times(1)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+			},
+		},
+		{
+			name: "url does not match any policy and default action is deny",
+			url:  "http://example.com/foo",
+			mock: func(md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{
+					URLPolicies: []*types.URLPolicy{
+						{Type: types.URLPolicyTypeHost, Pattern: "foo.com", Action: types.URLPolicyActionAllow},
+					},
+					URLPolicyDefaultAction: types.URLPolicyActionDeny,
+				}, true).Times(1)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				dferr, ok := err.(*dferrors.DfError)
+				assert.True(ok)
+				assert.Equal(dferr.Code, base.Code_SchedURLDenied)
+			},
+		},
+		{
+			name: "invalid policy is skipped",
+			url:  "http://example.com/foo",
+			mock: func(md *configmocks.MockDynconfigInterfaceMockRecorder) {
+				md.GetSchedulerClusterConfig().Return(types.SchedulerClusterConfig{
+					URLPolicies: []*types.URLPolicy{
+						{Type: types.URLPolicyTypeRegex, Pattern: "(", Action: types.URLPolicyActionDeny},
+					},
+				}, true).Times(1)
+			},
+			expect: func(t *testing.T, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			scheduler := mocks.NewMockScheduler(ctl)
+			res := resource.NewMockResource(ctl)
+			dynconfig := configmocks.NewMockDynconfigInterface(ctl)
+			storage := storagemocks.NewMockStorage(ctl)
+			svc := New(&config.Config{Scheduler: mockSchedulerConfig}, res, scheduler, dynconfig, storage)
+
+			tc.mock(dynconfig.EXPECT())
+			tc.expect(t, svc.CheckURLPolicy(context.Background(), tc.url))
+		})
+	}
+}
+
+func TestURLPolicies_load(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    [][]*types.URLPolicy
+		expect func(t *testing.T, policies []*urlPolicy)
+	}{
+		{
+			name: "load policies",
+			raw: [][]*types.URLPolicy{
+				{
+					{Type: types.URLPolicyTypeHost, Pattern: "example.com", Action: types.URLPolicyActionAllow},
+					{Type: "foo", Pattern: "example.com", Action: types.URLPolicyActionAllow},
+					{Type: types.URLPolicyTypeCIDR, Pattern: "foo", Action: types.URLPolicyActionAllow},
+					{Type: types.URLPolicyTypeHost, Pattern: "example.com", Action: "foo"},
+				},
+			},
+			expect: func(t *testing.T, policies []*urlPolicy) {
+				assert := assert.New(t)
+				assert.Len(policies, 1)
+				assert.Equal(policies[0].Pattern, "example.com")
+			},
+		},
+		{
+			name: "policies are changed",
+			raw: [][]*types.URLPolicy{
+				{
+					{Type: types.URLPolicyTypeHost, Pattern: "example.com", Action: types.URLPolicyActionAllow},
+				},
+				{
+					{Type: types.URLPolicyTypeRegex, Pattern: "foo", Action: types.URLPolicyActionDeny},
+					{Type: types.URLPolicyTypeCIDR, Pattern: "10.0.0.0/8", Action: types.URLPolicyActionDirect},
+				},
+			},
+			expect: func(t *testing.T, policies []*urlPolicy) {
+				assert := assert.New(t)
+				assert.Len(policies, 2)
+				assert.NotNil(policies[0].regexp)
+				assert.NotNil(policies[1].ipNet)
+			},
+		},
+		{
+			name: "policies are removed",
+			raw: [][]*types.URLPolicy{
+				{
+					{Type: types.URLPolicyTypeHost, Pattern: "example.com", Action: types.URLPolicyActionAllow},
+				},
+				nil,
+			},
+			expect: func(t *testing.T, policies []*urlPolicy) {
+				assert := assert.New(t)
+				assert.Empty(policies)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newURLPolicies()
+			var policies []*urlPolicy
+			for _, raw := range tc.raw {
+				policies = p.load(raw)
+			}
+
+			tc.expect(t, policies)
+		})
+	}
+}
+
+func TestURLPolicies_resolveHost(t *testing.T) {
+	tests := []struct {
+		name   string
+		host   string
+		mock   func(p *urlPolicies)
+		expect func(t *testing.T, p *urlPolicies, ips []net.IP)
+	}{
+		{
+			name: "host is ip",
+			host: "10.0.0.1",
+			mock: func(p *urlPolicies) {},
+			expect: func(t *testing.T, p *urlPolicies, ips []net.IP) {
+				assert := assert.New(t)
+				assert.Equal(ips, []net.IP{net.ParseIP("10.0.0.1")})
+				assert.Equal(p.hosts.ItemCount(), 0)
+			},
+		},
+		{
+			name: "ips of host are cached",
+			host: "foo",
+			mock: func(p *urlPolicies) {
+				p.hosts.SetDefault("foo", []net.IP{net.ParseIP("10.0.0.2")})
+			},
+			expect: func(t *testing.T, p *urlPolicies, ips []net.IP) {
+				assert := assert.New(t)
+				assert.Equal(ips, []net.IP{net.ParseIP("10.0.0.2")})
+			},
+		},
+		{
+			name: "resolve host and cache its ips",
+			host: "localhost",
+			mock: func(p *urlPolicies) {},
+			expect: func(t *testing.T, p *urlPolicies, ips []net.IP) {
+				assert := assert.New(t)
+				assert.NotEmpty(ips)
+				cached, ok := p.hosts.Get("localhost")
+				assert.True(ok)
+				assert.Equal(cached, ips)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newURLPolicies()
+			tc.mock(p)
+			tc.expect(t, p, p.resolveHost(context.Background(), tc.host))
+		})
+	}
+}
+
+func TestMatchHost(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		host    string
+		expect  bool
+	}{
+		{
+			name:    "host is equal to pattern",
+			pattern: "example.com",
+			host:    "Example.com",
+			expect:  true,
+		},
+		{
+			name:    "host is not equal to pattern",
+			pattern: "example.com",
+			host:    "foo.example.com",
+			expect:  false,
+		},
+		{
+			name:    "host matches wildcard pattern",
+			pattern: "*.example.com",
+			host:    "foo.example.com",
+			expect:  true,
+		},
+		{
+			name:    "wildcard pattern does not match the parent domain",
+			pattern: "*.example.com",
+			host:    "example.com",
+			expect:  false,
+		},
+		{
+			name:    "wildcard pattern does not match other domain",
+			pattern: "*.example.com",
+			host:    "fooexample.com",
+			expect:  false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(matchHost(tc.pattern, tc.host), tc.expect)
+		})
+	}
+}
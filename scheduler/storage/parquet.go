@@ -0,0 +1,426 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ParquetFileExt is extension of parquet file name.
+	ParquetFileExt = "parquet"
+
+	// parquetTimeFormat is the timestamp format of parquet filename.
+	parquetTimeFormat = "2006-01-02T15-04-05.000000000"
+
+	// parquetCreatedBy is the application which writes the parquet file.
+	parquetCreatedBy = "dragonfly scheduler"
+)
+
+// parquetMagic is the magic number at the beginning and the end of parquet file.
+var parquetMagic = []byte("PAR1")
+
+// Physical types of parquet.
+const (
+	parquetTypeBoolean   = 0
+	parquetTypeInt32     = 1
+	parquetTypeInt64     = 2
+	parquetTypeByteArray = 6
+)
+
+// Enums of parquet metadata.
+const (
+	parquetRepetitionRequired = 0
+	parquetConvertedTypeUTF8  = 0
+	parquetEncodingPlain      = 0
+	parquetEncodingRLE        = 3
+	parquetCodecUncompressed  = 0
+	parquetPageTypeDataPage   = 0
+)
+
+// Types of thrift compact protocol.
+const (
+	thriftTypeI32    = 5
+	thriftTypeI64    = 6
+	thriftTypeBinary = 8
+	thriftTypeList   = 9
+	thriftTypeStruct = 12
+)
+
+// parquetColumn is the column of record in parquet file.
+type parquetColumn struct {
+	name  string
+	index int
+	typ   int32
+}
+
+// newParquetColumns returns the columns of struct type, column name is the json tag of field.
+func newParquetColumns(t reflect.Type) ([]parquetColumn, error) {
+	var columns []parquetColumn
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		column := parquetColumn{
+			name:  strings.Split(field.Tag.Get("json"), ",")[0],
+			index: i,
+		}
+
+		switch field.Type.Kind() {
+		case reflect.Bool:
+			column.typ = parquetTypeBoolean
+		case reflect.Int32:
+			column.typ = parquetTypeInt32
+		case reflect.Int, reflect.Int64, reflect.Uint32:
+			column.typ = parquetTypeInt64
+		case reflect.String:
+			column.typ = parquetTypeByteArray
+		default:
+			return nil, fmt.Errorf("unsupported parquet type %s of field %s", field.Type, field.Name)
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+// parquetSink writes records to parquet files, every batch of records is written to a new file.
+type parquetSink struct {
+	baseDir    string
+	maxBackups int
+	columns    []parquetColumn
+	mu         *sync.Mutex
+}
+
+// NewParquetSink returns a new sink which writes records to parquet files in the directory,
+// the oldest files are removed when the count of files exceeds maxBackups.
+func NewParquetSink(baseDir string, maxBackups int) (Sink, error) {
+	columns, err := newParquetColumns(reflect.TypeOf(Record{}))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(baseDir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &parquetSink{
+		baseDir:    baseDir,
+		maxBackups: maxBackups,
+		columns:    columns,
+		mu:         &sync.Mutex{},
+	}, nil
+}
+
+// Write writes the records to a new parquet file.
+func (s *parquetSink) Write(records []Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buf := &bytes.Buffer{}
+	if err := writeParquet(buf, s.columns, records); err != nil {
+		return err
+	}
+
+	// Write to temporary file first, make sure that readers never see partial file.
+	filename := filepath.Join(s.baseDir, fmt.Sprintf("%s-%s.%s", RecordFilePrefix, time.Now().Format(parquetTimeFormat), ParquetFileExt))
+	if err := ioutil.WriteFile(filename+".tmp", buf.Bytes(), 0600); err != nil {
+		return err
+	}
+
+	if err := os.Rename(filename+".tmp", filename); err != nil {
+		return err
+	}
+
+	return s.removeBackups()
+}
+
+// removeBackups removes the oldest parquet files that exceed max backups.
+func (s *parquetSink) removeBackups() error {
+	if s.maxBackups <= 0 {
+		return nil
+	}
+
+	filenames, err := filepath.Glob(filepath.Join(s.baseDir, fmt.Sprintf("%s-*.%s", RecordFilePrefix, ParquetFileExt)))
+	if err != nil {
+		return err
+	}
+
+	// Filename contains timestamp, so the sorted filenames are in chronological order.
+	sort.Strings(filenames)
+	for len(filenames) > s.maxBackups {
+		if err := os.Remove(filenames[0]); err != nil {
+			return err
+		}
+
+		filenames = filenames[1:]
+	}
+
+	return nil
+}
+
+// writeParquet writes the records to writer in parquet format,
+// the file has one row group and every column has one uncompressed data page.
+func writeParquet(w io.Writer, columns []parquetColumn, records []Record) error {
+	buf := &bytes.Buffer{}
+	buf.Write(parquetMagic)
+
+	values := make([]reflect.Value, len(records))
+	for i := range records {
+		values[i] = reflect.ValueOf(records[i])
+	}
+
+	chunks := &thriftWriter{}
+	chunks.listBegin(thriftTypeStruct, len(columns))
+	var totalByteSize int64
+	for _, column := range columns {
+		page, err := encodeParquetPlain(column, values)
+		if err != nil {
+			return err
+		}
+
+		header := &thriftWriter{}
+		header.i32(1, parquetPageTypeDataPage)
+		header.i32(2, int32(len(page)))
+		header.i32(3, int32(len(page)))
+		header.structBegin(5)
+		header.i32(1, int32(len(records)))
+		header.i32(2, parquetEncodingPlain)
+		header.i32(3, parquetEncodingRLE)
+		header.i32(4, parquetEncodingRLE)
+		header.structEnd()
+		header.stop()
+
+		offset := int64(buf.Len())
+		size := int64(header.buf.Len() + len(page))
+		buf.Write(header.buf.Bytes())
+		buf.Write(page)
+		totalByteSize += size
+
+		// ColumnChunk.
+		chunks.elemBegin()
+		chunks.i64(2, offset)
+		chunks.structBegin(3)
+		chunks.i32(1, column.typ)
+		chunks.list(2, thriftTypeI32, 1)
+		chunks.listI32(parquetEncodingPlain)
+		chunks.list(3, thriftTypeBinary, 1)
+		chunks.listBinary(column.name)
+		chunks.i32(4, parquetCodecUncompressed)
+		chunks.i64(5, int64(len(records)))
+		chunks.i64(6, size)
+		chunks.i64(7, size)
+		chunks.i64(9, offset)
+		chunks.structEnd()
+		chunks.structEnd()
+	}
+
+	// FileMetaData.
+	meta := &thriftWriter{}
+	meta.i32(1, 1)
+	meta.list(2, thriftTypeStruct, len(columns)+1)
+	meta.elemBegin()
+	meta.binary(4, "schema")
+	meta.i32(5, int32(len(columns)))
+	meta.structEnd()
+	for _, column := range columns {
+		meta.elemBegin()
+		meta.i32(1, column.typ)
+		meta.i32(3, parquetRepetitionRequired)
+		meta.binary(4, column.name)
+		if column.typ == parquetTypeByteArray {
+			meta.i32(6, parquetConvertedTypeUTF8)
+		}
+		meta.structEnd()
+	}
+	meta.i64(3, int64(len(records)))
+	meta.list(4, thriftTypeStruct, 1)
+	meta.elemBegin()
+	meta.fieldBegin(1, thriftTypeList)
+	meta.buf.Write(chunks.buf.Bytes())
+	meta.i64(2, totalByteSize)
+	meta.i64(3, int64(len(records)))
+	meta.structEnd()
+	meta.binary(6, parquetCreatedBy)
+	meta.stop()
+
+	footer := make([]byte, 4)
+	binary.LittleEndian.PutUint32(footer, uint32(meta.buf.Len()))
+	buf.Write(meta.buf.Bytes())
+	buf.Write(footer)
+	buf.Write(parquetMagic)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// encodeParquetPlain encodes the values of column with plain encoding,
+// columns are required, so the page has no repetition and definition levels.
+func encodeParquetPlain(column parquetColumn, values []reflect.Value) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	b := make([]byte, 8)
+	switch column.typ {
+	case parquetTypeBoolean:
+		bits := make([]byte, (len(values)+7)/8)
+		for i, value := range values {
+			if value.Field(column.index).Bool() {
+				bits[i/8] |= 1 << (i % 8)
+			}
+		}
+		buf.Write(bits)
+	case parquetTypeInt32:
+		for _, value := range values {
+			binary.LittleEndian.PutUint32(b, uint32(value.Field(column.index).Int()))
+			buf.Write(b[:4])
+		}
+	case parquetTypeInt64:
+		for _, value := range values {
+			field := value.Field(column.index)
+			if field.Kind() == reflect.Uint32 {
+				binary.LittleEndian.PutUint64(b, field.Uint())
+			} else {
+				binary.LittleEndian.PutUint64(b, uint64(field.Int()))
+			}
+			buf.Write(b)
+		}
+	case parquetTypeByteArray:
+		for _, value := range values {
+			s := value.Field(column.index).String()
+			binary.LittleEndian.PutUint32(b, uint32(len(s)))
+			buf.Write(b[:4])
+			buf.WriteString(s)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported parquet type %d of column %s", column.typ, column.name)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// thriftWriter encodes parquet metadata with thrift compact protocol.
+type thriftWriter struct {
+	buf     bytes.Buffer
+	lastID  int16
+	lastIDs []int16
+}
+
+// fieldBegin writes the header of field.
+func (w *thriftWriter) fieldBegin(id int16, typ byte) {
+	if delta := id - w.lastID; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.varint(int64(id))
+	}
+	w.lastID = id
+}
+
+// structBegin writes the header of struct field.
+func (w *thriftWriter) structBegin(id int16) {
+	w.fieldBegin(id, thriftTypeStruct)
+	w.elemBegin()
+}
+
+// elemBegin begins the struct which is the element of list.
+func (w *thriftWriter) elemBegin() {
+	w.lastIDs = append(w.lastIDs, w.lastID)
+	w.lastID = 0
+}
+
+// structEnd ends the struct.
+func (w *thriftWriter) structEnd() {
+	w.stop()
+	w.lastID = w.lastIDs[len(w.lastIDs)-1]
+	w.lastIDs = w.lastIDs[:len(w.lastIDs)-1]
+}
+
+// stop writes the stop field.
+func (w *thriftWriter) stop() {
+	w.buf.WriteByte(0)
+}
+
+// list writes the header of list field.
+func (w *thriftWriter) list(id int16, elemType byte, size int) {
+	w.fieldBegin(id, thriftTypeList)
+	w.listBegin(elemType, size)
+}
+
+// listBegin writes the header of list without field header.
+func (w *thriftWriter) listBegin(elemType byte, size int) {
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elemType)
+		return
+	}
+
+	w.buf.WriteByte(0xf0 | elemType)
+	w.uvarint(uint64(size))
+}
+
+// i32 writes the i32 field.
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.fieldBegin(id, thriftTypeI32)
+	w.varint(int64(v))
+}
+
+// i64 writes the i64 field.
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.fieldBegin(id, thriftTypeI64)
+	w.varint(v)
+}
+
+// binary writes the binary field.
+func (w *thriftWriter) binary(id int16, v string) {
+	w.fieldBegin(id, thriftTypeBinary)
+	w.listBinary(v)
+}
+
+// listI32 writes the i32 element of list.
+func (w *thriftWriter) listI32(v int32) {
+	w.varint(int64(v))
+}
+
+// listBinary writes the binary element of list.
+func (w *thriftWriter) listBinary(v string) {
+	w.uvarint(uint64(len(v)))
+	w.buf.WriteString(v)
+}
+
+// varint writes the zigzag varint.
+func (w *thriftWriter) varint(v int64) {
+	w.uvarint(uint64((v << 1) ^ (v >> 63)))
+}
+
+// uvarint writes the unsigned varint.
+func (w *thriftWriter) uvarint(v uint64) {
+	b := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(b, v)
+	w.buf.Write(b[:n])
+}
@@ -0,0 +1,470 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParquetSink_Write(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxBackups int
+		records    [][]Record
+		expect     func(t *testing.T, baseDir string, err error)
+	}{
+		{
+			name:       "write empty records",
+			maxBackups: 1,
+			records:    [][]Record{{}},
+			expect: func(t *testing.T, baseDir string, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				filenames, err := filepath.Glob(filepath.Join(baseDir, "*"))
+				assert.NoError(err)
+				assert.Equal(len(filenames), 0)
+			},
+		},
+		{
+			name:       "write records",
+			maxBackups: 10,
+			records:    [][]Record{{{ID: "1"}}, {{ID: "2"}, {ID: "3"}}},
+			expect: func(t *testing.T, baseDir string, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				filenames, err := filepath.Glob(filepath.Join(baseDir, "*"))
+				assert.NoError(err)
+				assert.Equal(len(filenames), 2)
+				for _, filename := range filenames {
+					assert.Equal(filepath.Ext(filename), ".parquet")
+				}
+			},
+		},
+		{
+			name:       "remove records exceed max backups",
+			maxBackups: 1,
+			records:    [][]Record{{{ID: "1"}}, {{ID: "2"}}, {{ID: "3"}}},
+			expect: func(t *testing.T, baseDir string, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				filenames, err := filepath.Glob(filepath.Join(baseDir, "*"))
+				assert.NoError(err)
+				assert.Equal(len(filenames), 1)
+
+				data, err := os.ReadFile(filenames[0])
+				assert.NoError(err)
+				assert.True(bytes.Contains(data, []byte("3")))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			baseDir := t.TempDir()
+			s, err := NewParquetSink(baseDir, tc.maxBackups)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, records := range tc.records {
+				if err = s.Write(records); err != nil {
+					break
+				}
+			}
+			tc.expect(t, baseDir, err)
+		})
+	}
+}
+
+func TestNewParquetColumns(t *testing.T) {
+	tests := []struct {
+		name   string
+		typ    reflect.Type
+		expect func(t *testing.T, columns []parquetColumn, err error)
+	}{
+		{
+			name: "columns of record",
+			typ:  reflect.TypeOf(Record{}),
+			expect: func(t *testing.T, columns []parquetColumn, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				assert.Equal(len(columns), reflect.TypeOf(Record{}).NumField())
+				assert.Equal(columns[0], parquetColumn{name: "id", index: 0, typ: parquetTypeByteArray})
+			},
+		},
+		{
+			name: "unsupported field type",
+			typ: reflect.TypeOf(struct {
+				ID    string  `json:"id"`
+				Score float64 `json:"score"`
+			}{}),
+			expect: func(t *testing.T, columns []parquetColumn, err error) {
+				assert := assert.New(t)
+				assert.EqualError(err, "unsupported parquet type float64 of field Score")
+				assert.Nil(columns)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			columns, err := newParquetColumns(tc.typ)
+			tc.expect(t, columns, err)
+		})
+	}
+}
+
+func TestWriteParquet(t *testing.T) {
+	tests := []struct {
+		name    string
+		records []Record
+	}{
+		{
+			name:    "write empty records",
+			records: []Record{},
+		},
+		{
+			name: "write records",
+			records: []Record{
+				{
+					ID:              "foo",
+					IP:              "127.0.0.1",
+					Hostname:        "localhost",
+					Priority:        -1,
+					State:           PeerStateSucceeded,
+					Cost:            1,
+					TotalPieceCount: 100,
+					ContentLength:   1024,
+					CreateAt:        1,
+					UpdateAt:        2,
+					BackToSource:    true,
+				},
+				{ID: "bar", Cost: 4294967295, ParentHostname: "parent"},
+			},
+		},
+		{
+			name: "write records with more than eight booleans",
+			records: func() []Record {
+				var records []Record
+				for i := 0; i < 11; i++ {
+					records = append(records, Record{ID: fmt.Sprint(i), BackToSource: i%3 == 0})
+				}
+				return records
+			}(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			columns, err := newParquetColumns(reflect.TypeOf(Record{}))
+			assert.NoError(err)
+
+			buf := &bytes.Buffer{}
+			assert.NoError(writeParquet(buf, columns, tc.records))
+
+			records, err := readParquet(buf.Bytes())
+			assert.NoError(err)
+			assert.Equal(len(records), len(tc.records))
+			for i := range records {
+				assert.Equal(records[i], tc.records[i])
+			}
+		})
+	}
+}
+
+// readParquet reads records from parquet file, it follows the parquet format specification
+// independently of writeParquet, so the written file is checked by round trip.
+func readParquet(data []byte) ([]Record, error) {
+	if len(data) < 12 || !bytes.Equal(data[:4], parquetMagic) || !bytes.Equal(data[len(data)-4:], parquetMagic) {
+		return nil, errors.New("invalid magic number")
+	}
+
+	size := int(binary.LittleEndian.Uint32(data[len(data)-8 : len(data)-4]))
+	if size <= 0 || size > len(data)-12 {
+		return nil, fmt.Errorf("invalid metadata size %d", size)
+	}
+
+	meta, err := newThriftReader(data[len(data)-8-size : len(data)-8]).readStruct()
+	if err != nil {
+		return nil, err
+	}
+	if meta[1] != int64(1) || meta[6] != parquetCreatedBy {
+		return nil, fmt.Errorf("invalid metadata %v", meta)
+	}
+
+	numRows := int(meta[3].(int64))
+	schema := meta[2].([]interface{})
+	root := schema[0].(map[int16]interface{})
+	if int(root[5].(int64)) != len(schema)-1 {
+		return nil, errors.New("invalid number of schema children")
+	}
+
+	types := map[string]int64{}
+	for _, element := range schema[1:] {
+		element := element.(map[int16]interface{})
+		if element[3] != int64(parquetRepetitionRequired) {
+			return nil, fmt.Errorf("column %s is not required", element[4])
+		}
+		types[element[4].(string)] = element[1].(int64)
+	}
+
+	records := make([]Record, numRows)
+	rowGroups := meta[4].([]interface{})
+	if len(rowGroups) != 1 {
+		return nil, fmt.Errorf("invalid number of row groups %d", len(rowGroups))
+	}
+
+	rowGroup := rowGroups[0].(map[int16]interface{})
+	if int(rowGroup[3].(int64)) != numRows {
+		return nil, errors.New("invalid number of rows in row group")
+	}
+
+	fields := map[string]int{}
+	t := reflect.TypeOf(Record{})
+	for i := 0; i < t.NumField(); i++ {
+		fields[strings.Split(t.Field(i).Tag.Get("json"), ",")[0]] = i
+	}
+
+	for _, chunk := range rowGroup[1].([]interface{}) {
+		chunk := chunk.(map[int16]interface{})
+		chunkMeta := chunk[3].(map[int16]interface{})
+		name := chunkMeta[3].([]interface{})[0].(string)
+		typ := chunkMeta[1].(int64)
+		if types[name] != typ {
+			return nil, fmt.Errorf("type of column %s is not matched with schema", name)
+		}
+		if chunkMeta[4] != int64(parquetCodecUncompressed) || int(chunkMeta[5].(int64)) != numRows {
+			return nil, fmt.Errorf("invalid metadata of column %s", name)
+		}
+
+		index, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %s", name)
+		}
+
+		r := newThriftReader(data[chunkMeta[9].(int64):])
+		header, err := r.readStruct()
+		if err != nil {
+			return nil, err
+		}
+
+		dataPageHeader := header[5].(map[int16]interface{})
+		if header[1] != int64(parquetPageTypeDataPage) || int(dataPageHeader[1].(int64)) != numRows ||
+			dataPageHeader[2] != int64(parquetEncodingPlain) {
+			return nil, fmt.Errorf("invalid page header of column %s", name)
+		}
+
+		page := r.data[r.offset : r.offset+int(header[3].(int64))]
+		for i := range records {
+			field := reflect.ValueOf(&records[i]).Elem().Field(index)
+			switch typ {
+			case parquetTypeBoolean:
+				field.SetBool(page[i/8]&(1<<(i%8)) != 0)
+			case parquetTypeInt32:
+				field.SetInt(int64(int32(binary.LittleEndian.Uint32(page[i*4:]))))
+			case parquetTypeInt64:
+				v := binary.LittleEndian.Uint64(page[i*8:])
+				if field.Kind() == reflect.Uint32 {
+					field.SetUint(v)
+				} else {
+					field.SetInt(int64(v))
+				}
+			case parquetTypeByteArray:
+				n := int(binary.LittleEndian.Uint32(page))
+				field.SetString(string(page[4 : 4+n]))
+				page = page[4+n:]
+			default:
+				return nil, fmt.Errorf("unknown type %d of column %s", typ, name)
+			}
+		}
+	}
+
+	return records, nil
+}
+
+// thriftReader decodes thrift compact protocol, integers are decoded to int64,
+// binaries are decoded to string, lists are decoded to []interface{} and
+// structs are decoded to map[int16]interface{}.
+type thriftReader struct {
+	data   []byte
+	offset int
+}
+
+func newThriftReader(data []byte) *thriftReader {
+	return &thriftReader{data: data}
+}
+
+func (r *thriftReader) readStruct() (map[int16]interface{}, error) {
+	fields := map[int16]interface{}{}
+	var id int16
+	for {
+		b, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		if b == 0 {
+			return fields, nil
+		}
+
+		if delta := int16(b >> 4); delta != 0 {
+			id += delta
+		} else {
+			v, err := r.readVarint()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+
+		if fields[id], err = r.readValue(b & 0x0f); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (r *thriftReader) readValue(typ byte) (interface{}, error) {
+	switch typ {
+	case thriftTypeI32, thriftTypeI64:
+		return r.readVarint()
+	case thriftTypeBinary:
+		n, err := r.readUvarint()
+		if err != nil {
+			return nil, err
+		}
+		if r.offset+int(n) > len(r.data) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		v := string(r.data[r.offset : r.offset+int(n)])
+		r.offset += int(n)
+		return v, nil
+	case thriftTypeList:
+		b, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		size := int(b >> 4)
+		if size == 15 {
+			n, err := r.readUvarint()
+			if err != nil {
+				return nil, err
+			}
+			size = int(n)
+		}
+
+		list := make([]interface{}, size)
+		for i := range list {
+			if list[i], err = r.readValue(b & 0x0f); err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	case thriftTypeStruct:
+		return r.readStruct()
+	default:
+		return nil, fmt.Errorf("unknown thrift type %d", typ)
+	}
+}
+
+func (r *thriftReader) readByte() (byte, error) {
+	if r.offset >= len(r.data) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	b := r.data[r.offset]
+	r.offset++
+	return b, nil
+}
+
+func (r *thriftReader) readUvarint() (uint64, error) {
+	v, n := binary.Uvarint(r.data[r.offset:])
+	if n <= 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	r.offset += n
+	return v, nil
+}
+
+func (r *thriftReader) readVarint() (int64, error) {
+	v, err := r.readUvarint()
+	if err != nil {
+		return 0, err
+	}
+	return int64(v>>1) ^ -int64(v&1), nil
+}
+
+func TestThriftWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		write  func(w *thriftWriter)
+		expect []byte
+	}{
+		{
+			name: "write short field delta",
+			write: func(w *thriftWriter) {
+				w.i32(1, 1)
+				w.i64(2, -1)
+			},
+			expect: []byte{0x15, 0x02, 0x16, 0x01},
+		},
+		{
+			name: "write long field delta",
+			write: func(w *thriftWriter) {
+				w.i32(20, 1)
+			},
+			expect: []byte{0x05, 0x28, 0x02},
+		},
+		{
+			name: "write struct field",
+			write: func(w *thriftWriter) {
+				w.structBegin(1)
+				w.binary(1, "a")
+				w.structEnd()
+				w.i32(2, 0)
+			},
+			expect: []byte{0x1c, 0x18, 0x01, 'a', 0x00, 0x15, 0x00},
+		},
+		{
+			name: "write list field",
+			write: func(w *thriftWriter) {
+				w.list(1, thriftTypeI32, 1)
+				w.listI32(3)
+				w.list(2, thriftTypeI32, 15)
+			},
+			expect: []byte{0x19, 0x15, 0x06, 0x19, 0xf5, 0x0f},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			w := &thriftWriter{}
+			tc.write(w)
+			assert.Equal(w.buf.Bytes(), tc.expect)
+		})
+	}
+}
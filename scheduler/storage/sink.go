@@ -0,0 +1,198 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	logger "d7y.io/dragonfly/v2/internal/dflog"
+)
+
+const (
+	// DefaultHTTPSinkTimeout is the default timeout of pushing records to http sink.
+	DefaultHTTPSinkTimeout = 30 * time.Second
+
+	// DefaultSinkQueueSize is the default number of record batches waiting to be written to a sink.
+	DefaultSinkQueueSize = 64
+
+	// jsonLinesContentType is the content type of json lines.
+	jsonLinesContentType = "application/x-ndjson"
+)
+
+// Sink is the interface used for writing records to other destinations,
+// records are written to the sinks in batch when the buffer of storage is flushed.
+type Sink interface {
+	// Write writes the records to sink.
+	Write([]Record) error
+}
+
+// asyncSink writes records to sink in background, so the slow sink does not block creating records,
+// the records are dropped when the queue of sink is full.
+type asyncSink struct {
+	sink    Sink
+	queue   chan []Record
+	dropped func(count int)
+	done    chan struct{}
+	closed  bool
+	mu      *sync.RWMutex
+}
+
+// newAsyncSink returns a new asyncSink and starts writing records in background.
+func newAsyncSink(sink Sink, queueSize int, dropped func(count int)) *asyncSink {
+	s := &asyncSink{
+		sink:    sink,
+		queue:   make(chan []Record, queueSize),
+		dropped: dropped,
+		done:    make(chan struct{}),
+		mu:      &sync.RWMutex{},
+	}
+
+	go s.run()
+	return s
+}
+
+// Write puts the records into the queue of sink, the records must not be modified after written.
+func (s *asyncSink) Write(records []Record) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return fmt.Errorf("sink is closed, drop %d records", len(records))
+	}
+
+	select {
+	case s.queue <- records:
+		return nil
+	default:
+		if s.dropped != nil {
+			s.dropped(len(records))
+		}
+
+		return fmt.Errorf("sink queue is full, drop %d records", len(records))
+	}
+}
+
+// Close stops accepting records and waits for the records in queue to be written to sink.
+func (s *asyncSink) Close() error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.mu.Unlock()
+
+	<-s.done
+	return nil
+}
+
+// run writes the records in queue to sink until the queue is closed.
+func (s *asyncSink) run() {
+	defer close(s.done)
+	for records := range s.queue {
+		if err := s.sink.Write(records); err != nil {
+			logger.Errorf("write records to sink failed: %s", err.Error())
+		}
+	}
+}
+
+// httpSink pushes records to http endpoint in json lines format.
+type httpSink struct {
+	url     string
+	headers map[string]string
+	timeout time.Duration
+	client  *http.Client
+}
+
+// HTTPSinkOption is a functional option for configuring the http sink.
+type HTTPSinkOption func(s *httpSink)
+
+// WithHTTPSinkHeaders sets the headers of push request.
+func WithHTTPSinkHeaders(headers map[string]string) HTTPSinkOption {
+	return func(s *httpSink) {
+		s.headers = headers
+	}
+}
+
+// WithHTTPSinkTimeout sets the timeout of push request.
+func WithHTTPSinkTimeout(timeout time.Duration) HTTPSinkOption {
+	return func(s *httpSink) {
+		s.timeout = timeout
+	}
+}
+
+// NewHTTPSink returns a new sink which pushes records to the url.
+func NewHTTPSink(url string, options ...HTTPSinkOption) Sink {
+	s := &httpSink{
+		url:     url,
+		timeout: DefaultHTTPSinkTimeout,
+		client:  http.DefaultClient,
+	}
+
+	for _, opt := range options {
+		opt(s)
+	}
+
+	return s
+}
+
+// Write pushes the records to http endpoint.
+func (s *httpSink) Write(records []Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	body := &bytes.Buffer{}
+	encoder := json.NewEncoder(body)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", jsonLinesContentType)
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("push records to %s failed, status code %d: %s", s.url, resp.StatusCode, msg)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package storage
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPSink_Write(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		records []Record
+		expect  func(t *testing.T, req *http.Request, records []Record, err error)
+	}{
+		{
+			name:    "push records",
+			status:  http.StatusOK,
+			records: []Record{{ID: "1"}, {ID: "2", BackToSource: true}},
+			expect: func(t *testing.T, req *http.Request, records []Record, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				assert.Equal(req.Method, http.MethodPost)
+				assert.Equal(req.Header.Get("Content-Type"), "application/x-ndjson")
+				assert.Equal(req.Header.Get("foo"), "bar")
+				assert.Equal(len(records), 2)
+				assert.Equal(records[1].ID, "2")
+				assert.True(records[1].BackToSource)
+			},
+		},
+		{
+			name:    "push empty records",
+			status:  http.StatusOK,
+			records: []Record{},
+			expect: func(t *testing.T, req *http.Request, records []Record, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				assert.Nil(req)
+			},
+		},
+		{
+			name:    "push records failed",
+			status:  http.StatusInternalServerError,
+			records: []Record{{ID: "1"}},
+			expect: func(t *testing.T, req *http.Request, records []Record, err error) {
+				assert := assert.New(t)
+				assert.Error(err)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				req     *http.Request
+				records []Record
+			)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				req = r
+				decoder := json.NewDecoder(r.Body)
+				for decoder.More() {
+					var record Record
+					if err := decoder.Decode(&record); err != nil {
+						t.Fatal(err)
+					}
+					records = append(records, record)
+				}
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			s := NewHTTPSink(server.URL, WithHTTPSinkHeaders(map[string]string{"foo": "bar"}), WithHTTPSinkTimeout(time.Second))
+			err := s.Write(tc.records)
+			tc.expect(t, req, records, err)
+		})
+	}
+}
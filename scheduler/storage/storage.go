@@ -19,6 +19,8 @@
 package storage
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -55,6 +57,17 @@ const (
 	RecordFileExt = "csv"
 )
 
+// Format is the format of record file.
+type Format string
+
+const (
+	// FormatCSV writes records to file in csv format.
+	FormatCSV Format = "csv"
+
+	// FormatJSONL writes records to file in json lines format.
+	FormatJSONL Format = "jsonl"
+)
+
 const (
 	// Peer has been downloaded successfully.
 	PeerStateSucceeded = iota
@@ -80,121 +93,162 @@ const (
 // Record contains content for record.
 type Record struct {
 	// ID is peer id.
-	ID string `csv:"id"`
+	ID string `csv:"id" json:"id"`
 
 	// IP is host ip.
-	IP string `csv:"ip"`
+	IP string `csv:"ip" json:"ip"`
 
 	// Hostname is host name.
-	Hostname string `csv:"hostname"`
+	Hostname string `csv:"hostname" json:"hostname"`
 
 	// BizTag is peer biz tag.
-	BizTag string `csv:"bizTag"`
+	BizTag string `csv:"bizTag" json:"bizTag"`
 
 	// Cost is the task download time(millisecond).
-	Cost uint32 `csv:"cost"`
+	Cost uint32 `csv:"cost" json:"cost"`
 
 	// PieceCount is total piece count.
-	PieceCount int32 `csv:"pieceCount"`
+	PieceCount int32 `csv:"pieceCount" json:"pieceCount"`
 
 	// TotalPieceCount is total piece count.
-	TotalPieceCount int32 `csv:"totalPieceCount"`
+	TotalPieceCount int32 `csv:"totalPieceCount" json:"totalPieceCount"`
 
 	// ContentLength is task total content length.
-	ContentLength int64 `csv:"contentLength"`
+	ContentLength int64 `csv:"contentLength" json:"contentLength"`
 
 	// SecurityDomain is security domain of host.
-	SecurityDomain string `csv:"securityDomain"`
+	SecurityDomain string `csv:"securityDomain" json:"securityDomain"`
 
 	// IDC is internet data center of host.
-	IDC string `csv:"idc"`
+	IDC string `csv:"idc" json:"idc"`
 
 	// NetTopology is network topology of host.
 	// Example: switch|router|...
-	NetTopology string `csv:"netTopology"`
+	NetTopology string `csv:"netTopology" json:"netTopology"`
 
 	// Location is location of host.
 	// Example: country|province|...
-	Location string `csv:"location"`
+	Location string `csv:"location" json:"location"`
 
 	// FreeUploadLoad is free upload load of host.
-	FreeUploadLoad int32 `csv:"freeUpoladLoad"`
+	FreeUploadLoad int32 `csv:"freeUpoladLoad" json:"freeUploadLoad"`
 
 	// State is the download state of the peer.
-	State int `csv:"state"`
+	State int `csv:"state" json:"state"`
 
 	// HostType is peer host type.
-	HostType int `csv:"hostType"`
+	HostType int `csv:"hostType" json:"hostType"`
 
 	// CreateAt is peer create nanosecond time.
-	CreateAt int64 `csv:"createAt"`
+	CreateAt int64 `csv:"createAt" json:"createAt"`
 
 	// UpdateAt is peer update nanosecond time.
-	UpdateAt int64 `csv:"updateAt"`
+	UpdateAt int64 `csv:"updateAt" json:"updateAt"`
 
 	// ParentID is parent peer id.
-	ParentID string `csv:"parentID"`
+	ParentID string `csv:"parentID" json:"parentID"`
 
 	// ParentIP is parent host ip.
-	ParentIP string `csv:"parentIP"`
+	ParentIP string `csv:"parentIP" json:"parentIP"`
 
 	// ParentHostname is parent hostname.
-	ParentHostname string `csv:"parentHostname"`
+	ParentHostname string `csv:"parentHostname" json:"parentHostname"`
 
 	// ParentBizTag is parent peer biz tag.
-	ParentBizTag string `csv:"parentBizTag"`
+	ParentBizTag string `csv:"parentBizTag" json:"parentBizTag"`
 
 	// ParentPieceCount is parent total piece count.
-	ParentPieceCount int32 `csv:"parentPieceCount"`
+	ParentPieceCount int32 `csv:"parentPieceCount" json:"parentPieceCount"`
 
 	// ParentSecurityDomain is parent security domain of host.
-	ParentSecurityDomain string `csv:"parentSecurityDomain"`
+	ParentSecurityDomain string `csv:"parentSecurityDomain" json:"parentSecurityDomain"`
 
 	// ParentIDC is parent internet data center of host.
-	ParentIDC string `csv:"parentIDC"`
+	ParentIDC string `csv:"parentIDC" json:"parentIDC"`
 
 	// ParentNetTopology is parent network topology of host.
 	// Example: switch|router|...
-	ParentNetTopology string `csv:"parentNetTopology"`
+	ParentNetTopology string `csv:"parentNetTopology" json:"parentNetTopology"`
 
 	// ParentLocation is parent location of host.
 	// Example: country|province|...
-	ParentLocation string `csv:"parentLocation"`
+	ParentLocation string `csv:"parentLocation" json:"parentLocation"`
 
 	// ParentFreeUploadLoad is parent free upload load of host.
-	ParentFreeUploadLoad int32 `csv:"parentFreeUploadLoad"`
+	ParentFreeUploadLoad int32 `csv:"parentFreeUploadLoad" json:"parentFreeUploadLoad"`
 
 	// ParentHostType is parent host type.
-	ParentHostType int `csv:"parentHostType"`
+	ParentHostType int `csv:"parentHostType" json:"parentHostType"`
 
 	// ParentCreateAt is parent peer create nanosecond time.
-	ParentCreateAt int64 `csv:"parentCreateAt"`
+	ParentCreateAt int64 `csv:"parentCreateAt" json:"parentCreateAt"`
 
 	// ParentUpdateAt is parent peer update nanosecond time.
-	ParentUpdateAt int64 `csv:"parentUpdateAt"`
+	ParentUpdateAt int64 `csv:"parentUpdateAt" json:"parentUpdateAt"`
+
+	// TaskID is task id.
+	TaskID string `csv:"taskID" json:"taskID"`
+
+	// HostID is host id.
+	HostID string `csv:"hostID" json:"hostID"`
+
+	// Application is the application of task.
+	Application string `csv:"application" json:"application"`
+
+	// Priority is the download priority of peer.
+	Priority int `csv:"priority" json:"priority"`
+
+	// PieceCostAvg is the average of piece download time(millisecond).
+	PieceCostAvg int64 `csv:"pieceCostAvg" json:"pieceCostAvg"`
+
+	// PieceCostP50 is the 50th percentile of piece download time(millisecond).
+	PieceCostP50 int64 `csv:"pieceCostP50" json:"pieceCostP50"`
+
+	// PieceCostP90 is the 90th percentile of piece download time(millisecond).
+	PieceCostP90 int64 `csv:"pieceCostP90" json:"pieceCostP90"`
+
+	// PieceCostP99 is the 99th percentile of piece download time(millisecond).
+	PieceCostP99 int64 `csv:"pieceCostP99" json:"pieceCostP99"`
+
+	// PieceCostMax is the maximum of piece download time(millisecond).
+	PieceCostMax int64 `csv:"pieceCostMax" json:"pieceCostMax"`
+
+	// BackToSource is whether the peer is downloaded from source.
+	BackToSource bool `csv:"backToSource" json:"backToSource"`
+
+	// ParentHostID is parent host id.
+	ParentHostID string `csv:"parentHostID" json:"parentHostID"`
 }
 
 // Storage is the interface used for storage.
 type Storage interface {
-	// Create inserts the record into csv file.
+	// Create inserts the record into record file.
 	Create(Record) error
 
-	// List returns all of records in csv file.
+	// List returns all of records in record file.
 	List() ([]Record, error)
 
 	// Clear removes all record files.
 	Clear() error
+
+	// Close writes the records in buffer to record file and sinks,
+	// and waits for the sinks to write the queued records.
+	Close() error
 }
 
 // storage provides storage function.
 type storage struct {
-	baseDir    string
-	filename   string
-	maxSize    int64
-	maxBackups int
-	buffer     []Record
-	bufferSize int
-	mu         *sync.RWMutex
+	baseDir       string
+	filename      string
+	maxSize       int64
+	maxBackups    int
+	buffer        []Record
+	bufferSize    int
+	format        Format
+	sinks         []Sink
+	sinkQueueSize int
+	sinkDropped   func(count int)
+	mu            *sync.RWMutex
 }
 
 // Option is a functional option for configuring the Storage.
@@ -223,22 +277,63 @@ func WithBufferSize(bufferSize int) Option {
 	}
 }
 
+// WithFormat sets the format of storage file.
+func WithFormat(format Format) Option {
+	return func(s *storage) {
+		s.format = format
+	}
+}
+
+// WithSinks sets the sinks, records in the buffer are also
+// written to the sinks in background when they are written to the file.
+func WithSinks(sinks ...Sink) Option {
+	return func(s *storage) {
+		s.sinks = append(s.sinks, sinks...)
+	}
+}
+
+// WithSinkQueueSize sets the number of record batches waiting to be written to a sink,
+// the records are dropped when the queue is full.
+func WithSinkQueueSize(size int) Option {
+	return func(s *storage) {
+		s.sinkQueueSize = size
+	}
+}
+
+// WithSinkDropped sets the function called with the count of records dropped by sinks.
+func WithSinkDropped(dropped func(count int)) Option {
+	return func(s *storage) {
+		s.sinkDropped = dropped
+	}
+}
+
 // New returns a new Storage instence.
 func New(baseDir string, options ...Option) (Storage, error) {
 	s := &storage{
-		baseDir:    baseDir,
-		filename:   filepath.Join(baseDir, fmt.Sprintf("%s.%s", RecordFilePrefix, RecordFileExt)),
-		maxSize:    DefaultMaxSize * megabyte,
-		maxBackups: DefaultMaxBackups,
-		buffer:     make([]Record, 0, DefaultBufferSize),
-		bufferSize: DefaultBufferSize,
-		mu:         &sync.RWMutex{},
+		baseDir:       baseDir,
+		maxSize:       DefaultMaxSize * megabyte,
+		maxBackups:    DefaultMaxBackups,
+		buffer:        make([]Record, 0, DefaultBufferSize),
+		bufferSize:    DefaultBufferSize,
+		format:        FormatCSV,
+		sinkQueueSize: DefaultSinkQueueSize,
+		mu:            &sync.RWMutex{},
 	}
 
 	for _, opt := range options {
 		opt(s)
 	}
 
+	if s.format != FormatCSV && s.format != FormatJSONL {
+		return nil, fmt.Errorf("invalid storage format %s", s.format)
+	}
+	s.filename = filepath.Join(baseDir, fmt.Sprintf("%s.%s", RecordFilePrefix, s.format))
+
+	// Sinks are written in background, creating records is not blocked by slow sinks.
+	for i, sink := range s.sinks {
+		s.sinks[i] = newAsyncSink(sink, s.sinkQueueSize, s.sinkDropped)
+	}
+
 	file, err := os.OpenFile(s.filename, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 	if err != nil {
 		return nil, err
@@ -248,7 +343,7 @@ func New(baseDir string, options ...Option) (Storage, error) {
 	return s, nil
 }
 
-// Create inserts the record into csv file.
+// Create inserts the record into record file.
 func (s *storage) Create(record Record) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -268,7 +363,7 @@ func (s *storage) Create(record Record) error {
 	return nil
 }
 
-// List returns all of records in csv file.
+// List returns all of records in record file.
 func (s *storage) List() ([]Record, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -298,12 +393,7 @@ func (s *storage) List() ([]Record, error) {
 		closers = append(closers, file)
 	}
 
-	var records []Record
-	if err := gocsv.UnmarshalWithoutHeaders(io.MultiReader(readers...), &records); err != nil {
-		return nil, err
-	}
-
-	return records, nil
+	return s.decode(io.MultiReader(readers...))
 }
 
 // Clear removes all records.
@@ -326,7 +416,30 @@ func (s *storage) Clear() error {
 	return nil
 }
 
-// create inserts the records into csv file.
+// Close writes the records in buffer to record file and sinks,
+// and waits for the sinks to write the queued records.
+func (s *storage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+	if len(s.buffer) > 0 {
+		err = s.create(s.buffer...)
+		s.buffer = s.buffer[:0]
+	}
+
+	for _, sink := range s.sinks {
+		if closer, ok := sink.(io.Closer); ok {
+			if cerr := closer.Close(); cerr != nil {
+				logger.Errorf("close sink failed: %s", cerr.Error())
+			}
+		}
+	}
+
+	return err
+}
+
+// create inserts the records into record file and writes the records to sinks.
 func (s *storage) create(records ...Record) error {
 	file, err := s.openFile()
 	if err != nil {
@@ -334,13 +447,73 @@ func (s *storage) create(records ...Record) error {
 	}
 	defer file.Close()
 
-	if err := gocsv.MarshalWithoutHeaders(records, file); err != nil {
+	if err := s.encode(file, records); err != nil {
 		return err
 	}
 
+	// Failure of sinks does not affect the record file, the records are copied
+	// because the buffer is reused after they are written.
+	if len(s.sinks) > 0 {
+		batch := make([]Record, len(records))
+		copy(batch, records)
+		for _, sink := range s.sinks {
+			if err := sink.Write(batch); err != nil {
+				logger.Errorf("write records to sink failed: %s", err.Error())
+			}
+		}
+	}
+
 	return nil
 }
 
+// encode writes the records to writer in the format of storage.
+func (s *storage) encode(w io.Writer, records []Record) error {
+	if s.format == FormatJSONL {
+		encoder := json.NewEncoder(w)
+		for _, record := range records {
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return gocsv.MarshalWithoutHeaders(records, w)
+}
+
+// decode reads the records from reader in the format of storage.
+func (s *storage) decode(r io.Reader) ([]Record, error) {
+	var records []Record
+	if s.format == FormatJSONL {
+		decoder := json.NewDecoder(r)
+		for {
+			var record Record
+			if err := decoder.Decode(&record); err != nil {
+				if err == io.EOF {
+					break
+				}
+
+				return nil, err
+			}
+
+			records = append(records, record)
+		}
+
+		return records, nil
+	}
+
+	// Records written before the new fields were appended have fewer columns,
+	// they are parsed by position and the missing fields are left empty.
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	if err := gocsv.UnmarshalCSVWithoutHeaders(reader, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
 // openFile opens the record file and removes record files that exceed the total size.
 func (s *storage) openFile() (*os.File, error) {
 	fileInfo, err := os.Stat(s.filename)
@@ -377,7 +550,7 @@ func (s *storage) openFile() (*os.File, error) {
 // backupFilename generates file name of backup files.
 func (s *storage) backupFilename() string {
 	timestamp := time.Now().Format(backupTimeFormat)
-	return filepath.Join(s.baseDir, fmt.Sprintf("%s-%s.%s", RecordFilePrefix, timestamp, RecordFileExt))
+	return filepath.Join(s.baseDir, fmt.Sprintf("%s-%s.%s", RecordFilePrefix, timestamp, s.format))
 }
 
 // backupFilename returns backup file information.
@@ -390,7 +563,7 @@ func (s *storage) backups() ([]fs.FileInfo, error) {
 	var backups []fs.FileInfo
 	regexp := regexp.MustCompile(RecordFilePrefix)
 	for _, fileInfo := range fileInfos {
-		if !fileInfo.IsDir() && regexp.MatchString(fileInfo.Name()) {
+		if !fileInfo.IsDir() && regexp.MatchString(fileInfo.Name()) && filepath.Ext(fileInfo.Name()) == "."+string(s.format) {
 			backups = append(backups, fileInfo)
 		}
 	}
@@ -17,6 +17,7 @@
 package storage
 
 import (
+	"errors"
 	"fmt"
 	"io/fs"
 	"io/ioutil"
@@ -24,6 +25,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"sync"
 	"testing"
 	"time"
 
@@ -114,6 +116,30 @@ func TestStorage_New(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:    "new storage with format",
+			baseDir: os.TempDir(),
+			options: []Option{WithFormat(FormatJSONL)},
+			expect: func(t *testing.T, s Storage, err error) {
+				assert := assert.New(t)
+				assert.NoError(err)
+				assert.Equal(s.(*storage).format, FormatJSONL)
+				assert.Equal(s.(*storage).filename, filepath.Join(os.TempDir(), "record.jsonl"))
+
+				if err := s.Clear(); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name:    "new storage with invalid format",
+			baseDir: os.TempDir(),
+			options: []Option{WithFormat(Format("foo"))},
+			expect: func(t *testing.T, s Storage, err error) {
+				assert := assert.New(t)
+				assert.Error(err)
+			},
+		},
 		{
 			name:    "new storage failed",
 			baseDir: "/foo",
@@ -288,6 +314,40 @@ func TestStorage_List(t *testing.T) {
 				assert.EqualValues(records[0], record)
 			},
 		},
+		{
+			name:    "list records of jsonl file",
+			baseDir: os.TempDir(),
+			options: []Option{WithBufferSize(1), WithFormat(FormatJSONL)},
+			record: Record{
+				ID:           "1",
+				TaskID:       "task_id",
+				HostID:       "host_id",
+				Cost:         1,
+				PieceCostP99: 1,
+				BackToSource: true,
+				ParentID:     "2",
+				ParentHostID: "parent_host_id",
+			},
+			mock: func(t *testing.T, s Storage, baseDir string, record Record) {
+				if err := s.Create(record); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expect: func(t *testing.T, s Storage, baseDir string, record Record) {
+				assert := assert.New(t)
+				records, err := s.List()
+				assert.NoError(err)
+				assert.Equal(len(records), 0)
+
+				if err := s.Create(record); err != nil {
+					t.Fatal(err)
+				}
+				records, err = s.List()
+				assert.NoError(err)
+				assert.Equal(len(records), 1)
+				assert.EqualValues(records[0], record)
+			},
+		},
 		{
 			name:    "list records of multi files",
 			baseDir: os.TempDir(),
@@ -321,6 +381,39 @@ func TestStorage_List(t *testing.T) {
 				assert.Equal(records[1].ID, "1")
 			},
 		},
+		{
+			name:    "list records written before new fields are appended",
+			baseDir: os.TempDir(),
+			options: []Option{WithBufferSize(1)},
+			record:  Record{},
+			mock: func(t *testing.T, s Storage, baseDir string, record Record) {
+				row := "2,127.0.0.1,hostname,biz_tag,1,1,1,1,security_domain,idc,net_topology,location,1,0,0,1,1," +
+					"3,127.0.0.2,parent_hostname,parent_biz_tag,1,parent_security_domain,parent_idc,parent_net_topology,parent_location,1,0,1,1\n"
+				if err := os.WriteFile(filepath.Join(baseDir, "record-test.csv"), []byte(row), 0600); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := s.Create(Record{ID: "1", TaskID: "task_id"}); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := s.Create(Record{ID: "3"}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expect: func(t *testing.T, s Storage, baseDir string, record Record) {
+				assert := assert.New(t)
+				records, err := s.List()
+				assert.NoError(err)
+				assert.Equal(len(records), 2)
+				assert.Equal(records[0].ID, "2")
+				assert.Equal(records[0].ParentIP, "127.0.0.2")
+				assert.Equal(records[0].ParentUpdateAt, int64(1))
+				assert.Equal(records[0].TaskID, "")
+				assert.Equal(records[1].ID, "1")
+				assert.Equal(records[1].TaskID, "task_id")
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -398,6 +491,77 @@ func TestStorage_Clear(t *testing.T) {
 	}
 }
 
+func TestStorage_Close(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseDir string
+		options []Option
+		mock    func(s Storage)
+		expect  func(t *testing.T, s Storage, baseDir string)
+	}{
+		{
+			name:    "flush buffer to file",
+			baseDir: os.TempDir(),
+			options: []Option{WithBufferSize(10)},
+			mock: func(s Storage) {
+				for i := 0; i < 3; i++ {
+					if err := s.Create(Record{ID: fmt.Sprint(i)}); err != nil {
+						t.Fatal(err)
+					}
+				}
+			},
+			expect: func(t *testing.T, s Storage, baseDir string) {
+				assert := assert.New(t)
+				assert.NoError(s.Close())
+				assert.Equal(len(s.(*storage).buffer), 0)
+
+				records, err := s.List()
+				assert.NoError(err)
+				assert.Equal(len(records), 3)
+			},
+		},
+		{
+			name:    "wait for sinks to write queued records",
+			baseDir: os.TempDir(),
+			options: []Option{WithBufferSize(10), WithSinks(&testSink{block: make(chan struct{})})},
+			mock: func(s Storage) {
+				if err := s.Create(Record{ID: "1"}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expect: func(t *testing.T, s Storage, baseDir string) {
+				assert := assert.New(t)
+				sink := s.(*storage).sinks[0].(*asyncSink).sink.(*testSink)
+				go func() {
+					time.Sleep(10 * time.Millisecond)
+					close(sink.block)
+				}()
+
+				assert.NoError(s.Close())
+				assert.Equal(len(sink.load()), 1)
+
+				// Records are not written to closed sinks.
+				assert.Error(s.(*storage).sinks[0].Write([]Record{{ID: "2"}}))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := New(tc.baseDir, tc.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tc.mock(s)
+			tc.expect(t, s, tc.baseDir)
+			if err := s.Clear(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
 func TestStorage_create(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -431,6 +595,54 @@ func TestStorage_create(t *testing.T) {
 				s.(*storage).baseDir = baseDir
 			},
 		},
+		{
+			name:    "create record with sinks",
+			baseDir: os.TempDir(),
+			options: []Option{WithSinks(&testSink{}, &testSink{err: errors.New("foo")})},
+			mock:    func(s Storage) {},
+			expect: func(t *testing.T, s Storage, baseDir string) {
+				assert := assert.New(t)
+				records := []Record{{ID: "1"}, {ID: "2"}}
+				err := s.(*storage).create(records...)
+				assert.NoError(err)
+
+				// Records are copied before written to sinks.
+				records[1].ID = "3"
+				for _, sink := range s.(*storage).sinks {
+					sink := sink.(*asyncSink).sink.(*testSink)
+					assert.Eventually(func() bool {
+						return len(sink.load()) == 2
+					}, time.Second, 10*time.Millisecond)
+					assert.Equal(sink.load()[1].ID, "2")
+				}
+			},
+		},
+		{
+			name:    "create record with slow sink",
+			baseDir: os.TempDir(),
+			options: []Option{WithSinks(&testSink{block: make(chan struct{})}), WithSinkQueueSize(1)},
+			mock:    func(s Storage) {},
+			expect: func(t *testing.T, s Storage, baseDir string) {
+				assert := assert.New(t)
+				var dropped int
+				s.(*storage).sinks[0].(*asyncSink).dropped = func(count int) {
+					dropped += count
+				}
+
+				// The first batch is being written, the second batch is queued and the third batch is dropped.
+				for i := 0; i < 3; i++ {
+					assert.NoError(s.(*storage).create(Record{ID: fmt.Sprint(i)}))
+					time.Sleep(10 * time.Millisecond)
+				}
+				assert.Equal(dropped, 1)
+
+				sink := s.(*storage).sinks[0].(*asyncSink).sink.(*testSink)
+				close(sink.block)
+				assert.Eventually(func() bool {
+					return len(sink.load()) == 2
+				}, time.Second, 10*time.Millisecond)
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -449,6 +661,31 @@ func TestStorage_create(t *testing.T) {
 	}
 }
 
+// testSink records the written records for testing.
+type testSink struct {
+	mu      sync.Mutex
+	records []Record
+	block   chan struct{}
+	err     error
+}
+
+func (s *testSink) Write(records []Record) error {
+	if s.block != nil {
+		<-s.block
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, records...)
+	return s.err
+}
+
+func (s *testSink) load() []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.records
+}
+
 func TestStorage_openFile(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,222 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/montanaflynn/stats"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v3"
+
+	logger "d7y.io/dragonfly/v2/internal/dflog"
+	"d7y.io/dragonfly/v2/scheduler/storage"
+)
+
+var (
+	recordDir       string
+	topologyFile    string
+	algorithms      string
+	pluginDir       string
+	sourceBandwidth float64
+	uploadBandwidth float64
+	crossIDCFactor  float64
+	backSourceCount int
+	verbose         bool
+)
+
+func init() {
+	flag.StringVar(&recordDir, "records", "", "directory of scheduler record files, example: /var/lib/dragonfly")
+	flag.StringVar(&topologyFile, "topology", "", "yaml file of synthetic host topology, hosts not in topology are generated from records")
+	flag.StringVar(&algorithms, "algorithms", "default", "comma separated evaluator algorithms to compare, example: default,plugin,history")
+	flag.StringVar(&pluginDir, "plugin-dir", ".", "directory of evaluator plugin")
+	flag.Float64Var(&sourceBandwidth, "source-bandwidth", 50, "back-to-source bandwidth of every peer in MB/s")
+	flag.Float64Var(&uploadBandwidth, "upload-bandwidth", 100, "default upload bandwidth of host in MB/s")
+	flag.Float64Var(&crossIDCFactor, "cross-idc-factor", 0.5, "bandwidth factor when parent and child are in different idc")
+	flag.IntVar(&backSourceCount, "back-source-count", 3, "back-to-source peer limit of every task")
+	flag.BoolVar(&verbose, "verbose", false, "print scheduler logs")
+}
+
+// topology is the synthetic host topology.
+type topology struct {
+	Hosts []topologyHost `yaml:"hosts"`
+}
+
+// topologyHost is the host of synthetic topology.
+type topologyHost struct {
+	Hostname        string  `yaml:"hostname"`
+	IP              string  `yaml:"ip"`
+	IDC             string  `yaml:"idc"`
+	NetTopology     string  `yaml:"netTopology"`
+	Location        string  `yaml:"location"`
+	SecurityDomain  string  `yaml:"securityDomain"`
+	Type            string  `yaml:"type"`
+	UploadLoadLimit int32   `yaml:"uploadLoadLimit"`
+	UploadBandwidth float64 `yaml:"uploadBandwidth"`
+}
+
+func main() {
+	flag.Parse()
+
+	if !verbose {
+		logger.SetLevel(zapcore.FatalLevel)
+	}
+
+	if recordDir == "" {
+		log.Fatal("records directory is required")
+	}
+
+	records, err := loadRecords(recordDir)
+	if err != nil {
+		log.Fatalf("load records failed: %s", err)
+	}
+
+	if len(records) == 0 {
+		log.Fatalf("no record found in %s", recordDir)
+	}
+
+	var topo topology
+	if topologyFile != "" {
+		data, err := os.ReadFile(topologyFile)
+		if err != nil {
+			log.Fatalf("read topology failed: %s", err)
+		}
+
+		if err := yaml.Unmarshal(data, &topo); err != nil {
+			log.Fatalf("parse topology failed: %s", err)
+		}
+	}
+
+	fmt.Printf("Replay %d records\n", len(records))
+	for _, algorithm := range strings.Split(algorithms, ",") {
+		algorithm = strings.TrimSpace(algorithm)
+		if algorithm == "" {
+			continue
+		}
+
+		start := time.Now()
+		sim, err := newSimulator(algorithm, &topo, records)
+		if err != nil {
+			log.Fatalf("create simulator of %s failed: %s", algorithm, err)
+		}
+
+		result := sim.run()
+		result.print(os.Stdout, algorithm, time.Since(start))
+	}
+}
+
+// loadRecords loads all record files in dir and sorts records by create time.
+func loadRecords(dir string) ([]storage.Record, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []storage.Record
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, storage.RecordFilePrefix) ||
+			filepath.Ext(name) != "."+storage.RecordFileExt {
+			continue
+		}
+
+		file, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		var rs []storage.Record
+		err = gocsv.UnmarshalWithoutHeaders(file, &rs)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", name, err)
+		}
+
+		records = append(records, rs...)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].CreateAt < records[j].CreateAt
+	})
+
+	return records, nil
+}
+
+// result is the metrics of one simulation.
+type result struct {
+	peerCount         int
+	backToSourceCount int
+	failedCount       int
+	depths            []float64
+	uploadCounts      []float64
+	costs             []float64
+	recordCosts       []float64
+	makespan          time.Duration
+}
+
+func (r *result) print(w io.Writer, algorithm string, elapsed time.Duration) {
+	fmt.Fprintf(w, "\nAlgorithm %s (simulated in %s)\n", algorithm, elapsed.Round(time.Millisecond))
+	fmt.Fprintf(w, "  Peers                %d\n", r.peerCount)
+	fmt.Fprintf(w, "  Schedule failed      %d\n", r.failedCount)
+	if r.peerCount > 0 {
+		fmt.Fprintf(w, "  Back-to-source ratio %.2f%% (%d)\n", float64(r.backToSourceCount)*100/float64(r.peerCount), r.backToSourceCount)
+	}
+
+	fmt.Fprintf(w, "Tree Depth\n")
+	printDistribution(w, r.depths, func(v float64) string { return fmt.Sprintf("%.2f", v) })
+
+	fmt.Fprintf(w, "Upload Load (children per host)\n")
+	printDistribution(w, r.uploadCounts, func(v float64) string { return fmt.Sprintf("%.2f", v) })
+	if stddev, err := stats.StandardDeviation(r.uploadCounts); err == nil {
+		fmt.Fprintf(w, "  stddev %.2f\n", stddev)
+	}
+
+	fmt.Fprintf(w, "Estimated Completion Time\n")
+	printDistribution(w, r.costs, func(v float64) string { return time.Duration(v).Round(time.Millisecond).String() })
+	fmt.Fprintf(w, "  makespan %s\n", r.makespan.Round(time.Millisecond))
+
+	fmt.Fprintf(w, "Recorded Completion Time\n")
+	printDistribution(w, r.recordCosts, func(v float64) string { return time.Duration(v).Round(time.Millisecond).String() })
+}
+
+func printDistribution(w io.Writer, data []float64, format func(float64) string) {
+	if len(data) == 0 {
+		fmt.Fprintf(w, "  no data\n")
+		return
+	}
+
+	mean, _ := stats.Mean(data)
+	min, _ := stats.Min(data)
+	max, _ := stats.Max(data)
+	fmt.Fprintf(w, "  avg %s\n  min %s\n  max %s\n", format(mean), format(min), format(max))
+	for _, p := range []float64{50, 90, 99} {
+		v, err := stats.Percentile(data, p)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(w, "  %.0f%% %s\n", p, format(v))
+	}
+}
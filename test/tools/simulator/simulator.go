@@ -0,0 +1,445 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"d7y.io/dragonfly/v2/manager/types"
+	"d7y.io/dragonfly/v2/pkg/container/set"
+	"d7y.io/dragonfly/v2/pkg/gc"
+	"d7y.io/dragonfly/v2/pkg/idgen"
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
+	rpcscheduler "d7y.io/dragonfly/v2/pkg/rpc/scheduler"
+	"d7y.io/dragonfly/v2/scheduler/config"
+	"d7y.io/dragonfly/v2/scheduler/resource"
+	"d7y.io/dragonfly/v2/scheduler/scheduler"
+	"d7y.io/dragonfly/v2/scheduler/scheduler/evaluator"
+	"d7y.io/dragonfly/v2/scheduler/storage"
+)
+
+const (
+	// megabyte is the converted factor of bandwidth.
+	megabyte = 1024 * 1024
+)
+
+// simulator replays records against scheduler in-process.
+type simulator struct {
+	config    *config.Config
+	resource  resource.Resource
+	scheduler scheduler.Scheduler
+	records   []storage.Record
+
+	// storage provides the records finished before the replayed record to evaluator.
+	storage *recordStorage
+
+	// refresher is the evaluator learning from storage, it is refreshed whenever the replay time advances.
+	refresher evaluator.Refresher
+
+	// hosts is the synthetic topology, key is hostname and ip.
+	hosts map[string]*topologyHost
+
+	// bandwidths is upload bandwidth of host in bytes per second, key is host id.
+	bandwidths map[string]float64
+
+	// finishAt is the estimated finish time of running peers, key is peer id.
+	finishAt map[string]int64
+
+	// uploadCounts is the total children count of hosts, key is host id.
+	uploadCounts map[string]int
+
+	// events is the queue of peers which are downloading.
+	events *finishQueue
+}
+
+func newSimulator(algorithm string, topo *topology, records []storage.Record) (*simulator, error) {
+	cfg := config.New()
+	cfg.Scheduler.Algorithm = algorithm
+	cfg.Scheduler.BackSourceCount = backSourceCount
+	cfg.Scheduler.RetryInterval = 0
+	cfg.SeedPeer.Enable = false
+
+	dynconfig := &staticDynconfig{}
+	res, err := resource.New(cfg, gc.New(), dynconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := map[string]*topologyHost{}
+	for i := range topo.Hosts {
+		host := &topo.Hosts[i]
+		hosts[hostKey(host.Hostname, host.IP)] = host
+	}
+
+	history := &recordStorage{records: records}
+	s := &simulator{
+		config:       cfg,
+		resource:     res,
+		records:      records,
+		storage:      history,
+		hosts:        hosts,
+		bandwidths:   map[string]float64{},
+		finishAt:     map[string]int64{},
+		uploadCounts: map[string]int{},
+		events:       &finishQueue{},
+	}
+
+	// Replay takes less time than the refresh interval of evaluator, so the evaluator
+	// is refreshed by replay time instead of in background.
+	s.scheduler = scheduler.New(cfg.Scheduler, dynconfig, pluginDir, evaluator.WithStorage(history),
+		evaluator.WithManualRefresh(func(refresher evaluator.Refresher) {
+			s.refresher = refresher
+		}))
+	return s, nil
+}
+
+// run replays all records in order of create time.
+func (s *simulator) run() *result {
+	r := &result{}
+	ctx := context.Background()
+
+	var begin, end int64
+	for i, record := range s.records {
+		now := record.CreateAt
+		if i == 0 {
+			begin = now
+		}
+		s.finishUntil(now)
+		s.storage.advance(now)
+		if s.refresher != nil {
+			s.refresher.Refresh()
+		}
+
+		task, created := s.loadOrStoreTask(record)
+		host := s.loadOrStoreHost(record)
+		peerID := record.ID
+		if peerID == "" {
+			peerID = idgen.PeerID(record.IP)
+		}
+
+		peer := resource.NewPeer(fmt.Sprintf("%s-%d", peerID, i), task, host, resource.WithBizTag(record.BizTag))
+		s.resource.PeerManager().Store(peer)
+		if err := peer.FSM.Event(resource.PeerEventRegisterNormal); err != nil {
+			r.failedCount++
+			continue
+		}
+
+		if err := peer.FSM.Event(resource.PeerEventDownload); err != nil {
+			r.failedCount++
+			continue
+		}
+
+		// Peer of new task or seed peer downloads back-to-source, same as the scheduler service without seed peer.
+		peer.NeedBackToSource.Store(created || host.Type != resource.HostTypeNormal)
+		peer.StoreStream(&peerPacketStream{})
+		s.scheduler.ScheduleParent(ctx, peer, set.NewSafeSet())
+
+		r.peerCount++
+		if record.Cost > 0 {
+			r.recordCosts = append(r.recordCosts, float64(time.Duration(record.Cost)*time.Millisecond))
+		}
+
+		var (
+			bandwidth float64
+			finishAt  int64
+		)
+		if peer.FSM.Is(resource.PeerStateBackToSource) {
+			r.backToSourceCount++
+			r.depths = append(r.depths, 1)
+			bandwidth = sourceBandwidth * megabyte
+		} else if parent, ok := peer.LoadParent(); ok {
+			r.depths = append(r.depths, float64(peer.Depth()))
+			s.uploadCounts[parent.Host.ID]++
+
+			// Upload bandwidth of parent is shared by all children.
+			bandwidth = s.bandwidths[parent.Host.ID]
+			if count := parent.Host.UploadPeerCount.Load(); count > 1 {
+				bandwidth /= float64(count)
+			}
+
+			if parent.Host.IDC != host.IDC {
+				bandwidth *= crossIDCFactor
+			}
+
+			// Child can not finish before parent.
+			finishAt = s.finishAt[parent.ID]
+		} else {
+			r.failedCount++
+			if err := peer.FSM.Event(resource.PeerEventDownloadFailed); err != nil {
+				peer.Log.Error(err)
+			}
+			continue
+		}
+
+		cost := int64(0)
+		if bandwidth > 0 && task.ContentLength.Load() > 0 {
+			cost = int64(float64(task.ContentLength.Load()) / bandwidth * float64(time.Second))
+		}
+
+		if now+cost > finishAt {
+			finishAt = now + cost
+		}
+
+		if finishAt > end {
+			end = finishAt
+		}
+
+		r.costs = append(r.costs, float64(finishAt-now))
+		s.finishAt[peer.ID] = finishAt
+		heap.Push(s.events, &finishEvent{peer: peer, finishAt: finishAt})
+	}
+
+	s.finishUntil(end)
+	r.makespan = time.Duration(end - begin)
+	for id := range s.bandwidths {
+		r.uploadCounts = append(r.uploadCounts, float64(s.uploadCounts[id]))
+	}
+
+	return r
+}
+
+// finishUntil marks the peers finished before now as succeeded.
+func (s *simulator) finishUntil(now int64) {
+	for s.events.Len() > 0 && (*s.events)[0].finishAt <= now {
+		event := heap.Pop(s.events).(*finishEvent)
+		peer := event.peer
+		delete(s.finishAt, peer.ID)
+
+		totalPieceCount := peer.Task.TotalPieceCount.Load()
+		for i := int32(0); i < totalPieceCount; i++ {
+			peer.Pieces.Set(uint(i))
+		}
+
+		if err := peer.FSM.Event(resource.PeerEventDownloadSucceeded); err != nil {
+			peer.Log.Error(err)
+			continue
+		}
+
+		if !peer.Task.FSM.Is(resource.TaskStateSucceeded) {
+			if err := peer.Task.FSM.Event(resource.TaskEventDownloadSucceeded); err != nil {
+				peer.Task.Log.Error(err)
+			}
+		}
+	}
+}
+
+// loadOrStoreTask returns the task of record, the records of the same task
+// are grouped by content length and total piece count because task id is not recorded.
+func (s *simulator) loadOrStoreTask(record storage.Record) (*resource.Task, bool) {
+	id := fmt.Sprintf("%d-%d", record.ContentLength, record.TotalPieceCount)
+	if task, ok := s.resource.TaskManager().Load(id); ok && !task.FSM.Is(resource.TaskStateFailed) {
+		return task, false
+	}
+
+	task := resource.NewTask(id, id, resource.TaskTypeNormal, &base.UrlMeta{}, resource.WithBackToSourceLimit(int32(s.config.Scheduler.BackSourceCount)))
+	task.ContentLength.Store(record.ContentLength)
+	task.TotalPieceCount.Store(record.TotalPieceCount)
+	if err := task.FSM.Event(resource.TaskEventDownload); err != nil {
+		task.Log.Error(err)
+	}
+
+	s.resource.TaskManager().Store(task)
+	return task, true
+}
+
+// loadOrStoreHost returns the host of record, the host in topology overrides the fields of record.
+func (s *simulator) loadOrStoreHost(record storage.Record) *resource.Host {
+	id := hostKey(record.Hostname, record.IP)
+	if host, ok := s.resource.HostManager().Load(id); ok {
+		return host
+	}
+
+	rawHost := &rpcscheduler.PeerHost{
+		Id:             id,
+		Ip:             record.IP,
+		HostName:       record.Hostname,
+		SecurityDomain: record.SecurityDomain,
+		Idc:            record.IDC,
+		NetTopology:    record.NetTopology,
+		Location:       record.Location,
+	}
+
+	hostType := resource.HostType(record.HostType)
+	bandwidth := uploadBandwidth
+	var options []resource.HostOption
+	if t, ok := s.hosts[id]; ok {
+		rawHost.SecurityDomain = t.SecurityDomain
+		rawHost.Idc = t.IDC
+		rawHost.NetTopology = t.NetTopology
+		rawHost.Location = t.Location
+		hostType = parseHostType(t.Type)
+		if t.UploadLoadLimit > 0 {
+			options = append(options, resource.WithUploadLoadLimit(t.UploadLoadLimit))
+		}
+
+		if t.UploadBandwidth > 0 {
+			bandwidth = t.UploadBandwidth
+		}
+	}
+
+	if hostType != resource.HostTypeNormal {
+		options = append(options, resource.WithHostType(hostType), resource.WithUploadLoadLimit(config.DefaultSeedPeerLoadLimit))
+	}
+
+	host := resource.NewHost(rawHost, options...)
+	s.resource.HostManager().Store(host)
+	s.bandwidths[host.ID] = bandwidth * megabyte
+	return host
+}
+
+func hostKey(hostname, ip string) string {
+	return fmt.Sprintf("%s-%s", hostname, ip)
+}
+
+func parseHostType(t string) resource.HostType {
+	switch t {
+	case "super":
+		return resource.HostTypeSuperSeed
+	case "strong":
+		return resource.HostTypeStrongSeed
+	case "weak":
+		return resource.HostTypeWeakSeed
+	default:
+		return resource.HostTypeNormal
+	}
+}
+
+// finishEvent is the estimated finish of a peer.
+type finishEvent struct {
+	peer     *resource.Peer
+	finishAt int64
+}
+
+// finishQueue is the min heap of finishEvent.
+type finishQueue []*finishEvent
+
+func (q finishQueue) Len() int            { return len(q) }
+func (q finishQueue) Less(i, j int) bool  { return q[i].finishAt < q[j].finishAt }
+func (q finishQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *finishQueue) Push(x interface{}) { *q = append(*q, x.(*finishEvent)) }
+func (q *finishQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	event := old[n-1]
+	*q = old[:n-1]
+	return event
+}
+
+// peerPacketStream drops all packets sent by scheduler.
+type peerPacketStream struct {
+	grpc.ServerStream
+}
+
+func (s *peerPacketStream) Send(*rpcscheduler.PeerPacket) error {
+	return nil
+}
+
+func (s *peerPacketStream) Recv() (*rpcscheduler.PieceResult, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+// staticDynconfig uses default configuration of scheduler.
+type staticDynconfig struct{}
+
+func (d *staticDynconfig) GetSchedulerClusterConfig() (types.SchedulerClusterConfig, bool) {
+	return types.SchedulerClusterConfig{}, false
+}
+
+func (d *staticDynconfig) GetSchedulerClusterClientConfig() (types.SchedulerClusterClientConfig, bool) {
+	return types.SchedulerClusterClientConfig{}, false
+}
+
+func (d *staticDynconfig) GetActiveSchedulerCount() (uint32, bool) {
+	return 1, true
+}
+
+func (d *staticDynconfig) GetApplicationQuota(string) (types.ApplicationQuota, bool) {
+	return types.ApplicationQuota{}, false
+}
+
+func (d *staticDynconfig) Get() (*config.DynconfigData, error) {
+	return &config.DynconfigData{}, nil
+}
+
+func (d *staticDynconfig) Register(config.Observer) {}
+
+func (d *staticDynconfig) Deregister(config.Observer) {}
+
+func (d *staticDynconfig) Notify() error {
+	return nil
+}
+
+func (d *staticDynconfig) Serve() error {
+	return nil
+}
+
+func (d *staticDynconfig) Stop() error {
+	return nil
+}
+
+// recordStorage provides the replayed records to evaluator, only the records updated before
+// the replayed record are listed, so the evaluator does not learn from the future.
+type recordStorage struct {
+	mu      sync.RWMutex
+	records []storage.Record
+	until   int64
+}
+
+// advance moves the replay time to now.
+func (s *recordStorage) advance(now int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.until = now
+}
+
+func (s *recordStorage) Create(record storage.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *recordStorage) List() ([]storage.Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var records []storage.Record
+	for _, record := range s.records {
+		if record.UpdateAt < s.until {
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+func (s *recordStorage) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = nil
+	return nil
+}
+
+func (s *recordStorage) Close() error {
+	return nil
+}
@@ -0,0 +1,198 @@
+/*
+ *     Copyright 2022 The Dragonfly Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"d7y.io/dragonfly/v2/pkg/rpc/base"
+	rpcscheduler "d7y.io/dragonfly/v2/pkg/rpc/scheduler"
+	"d7y.io/dragonfly/v2/scheduler/resource"
+	"d7y.io/dragonfly/v2/scheduler/storage"
+)
+
+func mockRecords(n int) []storage.Record {
+	begin := time.Now().Add(-time.Hour).UnixNano()
+	var records []storage.Record
+	for i := 0; i < n; i++ {
+		createAt := begin + int64(i)*int64(time.Second)
+		records = append(records, storage.Record{
+			ID:              fmt.Sprintf("peer-%d", i),
+			IP:              fmt.Sprintf("127.0.0.%d", i+1),
+			Hostname:        fmt.Sprintf("host-%d", i),
+			IDC:             "idc",
+			State:           storage.PeerStateSucceeded,
+			Cost:            1000,
+			ContentLength:   10 * megabyte,
+			TotalPieceCount: 10,
+			CreateAt:        createAt,
+			UpdateAt:        createAt + int64(time.Second),
+		})
+	}
+
+	return records
+}
+
+// mockRecordsWithParent returns the records downloaded from the first peer,
+// the host of the second peer has the highest throughput.
+func mockRecordsWithParent(n int) []storage.Record {
+	records := mockRecords(n)
+	for i := 1; i < n; i++ {
+		records[i].ParentID = records[0].ID
+		records[i].ParentIP = records[0].IP
+		records[i].ParentHostname = records[0].Hostname
+		records[i].ParentIDC = records[0].IDC
+		if i > 1 {
+			records[i].Cost = 4000
+		}
+	}
+
+	return records
+}
+
+// mockEvaluatePeers returns the peers of hosts of records to evaluate.
+func mockEvaluatePeers(parentRecord, childRecord storage.Record) (*resource.Peer, *resource.Peer) {
+	task := resource.NewTask("task", "task", resource.TaskTypeNormal, &base.UrlMeta{})
+	newPeer := func(record storage.Record) *resource.Peer {
+		host := resource.NewHost(&rpcscheduler.PeerHost{
+			Id:       hostKey(record.Hostname, record.IP),
+			Ip:       record.IP,
+			HostName: record.Hostname,
+			Idc:      record.IDC,
+		})
+		return resource.NewPeer(record.ID, task, host)
+	}
+
+	return newPeer(parentRecord), newPeer(childRecord)
+}
+
+func TestSimulator_run(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		records   []storage.Record
+		expect    func(t *testing.T, sim *simulator, records []storage.Record)
+	}{
+		{
+			name:      "replay records with default algorithm",
+			algorithm: "default",
+			records:   mockRecords(4),
+			expect: func(t *testing.T, sim *simulator, records []storage.Record) {
+				assert := assert.New(t)
+				assert.Nil(sim.refresher)
+
+				r := sim.run()
+				assert.Equal(4, r.peerCount)
+				assert.Equal(0, r.failedCount)
+				assert.Equal(4, len(r.depths))
+				assert.Equal(4, len(r.recordCosts))
+				assert.True(r.backToSourceCount >= 1)
+				assert.True(r.makespan > 0)
+			},
+		},
+		{
+			name:      "replay records with history algorithm",
+			algorithm: "history",
+			records:   mockRecordsWithParent(4),
+			expect: func(t *testing.T, sim *simulator, records []storage.Record) {
+				assert := assert.New(t)
+				if !assert.NotNil(sim.refresher) {
+					return
+				}
+
+				parent, child := mockEvaluatePeers(records[0], records[1])
+				before := sim.refresher.Evaluate(parent, child, 10)
+
+				r := sim.run()
+				assert.Equal(4, r.peerCount)
+				assert.Equal(0, r.failedCount)
+
+				// The throughput of records updated before the last replayed record is learned.
+				after := sim.refresher.Evaluate(parent, child, 10)
+				assert.Greater(after, before)
+
+				// Replay is deterministic because the evaluator is refreshed by replay time.
+				replayed, err := newSimulator("history", &topology{}, records)
+				if err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(r.costs, replayed.run().costs)
+				assert.Equal(after, replayed.refresher.Evaluate(parent, child, 10))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sim, err := newSimulator(tc.algorithm, &topology{}, tc.records)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tc.expect(t, sim, tc.records)
+		})
+	}
+}
+
+func TestRecordStorage_List(t *testing.T) {
+	records := mockRecords(3)
+	tests := []struct {
+		name   string
+		now    int64
+		expect func(t *testing.T, records []storage.Record)
+	}{
+		{
+			name: "no record is updated before the first record",
+			now:  records[0].CreateAt,
+			expect: func(t *testing.T, records []storage.Record) {
+				assert := assert.New(t)
+				assert.Empty(records)
+			},
+		},
+		{
+			name: "list records updated before now",
+			now:  records[2].CreateAt,
+			expect: func(t *testing.T, rs []storage.Record) {
+				assert := assert.New(t)
+				assert.Equal(1, len(rs))
+				assert.Equal(records[0].ID, rs[0].ID)
+			},
+		},
+		{
+			name: "list all records after replaying",
+			now:  records[2].UpdateAt + 1,
+			expect: func(t *testing.T, rs []storage.Record) {
+				assert := assert.New(t)
+				assert.Equal(3, len(rs))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &recordStorage{records: records}
+			s.advance(tc.now)
+			rs, err := s.List()
+			assert.NoError(t, err)
+			tc.expect(t, rs)
+		})
+	}
+}